@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aoshimash/urlmap/internal/bench"
+	"github.com/aoshimash/urlmap/pkg/testsite"
+)
+
+var (
+	benchPages  int
+	benchFanout int
+)
+
+// benchCmd crawls a synthetic local site to measure crawl throughput
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Measure crawl throughput and memory use on a synthetic local site",
+	Long: `Serve a synthetic site of --pages pages, each linking to --fanout child pages,
+from a local server and crawl it with the given crawl flags. Reports pages per
+second, allocations and peak heap use, to help choose --concurrent and the
+JavaScript settings for your hardware. Network latency is not included.
+
+Examples:
+  urlmap bench --pages 5000 --fanout 20 -c 50
+  urlmap bench --pages 200 --js-render --js-pool-size 4 -f json`,
+	Args:         cobra.NoArgs,
+	RunE:         runBench,
+	SilenceUsage: true,
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	if benchPages < 1 {
+		return fmt.Errorf("--pages must be at least 1")
+	}
+	if benchFanout < 1 {
+		return fmt.Errorf("--fanout must be at least 1")
+	}
+	if outputFormat != "text" && outputFormat != "json" {
+		return fmt.Errorf("unsupported output format for bench: %s (supported: text, json)", outputFormat)
+	}
+
+	if err := applyPreset(cmd, preset); err != nil {
+		return err
+	}
+
+	logger := setupLogging()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to start benchmark server: %w", err)
+	}
+	server := &http.Server{Handler: testsite.Site{Pages: benchPages, Fanout: benchFanout}}
+	go server.Serve(listener)
+	defer server.Close()
+
+	urlFilter, err := newURLFilter()
+	if err != nil {
+		return err
+	}
+
+	clientOpts, err := loadClientOptions(logger)
+	if err != nil {
+		return err
+	}
+
+	detectorConfig, err := loadDetectorConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	crawlerConfig := newCrawlerConfig(logger, clientOpts)
+	crawlerConfig.URLFilter = urlFilter
+	crawlerConfig.DetectorConfig = detectorConfig
+	// The progress bar would be measured along with the crawl
+	crawlerConfig.ShowProgress = false
+	crawlerConfig.ProgressConfig.ShowProgress = false
+
+	ctx, stop := notifyShutdown(context.Background())
+	defer stop()
+
+	seed := "http://" + listener.Addr().String() + testsite.PagePath(0)
+	result, err := bench.Measure(func() (int, error) {
+		results, _, err := executeCrawl(ctx, crawlerConfig, seed, logger)
+		return len(results), err
+	})
+	if err != nil {
+		return fmt.Errorf("benchmark failed: %w", err)
+	}
+
+	return writeBenchResult(cmd.OutOrStdout(), result)
+}
+
+// writeBenchResult writes the benchmark measurements as text or JSON
+func writeBenchResult(w io.Writer, result bench.Result) error {
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	fmt.Fprintf(w, "Site:        %d pages, fanout %d\n", benchPages, benchFanout)
+	fmt.Fprintf(w, "Workers:     %d\n", concurrent)
+	fmt.Fprintf(w, "Crawled:     %d of %d pages in %s\n", result.Pages, benchPages, result.Duration.Round(time.Millisecond))
+	fmt.Fprintf(w, "Throughput:  %.1f pages/sec\n", result.PagesPerSec)
+	fmt.Fprintf(w, "Allocated:   %s (%d objects, %d per page)\n", formatBytes(result.TotalAlloc), result.Mallocs, perPage(result.Mallocs, result.Pages))
+	fmt.Fprintf(w, "Peak heap:   %s\n", formatBytes(result.PeakHeap))
+	fmt.Fprintf(w, "GC cycles:   %d\n", result.GCCycles)
+	return nil
+}
+
+// perPage divides n by the number of pages, or returns 0 when none were crawled
+func perPage(n uint64, pages int) uint64 {
+	if pages == 0 {
+		return 0
+	}
+	return n / uint64(pages)
+}
+
+// formatBytes formats a byte count with a binary unit
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
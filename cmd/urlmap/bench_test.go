@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/bench"
+)
+
+func TestRunBench(t *testing.T) {
+	originalFormat, originalConfigFile := outputFormat, configFile
+	t.Cleanup(func() {
+		outputFormat, configFile = originalFormat, originalConfigFile
+		benchPages, benchFanout = 1000, 10
+	})
+	outputFormat = "json"
+	configFile = ""
+	benchPages, benchFanout = 4, 3
+
+	var buf bytes.Buffer
+	benchCmd.SetOut(&buf)
+	defer benchCmd.SetOut(nil)
+
+	require.NoError(t, runBench(benchCmd, nil))
+
+	var result bench.Result
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, 4, result.Pages)
+	assert.Positive(t, result.PagesPerSec)
+	assert.Positive(t, result.TotalAlloc)
+
+	benchFanout = 0
+	assert.Error(t, runBench(benchCmd, nil))
+}
+
+func TestWriteBenchResult(t *testing.T) {
+	originalFormat := outputFormat
+	t.Cleanup(func() {
+		outputFormat = originalFormat
+		benchPages, benchFanout = 1000, 10
+	})
+	outputFormat = "text"
+	benchPages, benchFanout = 200, 5
+
+	result := bench.Result{
+		Pages:       200,
+		Duration:    2 * time.Second,
+		PagesPerSec: 100,
+		TotalAlloc:  3 * 1024 * 1024,
+		Mallocs:     20000,
+		PeakHeap:    1536,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeBenchResult(&buf, result))
+	assert.Contains(t, buf.String(), "Site:        200 pages, fanout 5\n")
+	assert.Contains(t, buf.String(), "Crawled:     200 of 200 pages in 2s\n")
+	assert.Contains(t, buf.String(), "Throughput:  100.0 pages/sec\n")
+	assert.Contains(t, buf.String(), "Allocated:   3.0 MiB (20000 objects, 100 per page)\n")
+	assert.Contains(t, buf.String(), "Peak heap:   1.5 KiB\n")
+}
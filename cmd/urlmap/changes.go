@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/state"
+)
+
+// crawlState builds the state recorded for this run from successful results
+func crawlState(results []crawler.CrawlResult) *state.State {
+	current := state.New()
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		current.Add(state.PageState{
+			URL:          result.URL,
+			ETag:         result.ETag,
+			LastModified: result.LastModified,
+			ContentHash:  result.ContentHash,
+			FetchTime:    result.FetchTime,
+			StatusCode:   result.StatusCode,
+			Depth:        result.Depth,
+			Parent:       result.Parent,
+			Title:        result.Title,
+			Links:        result.Links,
+		})
+	}
+	return current
+}
+
+// reportChanges compares this run with the state stored by the previous run,
+// writes the changed pages report and stores the new state
+func reportChanges(results []crawler.CrawlResult, logger *slog.Logger) error {
+	if stateFile == "" {
+		return nil
+	}
+
+	previous, err := state.Load(stateFile)
+	if err != nil {
+		return err
+	}
+	current := crawlState(results)
+
+	// A first run has nothing to compare against
+	if len(previous.Pages) > 0 {
+		changes := state.Compare(previous, current)
+		if err := writeChangeReport(changes); err != nil {
+			return err
+		}
+		logger.Info("Compared with previous crawl",
+			"previous_crawl", previous.CrawledAt.Format(time.RFC3339),
+			"changes", len(changes))
+	} else {
+		logger.Info("No previous crawl state, recording baseline", "state", stateFile)
+	}
+
+	return current.Save(stateFile)
+}
+
+// writeChangeReport writes the changes to the --changes-report file
+func writeChangeReport(changes []state.Change) error {
+	if changesReport == "" {
+		return nil
+	}
+
+	results := make([]output.ChangeResult, 0, len(changes))
+	for _, change := range changes {
+		results = append(results, output.ChangeResult{
+			URL:    change.URL,
+			Kind:   string(change.Kind),
+			Reason: change.Reason,
+		})
+	}
+
+	file, err := os.Create(changesReport)
+	if err != nil {
+		return fmt.Errorf("failed to create changes report: %w", err)
+	}
+	defer file.Close()
+
+	if err := output.WriteChangeReport(file, results, &output.OutputConfig{Format: output.OutputFormat(outputFormat)}); err != nil {
+		return fmt.Errorf("failed to write changes report: %w", err)
+	}
+	return file.Close()
+}
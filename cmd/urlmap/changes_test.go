@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestReportChanges(t *testing.T) {
+	t.Cleanup(func() {
+		stateFile = ""
+		changesReport = ""
+		outputFormat = "text"
+	})
+
+	dir := t.TempDir()
+	stateFile = filepath.Join(dir, "state.json")
+	changesReport = filepath.Join(dir, "changes.txt")
+	outputFormat = "text"
+	logger := slog.Default()
+
+	first := []crawler.CrawlResult{
+		{URL: "https://example.com/", ETag: `"v1"`},
+		{URL: "https://example.com/old", ContentHash: "a"},
+		{URL: "https://example.com/broken", Error: errors.New("HTTP error: 500")},
+	}
+	assert.NoError(t, reportChanges(first, logger))
+
+	// The first run only records the baseline
+	_, err := os.Stat(changesReport)
+	assert.True(t, os.IsNotExist(err))
+
+	second := []crawler.CrawlResult{
+		{URL: "https://example.com/", ETag: `"v2"`},
+		{URL: "https://example.com/new", ContentHash: "b"},
+	}
+	assert.NoError(t, reportChanges(second, logger))
+
+	report, err := os.ReadFile(changesReport)
+	assert.NoError(t, err)
+	assert.Equal(t, "changed  https://example.com/ (etag)\n"+
+		"added    https://example.com/new\n"+
+		"removed  https://example.com/old\n", string(report))
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"slices"
+	"sort"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/sitegraph"
+	"github.com/aoshimash/urlmap/internal/verify"
+	"github.com/spf13/cobra"
+)
+
+// checkExternal makes check also verify the links the crawl did not follow
+var checkExternal bool
+
+// checkCmd crawls a site and reports its broken links
+var checkCmd = &cobra.Command{
+	Use:   "check <URL>",
+	Short: "Crawl a site and report broken links with the pages linking to them",
+	Long: `Crawl a site like the root command and report every linked URL that
+returns a 4xx/5xx status or cannot be fetched, with the pages linking to it.
+The crawl flags of the root command (--depth, --include, --js-render, ...)
+apply.
+
+With --external, the links the crawl did not follow are fetched once each
+as well: links to other sites, and pages outside --depth, --include or
+--exclude.
+
+The command exits with an error if any link is broken (exit code 3, or 4 if
+every checked URL is broken), so it can gate CI pipelines.
+
+Examples:
+  urlmap check https://example.com
+  urlmap check --external -f csv https://example.com > broken.csv`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runCheck,
+	SilenceUsage: true, // Broken links are not a usage error
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	if err := applyPreset(cmd, preset); err != nil {
+		return err
+	}
+
+	outputConfig := &output.OutputConfig{Format: output.OutputFormat(outputFormat)}
+	switch outputConfig.Format {
+	case output.FormatText, output.FormatJSON, output.FormatCSV, output.FormatXML:
+		// Valid format
+	default:
+		return usageError(fmt.Errorf("unsupported output format: %s (supported: text, json, csv, xml)", outputFormat))
+	}
+	if err := validateTargetURL(args[0]); err != nil {
+		return usageError(err)
+	}
+
+	logger := setupLogging()
+
+	ctx, stop := notifyShutdown(context.Background())
+	defer stop()
+
+	results, clientOpts, err := crawlSite(ctx, cmd, args[0], logger)
+	if err != nil {
+		return crawlFailedError(err)
+	}
+
+	referrers := sitegraph.Referrers(checkPages(results))
+	broken, blocked := brokenPages(results, referrers)
+	if blocked > 0 {
+		logger.Warn("URLs blocked by bot protection were not checked, try --js-on-challenge or a lower --concurrent", "count", blocked)
+	}
+	checked := len(results)
+
+	if checkExternal && ctx.Err() == nil {
+		uncrawled := uncrawledLinks(results, referrers)
+		verifier := verify.New(&verify.Config{
+			Client:  newReportClient(clientOpts),
+			Workers: concurrent,
+			Logger:  logger,
+		})
+		for _, result := range verifier.Verify(ctx, uncrawled) {
+			if result.Error == nil {
+				continue
+			}
+			broken = append(broken, output.BrokenLink{
+				URL:        result.URL,
+				StatusCode: result.StatusCode,
+				Error:      result.Error.Error(),
+				External:   true,
+				Referrers:  referrers[result.URL],
+			})
+		}
+		checked += len(uncrawled)
+	}
+	sort.Slice(broken, func(i, j int) bool { return broken[i].URL < broken[j].URL })
+
+	if err := output.WriteBrokenLinks(cmd.OutOrStdout(), broken, checked, outputConfig); err != nil {
+		return fmt.Errorf("failed to output broken links: %w", err)
+	}
+
+	if err := interruptedError(ctx); err != nil {
+		return err
+	}
+	return failedURLsError(len(broken), checked, "broken")
+}
+
+// checkPages returns the pages of results with their links to other sites,
+// which are only recorded with --external
+func checkPages(results []crawler.CrawlResult) []sitegraph.Page {
+	pages := sitePages(results)
+	for i, result := range results {
+		pages[i].Links = append(slices.Clip(pages[i].Links), result.ExternalLinks...)
+	}
+	return pages
+}
+
+// brokenPages returns the crawled pages that returned a 4xx/5xx status or
+// could not be fetched, and the number of pages blocked by bot protection,
+// which are left out as the site may well serve them to visitors
+func brokenPages(results []crawler.CrawlResult, referrers map[string][]string) ([]output.BrokenLink, int) {
+	var broken []output.BrokenLink
+	blocked := 0
+	for _, result := range results {
+		if result.Error == nil || (result.StatusCode != 0 && result.StatusCode < 400) {
+			continue
+		}
+		if result.BotProtection != "" {
+			blocked++
+			continue
+		}
+		broken = append(broken, output.BrokenLink{
+			URL:        result.URL,
+			StatusCode: result.StatusCode,
+			Error:      result.Error.Error(),
+			Referrers:  referrers[result.URL],
+		})
+	}
+	return broken, blocked
+}
+
+// uncrawledLinks returns the http(s) URLs linked from crawled pages that the
+// crawl did not fetch, sorted
+func uncrawledLinks(results []crawler.CrawlResult, referrers map[string][]string) []string {
+	crawled := make(map[string]bool, len(results))
+	for _, result := range results {
+		crawled[result.URL] = true
+	}
+
+	var links []string
+	for link := range referrers {
+		u, err := neturl.Parse(link)
+		if crawled[link] || err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		links = append(links, link)
+	}
+	sort.Strings(links)
+	return links
+}
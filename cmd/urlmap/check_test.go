@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCheck(t *testing.T) {
+	external := newSiteServer(nil, "/gone")
+	defer external.Close()
+	// Another host name, so the crawler treats it as another site
+	externalURL := strings.Replace(external.URL, "127.0.0.1", "localhost", 1)
+	server := newSiteServer([]string{"/a", "/missing", externalURL + "/", externalURL + "/gone"}, "/missing")
+	defer server.Close()
+
+	originalFormat, originalProgress, originalJSRender, originalConfigFile := outputFormat, showProgress, jsRender, configFile
+	t.Cleanup(func() {
+		outputFormat, showProgress, jsRender, configFile = originalFormat, originalProgress, originalJSRender, originalConfigFile
+		checkExternal = false
+		checkCmd.SetOut(nil)
+	})
+	outputFormat, showProgress, jsRender, configFile = "text", false, false, ""
+
+	var buf bytes.Buffer
+	checkCmd.SetOut(&buf)
+	err := runCheck(checkCmd, []string{server.URL + "/"})
+	assert.EqualError(t, err, "1 of 3 URLs broken")
+	assert.Equal(t, exitPartial, exitCode(err))
+	assert.Equal(t, "404 "+server.URL+"/missing\n  linked from "+server.URL+"/\n", buf.String())
+
+	// --external also fetches the links to the other site
+	buf.Reset()
+	checkExternal = true
+	err = runCheck(checkCmd, []string{server.URL + "/"})
+	require.Error(t, err)
+	assert.Equal(t, "2 of 5 URLs broken", err.Error())
+	assert.Equal(t, "404 "+server.URL+"/missing\n  linked from "+server.URL+"/\n"+
+		"404 "+externalURL+"/gone (external)\n  linked from "+server.URL+"/\n", buf.String())
+
+	assert.Equal(t, exitUsage, exitCode(runCheck(checkCmd, []string{"ftp://example.com"})))
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/state"
+)
+
+// crawlCheckpoint saves the progress of a crawl to --state-file and, with
+// --resume, continues the crawl recorded in it
+type crawlCheckpoint struct {
+	seed    string
+	resumed *crawler.Checkpoint // Progress loaded from the file (with --resume)
+	logger  *slog.Logger
+}
+
+// openCheckpoint loads the checkpoint of the crawl of seed when --resume is
+// set. A missing file starts the crawl afresh. It returns nil without
+// --state-file.
+func openCheckpoint(seed string, logger *slog.Logger) (*crawlCheckpoint, error) {
+	if checkpointFile == "" {
+		return nil, nil
+	}
+
+	checkpoint := &crawlCheckpoint{seed: seed, logger: logger}
+	if !resumeCrawl {
+		return checkpoint, nil
+	}
+
+	saved, err := state.LoadCheckpoint(checkpointFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		logger.Info("No checkpoint to resume, starting a new crawl", "file", checkpointFile)
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if saved.Seed != seed {
+		return nil, fmt.Errorf("checkpoint %s records a crawl of %s, not %s", checkpointFile, saved.Seed, seed)
+	}
+	checkpoint.resumed = crawlerCheckpoint(saved)
+	return checkpoint, nil
+}
+
+// configure makes crawlerConfig save its progress and continue the resumed crawl
+func (c *crawlCheckpoint) configure(crawlerConfig *crawler.Config) {
+	if c == nil {
+		return
+	}
+	crawlerConfig.OnCheckpoint = c.save
+	crawlerConfig.CheckpointInterval = checkpointEvery
+	crawlerConfig.ResumeFrom = c.resumed
+}
+
+// save writes the progress to the file; failures are logged so the crawl keeps going
+func (c *crawlCheckpoint) save(cp crawler.Checkpoint) {
+	if err := stateCheckpoint(c.seed, cp).Save(checkpointFile); err != nil {
+		c.logger.Warn("Failed to save checkpoint", "file", checkpointFile, "error", err)
+	}
+}
+
+// merge returns the resumed results that were not crawled again, followed by results
+func (c *crawlCheckpoint) merge(results []crawler.CrawlResult) []crawler.CrawlResult {
+	if c == nil || c.resumed == nil {
+		return results
+	}
+	return mergeResumed(c.resumed.Results, results)
+}
+
+// stateCheckpoint converts the progress of the crawl of seed for saving
+func stateCheckpoint(seed string, cp crawler.Checkpoint) *state.Checkpoint {
+	saved := &state.Checkpoint{
+		Seed:    seed,
+		SavedAt: time.Now(),
+		Visited: cp.Visited,
+		Stats: state.CheckpointStats{
+			TotalURLs:       cp.Stats.TotalURLs,
+			CrawledURLs:     cp.Stats.CrawledURLs,
+			FailedURLs:      cp.Stats.FailedURLs,
+			SkippedURLs:     cp.Stats.SkippedURLs,
+			ThrottleEvents:  cp.Stats.ThrottleEvents,
+			MaxDepthReached: cp.Stats.MaxDepthReached,
+			Elapsed:         cp.Stats.TotalTime,
+			Frontier:        cp.Stats.Frontier,
+		},
+	}
+	for _, job := range cp.Pending {
+		saved.Pending = append(saved.Pending, state.PendingURL{URL: job.URL, Depth: job.Depth, Parent: job.Parent, Attempt: job.Attempt,
+			LowConfidence: job.LowConfidence, Guessed: job.Guessed, Historical: job.Historical, Tags: job.Tags})
+	}
+	for _, result := range cp.Results {
+		saved.Results = append(saved.Results, ndjsonFromCrawlResult(result))
+	}
+	return saved
+}
+
+// crawlerCheckpoint restores the progress of a crawl from a saved checkpoint
+func crawlerCheckpoint(saved *state.Checkpoint) *crawler.Checkpoint {
+	cp := &crawler.Checkpoint{
+		Visited: saved.Visited,
+		Stats: crawler.CrawlStats{
+			TotalURLs:       saved.Stats.TotalURLs,
+			CrawledURLs:     saved.Stats.CrawledURLs,
+			FailedURLs:      saved.Stats.FailedURLs,
+			SkippedURLs:     saved.Stats.SkippedURLs,
+			ThrottleEvents:  saved.Stats.ThrottleEvents,
+			MaxDepthReached: saved.Stats.MaxDepthReached,
+			TotalTime:       saved.Stats.Elapsed,
+			Frontier:        saved.Stats.Frontier,
+		},
+	}
+	for _, pending := range saved.Pending {
+		cp.Pending = append(cp.Pending, crawler.CrawlJob{URL: pending.URL, Depth: pending.Depth, Parent: pending.Parent, Attempt: pending.Attempt,
+			LowConfidence: pending.LowConfidence, Guessed: pending.Guessed, Historical: pending.Historical, Tags: pending.Tags})
+	}
+	for _, record := range saved.Results {
+		cp.Results = append(cp.Results, crawlResultFromNDJSON(record))
+	}
+	return cp
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/state"
+)
+
+func TestOpenCheckpoint(t *testing.T) {
+	t.Cleanup(func() { checkpointFile, resumeCrawl = "", false })
+
+	checkpoint, err := openCheckpoint("https://example.com", slog.Default())
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	// --resume without a saved checkpoint starts afresh
+	checkpointFile, resumeCrawl = filepath.Join(t.TempDir(), "crawl.state"), true
+	checkpoint, err = openCheckpoint("https://example.com", slog.Default())
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint.resumed)
+
+	// A checkpoint of another seed is not resumed
+	require.NoError(t, (&state.Checkpoint{Seed: "https://other.example.com"}).Save(checkpointFile))
+	_, err = openCheckpoint("https://example.com", slog.Default())
+	assert.ErrorContains(t, err, "records a crawl of https://other.example.com")
+
+	// --state-file is enough for --resume
+	stream, err := openResultStream(slog.Default(), &output.Provenance{Version: "test"})
+	assert.NoError(t, err)
+	assert.Nil(t, stream)
+}
+
+func TestCrawlCheckpoint_Resume(t *testing.T) {
+	server := newSiteServer([]string{"/a", "/b"}, "")
+	defer server.Close()
+
+	originalProgress, originalJSRender := showProgress, jsRender
+	t.Cleanup(func() {
+		checkpointFile, resumeCrawl, checkpointEvery = "", false, crawler.DefaultCheckpointInterval
+		showProgress, jsRender = originalProgress, originalJSRender
+	})
+	showProgress, jsRender = false, false
+	checkpointEvery = crawler.DefaultCheckpointInterval
+
+	// A crawl killed after it fetched the seed, with /b queued and /a not yet
+	checkpointFile = filepath.Join(t.TempDir(), "crawl.state")
+	interrupted := stateCheckpoint(server.URL, crawler.Checkpoint{
+		Visited: []string{server.URL + "/", server.URL + "/b"},
+		Pending: []crawler.CrawlJob{{URL: server.URL + "/b", Depth: 1, Parent: server.URL + "/"}},
+		Results: []crawler.CrawlResult{{URL: server.URL + "/", StatusCode: 200, Links: []string{server.URL + "/a", server.URL + "/b"}}},
+		Stats:   crawler.CrawlStats{TotalURLs: 2, CrawledURLs: 1},
+	})
+	require.NoError(t, interrupted.Save(checkpointFile))
+
+	resumeCrawl = true
+	checkpoint, err := openCheckpoint(server.URL, slog.Default())
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint.resumed)
+
+	clientOpts, err := loadClientOptions(slog.Default())
+	require.NoError(t, err)
+	crawlerConfig := newCrawlerConfig(slog.Default(), clientOpts)
+	checkpoint.configure(crawlerConfig)
+
+	results, stats, err := executeCrawl(context.Background(), crawlerConfig, server.URL, slog.Default())
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, 3, stats.CrawledURLs)
+
+	var urls []string
+	for _, result := range checkpoint.merge(results) {
+		urls = append(urls, result.URL)
+	}
+	sort.Strings(urls)
+	assert.Equal(t, []string{server.URL + "/", server.URL + "/a", server.URL + "/b"}, urls)
+
+	// The file now records the finished crawl
+	saved, err := state.LoadCheckpoint(checkpointFile)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL, saved.Seed)
+	assert.Empty(t, saved.Pending)
+	assert.Len(t, saved.Results, 3)
+	assert.Equal(t, 3, saved.Stats.CrawledURLs)
+}
+
+func TestStateCheckpoint_KeepsJobFlags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crawl.state")
+	pending := []crawler.CrawlJob{
+		{URL: "https://example.com/?page=3", Depth: 2, Parent: "https://example.com/?page=2", Guessed: true},
+		{URL: "https://example.com/api/items", Depth: 1, LowConfidence: true, Tags: []string{"api"}},
+		{URL: "https://example.com/old", Historical: true, Attempt: 1},
+	}
+	require.NoError(t, stateCheckpoint("https://example.com", crawler.Checkpoint{Pending: pending}).Save(path))
+
+	saved, err := state.LoadCheckpoint(path)
+	require.NoError(t, err)
+	assert.Equal(t, pending, crawlerCheckpoint(saved).Pending)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/aoshimash/urlmap/internal/classify"
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+// startClassifier starts the --classify-cmd command, which answers for
+// every discovered URL whether it is crawled and how it is tagged
+func startClassifier() (*classify.Command, error) {
+	if classifyCmd == "" {
+		return nil, nil
+	}
+	return classify.Start(classifyCmd, os.Stderr, 0)
+}
+
+// stopClassifier waits for the classifier command to exit
+func stopClassifier(command *classify.Command, logger *slog.Logger) {
+	if command == nil {
+		return
+	}
+	if err := command.Close(); err != nil {
+		logger.Warn("Classifier command did not exit cleanly", "error", err)
+	}
+}
+
+// classifier lets the crawler ask the command about discovered links (nil
+// without a command)
+func classifier(command *classify.Command) crawler.Classifier {
+	if command == nil {
+		return nil
+	}
+	return func(link, parent string, depth int) (bool, []string, error) {
+		decision, err := command.Classify(classify.Request{URL: link, Parent: parent, Depth: depth})
+		if err != nil {
+			return true, nil, err
+		}
+		return !decision.Skip(), decision.Tags, nil
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifier(t *testing.T) {
+	t.Cleanup(func() { classifyCmd = "" })
+
+	command, err := startClassifier()
+	require.NoError(t, err)
+	assert.Nil(t, command)
+	assert.Nil(t, classifier(command))
+
+	// cat echoes each request, which reads as an answer without an action
+	classifyCmd = "cat"
+	command, err = startClassifier()
+	require.NoError(t, err)
+	defer stopClassifier(command, slog.Default())
+
+	crawl, tags, err := classifier(command)("https://example.com/a", "https://example.com/", 1)
+	require.NoError(t, err)
+	assert.True(t, crawl)
+	assert.Empty(t, tags)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	neturl "net/url"
+	"os"
+	"strings"
+
+	"github.com/aoshimash/urlmap/internal/compare"
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compareBase   string
+	compareTarget string
+)
+
+// compareCmd crawls two environments of the same site and reports the differences
+var compareCmd = &cobra.Command{
+	Use:   "compare --base <URL|file> --target <URL|file>",
+	Short: "Compare the URLs of two environments of the same site",
+	Long: `Crawl two environments of a site (for example staging and production) and
+report paths found in only one of them and paths whose status codes differ.
+URLs are aligned by their path relative to each seed URL.
+
+Instead of a URL, --base or --target may name the output file of a previous
+run (text or --output-format json). Status codes are not stored in those
+files, so only missing paths are reported for them.
+
+The command exits with an error if any differences are found.
+
+Examples:
+  urlmap compare --base https://staging.example.com --target https://example.com
+  urlmap compare --base staging.json --target https://example.com -f json`,
+	Args:         cobra.NoArgs,
+	RunE:         runCompare,
+	SilenceUsage: true, // Differences are not a usage error
+}
+
+func runCompare(cmd *cobra.Command, args []string) error {
+	if compareBase == "" || compareTarget == "" {
+		return usageError(fmt.Errorf("both --base and --target are required"))
+	}
+
+	if err := applyPreset(cmd, preset); err != nil {
+		return err
+	}
+
+	outputConfig := &output.OutputConfig{Format: output.OutputFormat(outputFormat)}
+	switch outputConfig.Format {
+	case output.FormatText, output.FormatJSON, output.FormatCSV, output.FormatXML:
+		// Valid format
+	default:
+		return fmt.Errorf("unsupported output format: %s (supported: text, json, csv, xml)", outputFormat)
+	}
+
+	logger := setupLogging()
+
+	ctx, stop := notifyShutdown(context.Background())
+	defer stop()
+
+	base, err := loadEnvironment(ctx, cmd, compareBase, logger)
+	if err != nil {
+		return fmt.Errorf("base: %w", err)
+	}
+	target, err := loadEnvironment(ctx, cmd, compareTarget, logger)
+	if err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+
+	diffs, err := compare.Compare(base, target)
+	if err != nil {
+		return err
+	}
+
+	results := make([]output.CompareResult, len(diffs))
+	for i, diff := range diffs {
+		results[i] = output.CompareResult{
+			Path:         diff.Path,
+			Kind:         string(diff.Kind),
+			BaseURL:      diff.BaseURL,
+			TargetURL:    diff.TargetURL,
+			BaseStatus:   diff.BaseStatus,
+			TargetStatus: diff.TargetStatus,
+		}
+	}
+
+	if err := output.WriteCompareResults(cmd.OutOrStdout(), base.Seed, target.Seed, results, outputConfig); err != nil {
+		return fmt.Errorf("failed to output comparison: %w", err)
+	}
+
+	if len(diffs) > 0 {
+		return fmt.Errorf("%d differences found", len(diffs))
+	}
+	return nil
+}
+
+// loadEnvironment crawls source when it is a URL, otherwise reads it as stored results
+func loadEnvironment(ctx context.Context, cmd *cobra.Command, source string, logger *slog.Logger) (compare.Environment, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return crawlEnvironment(ctx, cmd, source, logger)
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return compare.Environment{}, fmt.Errorf("failed to open stored results: %w", err)
+	}
+	defer file.Close()
+
+	urls, err := output.ReadResultURLs(file)
+	if err != nil {
+		return compare.Environment{}, err
+	}
+	if len(urls) == 0 {
+		return compare.Environment{}, fmt.Errorf("no URLs in %s", source)
+	}
+
+	// Stored results do not record the seed, so align on the site root
+	first, err := neturl.Parse(urls[0])
+	if err != nil || first.Host == "" {
+		return compare.Environment{}, fmt.Errorf("invalid URL in %s: %s", source, urls[0])
+	}
+
+	env := compare.Environment{Seed: first.Scheme + "://" + first.Host + "/"}
+	for _, u := range urls {
+		env.Pages = append(env.Pages, compare.Page{URL: u})
+	}
+	return env, nil
+}
+
+// crawlEnvironment crawls seed with the crawl flags shared with the root command
+func crawlEnvironment(ctx context.Context, cmd *cobra.Command, seed string, logger *slog.Logger) (compare.Environment, error) {
+	results, _, err := crawlSite(ctx, cmd, seed, logger)
+	if err != nil {
+		return compare.Environment{}, err
+	}
+
+	env := compare.Environment{Seed: seed}
+	for _, result := range results {
+		env.Pages = append(env.Pages, compare.Page{URL: result.URL, StatusCode: result.StatusCode})
+	}
+	return env, nil
+}
+
+// crawlSite crawls seed with the crawl flags shared with the root command,
+// returning the results and the client options the crawl used
+func crawlSite(ctx context.Context, cmd *cobra.Command, seed string, logger *slog.Logger) ([]crawler.CrawlResult, *clientOptions, error) {
+	if err := validateTargetURL(seed); err != nil {
+		return nil, nil, err
+	}
+
+	urlFilter, err := newURLFilter()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientOpts, err := loadClientOptions(logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	detectorConfig, err := loadDetectorConfig(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	crawlerConfig := newCrawlerConfig(logger, clientOpts)
+	crawlerConfig.URLFilter = urlFilter
+	crawlerConfig.DetectorConfig = detectorConfig
+
+	results, _, err := executeCrawl(ctx, crawlerConfig, seed, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, clientOpts, nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSiteServer serves a page linking to each of paths; missing paths return 404
+func newSiteServer(paths []string, missing string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == missing {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		var links strings.Builder
+		if r.URL.Path == "/" {
+			for _, path := range paths {
+				fmt.Fprintf(&links, `<a href="%s">link</a>`, path)
+			}
+		}
+		fmt.Fprintf(w, "<html><body>%s</body></html>", links.String())
+	}))
+}
+
+func TestRunCompare(t *testing.T) {
+	staging := newSiteServer([]string{"/a", "/beta", "/gone"}, "/gone")
+	defer staging.Close()
+	production := newSiteServer([]string{"/a", "/gone", "/legacy"}, "")
+	defer production.Close()
+
+	originalFormat, originalProgress, originalConfigFile := outputFormat, showProgress, configFile
+	t.Cleanup(func() {
+		outputFormat, showProgress, configFile = originalFormat, originalProgress, originalConfigFile
+		compareBase, compareTarget = "", ""
+	})
+	outputFormat = "json"
+	showProgress = false
+	configFile = ""
+
+	var buf bytes.Buffer
+	compareCmd.SetOut(&buf)
+	defer compareCmd.SetOut(nil)
+
+	compareBase, compareTarget = staging.URL, production.URL
+	err := runCompare(compareCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "differences found")
+
+	var report output.CompareOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, 1, report.OnlyBase)
+	assert.Equal(t, 1, report.OnlyTarget)
+	assert.Equal(t, 1, report.StatusMismatch)
+
+	kinds := make(map[string]string)
+	for _, result := range report.Results {
+		kinds[result.Path] = result.Kind
+	}
+	assert.Equal(t, output.CompareOnlyBase, kinds["/beta"])
+	assert.Equal(t, output.CompareOnlyTarget, kinds["/legacy"])
+	assert.Equal(t, output.CompareStatusMismatch, kinds["/gone"])
+	assert.NotContains(t, kinds, "/a")
+
+	// Stored results compare on paths only
+	stored := filepath.Join(t.TempDir(), "production.txt")
+	require.NoError(t, os.WriteFile(stored, []byte(production.URL+"/\n"+production.URL+"/a\n"), 0o644))
+	buf.Reset()
+	compareBase, compareTarget = stored, stored
+	assert.NoError(t, runCompare(compareCmd, nil))
+
+	compareTarget = ""
+	assert.Error(t, runCompare(compareCmd, nil))
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/aoshimash/urlmap/internal/progress"
+	"github.com/aoshimash/urlmap/internal/terminal"
+)
+
+// newProgressConfig builds the progress configuration from command line flags.
+// Progress is redrawn in place when stderr is a terminal, and written as
+// periodic plain lines when it is redirected to a file or CI log, with
+// --plain, or when NO_COLOR is set (https://no-color.org).
+func newProgressConfig(logger *slog.Logger) *progress.Config {
+	return &progress.Config{
+		ShowProgress: showProgress,
+		RateLimit:    rateLimit,
+		Logger:       logger,
+		Plain:        plainOutput || os.Getenv("NO_COLOR") != "" || !terminal.IsTerminal(os.Stderr),
+		Width:        terminal.Width(os.Stderr),
+
+		RateLimitPerHost: rateLimitPerHost,
+	}
+}
+
+// notifyShutdown returns a context cancelled on the first request to stop, so
+// the crawl can shut down gracefully and still write its results.
+//
+// On Unix these are SIGINT and SIGTERM. On Windows, os.Interrupt covers
+// Ctrl+C and Ctrl+Break, and SIGTERM is how Go reports the console window
+// being closed, logoff and system shutdown; Windows ends the process a few
+// seconds after those, so shutdown must not wait on slow work.
+func notifyShutdown(ctx context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProgressConfig_Plain(t *testing.T) {
+	t.Cleanup(func() { plainOutput = false })
+
+	for _, name := range []string{"plain", "no-color"} {
+		plainOutput = false
+		require.NoError(t, rootCmd.Flags().Set(name, "true"))
+		assert.True(t, plainOutput, "--%s", name)
+		assert.True(t, newProgressConfig(slog.Default()).Plain, "--%s", name)
+	}
+
+	plainOutput = false
+	t.Setenv("NO_COLOR", "1")
+	assert.True(t, newProgressConfig(slog.Default()).Plain, "NO_COLOR")
+}
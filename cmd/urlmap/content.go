@@ -0,0 +1,30 @@
+package main
+
+import (
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// writeContentReport writes the --report-content distribution to w
+func writeContentReport(w io.Writer, results []crawler.CrawlResult) error {
+	if !reportContent {
+		return nil
+	}
+
+	summary := crawler.SummarizeContent(results)
+	report := output.ContentReport{
+		Responses:   summary.Responses,
+		TotalBytes:  summary.TotalBytes,
+		LargestURL:  summary.LargestURL,
+		LargestSize: summary.LargestSize,
+	}
+	for _, kind := range summary.Kinds {
+		report.Kinds = append(report.Kinds, output.ContentCount{Label: kind.Kind, Count: kind.Count})
+	}
+	for _, size := range summary.Sizes {
+		report.Sizes = append(report.Sizes, output.ContentCount{Label: size.Range, Count: size.Count})
+	}
+	return output.WriteContentReport(w, report)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteContentReport(t *testing.T) {
+	t.Cleanup(func() { reportContent = false })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", StatusCode: 200, ContentType: "text/html", Size: 1200},
+		{URL: "https://example.com/feed", StatusCode: 200, ContentType: "application/json", Size: 300},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeContentReport(&buf, results))
+	assert.Empty(t, buf.String())
+
+	reportContent = true
+	assert.NoError(t, writeContentReport(&buf, results))
+	assert.Contains(t, buf.String(), "Content types (2 responses, 1500 bytes):\n  html              1\n  json              1\n")
+	assert.Contains(t, buf.String(), "Largest response: https://example.com/ (1200 bytes)\n")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aoshimash/urlmap/internal/control"
+	"github.com/aoshimash/urlmap/internal/filter"
+)
+
+// defaultControlSocket is the socket path used when --control-socket is given
+// without a value, and the default of 'urlmap control --socket'
+const defaultControlSocket = "urlmap.sock"
+
+var controlSocketPath string
+
+// controlCmd groups the commands sent to a running crawl
+var controlCmd = &cobra.Command{
+	Use:   "control",
+	Short: "Change a running crawl through its control socket",
+	Long: `Send commands to a crawl started with --control-socket. Both commands
+default to the socket urlmap.sock in the current directory.
+
+Examples:
+  urlmap --control-socket https://example.com
+  urlmap control exclude '/calendar/*'`,
+}
+
+// controlExcludeCmd adds exclude patterns to a running crawl
+var controlExcludeCmd = &cobra.Command{
+	Use:   "exclude <pattern>...",
+	Short: "Exclude URLs matching the patterns from a running crawl",
+	Long: `Add exclude patterns to the URL filter of a running crawl, as if they had been
+passed with --exclude. Queued URLs that match are dropped instead of being
+fetched, and matching links found later are not queued.
+
+Examples:
+  urlmap control exclude '/calendar/*'
+  urlmap control exclude --socket /tmp/crawl.sock '/tag/*' 're:[?&]sort='`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, pattern := range args {
+			if _, err := filter.Compile(pattern); err != nil {
+				return err
+			}
+		}
+		for _, pattern := range args {
+			if err := control.Exclude(controlSocketPath, pattern); err != nil {
+				return fmt.Errorf("exclude %s: %w", pattern, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Excluded %s\n", pattern)
+		}
+		return nil
+	},
+}
+
+// startControlServer listens on --control-socket for commands changing
+// urlFilter during the crawl. It returns nil when the flag is not set.
+func startControlServer(urlFilter *filter.Filter, logger *slog.Logger) (*control.Server, error) {
+	if controlSocket == "" {
+		return nil, nil
+	}
+
+	server, err := control.Listen(controlSocket, urlFilter, logger)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Listening for control commands", "socket", controlSocket)
+	return server, nil
+}
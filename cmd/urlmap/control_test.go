@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/filter"
+)
+
+func TestControlExclude(t *testing.T) {
+	t.Cleanup(func() { controlSocket, controlSocketPath = "", defaultControlSocket })
+
+	urlFilter, err := filter.New(nil, nil)
+	require.NoError(t, err)
+
+	server, err := startControlServer(urlFilter, slog.Default())
+	require.NoError(t, err)
+	assert.Nil(t, server, "no server without --control-socket")
+
+	controlSocket = filepath.Join(t.TempDir(), "urlmap.sock")
+	server, err = startControlServer(urlFilter, slog.Default())
+	require.NoError(t, err)
+	defer server.Close()
+
+	controlSocketPath = controlSocket
+	var out bytes.Buffer
+	controlExcludeCmd.SetOut(&out)
+	require.NoError(t, controlExcludeCmd.RunE(controlExcludeCmd, []string{"/calendar/*", "/tag/*"}))
+
+	assert.Equal(t, []string{"/calendar/*", "/tag/*"}, urlFilter.Excludes())
+	assert.Equal(t, "Excluded /calendar/*\nExcluded /tag/*\n", out.String())
+
+	// Invalid patterns are rejected before any is sent
+	assert.Error(t, controlExcludeCmd.RunE(controlExcludeCmd, []string{"/archive/*", "re:("}))
+	assert.Len(t, urlFilter.Excludes(), 2)
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/sitegraph"
+)
+
+// writeCycleReport writes the --report-cycles analysis to w
+func writeCycleReport(w io.Writer, results []crawler.CrawlResult) error {
+	if !reportCycles {
+		return nil
+	}
+
+	cycles := sitegraph.AnalyzeCycles(sitePages(results))
+	return output.WriteCycleReport(w, output.CycleReport{
+		RedirectLoops:    cycles.RedirectLoops,
+		SelfLinkOnly:     cycles.SelfLinkOnly,
+		IsolatedClusters: cycles.IsolatedClusters,
+	})
+}
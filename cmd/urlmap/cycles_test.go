@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteCycleReport(t *testing.T) {
+	t.Cleanup(func() { reportCycles = false })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", Links: []string{"https://example.com/self", "https://example.com/loop"}},
+		{URL: "https://example.com/self", Depth: 1, Links: []string{"https://example.com/self"}},
+		{URL: "https://example.com/loop", Depth: 1, Error: fmt.Errorf("failed to fetch URL: %w", client.ErrRedirectLoop)},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeCycleReport(&buf, results))
+	assert.Empty(t, buf.String())
+
+	reportCycles = true
+	assert.NoError(t, writeCycleReport(&buf, results))
+	assert.Contains(t, buf.String(), "Redirect loops: 1\n  https://example.com/loop -> https://example.com/loop\n")
+	assert.Contains(t, buf.String(), "Pages linking only to themselves: 1\n  https://example.com/self\n")
+}
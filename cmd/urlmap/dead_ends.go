@@ -0,0 +1,23 @@
+package main
+
+import (
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/sitegraph"
+)
+
+// writeDeadEndReport writes the --report-dead-ends analysis to w
+func writeDeadEndReport(w io.Writer, results []crawler.CrawlResult) error {
+	if !reportDeadEnds {
+		return nil
+	}
+
+	deadEnds := sitegraph.AnalyzeDeadEnds(sitePages(results))
+	report := output.DeadEndReport{NoOutlinks: deadEnds.NoOutlinks}
+	for _, page := range deadEnds.SinglePaths {
+		report.SinglePaths = append(report.SinglePaths, output.SinglePathResult{URL: page.URL, From: page.From})
+	}
+	return output.WriteDeadEndReport(w, report)
+}
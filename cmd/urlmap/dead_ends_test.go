@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteDeadEndReport(t *testing.T) {
+	t.Cleanup(func() { reportDeadEnds = false })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", Links: []string{"https://example.com/terms"}},
+		{URL: "https://example.com/terms", Depth: 1, Parent: "https://example.com/"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeDeadEndReport(&buf, results))
+	assert.Empty(t, buf.String())
+
+	reportDeadEnds = true
+	assert.NoError(t, writeDeadEndReport(&buf, results))
+	assert.Contains(t, buf.String(), "no outgoing internal links): 1\n  https://example.com/terms\n")
+	assert.Contains(t, buf.String(), "  https://example.com/terms (from https://example.com/)\n")
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/config"
+	"github.com/aoshimash/urlmap/internal/detector"
+)
+
+// detectCmd runs SPA detection against a single URL
+var detectCmd = &cobra.Command{
+	Use:   "detect <URL>",
+	Short: "Run SPA detection against a single URL",
+	Long: `Fetch a single page over HTTP, run the SPA detector on it and print the
+detection result: confidence, matched indicators and whether --js-auto would
+render the page. With --js-auto-strict the page is also rendered in a browser
+and the link counts are compared (dynamic verification).
+
+Use this to debug why automatic JavaScript rendering did or didn't trigger.
+Detector thresholds and custom signatures are read from the configuration file.
+
+Examples:
+  urlmap detect https://example.com
+  urlmap detect --js-auto-strict -f json https://example.com`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runDetect,
+	SilenceUsage: true,
+}
+
+// detectFlags are the root command flags that also apply to detect
+var detectFlags = []string{
+	"verbose", "user-agent", "output-format", "headers-file", "cookie-jar", "accept-language", "debug-requests", "redact", "redact-secrets", "replay-from",
+	"chaos", "chaos-latency", "chaos-seed",
+	"connect-timeout", "header-timeout", "read-timeout", "request-timeout",
+	"js-auto-strict", "js-threshold", "js-browser", "js-headless", "js-timeout", "js-wait",
+}
+
+// detectReport is the outcome of running the detector against a URL
+type detectReport struct {
+	URL       string                    `json:"url"`
+	Threshold float64                   `json:"threshold"`
+	RenderJS  bool                      `json:"render_js"` // Whether --js-auto would render this page
+	Static    *detector.DetectionResult `json:"static"`
+	Dynamic   *detector.DetectionResult `json:"dynamic,omitempty"`
+}
+
+func runDetect(cmd *cobra.Command, args []string) error {
+	targetURL := args[0]
+	if err := validateTargetURL(targetURL); err != nil {
+		return err
+	}
+
+	if outputFormat != "text" && outputFormat != "json" {
+		return fmt.Errorf("unsupported output format for detect: %s (supported: text, json)", outputFormat)
+	}
+
+	var problems optionProblems
+	checkJSOptions(&problems)
+	if err := problems.err(); err != nil {
+		return err
+	}
+
+	logger := setupLogging()
+
+	detectorConfig, err := loadDetectorConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if detectorConfig.Threshold == 0 {
+		detectorConfig.Threshold = jsThreshold
+	}
+	spaDetector, err := detector.NewSPADetectorWithConfig(logger, detectorConfig)
+	if err != nil {
+		return fmt.Errorf("invalid detector configuration: %w", err)
+	}
+
+	clientOpts, err := loadClientOptions(logger)
+	if err != nil {
+		return err
+	}
+
+	clientConfig := client.DefaultConfig()
+	clientConfig.UserAgent = userAgent
+	clientConfig.HeaderRules = clientOpts.headerRules
+	clientConfig.Headers = clientOpts.headers
+	clientConfig.CookieJar = clientOpts.cookieJar
+	clientConfig.RequestLog = clientOpts.requestLog
+	clientConfig.Redactor = clientOpts.redactor
+	clientConfig.Transport = clientOpts.transport
+	clientConfig.Chaos = clientOpts.chaos
+	clientConfig.Timeout = requestTimeout
+	clientConfig.ConnectTimeout = connectTimeout
+	clientConfig.ResponseHeaderTimeout = headerTimeout
+	clientConfig.ReadTimeout = readTimeout
+
+	ctx, stop := notifyShutdown(context.Background())
+	defer stop()
+
+	response, err := client.NewClient(clientConfig).Get(ctx, targetURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", targetURL, err)
+	}
+	staticHTML := response.String()
+
+	report := &detectReport{URL: targetURL, Threshold: spaDetector.Threshold()}
+	report.Static, err = spaDetector.DetectSPA(targetURL, staticHTML)
+	if err != nil {
+		return fmt.Errorf("SPA detection failed: %w", err)
+	}
+
+	// Mirror the crawler: dynamic verification only runs for likely SPAs
+	result := report.Static
+	if jsAutoStrict && result.IsSPA {
+		report.Dynamic, err = verifyDetection(ctx, spaDetector, targetURL, staticHTML, logger)
+		if err != nil {
+			return err
+		}
+		if report.Dynamic.Confidence > result.Confidence {
+			result = report.Dynamic
+		}
+	}
+	report.RenderJS = result.IsSPA && result.Confidence >= report.Threshold
+
+	if err := clientOpts.saveCookies(); err != nil {
+		return err
+	}
+
+	return writeDetectReport(cmd.OutOrStdout(), report)
+}
+
+// verifyDetection renders the page in a browser and compares it with the static HTML
+func verifyDetection(ctx context.Context, spaDetector *detector.SPADetector, targetURL, staticHTML string, logger *slog.Logger) (*detector.DetectionResult, error) {
+	jsClient, err := client.NewJSClient(&client.JSConfig{
+		Enabled:     true,
+		BrowserType: jsBrowser,
+		Headless:    jsHeadless,
+		Timeout:     jsTimeout,
+		WaitFor:     jsWaitType,
+		UserAgent:   userAgent,
+		PoolSize:    1,
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JS client: %w", err)
+	}
+	defer jsClient.Close()
+
+	result, err := spaDetector.VerifyWithJS(ctx, targetURL, staticHTML, jsClient)
+	if err != nil {
+		return nil, fmt.Errorf("dynamic verification failed: %w", err)
+	}
+	return result, nil
+}
+
+// writeDetectReport writes the detection report as text or JSON
+func writeDetectReport(w io.Writer, report *detectReport) error {
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	fmt.Fprintf(w, "URL:        %s\n", report.URL)
+	fmt.Fprintf(w, "Threshold:  %.2f\n", report.Threshold)
+	writeDetectionResult(w, "Static", report.Static)
+	if report.Dynamic != nil {
+		writeDetectionResult(w, "Dynamic", report.Dynamic)
+	}
+	_, err := fmt.Fprintf(w, "Render JS:  %t\n", report.RenderJS)
+	return err
+}
+
+// writeDetectionResult writes one detection result as indented text
+func writeDetectionResult(w io.Writer, label string, result *detector.DetectionResult) {
+	indicators := strings.Join(result.Indicators, ", ")
+	if indicators == "" {
+		indicators = "(none)"
+	}
+	fmt.Fprintf(w, "%s (%s):\n", label, result.Method)
+	fmt.Fprintf(w, "  SPA:        %t\n", result.IsSPA)
+	fmt.Fprintf(w, "  Confidence: %.2f\n", result.Confidence)
+	fmt.Fprintf(w, "  Indicators: %s\n", indicators)
+}
+
+// loadDetectorConfig reads SPA detection tuning from the configuration file.
+// An explicit --js-threshold overrides the file's threshold.
+func loadDetectorConfig(cmd *cobra.Command) (*detector.Config, error) {
+	fileConfig, err := config.LoadFileConfig(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	detectorConfig := &detector.Config{}
+	if fileConfig.Detector != nil {
+		*detectorConfig = *fileConfig.Detector
+	}
+	if flag := cmd.Flags().Lookup("js-threshold"); flag != nil && flag.Changed {
+		detectorConfig.Threshold = jsThreshold
+	}
+	return detectorConfig, nil
+}
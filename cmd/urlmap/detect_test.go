@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDetectorConfig(t *testing.T) {
+	originalConfigFile, originalThreshold := configFile, jsThreshold
+	t.Cleanup(func() {
+		configFile, jsThreshold = originalConfigFile, originalThreshold
+	})
+
+	configFile = filepath.Join(t.TempDir(), "config.json")
+	content := `{"detector": {"threshold": 0.7, "signatures": [{"name": "qwik", "pattern": "q:container"}]}}`
+	assert.NoError(t, os.WriteFile(configFile, []byte(content), 0o644))
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Float64Var(&jsThreshold, "js-threshold", 0.5, "")
+		return cmd
+	}
+
+	cmd := newCmd()
+	detectorConfig, err := loadDetectorConfig(cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.7, detectorConfig.Threshold)
+	assert.Len(t, detectorConfig.Signatures, 1)
+
+	// An explicit flag wins over the file
+	cmd = newCmd()
+	assert.NoError(t, cmd.Flags().Set("js-threshold", "0.9"))
+	detectorConfig, err = loadDetectorConfig(cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.9, detectorConfig.Threshold)
+}
+
+func TestRunDetect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path == "/spa" {
+			fmt.Fprint(w, `<html><body><div id="root"></div><script src="/static/react.js"></script></body></html>`)
+			return
+		}
+		fmt.Fprint(w, `<html><body><h1>Docs</h1><p>Static page with plenty of text content.</p><a href="/a">A</a></body></html>`)
+	}))
+	defer server.Close()
+
+	originalFormat, originalConfigFile := outputFormat, configFile
+	t.Cleanup(func() {
+		outputFormat, configFile = originalFormat, originalConfigFile
+	})
+	configFile = ""
+	outputFormat = "json"
+
+	run := func(path string) detectReport {
+		var buf bytes.Buffer
+		detectCmd.SetOut(&buf)
+		defer detectCmd.SetOut(nil)
+
+		assert.NoError(t, runDetect(detectCmd, []string{server.URL + path}))
+		var report detectReport
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+		return report
+	}
+
+	spa := run("/spa")
+	assert.True(t, spa.Static.IsSPA)
+	assert.True(t, spa.RenderJS)
+	assert.Contains(t, spa.Static.Indicators, "framework_detected")
+	assert.Nil(t, spa.Dynamic)
+
+	static := run("/static")
+	assert.False(t, static.Static.IsSPA)
+	assert.False(t, static.RenderJS)
+	assert.Equal(t, 0.5, static.Threshold)
+
+	outputFormat = "csv"
+	assert.Error(t, runDetect(detectCmd, []string{server.URL + "/spa"}))
+}
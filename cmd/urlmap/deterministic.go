@@ -0,0 +1,28 @@
+package main
+
+import (
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// deterministicSeed seeds every random choice of a --deterministic run
+// that has no seed of its own
+const deterministicSeed = 1
+
+// randomSeed returns seed, or deterministicSeed with --deterministic when
+// seed is 0 (random)
+func randomSeed(seed int64) int64 {
+	if seed == 0 && deterministic {
+		return deterministicSeed
+	}
+	return seed
+}
+
+// freezeOutputTime stamps output documents with the zero time with
+// --deterministic, so repeated runs write identical files
+func freezeOutputTime() {
+	if deterministic {
+		output.FreezeTime(time.Time{})
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// deviceResults converts the pages whose desktop and mobile versions differ
+// to report rows, sorted by URL. It also returns how many pages were compared.
+func deviceResults(results []crawler.CrawlResult) ([]output.DeviceResult, int) {
+	compared := 0
+	rows := make([]output.DeviceResult, 0)
+	for _, result := range results {
+		if result.Devices == nil {
+			continue
+		}
+		compared++
+		if !result.Devices.Differs() {
+			continue
+		}
+
+		row := output.DeviceResult{
+			URL:             result.URL,
+			DesktopStatus:   result.Devices.DesktopStatus,
+			MobileStatus:    result.Devices.MobileStatus,
+			DesktopFinalURL: result.Devices.DesktopFinalURL,
+			MobileFinalURL:  result.Devices.MobileFinalURL,
+			MissingOnMobile: result.Devices.MissingOnMobile,
+			ExtraOnMobile:   result.Devices.ExtraOnMobile,
+		}
+		if result.Devices.Error != nil {
+			row.Error = result.Devices.Error.Error()
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].URL < rows[j].URL
+	})
+	return rows, compared
+}
+
+// writeDeviceComparison outputs the --dual-ua report of pages served
+// differently to desktop and mobile user agents
+func writeDeviceComparison(results []crawler.CrawlResult, logger *slog.Logger) error {
+	rows, compared := deviceResults(results)
+
+	logger.Info("Desktop and mobile comparison complete",
+		"compared_pages", compared,
+		"differing_pages", len(rows))
+
+	outputConfig := &output.OutputConfig{Format: output.OutputFormat(outputFormat)}
+	if err := output.OutputDeviceResults(rows, compared, outputConfig); err != nil {
+		return fmt.Errorf("failed to output device comparison: %w", err)
+	}
+	return nil
+}
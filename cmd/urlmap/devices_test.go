@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestDeviceResults(t *testing.T) {
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/b", Devices: &crawler.DeviceComparison{DesktopStatus: 200, MobileStatus: 404}},
+		{URL: "https://example.com/same", Devices: &crawler.DeviceComparison{DesktopStatus: 200, MobileStatus: 200}},
+		{URL: "https://example.com/failed"},
+		{URL: "https://example.com/a", Devices: &crawler.DeviceComparison{
+			DesktopStatus: 200, MobileStatus: 200, ExtraOnMobile: []string{"https://example.com/app"},
+		}},
+	}
+
+	rows, compared := deviceResults(results)
+	assert.Equal(t, 3, compared)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "https://example.com/a", rows[0].URL)
+	assert.Equal(t, 404, rows[1].MobileStatus)
+}
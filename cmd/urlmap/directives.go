@@ -0,0 +1,29 @@
+package main
+
+import (
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// writeRobotsReport writes the --report-robots summary to w
+func writeRobotsReport(w io.Writer, results []crawler.CrawlResult) error {
+	if !reportRobots {
+		return nil
+	}
+
+	var report output.RobotsReport
+	for _, result := range results {
+		if len(result.Robots) > 0 {
+			report.Pages++
+		}
+	}
+	for _, count := range crawler.SummarizeRobotsDirectives(results) {
+		report.Directives = append(report.Directives, output.DirectiveCountResult{
+			Directive: count.Directive,
+			Pages:     count.Pages,
+		})
+	}
+	return output.WriteRobotsReport(w, report)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteRobotsReport(t *testing.T) {
+	t.Cleanup(func() { reportRobots = false })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", Robots: []string{"noarchive"}},
+		{URL: "https://example.com/private", Robots: []string{"noindex", "noarchive"}},
+		{URL: "https://example.com/about"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeRobotsReport(&buf, results))
+	assert.Empty(t, buf.String())
+
+	reportRobots = true
+	assert.NoError(t, writeRobotsReport(&buf, results))
+	assert.Equal(t, "Pages with robots directives: 2\n  noarchive: 2 pages\n  noindex: 1 pages\n", buf.String())
+}
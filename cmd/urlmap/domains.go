@@ -0,0 +1,25 @@
+package main
+
+import (
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// writeDomainReport writes the --report-domains summary to w
+func writeDomainReport(w io.Writer, results []crawler.CrawlResult) error {
+	if !reportDomains {
+		return nil
+	}
+
+	var report output.DomainReport
+	for _, use := range crawler.SummarizeExternalDomains(results) {
+		report.Domains = append(report.Domains, output.DomainUseResult{
+			Domain: use.Domain,
+			Kinds:  use.Kinds,
+			Pages:  use.Pages,
+		})
+	}
+	return output.WriteDomainReport(w, report)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteDomainReport(t *testing.T) {
+	t.Cleanup(func() { reportDomains = false })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", ExternalDomains: []crawler.ExternalDomain{
+			{Domain: "cdn.jsdelivr.net", Kinds: []string{"script"}},
+			{Domain: "twitter.com", Kinds: []string{"link"}},
+		}},
+		{URL: "https://example.com/about", ExternalDomains: []crawler.ExternalDomain{
+			{Domain: "cdn.jsdelivr.net", Kinds: []string{"stylesheet"}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeDomainReport(&buf, results))
+	assert.Empty(t, buf.String())
+
+	reportDomains = true
+	assert.NoError(t, writeDomainReport(&buf, results))
+	assert.Equal(t, "Third-party domains: 2\n"+
+		"  cdn.jsdelivr.net (script, stylesheet) on 2 pages\n"+
+		"  twitter.com (link) on 1 page\n", buf.String())
+
+	// Resumed results keep their domains
+	restored := crawlResultFromNDJSON(ndjsonFromCrawlResult(results[0]))
+	assert.Equal(t, results[0].ExternalDomains, restored.ExternalDomains)
+}
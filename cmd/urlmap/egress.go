@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+// loadEgress parses the --egress proxies and checks that --region names one
+// of their regions. Rendered pages would leave through the browser's own
+// network, so JavaScript rendering cannot be combined with egress proxies.
+func loadEgress() ([]client.Egress, error) {
+	if len(egressProxies) == 0 {
+		if region != "" {
+			return nil, fmt.Errorf("--region %s requires --egress proxies", region)
+		}
+		return nil, nil
+	}
+
+	conflicts := []struct {
+		set  bool
+		flag string
+	}{
+		{jsRender, "--js-render"},
+		{jsAuto, "--js-auto"},
+		{jsAutoStrict, "--js-auto-strict"},
+		{compareRender, "--compare-render"},
+		{jsOnChallenge, "--js-on-challenge"},
+	}
+	for _, conflict := range conflicts {
+		if conflict.set {
+			return nil, fmt.Errorf("--egress cannot be combined with %s: the browser does not go through egress proxies", conflict.flag)
+		}
+	}
+
+	egresses, err := client.ParseEgress(egressProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	if region != "" {
+		var regions []string
+		for _, egress := range egresses {
+			if !slices.Contains(regions, egress.Region) {
+				regions = append(regions, egress.Region)
+			}
+		}
+		if !slices.Contains(regions, strings.ToLower(region)) {
+			return nil, fmt.Errorf("no --egress proxy for region %s (regions: %s)", region, strings.Join(regions, ", "))
+		}
+	}
+	return egresses, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEgress(t *testing.T) {
+	t.Cleanup(func() {
+		egressProxies, region, jsRender = nil, "", false
+	})
+
+	egresses, err := loadEgress()
+	require.NoError(t, err)
+	assert.Empty(t, egresses)
+
+	region = "eu"
+	_, err = loadEgress()
+	assert.ErrorContains(t, err, "requires --egress")
+
+	egressProxies = []string{"eu=http://proxy-eu.example.com:8080", "us=http://proxy-us.example.com:8080"}
+	egresses, err = loadEgress()
+	require.NoError(t, err)
+	assert.Len(t, egresses, 2)
+
+	region = "EU"
+	_, err = loadEgress()
+	assert.NoError(t, err, "regions are case-insensitive")
+
+	region = "apac"
+	_, err = loadEgress()
+	assert.ErrorContains(t, err, "regions: eu, us")
+
+	region, jsRender = "", true
+	_, err = loadEgress()
+	assert.ErrorContains(t, err, "--js-render")
+}
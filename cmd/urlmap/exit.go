@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit codes, so scripts can branch on the kind of failure
+const (
+	exitError       = 1   // Any other error
+	exitUsage       = 2   // Invalid flags or arguments
+	exitPartial     = 3   // Some URLs failed
+	exitFailed      = 4   // Every URL failed, or the crawl could not run
+	exitInterrupted = 130 // Stopped by Ctrl+C or SIGTERM (128 + SIGINT)
+)
+
+// cliError is an error ending urlmap with a specific exit code
+type cliError struct {
+	code int
+	kind string // Kind written in JSON errors: usage, partial, failed or interrupted
+	err  error
+}
+
+func (e *cliError) Error() string { return e.err.Error() }
+
+func (e *cliError) Unwrap() error { return e.err }
+
+// usageError marks err as caused by invalid flags or arguments
+func usageError(err error) error {
+	return &cliError{code: exitUsage, kind: "usage", err: err}
+}
+
+// crawlFailedError marks err as preventing the crawl from running at all
+func crawlFailedError(err error) error {
+	return &cliError{code: exitFailed, kind: "failed", err: err}
+}
+
+// interruptedError reports a run stopped by a shutdown signal, or nil if ctx
+// was not cancelled
+func interruptedError(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	return &cliError{code: exitInterrupted, kind: "interrupted", err: errors.New("interrupted, results are incomplete")}
+}
+
+// failedURLsError reports failed of total URLs failing, as a total failure
+// when none succeeded and a partial one otherwise, or nil if none failed
+func failedURLsError(failed, total int, what string) error {
+	if failed == 0 {
+		return nil
+	}
+	err := fmt.Errorf("%d of %d URLs %s", failed, total, what)
+	if failed == total {
+		return &cliError{code: exitFailed, kind: "failed", err: err}
+	}
+	return &cliError{code: exitPartial, kind: "partial", err: err}
+}
+
+// exitCode returns the exit code for err
+func exitCode(err error) int {
+	var cliErr *cliError
+	if errors.As(err, &cliErr) {
+		return cliErr.code
+	}
+	return exitError
+}
+
+// errorRecord is the --error-format json representation of an error
+type errorRecord struct {
+	Error    string `json:"error"`
+	Kind     string `json:"kind"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// validateErrorFormat checks --error-format before any command runs
+func validateErrorFormat(cmd *cobra.Command, args []string) error {
+	if errorFormat != "text" && errorFormat != "json" {
+		return usageError(fmt.Errorf("unsupported error format: %s (supported: text, json)", errorFormat))
+	}
+	return nil
+}
+
+// reportError writes err to w in the --error-format, followed by the usage of
+// cmd for usage errors in text format
+func reportError(w io.Writer, cmd *cobra.Command, err error) {
+	code := exitCode(err)
+
+	if errorFormat == "json" {
+		record := errorRecord{Error: err.Error(), Kind: "error", ExitCode: code}
+		var cliErr *cliError
+		if errors.As(err, &cliErr) {
+			record.Kind = cliErr.kind
+		}
+		// Nothing useful can be done if stderr cannot be written
+		_ = json.NewEncoder(w).Encode(record)
+		return
+	}
+
+	fmt.Fprintf(w, "Error: %v\n", err)
+	if code == exitUsage && cmd != nil {
+		fmt.Fprintf(w, "\n%s", cmd.UsageString())
+	}
+}
+
+// markUsageErrors makes flag parsing and argument validation errors of cmd
+// and its subcommands usage errors
+func markUsageErrors(cmd *cobra.Command) {
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return usageError(err)
+	})
+
+	var mark func(*cobra.Command)
+	mark = func(c *cobra.Command) {
+		if validate := c.Args; validate != nil {
+			c.Args = func(c *cobra.Command, args []string) error {
+				if err := validate(c, args); err != nil {
+					return usageError(err)
+				}
+				return nil
+			}
+		}
+		for _, sub := range c.Commands() {
+			mark(sub)
+		}
+	}
+	mark(cmd)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailedURLsError(t *testing.T) {
+	assert.NoError(t, failedURLsError(0, 5, "failed"))
+
+	err := failedURLsError(2, 5, "failed")
+	assert.EqualError(t, err, "2 of 5 URLs failed")
+	assert.Equal(t, exitPartial, exitCode(err))
+
+	assert.Equal(t, exitFailed, exitCode(failedURLsError(5, 5, "failed")))
+	assert.Equal(t, exitError, exitCode(errors.New("boom")))
+}
+
+func TestInterruptedError(t *testing.T) {
+	assert.NoError(t, interruptedError(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, exitInterrupted, exitCode(interruptedError(ctx)))
+}
+
+func TestReportError(t *testing.T) {
+	t.Cleanup(func() { errorFormat = "text" })
+	cmd := &cobra.Command{Use: "urlmap <URL>"}
+
+	var out bytes.Buffer
+	errorFormat = "text"
+	reportError(&out, cmd, usageError(errors.New("invalid URL")))
+	assert.Contains(t, out.String(), "Error: invalid URL\n")
+	assert.Contains(t, out.String(), "Usage:")
+
+	// Only usage errors print the usage
+	out.Reset()
+	reportError(&out, cmd, failedURLsError(1, 2, "failed"))
+	assert.Equal(t, "Error: 1 of 2 URLs failed\n", out.String())
+
+	out.Reset()
+	errorFormat = "json"
+	reportError(&out, cmd, failedURLsError(1, 2, "failed"))
+	var record errorRecord
+	require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, errorRecord{Error: "1 of 2 URLs failed", Kind: "partial", ExitCode: exitPartial}, record)
+
+	out.Reset()
+	reportError(&out, cmd, errors.New("boom"))
+	assert.JSONEq(t, `{"error":"boom","kind":"error","exit_code":1}`, out.String())
+}
+
+func TestMarkUsageErrors(t *testing.T) {
+	root := &cobra.Command{
+		Use:           "urlmap",
+		Args:          cobra.ExactArgs(1),
+		RunE:          func(cmd *cobra.Command, args []string) error { return nil },
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	root.AddCommand(&cobra.Command{
+		Use:  "verify",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { return nil },
+	})
+	root.SetOut(&bytes.Buffer{})
+	markUsageErrors(root)
+
+	for _, args := range [][]string{{"--unknown", "https://example.com"}, {}, {"verify", "extra"}} {
+		root.SetArgs(args)
+		assert.Equal(t, exitUsage, exitCode(root.Execute()), "%v", args)
+	}
+}
+
+func TestRunCrawl_ExitCodes(t *testing.T) {
+	server := newSiteServer([]string{"/a", "/missing"}, "/missing")
+	defer server.Close()
+
+	originalProgress, originalJSRender := showProgress, jsRender
+	t.Cleanup(func() { showProgress, jsRender = originalProgress, originalJSRender })
+	showProgress, jsRender = false, false
+
+	err := runCrawl(rootCmd, []string{server.URL + "/"})
+	assert.EqualError(t, err, "1 of 3 URLs failed")
+	assert.Equal(t, exitPartial, exitCode(err))
+
+	assert.Equal(t, exitUsage, exitCode(runCrawl(rootCmd, []string{"ftp://example.com"})))
+}
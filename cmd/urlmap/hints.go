@@ -0,0 +1,25 @@
+package main
+
+import (
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// writeHintReport writes the --report-hints summary to w
+func writeHintReport(w io.Writer, results []crawler.CrawlResult) error {
+	if !reportHints {
+		return nil
+	}
+
+	var report output.HintReport
+	for _, origin := range crawler.SummarizeHints(results) {
+		report.Origins = append(report.Origins, output.HintOriginResult{
+			Origin: origin.Origin,
+			Rels:   origin.Rels,
+			Pages:  origin.Pages,
+		})
+	}
+	return output.WriteHintReport(w, report)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+func TestWriteHintReport(t *testing.T) {
+	t.Cleanup(func() { reportHints = false })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", Hints: []parser.ResourceHint{
+			{Rel: "preconnect", URL: "https://fonts.gstatic.com"},
+			{Rel: "prefetch", URL: "https://example.com/next"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeHintReport(&buf, results))
+	assert.Empty(t, buf.String())
+
+	reportHints = true
+	assert.NoError(t, writeHintReport(&buf, results))
+	assert.Equal(t, "Third-party origins in resource hints: 1\n  https://fonts.gstatic.com (preconnect) on 1 page\n", buf.String())
+}
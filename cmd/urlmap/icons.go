@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+// writeIconReport fetches the favicons and manifests of every crawled origin
+// and writes the --report-icons summary to w. Icons listed in a manifest are
+// fetched as well.
+func writeIconReport(ctx context.Context, w io.Writer, results []crawler.CrawlResult, clientOpts *clientOptions) error {
+	if !reportIcons {
+		return nil
+	}
+
+	httpClient := newReportClient(clientOpts)
+
+	var report output.IconReport
+	for _, origin := range crawler.CollectIcons(results) {
+		if ctx.Err() != nil {
+			break
+		}
+		result := output.OriginIconResult{Origin: origin.Origin}
+		for _, icon := range origin.Icons {
+			checked, listed := checkIcon(ctx, httpClient, icon)
+			result.Icons = append(result.Icons, checked)
+			for _, listedIcon := range listed {
+				checked, _ := checkIcon(ctx, httpClient, listedIcon)
+				result.Icons = append(result.Icons, checked)
+			}
+		}
+		report.Origins = append(report.Origins, result)
+	}
+	return output.WriteIconReport(w, report)
+}
+
+// checkIcon fetches an icon or manifest and, for a valid manifest, returns
+// the icons it lists
+func checkIcon(ctx context.Context, httpClient *client.Client, icon parser.Icon) (output.IconResult, []parser.Icon) {
+	result := output.IconResult{Rel: icon.Rel, URL: icon.URL, Sizes: icon.Sizes}
+
+	response, err := httpClient.Get(ctx, icon.URL)
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	result.StatusCode = response.StatusCode()
+	if result.StatusCode < 200 || result.StatusCode >= 400 {
+		result.Error = fmt.Sprintf("HTTP error: %d", result.StatusCode)
+		return result, nil
+	}
+
+	if icon.Rel != parser.RelManifest {
+		return result, nil
+	}
+	listed, err := parser.ExtractManifestIcons(icon.URL, response.Body())
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	return result, listed
+}
+
+// newReportClient creates the client reports use to fetch files after the
+// crawl, configured like the crawler's
+func newReportClient(clientOpts *clientOptions) *client.Client {
+	clientConfig := client.DefaultConfig()
+	clientConfig.UserAgent = userAgent
+	clientConfig.HeaderRules = clientOpts.headerRules
+	clientConfig.Headers = clientOpts.headers
+	clientConfig.CookieJar = clientOpts.cookieJar
+	clientConfig.DNSCache = clientOpts.dnsCache
+	clientConfig.RequestLog = clientOpts.requestLog
+	clientConfig.Redactor = clientOpts.redactor
+	clientConfig.Transport = clientOpts.transport
+	clientConfig.Chaos = clientOpts.chaos
+	clientConfig.Timeout = requestTimeout
+	clientConfig.ConnectTimeout = connectTimeout
+	clientConfig.ResponseHeaderTimeout = headerTimeout
+	clientConfig.ReadTimeout = readTimeout
+	return client.NewClient(clientConfig)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+func TestWriteIconReport(t *testing.T) {
+	t.Cleanup(func() { reportIcons = false })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/favicon.ico", "/icons/192.png":
+			w.Header().Set("Content-Type", "image/png")
+			fmt.Fprint(w, "png")
+		case "/site.webmanifest":
+			fmt.Fprint(w, `{"icons":[{"src":"/icons/192.png","sizes":"192x192"},{"src":"/icons/512.png","sizes":"512x512"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	results := []crawler.CrawlResult{{URL: server.URL + "/", Icons: []parser.Icon{
+		{Rel: parser.RelManifest, URL: server.URL + "/site.webmanifest"},
+	}}}
+
+	clientOpts, err := loadClientOptions(slog.Default())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeIconReport(context.Background(), &buf, results, clientOpts))
+	assert.Empty(t, buf.String())
+
+	reportIcons = true
+	assert.NoError(t, writeIconReport(context.Background(), &buf, results, clientOpts))
+	assert.Equal(t, "Icons and manifests: 1 origins, 1 broken\n"+
+		server.URL+"\n"+
+		"  icon "+server.URL+"/favicon.ico: 200\n"+
+		"  manifest "+server.URL+"/site.webmanifest: 200\n"+
+		"  manifest-icon 192x192 "+server.URL+"/icons/192.png: 200\n"+
+		"  manifest-icon 512x512 "+server.URL+"/icons/512.png: error: HTTP error: 404\n", buf.String())
+}
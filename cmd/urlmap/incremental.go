@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/incremental"
+	"github.com/aoshimash/urlmap/internal/state"
+)
+
+// incrementalPlan makes each crawl skip the pages of the --state file that
+// did not change since it was written, with --incremental. The links stored
+// for them are still followed.
+type incrementalPlan struct {
+	previous   *state.State
+	clientOpts *clientOptions
+	logger     *slog.Logger
+	unchanged  []crawler.CrawlResult // Pages skipped by the last crawl
+}
+
+// newIncrementalPlan loads the --state file, or returns nil without
+// --incremental or a previous crawl to compare with
+func newIncrementalPlan(clientOpts *clientOptions, logger *slog.Logger) *incrementalPlan {
+	if !incrementalCrawl {
+		return nil
+	}
+
+	previous, err := state.Load(stateFile)
+	if err != nil {
+		logger.Warn("Failed to load crawl state, crawling every page", "state", stateFile, "error", err)
+		return nil
+	}
+	if len(previous.Pages) == 0 {
+		logger.Info("No previous crawl state, crawling every page", "state", stateFile)
+		return nil
+	}
+	return &incrementalPlan{previous: previous, clientOpts: clientOpts, logger: logger}
+}
+
+// configure makes the crawl of seed check the pages of the previous crawl
+// when it starts, so the requests are held back by the crawl's rate limits,
+// politeness and circuit breaker
+func (p *incrementalPlan) configure(crawlerConfig *crawler.Config, seed string) {
+	if p == nil {
+		return
+	}
+	p.unchanged = nil
+	crawlerConfig.PlanResume = func(ctx context.Context, gate crawler.Gate) []crawler.CrawlResult {
+		planner := incremental.New(&incremental.Config{
+			Client:  newReportClient(p.clientOpts),
+			Workers: concurrent,
+			Sample:  incrementalSample,
+			Seed:    uint64(randomSeed(0)),
+			Logger:  p.logger,
+			Gate:    gate,
+		})
+		plan := planner.Plan(ctx, seed, p.previous)
+		p.logger.Info("Planned incremental crawl", "seed", seed,
+			"unchanged", len(plan.Unchanged), "changed", len(plan.Changed), "sampled", len(plan.Sampled))
+
+		for _, page := range plan.Unchanged {
+			p.unchanged = append(p.unchanged, crawlResultFromPageState(page))
+		}
+		return p.unchanged
+	}
+}
+
+// skipped returns the pages the last crawl skipped, as results to merge
+// with the crawled ones, which --stream prints too
+func (p *incrementalPlan) skipped() []crawler.CrawlResult {
+	if p == nil {
+		return nil
+	}
+	return p.unchanged
+}
+
+// crawlResultFromPageState converts a page stored in the crawl state back to a result
+func crawlResultFromPageState(page state.PageState) crawler.CrawlResult {
+	return crawler.CrawlResult{
+		URL:          page.URL,
+		Depth:        page.Depth,
+		Parent:       page.Parent,
+		Links:        page.Links,
+		FetchTime:    page.FetchTime,
+		StatusCode:   page.StatusCode,
+		ETag:         page.ETag,
+		LastModified: page.LastModified,
+		ContentHash:  page.ContentHash,
+		Title:        page.Title,
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/state"
+)
+
+func TestPlanIncremental(t *testing.T) {
+	// /b changed since the last crawl and now links to /new
+	var mu sync.Mutex
+	fetched := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/sitemap.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		etag := `"v1"`
+		if r.URL.Path == "/b" {
+			etag = `"v2"`
+		}
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Method == http.MethodGet {
+			mu.Lock()
+			fetched[r.URL.Path]++
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><a href="/a">a</a><a href="/b">b</a></body></html>`)
+		case "/b":
+			fmt.Fprint(w, `<html><body><a href="/new">new</a></body></html>`)
+		default:
+			fmt.Fprint(w, `<html><body></body></html>`)
+		}
+	}))
+	defer server.Close()
+
+	originalProgress, originalJSRender, originalFormat := showProgress, jsRender, outputFormat
+	t.Cleanup(func() {
+		stateFile, incrementalCrawl, incrementalSample = "", false, 0.05
+		showProgress, jsRender, streamOutput, outputFormat = originalProgress, originalJSRender, false, originalFormat
+	})
+	showProgress, jsRender = false, false
+
+	stateFile = filepath.Join(t.TempDir(), "state.json")
+	previous := state.New()
+	previous.Add(state.PageState{URL: server.URL + "/", ETag: `"v1"`, FetchTime: time.Now(), StatusCode: 200,
+		Links: []string{server.URL + "/a", server.URL + "/b"}})
+	previous.Add(state.PageState{URL: server.URL + "/a", ETag: `"v1"`, FetchTime: time.Now(), StatusCode: 200, Depth: 1, Parent: server.URL + "/"})
+	previous.Add(state.PageState{URL: server.URL + "/b", ETag: `"v1"`, FetchTime: time.Now(), StatusCode: 200, Depth: 1, Parent: server.URL + "/"})
+	require.NoError(t, previous.Save(stateFile))
+
+	clientOpts, err := loadClientOptions(slog.Default())
+	require.NoError(t, err)
+	crawlerConfig := newCrawlerConfig(slog.Default(), clientOpts)
+
+	// Without --incremental every page is crawled
+	plan := newIncrementalPlan(clientOpts, slog.Default())
+	assert.Nil(t, plan)
+	plan.configure(crawlerConfig, server.URL)
+	assert.Nil(t, crawlerConfig.PlanResume)
+
+	incrementalCrawl, incrementalSample = true, 0
+	plan = newIncrementalPlan(clientOpts, slog.Default())
+	require.NotNil(t, plan)
+	plan.configure(crawlerConfig, server.URL)
+
+	// With --stream the skipped pages are printed along with the crawled ones
+	streamOutput, outputFormat = true, "text"
+	var out bytes.Buffer
+	live, err := openURLStream(&out, slog.Default(), nil)
+	require.NoError(t, err)
+	live.configure(crawlerConfig)
+
+	results, _, err := executeCrawl(context.Background(), crawlerConfig, server.URL, slog.Default())
+	require.NoError(t, err)
+	unchanged := plan.skipped()
+	require.Len(t, unchanged, 2)
+	assert.Equal(t, server.URL+"/", unchanged[0].URL)
+	assert.Equal(t, server.URL+"/a", unchanged[1].URL)
+	merged := mergeResumed(unchanged, results)
+	live.writeKept(merged, results)
+
+	var urls []string
+	for _, result := range merged {
+		urls = append(urls, result.URL)
+	}
+	sort.Strings(urls)
+	assert.Equal(t, []string{server.URL + "/", server.URL + "/a", server.URL + "/b", server.URL + "/new"}, urls)
+	assert.ElementsMatch(t, urls, strings.Split(strings.TrimSpace(out.String()), "\n"))
+	assert.Equal(t, map[string]int{"/b": 1, "/new": 1}, fetched, "unchanged pages are not fetched again")
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/sitegraph"
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// loadIndexedURLs reads the --report-index export before the crawl, so an
+// unreadable file fails the run early
+func loadIndexedURLs() ([]string, error) {
+	if reportIndex == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(reportIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index export: %w", err)
+	}
+	defer file.Close()
+
+	urls, err := output.ReadIndexExport(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no URLs in %s", reportIndex)
+	}
+	return urls, nil
+}
+
+// writeIndexReport writes the --report-index comparison of the crawl with
+// the indexed URLs to w. Indexed URLs outside the scope of every seed are
+// ignored, and pages only found in web archives do not count as linked.
+func writeIndexReport(w io.Writer, results []crawler.CrawlResult, seeds, indexed []string) error {
+	if reportIndex == "" {
+		return nil
+	}
+
+	var inScope []string
+	for _, rawURL := range indexed {
+		normalized, err := url.NormalizeURL(rawURL)
+		if err != nil {
+			continue
+		}
+		if !keepSessionIDs {
+			normalized = url.StripSessionIDs(normalized)
+		}
+		for _, seed := range seeds {
+			if same, err := url.IsSamePathPrefix(seed, normalized); err == nil && same {
+				inScope = append(inScope, normalized)
+				break
+			}
+		}
+	}
+
+	linked := make([]crawler.CrawlResult, 0, len(results))
+	for _, result := range results {
+		if !result.Historical {
+			linked = append(linked, result)
+		}
+	}
+
+	comparison := sitegraph.CompareIndex(sitePages(linked), inScope)
+	return output.WriteIndexReport(w, output.IndexReport{
+		Indexed:    len(inScope),
+		NotLinked:  comparison.NotLinked,
+		NotIndexed: comparison.NotIndexed,
+	})
+}
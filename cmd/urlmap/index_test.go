@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestLoadIndexedURLs(t *testing.T) {
+	t.Cleanup(func() { reportIndex = "" })
+
+	urls, err := loadIndexedURLs()
+	require.NoError(t, err)
+	assert.Nil(t, urls)
+
+	dir := t.TempDir()
+	reportIndex = filepath.Join(dir, "pages.csv")
+	require.NoError(t, os.WriteFile(reportIndex, []byte("Top pages,Clicks\nhttps://example.com/docs/,3\n"), 0o644))
+	urls, err = loadIndexedURLs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/docs/"}, urls)
+
+	require.NoError(t, os.WriteFile(reportIndex, []byte("Top pages,Clicks\n"), 0o644))
+	_, err = loadIndexedURLs()
+	assert.ErrorContains(t, err, "no URLs")
+
+	reportIndex = filepath.Join(dir, "missing.csv")
+	_, err = loadIndexedURLs()
+	assert.Error(t, err)
+}
+
+func TestWriteIndexReport(t *testing.T) {
+	t.Cleanup(func() { reportIndex = "" })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/docs", Links: []string{"https://example.com/docs/a", "https://example.com/docs/new"}},
+		{URL: "https://example.com/docs/a", Depth: 1},
+		{URL: "https://example.com/docs/new", Depth: 1},
+		{URL: "https://example.com/docs/old", Historical: true},
+		{URL: "https://example.com/docs/broken", Depth: 1, Error: errors.New("HTTP error: 404")},
+	}
+	indexed := []string{
+		"https://example.com/docs/",
+		"https://example.com/docs/a/#top",
+		"https://example.com/docs/old",
+		"https://example.com/blog/post",
+	}
+	seeds := []string{"https://example.com/docs/"}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeIndexReport(&buf, results, seeds, indexed))
+	assert.Empty(t, buf.String())
+
+	reportIndex = "pages.csv"
+	assert.NoError(t, writeIndexReport(&buf, results, seeds, indexed))
+	assert.Contains(t, buf.String(), "Indexed URLs in scope: 3\n")
+	assert.Contains(t, buf.String(), "(orphan pages): 1\n  https://example.com/docs/old\n")
+	assert.Contains(t, buf.String(), "Linked but not indexed: 1\n  https://example.com/docs/new\n")
+}
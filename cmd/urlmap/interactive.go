@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/filter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// interactiveCmd previews a site and lets the user refine the crawl scope
+var interactiveCmd = &cobra.Command{
+	Use:   "interactive <URL>",
+	Short: "Preview a site at depth 1 and refine the crawl scope interactively",
+	Long: `Crawl the given URL to depth 1, group the discovered URLs by their first
+path segment and let you include or exclude groups (or arbitrary patterns)
+before launching the full crawl with the chosen filters.
+
+Prompts are written to stderr so the final results on stdout can still be
+redirected to a file.
+
+Commands:
+  list                 Show discovered URL groups
+  include <N|pattern>  Only crawl URLs in group N or matching the pattern
+  exclude <N|pattern>  Skip URLs in group N or matching the pattern
+  reset                Remove all interactively added patterns
+  run                  Start the full crawl with the current filters
+  quit                 Exit without crawling`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInteractive,
+}
+
+// urlGroup is a set of discovered URLs sharing the same first path segment
+type urlGroup struct {
+	Pattern  string   // Filter pattern selecting the group
+	Count    int      // Number of discovered URLs in the group
+	Examples []string // A few example URLs
+}
+
+// maxGroupExamples limits the example URLs shown per group
+const maxGroupExamples = 2
+
+func runInteractive(cmd *cobra.Command, args []string) error {
+	if err := applyPreset(cmd, preset); err != nil {
+		return err
+	}
+	if err := validateCrawlOptions(); err != nil {
+		return err
+	}
+
+	targetURL := args[0]
+	if err := validateTargetURL(targetURL); err != nil {
+		return err
+	}
+
+	logger := setupLogging()
+	prompt := cmd.ErrOrStderr()
+
+	clientOpts, err := loadClientOptions(logger)
+	if err != nil {
+		return err
+	}
+
+	detectorConfig, err := loadDetectorConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := notifyShutdown(context.Background())
+	defer stop()
+
+	// Preview crawl limited to depth 1
+	previewConfig := newCrawlerConfig(logger, clientOpts)
+	previewConfig.MaxDepth = 1
+	previewFilter, err := newURLFilter()
+	if err != nil {
+		return err
+	}
+	previewConfig.URLFilter = previewFilter
+	previewConfig.DetectorConfig = detectorConfig
+
+	fmt.Fprintf(prompt, "Previewing %s (depth 1)...\n", targetURL)
+	results, _, err := executeCrawl(ctx, previewConfig, targetURL, logger)
+	if err != nil {
+		return err
+	}
+
+	session := &scopeSession{
+		groups:  groupURLs(collectDiscoveredURLs(results), targetURL),
+		include: previewFilter.Includes(),
+		exclude: previewFilter.Excludes(),
+		out:     prompt,
+	}
+	session.printGroups()
+
+	run, err := session.loop(cmd.InOrStdin())
+	if err != nil || !run {
+		return err
+	}
+
+	// Full crawl with the refined filters
+	urlFilter, err := filter.New(session.include, session.exclude)
+	if err != nil {
+		return fmt.Errorf("invalid filter pattern: %w", err)
+	}
+	crawlerConfig := newCrawlerConfig(logger, clientOpts)
+	crawlerConfig.URLFilter = urlFilter
+	crawlerConfig.DetectorConfig = detectorConfig
+
+	fmt.Fprintf(prompt, "Starting full crawl with --include=%s --exclude=%s\n",
+		strings.Join(session.include, ","), strings.Join(session.exclude, ","))
+
+	// Record the refined filters as the options of the run
+	for name, patterns := range map[string][]string{"include": session.include, "exclude": session.exclude} {
+		if err := cmd.Flags().Lookup(name).Value.(pflag.SliceValue).Replace(patterns); err != nil {
+			return err
+		}
+	}
+	provenance := newProvenance(cmd, []string{targetURL}, time.Now())
+
+	results, _, err = executeCrawl(ctx, crawlerConfig, targetURL, logger)
+	if err != nil {
+		return err
+	}
+
+	if err := clientOpts.saveCookies(); err != nil {
+		return err
+	}
+
+	return writeResults(results, provenance)
+}
+
+// collectDiscoveredURLs returns crawled URLs plus the links found on them
+func collectDiscoveredURLs(results []crawler.CrawlResult) []string {
+	var urls []string
+	for _, result := range results {
+		urls = append(urls, result.URL)
+		urls = append(urls, result.Links...)
+	}
+	return urls
+}
+
+// groupURLs groups URLs below the seed's path by their first path segment
+func groupURLs(urls []string, seedURL string) []urlGroup {
+	basePath := "/"
+	if parsed, err := url.Parse(seedURL); err == nil && parsed.Path != "" {
+		basePath = parsed.Path
+	}
+	if !strings.HasSuffix(basePath, "/") {
+		basePath += "/"
+	}
+
+	seen := make(map[string]bool)
+	groups := make(map[string]*urlGroup)
+	for _, rawURL := range urls {
+		if seen[rawURL] {
+			continue
+		}
+		seen[rawURL] = true
+
+		parsed, err := url.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+
+		rel, ok := strings.CutPrefix(parsed.Path, basePath)
+		if !ok || rel == "" {
+			continue // Seed page itself or outside the seed path
+		}
+
+		segment, _, _ := strings.Cut(rel, "/")
+		pattern := basePath + segment + "/*"
+
+		group, exists := groups[pattern]
+		if !exists {
+			group = &urlGroup{Pattern: pattern}
+			groups[pattern] = group
+		}
+		group.Count++
+		if len(group.Examples) < maxGroupExamples {
+			group.Examples = append(group.Examples, rawURL)
+		}
+	}
+
+	result := make([]urlGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+
+	// Largest groups first, then alphabetically
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Pattern < result[j].Pattern
+	})
+
+	return result
+}
+
+// scopeSession holds the state of an interactive scope refinement session
+type scopeSession struct {
+	groups  []urlGroup
+	include []string
+	exclude []string
+	out     io.Writer
+}
+
+// loop reads commands until the user runs the crawl or quits.
+// It returns true if the full crawl should be started.
+func (s *scopeSession) loop(in io.Reader) (bool, error) {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(s.out, "urlmap> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(s.out)
+			return false, scanner.Err()
+		}
+
+		done, run := s.handle(scanner.Text())
+		if done {
+			return run, nil
+		}
+	}
+}
+
+// handle executes a single command line and reports whether the session
+// is finished and, if so, whether the crawl should run
+func (s *scopeSession) handle(line string) (done bool, run bool) {
+	command, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	arg = strings.TrimSpace(arg)
+
+	switch command {
+	case "":
+		return false, false
+	case "list", "l":
+		s.printGroups()
+	case "include", "i":
+		s.addPattern(&s.include, arg)
+	case "exclude", "x":
+		s.addPattern(&s.exclude, arg)
+	case "reset":
+		s.include = nil
+		s.exclude = nil
+		fmt.Fprintln(s.out, "Filters cleared")
+	case "run", "r":
+		return true, true
+	case "quit", "q", "exit":
+		return true, false
+	case "help", "h", "?":
+		fmt.Fprintln(s.out, "Commands: list, include <N|pattern>, exclude <N|pattern>, reset, run, quit")
+	default:
+		fmt.Fprintf(s.out, "Unknown command: %s (type 'help')\n", command)
+	}
+	return false, false
+}
+
+// addPattern resolves a group number or pattern and appends it to the list
+func (s *scopeSession) addPattern(list *[]string, arg string) {
+	if arg == "" {
+		fmt.Fprintln(s.out, "A group number or pattern is required")
+		return
+	}
+
+	pattern := arg
+	if n, err := strconv.Atoi(arg); err == nil {
+		if n < 1 || n > len(s.groups) {
+			fmt.Fprintf(s.out, "No such group: %d\n", n)
+			return
+		}
+		pattern = s.groups[n-1].Pattern
+	}
+
+	if _, err := filter.Compile(pattern); err != nil {
+		fmt.Fprintf(s.out, "Invalid pattern: %v\n", err)
+		return
+	}
+
+	*list = append(*list, pattern)
+	fmt.Fprintf(s.out, "Added %s\n", pattern)
+}
+
+// printGroups shows the discovered groups and their current filter state
+func (s *scopeSession) printGroups() {
+	urlFilter, err := filter.New(s.include, s.exclude)
+	if err != nil {
+		urlFilter = nil
+	}
+
+	if len(s.groups) == 0 {
+		fmt.Fprintln(s.out, "No URL groups discovered below the start URL")
+	}
+
+	for i, group := range s.groups {
+		state := "+"
+		if len(group.Examples) > 0 && !urlFilter.Allow(group.Examples[0]) {
+			state = "-"
+		}
+		fmt.Fprintf(s.out, "%3d [%s] %-40s %5d URLs\n", i+1, state, group.Pattern, group.Count)
+		for _, example := range group.Examples {
+			fmt.Fprintf(s.out, "          %s\n", example)
+		}
+	}
+
+	if len(s.include) > 0 {
+		fmt.Fprintf(s.out, "Include: %s\n", strings.Join(s.include, ", "))
+	}
+	if len(s.exclude) > 0 {
+		fmt.Fprintf(s.out, "Exclude: %s\n", strings.Join(s.exclude, ", "))
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGroupURLs(t *testing.T) {
+	urls := []string{
+		"https://example.com/docs",
+		"https://example.com/docs/guide/intro",
+		"https://example.com/docs/guide/setup",
+		"https://example.com/docs/guide/setup", // duplicate
+		"https://example.com/docs/api/v1",
+		"https://example.com/docs/faq",
+		"https://example.com/blog/post", // outside seed path
+	}
+
+	groups := groupURLs(urls, "https://example.com/docs/")
+
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 groups, got %d: %+v", len(groups), groups)
+	}
+
+	if groups[0].Pattern != "/docs/guide/*" || groups[0].Count != 2 {
+		t.Errorf("expected largest group /docs/guide/* with 2 URLs, got %+v", groups[0])
+	}
+	if groups[1].Pattern != "/docs/api/*" || groups[2].Pattern != "/docs/faq/*" {
+		t.Errorf("groups with equal counts should be sorted alphabetically, got %s, %s", groups[1].Pattern, groups[2].Pattern)
+	}
+	if len(groups[0].Examples) != 2 {
+		t.Errorf("expected 2 examples, got %v", groups[0].Examples)
+	}
+}
+
+func TestScopeSessionHandle(t *testing.T) {
+	var out bytes.Buffer
+	session := &scopeSession{
+		groups: []urlGroup{
+			{Pattern: "/docs/*", Count: 3, Examples: []string{"https://example.com/docs/a"}},
+			{Pattern: "/blog/*", Count: 1, Examples: []string{"https://example.com/blog/a"}},
+		},
+		out: &out,
+	}
+
+	if done, _ := session.handle("exclude 2"); done {
+		t.Fatal("exclude should not end the session")
+	}
+	if len(session.exclude) != 1 || session.exclude[0] != "/blog/*" {
+		t.Errorf("expected /blog/* to be excluded, got %v", session.exclude)
+	}
+
+	session.handle("i /docs/guide/*")
+	if len(session.include) != 1 || session.include[0] != "/docs/guide/*" {
+		t.Errorf("expected include pattern, got %v", session.include)
+	}
+
+	session.handle("x 9")
+	if !strings.Contains(out.String(), "No such group") {
+		t.Errorf("expected error for invalid group, got: %s", out.String())
+	}
+
+	session.handle("x re:([")
+	if !strings.Contains(out.String(), "Invalid pattern") {
+		t.Errorf("expected error for invalid pattern, got: %s", out.String())
+	}
+
+	out.Reset()
+	session.handle("list")
+	if !strings.Contains(out.String(), "[-] /blog/*") {
+		t.Errorf("excluded group should be marked, got: %s", out.String())
+	}
+
+	session.handle("reset")
+	if len(session.include) != 0 || len(session.exclude) != 0 {
+		t.Error("reset should clear all patterns")
+	}
+
+	if done, run := session.handle("run"); !done || !run {
+		t.Error("run should end the session and start the crawl")
+	}
+	if done, run := session.handle("quit"); !done || run {
+		t.Error("quit should end the session without crawling")
+	}
+}
+
+func TestScopeSessionLoop(t *testing.T) {
+	var out bytes.Buffer
+	session := &scopeSession{out: &out}
+
+	run, err := session.loop(strings.NewReader("exclude /calendar/*\nrun\n"))
+	if err != nil {
+		t.Fatalf("loop() unexpected error: %v", err)
+	}
+	if !run {
+		t.Error("expected run to be requested")
+	}
+	if len(session.exclude) != 1 {
+		t.Errorf("expected one exclude pattern, got %v", session.exclude)
+	}
+
+	// EOF without run quits
+	run, err = (&scopeSession{out: &out}).loop(strings.NewReader("list\n"))
+	if err != nil || run {
+		t.Errorf("expected EOF to quit without running, got run=%v err=%v", run, err)
+	}
+}
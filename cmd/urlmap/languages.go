@@ -0,0 +1,26 @@
+package main
+
+import (
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// writeLanguageReport writes the --report-languages summary to w
+func writeLanguageReport(w io.Writer, results []crawler.CrawlResult) error {
+	if !reportLanguages {
+		return nil
+	}
+
+	summary := crawler.SummarizeLanguages(results)
+	report := output.LanguageReport{MissingLang: summary.MissingLang}
+	for _, count := range summary.Languages {
+		report.Languages = append(report.Languages, output.LanguageCountResult{
+			Language: count.Language,
+			Pages:    count.Pages,
+			Detected: count.Detected,
+		})
+	}
+	return output.WriteLanguageReport(w, report)
+}
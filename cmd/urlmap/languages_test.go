@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteLanguageReport(t *testing.T) {
+	t.Cleanup(func() { reportLanguages = false })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", Language: "en"},
+		{URL: "https://example.com/old", Language: "en", LanguageDetected: true},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeLanguageReport(&buf, results))
+	assert.Empty(t, buf.String())
+
+	reportLanguages = true
+	assert.NoError(t, writeLanguageReport(&buf, results))
+	assert.Equal(t, "Languages: 1\n  en: 2 pages (1 detected from text)\n"+
+		"Pages without a lang attribute: 1\n  https://example.com/old\n", buf.String())
+}
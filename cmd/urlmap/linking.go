@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/sitegraph"
+)
+
+// defaultLinkingTop is the number of pages listed by a bare --report-linking
+const defaultLinkingTop = 10
+
+// writeLinkingReport writes the top --report-linking pages by inbound and
+// outbound internal links to w
+func writeLinkingReport(w io.Writer, results []crawler.CrawlResult) error {
+	if reportLinking == 0 {
+		return nil
+	}
+	if reportLinking < 0 {
+		return fmt.Errorf("report-linking must be positive, got %d", reportLinking)
+	}
+
+	linking := sitegraph.AnalyzeLinking(sitePages(results), reportLinking)
+	return output.WriteLinkingReport(w, output.LinkingReport{
+		MostLinked:  linkCounts(linking.InDegree),
+		MostLinking: linkCounts(linking.OutDegree),
+	})
+}
+
+// linkCounts converts site graph degrees to report rows
+func linkCounts(degrees []sitegraph.Degree) []output.LinkCount {
+	counts := make([]output.LinkCount, 0, len(degrees))
+	for _, degree := range degrees {
+		counts = append(counts, output.LinkCount{URL: degree.URL, Count: degree.Count})
+	}
+	return counts
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteLinkingReport(t *testing.T) {
+	t.Cleanup(func() { reportLinking = 0 })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", Links: []string{"https://example.com/a", "https://example.com/b"}},
+		{URL: "https://example.com/a", Links: []string{"https://example.com/b"}},
+		{URL: "https://example.com/b"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeLinkingReport(&buf, results))
+	assert.Empty(t, buf.String())
+
+	reportLinking = 1
+	assert.NoError(t, writeLinkingReport(&buf, results))
+	assert.Contains(t, buf.String(), "      2 https://example.com/b\n")
+	assert.NotContains(t, buf.String(), "      1 https://example.com/a\n")
+
+	reportLinking = -1
+	assert.Error(t, writeLinkingReport(&buf, results))
+}
+
+func TestReportLinkingFlagDefault(t *testing.T) {
+	t.Cleanup(func() { reportLinking = 0 })
+
+	flag := rootCmd.Flags().Lookup("report-linking")
+	assert.NotNil(t, flag)
+	assert.NoError(t, rootCmd.Flags().Parse([]string{"--report-linking"}))
+	assert.Equal(t, defaultLinkingTop, reportLinking)
+}
@@ -1,19 +1,27 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log/slog"
-	"net/url"
+	"net/http"
+	neturl "net/url"
 	"os"
-	"os/signal"
-	"syscall"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/aoshimash/urlmap/internal/cache"
 	"github.com/aoshimash/urlmap/internal/client"
 	"github.com/aoshimash/urlmap/internal/config"
 	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/filter"
 	"github.com/aoshimash/urlmap/internal/output"
-	"github.com/aoshimash/urlmap/internal/progress"
+	"github.com/aoshimash/urlmap/internal/redact"
+	"github.com/aoshimash/urlmap/internal/replay"
+	"github.com/aoshimash/urlmap/internal/robots"
+	"github.com/aoshimash/urlmap/internal/url"
 	"github.com/spf13/cobra"
 )
 
@@ -26,28 +34,162 @@ var (
 
 // Command line flags
 var (
-	depth        int
-	verbose      bool
-	userAgent    string
-	concurrent   int
-	showProgress bool
-	rateLimit    float64
-	outputFormat string
+	depth            int
+	verbose          bool
+	userAgent        string
+	concurrent       int
+	showProgress     bool
+	plainOutput      bool
+	rateLimit        float64
+	rateLimitPerHost float64
+	outputFormat     string
+	outputDir        string
+	workdir          string
+	showDepth        bool
+	indentDepth      bool
+	outputLimit      int
+	sampleRate       float64
+	hashAlgo         string
+	extractMeta      bool
+	extractJSON      bool
+	scriptURLs       bool
 
 	// JavaScript rendering flags
-	jsRender     bool
-	jsBrowser    string
-	jsHeadless   bool
-	jsTimeout    time.Duration
-	jsWaitType   string
-	jsFallback   bool
-	jsAuto       bool
-	jsAutoStrict bool
-	jsThreshold  float64
-	jsPoolSize   int
+	jsRender      bool
+	jsBrowser     string
+	jsHeadless    bool
+	jsTimeout     time.Duration
+	jsWaitType    string
+	jsFallback    bool
+	jsAuto        bool
+	jsAutoStrict  bool
+	jsThreshold   float64
+	jsPoolSize    int
+	jsOnlyPaths   []string
+	jsNeverPaths  []string
+	jsDiagnostics bool
+	jsPartial     bool
+	jsConsent     bool
+	jsHashRoutes  bool
+	jsRoutes      bool
+	jsBlockSW     bool
+
+	// Render comparison flags
+	compareRender bool
+
+	// Bot protection flags
+	jsOnChallenge bool
+
+	// Device comparison flags
+	dualUA          bool
+	mobileUserAgent string
 
 	// Robots.txt flags
 	respectRobots bool
+	robotsTTL     time.Duration
+	politeness    string
+
+	// Preset and configuration file flags
+	preset     string
+	configFile string
+
+	// Scope filter flags
+	includePatterns []string
+	excludePatterns []string
+	allowFile       string
+	denyFile        string
+	maxPerDir       int
+	maxPagination   int
+	langPrefixes    []string
+	acceptLanguage  string
+	keepSessionIDs  bool
+
+	// Sampling flags
+	samplePerPattern int
+
+	// Input flags
+	readStdin bool
+	warmCache string
+
+	// Streaming flags
+	streamOutput    bool
+	ndjsonFile      string
+	resumeCrawl     bool
+	checkpointFile  string
+	checkpointEvery time.Duration
+
+	// Control flags
+	controlSocket string
+
+	// Error reporting flags
+	errorFormat string
+
+	// Request flags
+	headersFile   string
+	cookieJarFile string
+	dnsCacheTTL   time.Duration
+	dnsPrefetch   bool
+	cacheTTL      time.Duration
+	cacheSize     int
+	cacheDir      string
+	rewriteHosts  []string
+	debugRequests bool
+	replayFrom    string
+	redactNames   []string
+	redactSecrets bool
+
+	// Privacy flags
+	noStoreContent bool
+
+	// Failure handling flags
+	breakerThreshold int
+	breakerCoolOff   time.Duration
+	chaosRate        float64
+	chaosLatency     time.Duration
+	chaosSeed        int64
+
+	// Reproducibility flags
+	deterministic bool
+
+	// Egress flags
+	egressProxies []string
+	region        string
+
+	// Web archive flags
+	seedWayback bool
+
+	// Classification flags
+	classifyCmd string
+
+	// Timeout flags
+	requestTimeout time.Duration
+	connectTimeout time.Duration
+	headerTimeout  time.Duration
+	readTimeout    time.Duration
+	pageTimeout    time.Duration
+
+	// Change detection flags
+	stateFile         string
+	changesReport     string
+	incrementalCrawl  bool
+	incrementalSample float64
+
+	// Report flags
+	reportStructure bool
+	reportLinking   int
+	reportCycles    bool
+	reportDeadEnds  bool
+	reportTemplates bool
+	reportContent   bool
+	reportRetries   bool
+	reportIndex     string
+	reportHints     bool
+	reportIcons     bool
+	reportOGImages  bool
+	reportSW        bool
+	reportDomains   bool
+	reportLanguages bool
+	reportRobots    bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -63,9 +205,15 @@ Examples:
   urlmap https://example.com/docs/                    # Crawl only under /docs/ path
   urlmap https://example.com/                         # Crawl entire domain
   urlmap -d 3 -c 5 https://example.com/api/          # Limit depth and concurrency
-  urlmap --verbose https://example.com/guides/       # Enable verbose logging`,
-	Args: cobra.ExactArgs(1), // Require exactly one URL argument
-	RunE: runCrawl,
+  urlmap --verbose https://example.com/guides/       # Enable verbose logging
+  urlmap --preset link-check https://example.com/    # Use a named preset (see 'urlmap presets')
+  urlmap --sample 0.1 --limit 1000 https://example.com/  # Quick look at a large site
+  grep /docs/ seeds.txt | urlmap --stdin             # Read seed URLs from stdin`,
+	Args:              seedArgs, // Require exactly one URL argument unless --stdin is set
+	RunE:              runCrawl,
+	PersistentPreRunE: validateErrorFormat,
+	SilenceErrors:     true, // Errors are written by Execute in --error-format
+	SilenceUsage:      true, // Usage is only shown for usage errors
 }
 
 // versionCmd represents the version command
@@ -86,8 +234,21 @@ func init() {
 	rootCmd.Flags().StringVarP(&userAgent, "user-agent", "u", "urlmap/0.2.0 (+https://github.com/aoshimash/urlmap)", "Custom User-Agent string")
 	rootCmd.Flags().IntVarP(&concurrent, "concurrent", "c", 10, "Number of concurrent requests")
 	rootCmd.Flags().BoolVarP(&showProgress, "progress", "p", true, "Show progress indicators (default: true)")
+	rootCmd.Flags().BoolVar(&plainOutput, "plain", false, "Write progress as plain log lines instead of redrawing one line, even on a terminal (automatic when stderr is redirected)")
+	rootCmd.Flags().BoolVar(&plainOutput, "no-color", false, "Same as --plain (urlmap writes no colors or emoji; this only turns off the redrawn progress line)")
 	rootCmd.Flags().Float64VarP(&rateLimit, "rate-limit", "r", 0, "Rate limit requests per second (0 = no limit)")
-	rootCmd.Flags().StringVarP(&outputFormat, "output-format", "f", "text", "Output format (text, json, csv, xml)")
+	rootCmd.Flags().Float64Var(&rateLimitPerHost, "rate-limit-per-host", 0, "Rate limit requests per second to each host, on top of --rate-limit (0 = no limit)")
+	rootCmd.Flags().StringVarP(&outputFormat, "output-format", "f", "text", "Output format (text, json, csv, xml, sitemap, markdown, dot, graphml); a comma-separated list writes each format with --output-dir")
+	rootCmd.Flags().StringVar(&outputDir, "output-dir", "", "Write results to files in this directory (urls.txt, urls.json, urls.csv, urls.xml, sitemap.xml, urls.md) instead of stdout")
+	rootCmd.Flags().StringVar(&workdir, "workdir", "", "Write the artifacts of the run to this directory: results to output/, --state-file state.json, --ndjson results.ndjson, a copy of the log to urlmap.log and a manifest.json listing them (flags given explicitly take precedence)")
+	rootCmd.Flags().BoolVar(&showDepth, "show-depth", false, "Prefix each output URL with its crawl depth")
+	rootCmd.Flags().BoolVar(&indentDepth, "indent", false, "Indent text output by crawl depth")
+	rootCmd.Flags().IntVar(&outputLimit, "limit", 0, "Stop output after N URLs (0 = no limit)")
+	rootCmd.Flags().Float64Var(&sampleRate, "sample", 0, "Output a random sample of results, e.g. 0.1 for 10% (0 = all)")
+	rootCmd.Flags().StringVar(&hashAlgo, "hash", "", "Output a content hash next to each URL (supported: sha256)")
+	rootCmd.Flags().BoolVar(&extractMeta, "extract-metadata", false, "Extract page titles (shown by json, xml and markdown output), languages and robots directives (json and xml)")
+	rootCmd.Flags().BoolVar(&extractJSON, "extract-json", false, "Follow URLs found in JSON responses such as API endpoints (links in XML sitemaps and feeds are always followed)")
+	rootCmd.Flags().BoolVar(&scriptURLs, "extract-script-urls", false, "Follow URLs that inline scripts navigate to with window.location or router.push, marked low confidence in output")
 
 	// JavaScript rendering flags
 	rootCmd.Flags().BoolVar(&jsRender, "js-render", false, "Enable JavaScript rendering for SPA sites")
@@ -96,48 +257,694 @@ func init() {
 	rootCmd.Flags().DurationVar(&jsTimeout, "js-timeout", 30*time.Second, "Page load timeout for JavaScript rendering")
 	rootCmd.Flags().StringVar(&jsWaitType, "js-wait", "networkidle", "Wait condition for JavaScript rendering (networkidle, domcontentloaded, load)")
 	rootCmd.Flags().BoolVar(&jsFallback, "js-fallback", true, "Enable fallback to HTTP client on JavaScript rendering errors")
+	rootCmd.Flags().BoolVar(&jsDiagnostics, "js-diagnostics", false, "Record the console errors and failed network requests of each rendered page as render_diagnostics in JSON, XML and ND-JSON results")
+	rootCmd.Flags().BoolVar(&jsPartial, "js-partial-on-timeout", false, "Keep the links of a page whose rendering hits --js-timeout after it showed content, marking it partial_render, instead of failing it")
+	rootCmd.Flags().BoolVar(&jsHashRoutes, "js-hash-routes", false, "Crawl the routes of hash-router single page apps (https://example.com/#/about) as pages of their own, rendering each of them")
+	rootCmd.Flags().BoolVar(&jsRoutes, "js-enumerate-routes", false, "Also crawl the routes declared in the router of rendered pages (Vue Router, React Router), finding pages no link points to")
+	rootCmd.Flags().BoolVar(&jsBlockSW, "js-block-service-workers", false, "Keep rendered pages from registering service workers, so every page is loaded from the network instead of a worker's cache")
+	rootCmd.Flags().BoolVar(&jsConsent, "js-dismiss-consent", false, "Set common consent cookies and click the accept button of cookie consent banners (OneTrust, Cookiebot, Didomi, ...) on rendered pages")
 
 	// Automatic SPA detection flags
 	rootCmd.Flags().BoolVar(&jsAuto, "js-auto", false, "Enable automatic SPA detection")
 	rootCmd.Flags().BoolVar(&jsAutoStrict, "js-auto-strict", false, "Enable strict automatic detection with dynamic verification")
-	rootCmd.Flags().Float64Var(&jsThreshold, "js-threshold", 0.5, "SPA detection threshold (0.0-1.0)")
+	rootCmd.Flags().Float64Var(&jsThreshold, "js-threshold", 0.5, "SPA detection threshold (0.0-1.0, overrides detector.threshold in the config file)")
 
 	// Browser pool flags
 	rootCmd.Flags().IntVar(&jsPoolSize, "js-pool-size", 2, "Number of browser instances in the pool")
 
+	// Rendering scope flags
+	rootCmd.Flags().StringSliceVar(&jsOnlyPaths, "js-only-paths", nil, "Render only pages matching these patterns with JavaScript, even without --js-render; other pages are fetched with HTTP (glob, or 're:' regex; '/'-prefixed patterns match the path)")
+	rootCmd.Flags().StringSliceVar(&jsNeverPaths, "js-never-paths", nil, "Never render pages matching these patterns with JavaScript, also with --js-render, --js-auto or --js-only-paths")
+
+	// Render comparison flags
+	rootCmd.Flags().BoolVar(&compareRender, "compare-render", false, "Fetch each page via both HTTP and JavaScript rendering and report link count differences instead of URLs")
+
+	// Bot protection flags
+	rootCmd.Flags().BoolVar(&jsOnChallenge, "js-on-challenge", false, "Retry pages blocked by a bot protection challenge (Cloudflare, Akamai, ...) with JavaScript rendering")
+
+	// Device comparison flags
+	rootCmd.Flags().BoolVar(&dualUA, "dual-ua", false, "Fetch each page with desktop and mobile user agents and report pages whose status, redirect or links differ instead of URLs")
+	rootCmd.Flags().StringVar(&mobileUserAgent, "mobile-user-agent", client.DefaultMobileUserAgent, "User-Agent of the mobile pass of --dual-ua")
+
 	// Robots.txt flags
 	rootCmd.Flags().BoolVar(&respectRobots, "respect-robots", false, "Respect robots.txt rules and crawl delays")
+	rootCmd.Flags().DurationVar(&robotsTTL, "robots-ttl", robots.DefaultCacheTTL, "How long a fetched robots.txt is used before it is revalidated (0 = for the whole run)")
+	rootCmd.Flags().StringVar(&politeness, "politeness", crawler.PolitenessNormal, "How Crawl-delay, requests per host and retries of throttled URLs combine: strict (one request per host at a time, always keeping Crawl-delay, implies --respect-robots), normal, aggressive (--rate-limit replaces Crawl-delay)")
+
+	// Scope filter flags
+	rootCmd.Flags().StringSliceVar(&includePatterns, "include", nil, "Only crawl URLs matching these patterns (glob, or 're:' regex; '/'-prefixed patterns match the path)")
+	rootCmd.Flags().StringSliceVar(&excludePatterns, "exclude", nil, "Skip URLs matching these patterns (glob, or 're:' regex; '/'-prefixed patterns match the path)")
+	rootCmd.Flags().StringVar(&allowFile, "allow-file", "", "File with one --include pattern per line ('#' starts a comment)")
+	rootCmd.Flags().StringVar(&denyFile, "deny-file", "", "File with one --exclude pattern per line ('#' starts a comment)")
+	rootCmd.Flags().IntVar(&maxPerDir, "max-per-dir", 0, "Maximum URLs to crawl under each path directory (0 = no limit)")
+	rootCmd.Flags().IntVar(&maxPagination, "max-pagination", 0, "Also crawl the next page of paginated URLs (?page=N, /page/N/) without a link to it, up to page N (0 = off)")
+	rootCmd.Flags().StringSliceVar(&langPrefixes, "lang-prefix", nil, "Only crawl these locale path prefixes, e.g. en,ja (other locales are recorded as skipped alternates)")
+	rootCmd.Flags().StringVar(&acceptLanguage, "accept-language", "", "Send this Accept-Language header; its languages are used as --lang-prefix if that is not set")
+	rootCmd.Flags().BoolVar(&keepSessionIDs, "keep-session-ids", false, "Keep session-ID parameters (jsessionid, PHPSESSID, sid, ...) in URLs instead of stripping them")
+	rootCmd.Flags().IntVar(&samplePerPattern, "sample-per-pattern", 0, "Only crawl N URLs per path template (e.g. /products/{id}) and print estimated totals per template to stderr (0 = crawl everything)")
+
+	// Input flags
+	rootCmd.Flags().BoolVar(&readStdin, "stdin", false, "Read URLs from stdin, one per line ('#' starts a comment)")
+	rootCmd.Flags().StringVar(&warmCache, "warm-cache", "", "Results of a previous run (JSON or text); its URLs are crawled after newly discovered ones")
+
+	// Streaming flags
+	rootCmd.Flags().BoolVar(&streamOutput, "stream", false, "Print each URL to stdout as soon as it is crawled instead of sorted once the crawl is done (text, or json as one JSON object per line)")
+	rootCmd.Flags().StringVar(&ndjsonFile, "ndjson", "", "Append each result to this ND-JSON file as soon as it is crawled")
+	rootCmd.Flags().BoolVar(&resumeCrawl, "resume", false, "Continue the crawl recorded in --state-file, or in --ndjson: its pages are not fetched again and the links they found are crawled")
+	rootCmd.Flags().StringVar(&checkpointFile, "state-file", "", "Save the visited URLs, pending queue, results and statistics of the crawl to this file so --resume can continue it where it stopped (unrelated to --state)")
+	rootCmd.Flags().DurationVar(&checkpointEvery, "checkpoint-interval", crawler.DefaultCheckpointInterval, "How often to save --state-file while crawling")
+
+	// Control flags
+	rootCmd.Flags().StringVar(&controlSocket, "control-socket", "", "Accept commands such as 'urlmap control exclude' on this Unix socket during the crawl (default path "+defaultControlSocket+")")
+	rootCmd.Flags().Lookup("control-socket").NoOptDefVal = defaultControlSocket
+
+	// Request flags
+	rootCmd.Flags().StringVar(&headersFile, "headers-file", "", "JSON file mapping URL patterns to extra request headers")
+	rootCmd.Flags().StringVar(&cookieJarFile, "cookie-jar", "", "Load cookies from and save them to this file")
+	rootCmd.Flags().DurationVar(&dnsCacheTTL, "dns-cache-ttl", client.DefaultDNSCacheTTL, "How long resolved host addresses are reused (0 = disable the DNS cache)")
+	rootCmd.Flags().BoolVar(&dnsPrefetch, "dns-prefetch", false, "Resolve seed hosts before crawling")
+	rootCmd.Flags().DurationVar(&cacheTTL, "cache-ttl", client.DefaultResponseCacheTTL, "How long fetched and rendered pages are reused (0 = disable the response cache)")
+	rootCmd.Flags().IntVar(&cacheSize, "cache-size", client.DefaultResponseCacheSize, "Maximum number of pages held in the response cache (0 = disable)")
+	rootCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "Keep pages with an ETag or Last-Modified in this directory and revalidate them, so later runs only download changed pages")
+	rootCmd.Flags().StringSliceVar(&rewriteHosts, "rewrite-host", nil, "Fetch URLs on a host from another host while reporting the original URLs, e.g. example.com=staging.example.com")
+	rootCmd.Flags().BoolVar(&debugRequests, "debug-requests", false, "Log every HTTP request to stderr as a curl command that reproduces it")
+	rootCmd.Flags().StringSliceVar(&redactNames, "redact", nil, "Mask query parameters, headers and log fields whose names contain this text in logs and --debug-requests output (repeatable)")
+	rootCmd.Flags().BoolVar(&redactSecrets, "redact-secrets", false, "Mask common secret names (token, session, key, password, auth, cookie, ...) in logs and --debug-requests output")
+	rootCmd.Flags().StringVar(&replayFrom, "replay-from", "", "Serve every request from an archive instead of the network: a mirror directory (host/path, as written by wget --mirror) or a .warc/.warc.gz file")
+
+	// Privacy flags
+	rootCmd.Flags().BoolVar(&noStoreContent, "no-store-content", false, "Keep only URLs and metadata: no response cache, no cookies, no page content in memory beyond link extraction (not allowed with --cookie-jar or JavaScript rendering)")
+
+	// Failure handling flags
+	rootCmd.Flags().IntVar(&breakerThreshold, "breaker-threshold", crawler.DefaultBreakerThreshold, "Skip a host after this many consecutive connection failures (-1 = never)")
+	rootCmd.Flags().DurationVar(&breakerCoolOff, "breaker-cooloff", crawler.DefaultBreakerCoolOff, "How long to skip a failing host")
+	rootCmd.Flags().Float64Var(&chaosRate, "chaos", 0, "Inject a random delay, dropped connection or 503 response into this fraction of HTTP requests, e.g. 0.05, to test resilience (0 = off)")
+	rootCmd.Flags().DurationVar(&chaosLatency, "chaos-latency", client.DefaultChaosMaxLatency, "Longest delay injected by --chaos")
+	rootCmd.Flags().Int64Var(&chaosSeed, "chaos-seed", 0, "Random seed for reproducible --chaos runs (0 = random)")
+
+	// Reproducibility flags
+	rootCmd.Flags().BoolVar(&deterministic, "deterministic", false, "Crawl one page at a time in a reproducible order, with fixed random seeds and no timestamps, so runs against an unchanged site give identical output (ignores --concurrent)")
+
+	// Egress flags
+	rootCmd.Flags().StringSliceVar(&egressProxies, "egress", nil, "Fetch pages through a proxy tagged by region, e.g. eu=http://proxy-eu.example.com:8080 (repeatable; pages rotate across regions unless --region is set)")
+	rootCmd.Flags().StringVar(&region, "region", "", "Fetch every page through the --egress proxies of this region")
+
+	// Web archive flags
+	rootCmd.Flags().BoolVar(&seedWayback, "seed-wayback", false, "Look up historical URLs of the site in the Wayback Machine and, once the crawl is done, check those it no longer links to; the ones that still resolve are tagged historical")
+
+	// Classification flags
+	rootCmd.Flags().StringVar(&classifyCmd, "classify-cmd", "", "Run this command and send it each discovered URL as a JSON line on stdin; it answers each with a JSON line such as {\"action\":\"skip\"} or {\"action\":\"enqueue\",\"tags\":[\"product\"]}")
+
+	// Timeout flags (0 = no limit)
+	rootCmd.Flags().DurationVar(&connectTimeout, "connect-timeout", client.DefaultConnectTimeout, "Time allowed to establish a connection, including TLS")
+	rootCmd.Flags().DurationVar(&headerTimeout, "header-timeout", client.DefaultResponseHeaderTimeout, "Time allowed to wait for response headers")
+	rootCmd.Flags().DurationVar(&readTimeout, "read-timeout", client.DefaultReadTimeout, "Time a response body may stall without sending data")
+	rootCmd.Flags().DurationVar(&requestTimeout, "request-timeout", 0, "Total time allowed per request attempt (0 = no limit)")
+	rootCmd.Flags().DurationVar(&pageTimeout, "page-timeout", 2*time.Minute, "Overall deadline per page, including retries and rendering (0 = no limit)")
+
+	// Change detection flags
+	rootCmd.Flags().StringVar(&stateFile, "state", "", "Compare with and update the crawl state stored in this file")
+	rootCmd.Flags().StringVar(&changesReport, "changes-report", "", "Write pages added, removed or changed since the stored state to this file (requires --state)")
+	rootCmd.Flags().BoolVar(&incrementalCrawl, "incremental", false, "Crawl only pages that are new or changed since the stored state, by sitemap lastmod or ETag/Last-Modified (requires --state)")
+	rootCmd.Flags().Float64Var(&incrementalSample, "incremental-sample", 0.05, "Fraction of unchanged pages crawled again anyway with --incremental, to catch changes the sitemap and validators miss")
+
+	// Report flags
+	rootCmd.Flags().BoolVar(&reportStructure, "report-structure", false, "Print pages per depth, average links per page, max breadth and the longest path chain to stderr")
+	rootCmd.Flags().IntVar(&reportLinking, "report-linking", 0, "Print the N pages with the most inbound and outbound internal links to stderr (default N is 10)")
+	rootCmd.Flags().Lookup("report-linking").NoOptDefVal = strconv.Itoa(defaultLinkingTop)
+	rootCmd.Flags().BoolVar(&reportCycles, "report-cycles", false, "Print redirect loops, pages linking only to themselves and link clusters unreachable from the homepage to stderr")
+	rootCmd.Flags().BoolVar(&reportDeadEnds, "report-dead-ends", false, "Print pages without outgoing internal links and pages linked from only one page to stderr")
+	rootCmd.Flags().BoolVar(&reportTemplates, "report-templates", false, "Print discovered URLs grouped by path template (numeric, UUID and hash segments as placeholders) with counts to stderr")
+	rootCmd.Flags().BoolVar(&reportContent, "report-content", false, "Print the distribution of response content types (HTML, JSON, ...) and body sizes to stderr")
+	rootCmd.Flags().BoolVar(&reportRetries, "report-retries", false, "Print URLs that needed more than one request attempt, with each attempt's outcome and latency, and totals per host to stderr")
+	rootCmd.Flags().StringVar(&reportIndex, "report-index", "", "Compare the crawl with URLs exported from Google Search Console or Bing Webmaster Tools (CSV or one URL per line) and print indexed-but-not-linked and linked-but-not-indexed URLs to stderr")
+	rootCmd.Flags().BoolVar(&reportHints, "report-hints", false, "Collect preload, prefetch and preconnect hints of each page and print the third-party origins they name, with the number of pages, to stderr")
+	rootCmd.Flags().BoolVar(&reportIcons, "report-icons", false, "Collect the favicons and web app manifest of each page, fetch them and the icons the manifest lists once per origin, and print whether they resolve to stderr")
+	rootCmd.Flags().BoolVar(&reportOGImages, "report-og-images", false, "Check the og:image of each page once per image (status 200, image Content-Type, at least 200x200) and print broken social preview images with their pages to stderr")
+	rootCmd.Flags().BoolVar(&reportSW, "report-service-workers", false, "Record the service worker each page registers (from inline scripts, or from the browser on rendered pages), fetch each worker once and print the URLs it precaches for offline use, marking those the crawl did not find, to stderr")
+	rootCmd.Flags().BoolVar(&reportDomains, "report-domains", false, "Record the third-party domains each page links to or loads scripts, stylesheets, images and frames from, and print them with the number of pages to stderr")
+	rootCmd.Flags().BoolVar(&reportLanguages, "report-languages", false, "Print the number of pages per language (lang attribute, or detected from the text) and the pages without a lang attribute to stderr")
+	rootCmd.Flags().BoolVar(&reportRobots, "report-robots", false, "Print how many pages use each robots meta tag and X-Robots-Tag directive (noindex, noarchive, noimageindex, max-snippet, ...) to stderr")
+
+	// Preset and configuration file flags
+	rootCmd.Flags().StringVar(&preset, "preset", "", "Apply a named flag preset (see 'urlmap presets')")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", config.DefaultConfigPath(), "Path to the configuration file")
+
+	// Error reporting flags
+	rootCmd.PersistentFlags().StringVar(&errorFormat, "error-format", "text", "Format of errors written to stderr (text, json)")
 
 	// Add subcommands
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(presetsCmd)
+	rootCmd.AddCommand(interactiveCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(detectCmd)
+	rootCmd.AddCommand(renderCmd)
+	rootCmd.AddCommand(compareCmd)
+	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(rerunCmd)
+	rootCmd.AddCommand(simulateCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(mergeCmd)
+	rootCmd.AddCommand(controlCmd)
+	controlCmd.AddCommand(controlExcludeCmd)
+
+	compareCmd.Flags().StringVar(&compareBase, "base", "", "Base environment: seed URL or stored results file")
+	compareCmd.Flags().StringVar(&compareTarget, "target", "", "Target environment: seed URL or stored results file")
+	checkCmd.Flags().BoolVar(&checkExternal, "external", false, "Also fetch the links the crawl did not follow (other sites, pages outside the crawl scope) once each")
+	simulateCmd.Flags().IntVar(&simulateMaxPages, "max-pages", 500, "Page budget to simulate")
+	benchCmd.Flags().IntVar(&benchPages, "pages", 1000, "Number of pages in the synthetic site")
+	benchCmd.Flags().IntVar(&benchFanout, "fanout", 10, "Number of child pages each synthetic page links to")
+	renderCmd.Flags().BoolVar(&renderHeadful, "headful", false, "Show the page in a visible browser window")
+	renderCmd.Flags().BoolVar(&renderPause, "pause", false, "Keep the page open once loaded until Enter is pressed (requires --headful)")
+	mergeCmd.Flags().StringVarP(&mergeOutput, "output", "o", "", "Write the merged results to this file instead of stdout (.ndjson or .jsonl for ND-JSON)")
+	controlCmd.PersistentFlags().StringVar(&controlSocketPath, "socket", defaultControlSocket, "Control socket of the running crawl")
+
+	// Crawl subcommands share the crawl flags of the root command
+	interactiveCmd.Flags().AddFlagSet(rootCmd.Flags())
+	compareCmd.Flags().AddFlagSet(rootCmd.Flags())
+	checkCmd.Flags().AddFlagSet(rootCmd.Flags())
+	simulateCmd.Flags().AddFlagSet(rootCmd.Flags())
+	benchCmd.Flags().AddFlagSet(rootCmd.Flags())
+	for _, name := range verifyFlags {
+		verifyCmd.Flags().AddFlag(rootCmd.Flags().Lookup(name))
+	}
+	for _, name := range detectFlags {
+		detectCmd.Flags().AddFlag(rootCmd.Flags().Lookup(name))
+	}
+	for _, name := range renderFlags {
+		renderCmd.Flags().AddFlag(rootCmd.Flags().Lookup(name))
+	}
 }
 
-func runCrawl(cmd *cobra.Command, args []string) error {
-	// Validate URL argument
-	targetURL := args[0]
-	parsedURL, err := url.Parse(targetURL)
+func runCrawl(cmd *cobra.Command, args []string) (err error) {
+	// Apply preset flag values before anything reads them
+	if err := applyPreset(cmd, preset); err != nil {
+		return err
+	}
+	run, err := openWorkdir(cmd, args)
+	if err != nil {
+		return err
+	}
+	defer run.Close()
+
+	// Report every invalid option before anything is fetched
+	if err := validateCrawlOptions(); err != nil {
+		return err
+	}
+	freezeOutputTime()
+
+	// Collect seed URLs from the argument or stdin
+	seeds, err := seedURLs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	// Validate URL arguments
+	for _, targetURL := range seeds {
+		if err := validateTargetURL(targetURL); err != nil {
+			return usageError(err)
+		}
+	}
+	if checkpointFile != "" && len(seeds) > 1 {
+		return usageError(fmt.Errorf("--state-file records the crawl of a single seed URL, got %d", len(seeds)))
+	}
+
+	// Set up logging based on verbose flag
+	logger := setupLogging()
+
+	// Build include/exclude filter from flags
+	urlFilter, err := newURLFilter()
+	if err != nil {
+		return err
+	}
+
+	clientOpts, err := loadClientOptions(logger)
+	if err != nil {
+		return err
+	}
+
+	detectorConfig, err := loadDetectorConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	knownURLs, err := loadKnownURLs()
+	if err != nil {
+		return err
+	}
+
+	indexedURLs, err := loadIndexedURLs()
+	if err != nil {
+		return err
+	}
+
+	classifierCmd, err := startClassifier()
+	if err != nil {
+		return err
+	}
+	defer stopClassifier(classifierCmd, logger)
+
+	provenance := newProvenance(cmd, seeds, time.Now())
+	defer func() { run.writeManifest(provenance, err, logger) }()
+	stream, err := openResultStream(logger, provenance)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	live, err := openURLStream(cmd.OutOrStdout(), logger, provenance)
+	if err != nil {
+		return err
+	}
+
+	controlServer, err := startControlServer(urlFilter, logger)
+	if err != nil {
+		return err
+	}
+	defer controlServer.Close()
+
+	ctx, stop := notifyShutdown(context.Background())
+	defer stop()
+
+	clientOpts.prefetchDNS(ctx, seeds, logger)
+	incrementalPlan := newIncrementalPlan(clientOpts, logger)
+
+	// Crawl each seed with its own scope and combine the results
+	var allResults []crawler.CrawlResult
+	var templateCounts []crawler.TemplateCount
+	var crawledURLs, failedURLs int
+	for _, targetURL := range seeds {
+		if ctx.Err() != nil {
+			break
+		}
+
+		// Log the start of crawl operation with structured logging
+		config.LogCrawlStart(targetURL, depth, concurrent, userAgent)
+
+		crawlerConfig := newCrawlerConfig(logger, clientOpts)
+		crawlerConfig.URLFilter = urlFilter
+		crawlerConfig.DetectorConfig = detectorConfig
+		crawlerConfig.KnownURLs = knownURLs
+		crawlerConfig.HistoricalURLs = historicalURLs(ctx, targetURL, logger)
+		crawlerConfig.Classify = classifier(classifierCmd)
+		stream.configure(crawlerConfig)
+		checkpoint, err := openCheckpoint(targetURL, logger)
+		if err != nil {
+			return err
+		}
+		checkpoint.configure(crawlerConfig)
+		incrementalPlan.configure(crawlerConfig, targetURL)
+		live.configure(crawlerConfig)
+
+		results, stats, err := executeCrawl(ctx, crawlerConfig, targetURL, logger)
+		if err != nil {
+			return crawlFailedError(err)
+		}
+		merged := mergeResumed(incrementalPlan.skipped(), checkpoint.merge(results))
+		live.writeKept(merged, results)
+		allResults = append(allResults, merged...)
+		crawledURLs += stats.CrawledURLs
+		failedURLs += stats.FailedURLs
+		templateCounts = append(templateCounts, stats.TemplateCounts...)
+
+		// Log completion stats to stderr
+		config.LogCrawlComplete(targetURL, stats.CrawledURLs, stats.FailedURLs)
+		logHistorical(stats, logger)
+		if stats.BotBlocked > 0 {
+			logger.Warn("URLs blocked by bot protection, try --js-on-challenge or a lower --concurrent", "count", stats.BotBlocked)
+		}
+		if stats.LocaleSkipped > 0 {
+			logger.Info("Skipped alternate locale URLs", "count", stats.LocaleSkipped, "urls", stats.SkippedAlternates)
+		}
+	}
+
+	merged := stream.merge(allResults)
+	live.writeKept(merged, allResults)
+	allResults = merged
+
+	clientOpts.logCacheStats(logger)
+
+	if err := clientOpts.saveCookies(); err != nil {
+		return err
+	}
+
+	if err := reportChanges(allResults, logger); err != nil {
+		return err
+	}
+
+	if err := writeSampleReport(cmd.ErrOrStderr(), templateCounts); err != nil {
+		return err
+	}
+	if err := writeStructureReport(cmd.ErrOrStderr(), allResults); err != nil {
+		return err
+	}
+	if err := writeLinkingReport(cmd.ErrOrStderr(), allResults); err != nil {
+		return err
+	}
+	if err := writeCycleReport(cmd.ErrOrStderr(), allResults); err != nil {
+		return err
+	}
+	if err := writeDeadEndReport(cmd.ErrOrStderr(), allResults); err != nil {
+		return err
+	}
+	if err := writeTemplateReport(cmd.ErrOrStderr(), allResults); err != nil {
+		return err
+	}
+	if err := writeContentReport(cmd.ErrOrStderr(), allResults); err != nil {
+		return err
+	}
+	if err := writeRetryReport(cmd.ErrOrStderr(), allResults); err != nil {
+		return err
+	}
+	if err := writeIndexReport(cmd.ErrOrStderr(), allResults, seeds, indexedURLs); err != nil {
+		return err
+	}
+	if err := writeHintReport(cmd.ErrOrStderr(), allResults); err != nil {
+		return err
+	}
+	if err := writeDomainReport(cmd.ErrOrStderr(), allResults); err != nil {
+		return err
+	}
+	if err := writeLanguageReport(cmd.ErrOrStderr(), allResults); err != nil {
+		return err
+	}
+	if err := writeRobotsReport(cmd.ErrOrStderr(), allResults); err != nil {
+		return err
+	}
+	if err := writeIconReport(ctx, cmd.ErrOrStderr(), allResults, clientOpts); err != nil {
+		return err
+	}
+	if err := writeOGImageReport(ctx, cmd.ErrOrStderr(), allResults, clientOpts); err != nil {
+		return err
+	}
+	if err := writeServiceWorkerReport(ctx, cmd.ErrOrStderr(), allResults, clientOpts); err != nil {
+		return err
+	}
+
+	switch {
+	case compareRender:
+		err = writeRenderComparison(allResults, logger)
+	case dualUA:
+		err = writeDeviceComparison(allResults, logger)
+	case live != nil:
+		// Results were printed as they were crawled
+	default:
+		err = writeResults(allResults, provenance)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Results are written even when the crawl was interrupted or URLs failed,
+	// the exit code tells scripts which happened
+	if err := interruptedError(ctx); err != nil {
+		return err
+	}
+	return failedURLsError(failedURLs, crawledURLs+failedURLs, "failed")
+}
+
+// seedArgs validates positional arguments: exactly one URL, or none with --stdin
+func seedArgs(cmd *cobra.Command, args []string) error {
+	if readStdin {
+		if len(args) > 0 {
+			return fmt.Errorf("cannot combine --stdin with URL arguments")
+		}
+		return nil
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// seedURLs returns the seed URLs from the positional argument or from stdin
+func seedURLs(cmd *cobra.Command, args []string) ([]string, error) {
+	if !readStdin {
+		return args, nil
+	}
+
+	seeds, err := url.ReadURLList(cmd.InOrStdin())
+	if err != nil {
+		return nil, err
+	}
+	if len(seeds) == 0 {
+		return nil, fmt.Errorf("no URLs provided on stdin")
+	}
+	return seeds, nil
+}
+
+// validateTargetURL checks that the seed URL is an absolute http(s) URL
+func validateTargetURL(targetURL string) error {
+	parsedURL, err := neturl.Parse(targetURL)
 	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
 		return fmt.Errorf("invalid URL: %s (must be http or https)", targetURL)
 	}
+	return nil
+}
 
-	// Set up logging based on verbose flag
+// newURLFilter builds the scope filter from --include/--exclude and the
+// patterns in --allow-file/--deny-file
+func newURLFilter() (*filter.Filter, error) {
+	include := append([]string(nil), includePatterns...)
+	exclude := append([]string(nil), excludePatterns...)
+
+	if allowFile != "" {
+		patterns, err := filter.LoadPatterns(allowFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allow file: %w", err)
+		}
+		include = append(include, patterns...)
+	}
+	if denyFile != "" {
+		patterns, err := filter.LoadPatterns(denyFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deny file: %w", err)
+		}
+		exclude = append(exclude, patterns...)
+	}
+
+	urlFilter, err := filter.New(include, exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter pattern: %w", err)
+	}
+	return urlFilter, nil
+}
+
+// setupLogging configures the default logger from the verbose flag
+func setupLogging() *slog.Logger {
 	loggingConfig := config.NewLoggingConfig(verbose)
+	loggingConfig.Redactor = newRedactor()
+	loggingConfig.Output = logOutput
 	loggingConfig.SetupLogger()
-	logger := slog.Default()
+	return slog.Default()
+}
 
-	// Log the start of crawl operation with structured logging
-	config.LogCrawlStart(targetURL, depth, concurrent, userAgent)
+// newRedactor builds the redactor for --redact and --redact-secrets, or nil
+// when neither is set
+func newRedactor() *redact.Redactor {
+	patterns := append([]string(nil), redactNames...)
+	if redactSecrets {
+		patterns = append(patterns, redact.DefaultPatterns...)
+	}
+	return redact.New(patterns)
+}
 
-	// Create progress configuration
-	progressConfig := &progress.Config{
-		ShowProgress: showProgress,
-		RateLimit:    rateLimit,
-		Logger:       logger,
+// clientOptions holds request state shared by every client of a run
+type clientOptions struct {
+	headers     map[string]string
+	headerRules *client.HeaderRules
+	cookieJar   *client.CookieJar
+	dnsCache    *client.DNSCache
+	cache       *client.ResponseCache
+	httpCache   *cache.Cache // --cache-dir (optional)
+	rewrites    map[string]string
+	requestLog  io.Writer
+	redactor    *redact.Redactor
+	transport   http.RoundTripper
+	chaos       *client.ChaosConfig
+	egress      []client.Egress
+	robots      *robots.RobotsChecker // Shared by all seeds (only with --respect-robots)
+	render      *filter.Filter        // --js-only-paths and --js-never-paths (optional)
+}
+
+// loadClientOptions loads the --headers-file rules and the --cookie-jar file
+// and creates the robots.txt cache shared by all seeds.
+// The cookie jar is always created so cookies are shared across seeds, or
+// discards every cookie with --no-store-content.
+func loadClientOptions(logger *slog.Logger) (*clientOptions, error) {
+	opts := &clientOptions{cookieJar: client.NewCookieJar()}
+
+	if dnsCacheTTL > 0 {
+		opts.dnsCache = client.NewDNSCache(dnsCacheTTL)
+	}
+
+	if respectRobots || politeness == crawler.PolitenessStrict {
+		opts.robots = robots.NewRobotsChecker(userAgent, logger)
+		opts.robots.SetCacheTTL(robotsTTL)
+	}
+
+	if noStoreContent {
+		if err := validateNoStoreContent(); err != nil {
+			return nil, err
+		}
+		opts.cookieJar = client.NewDiscardCookieJar()
+	} else if cacheTTL > 0 && cacheSize > 0 {
+		opts.cache = client.NewResponseCache(cacheTTL, cacheSize)
+	}
+
+	if cacheDir != "" {
+		httpCache, err := cache.Open(cacheDir)
+		if err != nil {
+			return nil, err
+		}
+		opts.httpCache = httpCache
+	}
+
+	if debugRequests {
+		opts.requestLog = os.Stderr
+		opts.redactor = newRedactor()
+	}
+
+	if chaosRate < 0 || chaosRate > 1 {
+		return nil, fmt.Errorf("--chaos must be between 0 and 1, got %g", chaosRate)
+	}
+	if chaosRate > 0 {
+		opts.chaos = &client.ChaosConfig{Rate: chaosRate, MaxLatency: chaosLatency, Seed: randomSeed(chaosSeed)}
+	}
+
+	if replayFrom != "" {
+		if err := validateReplay(); err != nil {
+			return nil, err
+		}
+		archive, err := replay.Open(replayFrom)
+		if err != nil {
+			return nil, err
+		}
+		opts.transport = archive
+	}
+
+	if acceptLanguage != "" {
+		opts.headers = map[string]string{"Accept-Language": acceptLanguage}
+	}
+
+	rewrites, err := crawler.ParseHostRewrites(rewriteHosts)
+	if err != nil {
+		return nil, err
+	}
+	opts.rewrites = rewrites
+
+	egress, err := loadEgress()
+	if err != nil {
+		return nil, err
 	}
+	opts.egress = egress
+
+	if len(jsOnlyPaths) > 0 || len(jsNeverPaths) > 0 {
+		render, err := filter.New(jsOnlyPaths, jsNeverPaths)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rendering path pattern: %w", err)
+		}
+		opts.render = render
+	}
+
+	if headersFile != "" {
+		rules, err := client.LoadHeaderRules(headersFile)
+		if err != nil {
+			return nil, err
+		}
+		opts.headerRules = rules
+	}
+
+	if cookieJarFile != "" {
+		jar, err := client.LoadCookieJar(cookieJarFile)
+		if err != nil {
+			return nil, err
+		}
+		opts.cookieJar = jar
+	}
+
+	return opts, nil
+}
+
+// loadKnownURLs reads the URLs of a previous run from --warm-cache
+func loadKnownURLs() ([]string, error) {
+	if warmCache == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(warmCache)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open previous results: %w", err)
+	}
+	defer file.Close()
+
+	return output.ReadResultURLs(file)
+}
+
+// prefetchDNS resolves the seed hosts ahead of the crawl when --dns-prefetch is set
+func (o *clientOptions) prefetchDNS(ctx context.Context, seeds []string, logger *slog.Logger) {
+	if !dnsPrefetch || o.dnsCache == nil {
+		return
+	}
+	for _, seed := range seeds {
+		host, err := url.ExtractDomain(seed)
+		if err != nil {
+			continue
+		}
+		if err := o.dnsCache.Prefetch(ctx, host); err != nil {
+			logger.Warn("DNS prefetch failed", "host", host, "error", err)
+		}
+	}
+}
+
+// logCacheStats reports DNS, response, HTTP and robots.txt cache usage at the end of a run
+func (o *clientOptions) logCacheStats(logger *slog.Logger) {
+	if o.dnsCache != nil {
+		stats := o.dnsCache.Stats()
+		logger.Info("DNS cache statistics", "hits", stats.Hits, "misses", stats.Misses, "hosts", stats.Entries)
+	}
+	if o.cache != nil {
+		stats := o.cache.Stats()
+		logger.Info("Response cache statistics",
+			"http_hits", stats.HTTPHits, "http_misses", stats.HTTPMisses,
+			"js_hits", stats.JSHits, "js_misses", stats.JSMisses,
+			"evictions", stats.Evictions, "entries", stats.Entries)
+	}
+	if o.httpCache != nil {
+		stats := o.httpCache.Stats()
+		logger.Info("HTTP cache statistics",
+			"revalidated", stats.Revalidated, "fetched", stats.Fetched, "stored", stats.Stored, "dir", cacheDir)
+	}
+	if o.robots != nil {
+		stats := o.robots.Stats()
+		logger.Info("robots.txt cache statistics",
+			"hits", stats.Hits, "misses", stats.Misses, "revalidated", stats.Revalidated, "domains", stats.Entries)
+	}
+}
+
+// saveCookies writes the cookie jar back to --cookie-jar, if given
+func (o *clientOptions) saveCookies() error {
+	if cookieJarFile == "" {
+		return nil
+	}
+	return o.cookieJar.Save(cookieJarFile)
+}
+
+// crawlLocales returns the locale prefixes to crawl from --lang-prefix,
+// falling back to the languages in --accept-language
+func crawlLocales() []string {
+	if len(langPrefixes) > 0 {
+		return langPrefixes
+	}
+	return crawler.LocalesFromAcceptLanguage(acceptLanguage)
+}
+
+// newCrawlerConfig builds the crawler configuration from command line flags
+func newCrawlerConfig(logger *slog.Logger, clientOpts *clientOptions) *crawler.Config {
+	// Create progress configuration
+	progressConfig := newProgressConfig(logger)
 
 	// Create JavaScript configuration if enabled
 	var jsConfig *client.JSConfig
-	if jsRender || jsAuto || jsAutoStrict {
+	if jsRender || jsAuto || jsAutoStrict || compareRender || jsOnChallenge || len(jsOnlyPaths) > 0 {
 		jsConfig = &client.JSConfig{
 			Enabled:     jsRender || jsAuto || jsAutoStrict, // 自動検出の場合も有効にする
 			BrowserType: jsBrowser,
@@ -150,17 +957,40 @@ func runCrawl(cmd *cobra.Command, args []string) error {
 			StrictMode:  jsAutoStrict,
 			Threshold:   jsThreshold,
 			PoolSize:    jsPoolSize,
+			Diagnostics: jsDiagnostics,
+
+			PartialOnTimeout: jsPartial,
+			DismissConsent:   jsConsent,
+			EnumerateRoutes:  jsRoutes,
+
+			DetectServiceWorkers: reportSW,
+			BlockServiceWorkers:  jsBlockSW,
 		}
 	}
 
 	// Create unified client configuration
 	unifiedConfig := &client.UnifiedConfig{
-		UserAgent: userAgent,
-		JSConfig:  jsConfig,
+		UserAgent:             userAgent,
+		Headers:               clientOpts.headers,
+		HeaderRules:           clientOpts.headerRules,
+		CookieJar:             clientOpts.cookieJar,
+		DNSCache:              clientOpts.dnsCache,
+		RequestLog:            clientOpts.requestLog,
+		Redactor:              clientOpts.redactor,
+		Transport:             clientOpts.transport,
+		Chaos:                 clientOpts.chaos,
+		HTTPCache:             clientOpts.httpCache,
+		ResponseCache:         clientOpts.cache,
+		Timeout:               requestTimeout,
+		ConnectTimeout:        connectTimeout,
+		ResponseHeaderTimeout: headerTimeout,
+		ReadTimeout:           readTimeout,
+		JSConfig:              jsConfig,
+		CompareRender:         compareRender,
 	}
 
 	// Create crawler configuration
-	crawlerConfig := &crawler.Config{
+	return &crawler.Config{
 		MaxDepth:       depth,
 		SameDomain:     true, // For now, limit to same domain
 		SamePathPrefix: true, // デフォルトでパスプレフィックスフィルタリングを有効にする
@@ -171,20 +1001,52 @@ func runCrawl(cmd *cobra.Command, args []string) error {
 		ProgressConfig: progressConfig,
 		JSConfig:       unifiedConfig,
 		RespectRobots:  respectRobots,
+		RobotsChecker:  clientOpts.robots,
+		RenderFilter:   clientOpts.render,
+		Politeness:     politeness,
+		PageTimeout:    pageTimeout,
+		MaxPerDir:      maxPerDir,
+		MaxPagination:  maxPagination,
+		ErrorBodySize:  errorBodySize(),
+		LangPrefixes:   crawlLocales(),
+		CompareRender:  compareRender,
+		DualUA:         dualUA,
+		HostRewrites:   clientOpts.rewrites,
+		Egress:         clientOpts.egress,
+		Region:         strings.ToLower(region),
+
+		MobileUserAgent:  mobileUserAgent,
+		SamplePerPattern: samplePerPattern,
+		KeepSessionIDs:   keepSessionIDs,
+		HashRoutes:       jsHashRoutes,
+		ExtractMetadata:  extractMeta,
+		ExtractJSON:      extractJSON,
+		ExtractHints:     reportHints,
+		ExtractIcons:     reportIcons,
+		ExtractOGImage:   reportOGImages,
+
+		ExtractServiceWorkers: reportSW,
+		ExtractExternalLinks:  checkExternal,
+		ExtractDomains:        reportDomains,
+		DetectLanguage:        extractMeta || reportLanguages,
+		ExtractRobots:         extractMeta || reportRobots,
+		BreakerThreshold:      breakerThreshold,
+		BreakerCoolOff:        breakerCoolOff,
+
+		ExtractScriptURLs: scriptURLs,
+		RenderBlocked:     jsOnChallenge,
+		Deterministic:     deterministic,
 	}
+}
 
+// executeCrawl runs a concurrent crawl, stopping gracefully when ctx is cancelled
+func executeCrawl(ctx context.Context, crawlerConfig *crawler.Config, targetURL string, logger *slog.Logger) ([]crawler.CrawlResult, *crawler.CrawlStats, error) {
 	// Create and configure the concurrent crawler
 	c, err := crawler.NewConcurrentCrawler(crawlerConfig)
 	if err != nil {
-		return fmt.Errorf("failed to create crawler: %w", err)
+		return nil, nil, fmt.Errorf("failed to create crawler: %w", err)
 	}
 
-	// Set up signal handling for graceful shutdown
-
-	// Create a channel to listen for interrupt signals
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-
 	// Start crawling in a goroutine
 	type crawlResult struct {
 		results []crawler.CrawlResult
@@ -208,7 +1070,7 @@ func runCrawl(cmd *cobra.Command, args []string) error {
 		results = result.results
 		stats = result.stats
 		crawlErr = result.err
-	case <-sigChan:
+	case <-ctx.Done():
 		logger.Info("Received interrupt signal, stopping crawl...")
 		c.Cancel()
 		// Wait for crawl to stop gracefully
@@ -220,46 +1082,97 @@ func runCrawl(cmd *cobra.Command, args []string) error {
 	}
 
 	if crawlErr != nil {
-		return fmt.Errorf("crawl failed: %w", crawlErr)
+		return nil, nil, fmt.Errorf("crawl failed: %w", crawlErr)
 	}
 
-	// Extract all URLs from the results
-	var allURLs []string
+	return results, stats, nil
+}
+
+// writeResults writes crawl results to stdout in the selected output format,
+// or to --output-dir in each selected format, with the provenance of the run
+// in JSON and XML
+func writeResults(results []crawler.CrawlResult, provenance *output.Provenance) error {
+	// Convert crawl results to output results
+	urlResults := make([]output.URLResult, 0, len(results))
 	for _, result := range results {
-		allURLs = append(allURLs, result.URL)
+		urlResult := output.URLResult{
+			URL:       result.URL,
+			Timestamp: result.FetchTime,
+			Depth:     result.Depth,
+			Title:     result.Title,
+			Parent:    result.Parent,
+			Links:     result.Links,
+
+			LowConfidence: result.LowConfidence,
+			BotProtection: result.BotProtection,
+			Historical:    result.Historical,
+			Tags:          result.Tags,
+			Egress:        result.Egress,
+
+			Language:         result.Language,
+			LanguageDetected: result.LanguageDetected,
+			Robots:           result.Robots,
+
+			RenderDiagnostics: outputRenderDiagnostics(result.RenderDiagnostics),
+			PartialRender:     result.PartialRender,
+		}
+		if hashAlgo != "" {
+			urlResult.Hash = result.ContentHash
+		}
+		urlResults = append(urlResults, urlResult)
+	}
+
+	// Validate output formats
+	formats, err := output.ParseFormats(outputFormat)
+	if err != nil {
+		return err
+	}
+	if len(formats) > 1 && outputDir == "" {
+		return fmt.Errorf("writing several output formats (%s) requires --output-dir", outputFormat)
 	}
 
 	// Create output configuration
 	outputConfig := &output.OutputConfig{
-		Format: output.OutputFormat(outputFormat),
+		Format:      formats[0],
+		ShowDepth:   showDepth,
+		IndentDepth: indentDepth,
+		Limit:       outputLimit,
+		Sample:      sampleRate,
+		ShowHash:    hashAlgo != "",
+		Confidence:  scriptURLs,
+		Seed:        uint64(randomSeed(0)),
+		Provenance:  finished(provenance, time.Now()),
 	}
 
-	// Validate output format
-	switch outputConfig.Format {
-	case output.FormatText, output.FormatJSON, output.FormatCSV, output.FormatXML:
-		// Valid format
-	default:
-		return fmt.Errorf("unsupported output format: %s (supported: text, json, csv, xml)", outputFormat)
+	// Write one file per format, sharing a single selection of URLs
+	if outputDir != "" {
+		if err := output.WriteResultsToDir(outputDir, urlResults, formats, outputConfig); err != nil {
+			return fmt.Errorf("failed to output URLs: %w", err)
+		}
+		return nil
 	}
 
 	// Output URLs to stdout (logs are already going to stderr)
-	if err := output.OutputURLsWithFormat(allURLs, outputConfig); err != nil {
+	if err := output.OutputResultsWithFormat(urlResults, outputConfig); err != nil {
 		return fmt.Errorf("failed to output URLs: %w", err)
 	}
 
-	// Log completion stats to stderr
-	config.LogCrawlComplete(targetURL, stats.CrawledURLs, stats.FailedURLs)
-
 	return nil
 }
 
+// Execute runs the command line and writes any error to stderr
 func Execute() error {
-	return rootCmd.Execute()
+	commandLine = os.Args[1:]
+	markUsageErrors(rootCmd)
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
+		reportError(cmd.ErrOrStderr(), cmd, err)
+	}
+	return err
 }
 
 func main() {
 	if err := Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
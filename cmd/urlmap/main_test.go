@@ -3,16 +3,26 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"log/slog"
+	"net/http"
+	neturl "net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/crawler"
 )
 
 func TestRootCommand(t *testing.T) {
+	server := newSiteServer(nil, "")
+	defer server.Close()
+
 	tests := []struct {
 		name    string
 		args    []string
@@ -20,7 +30,7 @@ func TestRootCommand(t *testing.T) {
 	}{
 		{
 			name:    "Valid URL",
-			args:    []string{"https://example.com"},
+			args:    []string{server.URL + "/"},
 			wantErr: false,
 		},
 		{
@@ -550,3 +560,299 @@ func TestInvalidOutputFormat(t *testing.T) {
 		t.Errorf("Expected error message to contain '%s', got: %s", expectedErrorMessage, err.Error())
 	}
 }
+
+func TestSeedArgs(t *testing.T) {
+	defer func() { readStdin = false }()
+
+	readStdin = false
+	assert.Error(t, seedArgs(rootCmd, nil), "URL argument is required without --stdin")
+	assert.NoError(t, seedArgs(rootCmd, []string{"https://example.com"}))
+
+	readStdin = true
+	assert.NoError(t, seedArgs(rootCmd, nil))
+	assert.Error(t, seedArgs(rootCmd, []string{"https://example.com"}), "--stdin cannot be combined with URL arguments")
+}
+
+func TestSeedURLsFromStdin(t *testing.T) {
+	defer func() { readStdin = false }()
+	readStdin = true
+
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader("# seeds\nhttps://example.com/a\n\nhttps://example.com/b\n"))
+
+	seeds, err := seedURLs(cmd, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/a", "https://example.com/b"}, seeds)
+
+	cmd.SetIn(strings.NewReader("\n# nothing\n"))
+	_, err = seedURLs(cmd, nil)
+	assert.Error(t, err)
+}
+
+func TestLoadClientOptions(t *testing.T) {
+	t.Cleanup(func() {
+		headersFile = ""
+		cookieJarFile = ""
+	})
+
+	dir := t.TempDir()
+	headersFile = dir + "/headers.json"
+	cookieJarFile = dir + "/cookies.json"
+	assert.NoError(t, os.WriteFile(headersFile, []byte(`[{"pattern": "/preview/*", "headers": {"X-Token": "abc"}}]`), 0o644))
+
+	opts, err := loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, opts.headerRules.Len())
+	assert.NotNil(t, opts.cookieJar)
+
+	// A missing cookie jar file is created on save
+	assert.NoError(t, opts.saveCookies())
+	_, err = os.Stat(cookieJarFile)
+	assert.NoError(t, err)
+
+	headersFile = dir + "/missing.json"
+	_, err = loadClientOptions(slog.Default())
+	assert.Error(t, err)
+}
+
+func TestLoadClientOptions_RenderPaths(t *testing.T) {
+	t.Cleanup(func() { jsOnlyPaths, jsNeverPaths = nil, nil })
+
+	opts, err := loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.Nil(t, opts.render)
+
+	jsOnlyPaths, jsNeverPaths = []string{"/app/*"}, []string{"/app/help/*"}
+	opts, err = loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/app/*"}, opts.render.Includes())
+	assert.Equal(t, []string{"/app/help/*"}, opts.render.Excludes())
+	assert.Same(t, opts.render, newCrawlerConfig(nil, opts).RenderFilter)
+
+	jsOnlyPaths = []string{"re:("}
+	_, err = loadClientOptions(slog.Default())
+	assert.ErrorContains(t, err, "invalid rendering path pattern")
+}
+
+func TestLoadClientOptions_RewriteHost(t *testing.T) {
+	t.Cleanup(func() { rewriteHosts = nil })
+
+	rewriteHosts = []string{"example.com=staging.example.com"}
+	opts, err := loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"example.com": "staging.example.com"}, opts.rewrites)
+	assert.Equal(t, opts.rewrites, newCrawlerConfig(nil, opts).HostRewrites)
+
+	rewriteHosts = []string{"example.com"}
+	_, err = loadClientOptions(slog.Default())
+	assert.Error(t, err)
+}
+
+func TestLoadClientOptions_DebugRequests(t *testing.T) {
+	t.Cleanup(func() { debugRequests = false })
+
+	opts, err := loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.Nil(t, opts.requestLog)
+
+	debugRequests = true
+	opts, err = loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.Equal(t, os.Stderr, opts.requestLog)
+	assert.Equal(t, opts.requestLog, newCrawlerConfig(nil, opts).JSConfig.RequestLog)
+}
+
+func TestNewRedactor(t *testing.T) {
+	t.Cleanup(func() { redactNames, redactSecrets = nil, false })
+
+	assert.Nil(t, newRedactor())
+
+	redactNames = []string{"tracking"}
+	redactor := newRedactor()
+	if !assert.NotNil(t, redactor) {
+		return
+	}
+	assert.True(t, redactor.Matches("x-tracking-id"))
+	assert.False(t, redactor.Matches("access_token"))
+
+	redactSecrets = true
+	assert.True(t, newRedactor().Matches("access_token"))
+
+	debugRequests = true
+	t.Cleanup(func() { debugRequests = false })
+	opts, err := loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.NotNil(t, opts.redactor)
+	assert.Equal(t, opts.redactor, newCrawlerConfig(nil, opts).JSConfig.Redactor)
+}
+
+func TestLoadClientOptions_NoStoreContent(t *testing.T) {
+	originalJSRender := jsRender
+	t.Cleanup(func() { noStoreContent, jsRender, cookieJarFile = false, originalJSRender, "" })
+	jsRender = false
+
+	opts, err := loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.NotNil(t, opts.cache)
+
+	noStoreContent = true
+	opts, err = loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.Nil(t, opts.cache)
+	assert.Nil(t, newCrawlerConfig(nil, opts).JSConfig.ResponseCache)
+
+	u, _ := neturl.Parse("https://example.com/")
+	opts.cookieJar.SetCookies(u, []*http.Cookie{{Name: "session", Value: "abc"}})
+	assert.Empty(t, opts.cookieJar.Cookies(u))
+
+	jsRender = true
+	_, err = loadClientOptions(slog.Default())
+	assert.ErrorContains(t, err, "--js-render")
+
+	jsRender = false
+	cookieJarFile = filepath.Join(t.TempDir(), "cookies.json")
+	_, err = loadClientOptions(slog.Default())
+	assert.ErrorContains(t, err, "--cookie-jar")
+}
+
+func TestLoadClientOptions_CacheDir(t *testing.T) {
+	t.Cleanup(func() { cacheDir, noStoreContent = "", false })
+
+	opts, err := loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.Nil(t, opts.httpCache)
+
+	cacheDir = filepath.Join(t.TempDir(), "http-cache")
+	opts, err = loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.NotNil(t, opts.httpCache)
+	assert.Equal(t, opts.httpCache, newCrawlerConfig(nil, opts).JSConfig.HTTPCache)
+	assert.DirExists(t, cacheDir)
+
+	noStoreContent = true
+	_, err = loadClientOptions(slog.Default())
+	assert.ErrorContains(t, err, "--cache-dir")
+}
+
+func TestLoadClientOptions_Chaos(t *testing.T) {
+	t.Cleanup(func() { chaosRate, chaosSeed = 0, 0 })
+
+	opts, err := loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.Nil(t, opts.chaos)
+
+	chaosRate, chaosSeed = 0.05, 7
+	opts, err = loadClientOptions(slog.Default())
+	assert.NoError(t, err)
+	assert.Equal(t, &client.ChaosConfig{Rate: 0.05, MaxLatency: chaosLatency, Seed: 7}, opts.chaos)
+	assert.Equal(t, opts.chaos, newCrawlerConfig(nil, opts).JSConfig.Chaos)
+
+	chaosRate = 1.5
+	_, err = loadClientOptions(slog.Default())
+	assert.Error(t, err)
+}
+
+func TestRandomSeed(t *testing.T) {
+	t.Cleanup(func() { deterministic = false })
+
+	assert.Equal(t, int64(0), randomSeed(0))
+	assert.Equal(t, int64(7), randomSeed(7))
+
+	deterministic = true
+	assert.Equal(t, int64(deterministicSeed), randomSeed(0))
+	assert.Equal(t, int64(7), randomSeed(7))
+	assert.True(t, newCrawlerConfig(nil, &clientOptions{}).Deterministic)
+}
+
+func TestNewURLFilter(t *testing.T) {
+	t.Cleanup(func() {
+		includePatterns, excludePatterns = nil, nil
+		allowFile, denyFile = "", ""
+	})
+
+	dir := t.TempDir()
+	allowFile = dir + "/allow.txt"
+	denyFile = dir + "/deny.txt"
+	assert.NoError(t, os.WriteFile(allowFile, []byte("# docs only\n/docs/*\n"), 0o644))
+	assert.NoError(t, os.WriteFile(denyFile, []byte("/docs/private/*\n"), 0o644))
+	excludePatterns = []string{"/docs/old/*"}
+
+	urlFilter, err := newURLFilter()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/docs/*"}, urlFilter.Includes())
+	assert.Equal(t, []string{"/docs/old/*", "/docs/private/*"}, urlFilter.Excludes())
+	assert.True(t, urlFilter.Allow("https://example.com/docs/intro"))
+	assert.False(t, urlFilter.Allow("https://example.com/docs/private/keys"))
+	assert.False(t, urlFilter.Allow("https://example.com/blog/post"))
+
+	assert.NoError(t, os.WriteFile(denyFile, []byte("re:([\n"), 0o644))
+	_, err = newURLFilter()
+	assert.ErrorContains(t, err, "line 1")
+
+	allowFile = dir + "/missing.txt"
+	_, err = newURLFilter()
+	assert.Error(t, err)
+}
+
+func TestLoadKnownURLs(t *testing.T) {
+	t.Cleanup(func() { warmCache = "" })
+
+	urls, err := loadKnownURLs()
+	assert.NoError(t, err)
+	assert.Nil(t, urls)
+
+	warmCache = t.TempDir() + "/previous.json"
+	assert.NoError(t, os.WriteFile(warmCache, []byte(`{"urls": [{"url": "https://example.com/a"}], "total": 1}`), 0o644))
+
+	urls, err = loadKnownURLs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/a"}, urls)
+
+	warmCache = t.TempDir() + "/missing.json"
+	_, err = loadKnownURLs()
+	assert.Error(t, err)
+}
+
+func TestWriteResults_OutputDir(t *testing.T) {
+	originalFormat := outputFormat
+	t.Cleanup(func() { outputFormat, outputDir = originalFormat, "" })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/"},
+		{URL: "https://example.com/about", Depth: 1},
+	}
+
+	outputFormat = "json,sitemap"
+	assert.ErrorContains(t, writeResults(results, nil), "--output-dir")
+
+	outputDir = t.TempDir()
+	assert.NoError(t, writeResults(results, nil))
+
+	sitemap, err := os.ReadFile(filepath.Join(outputDir, "sitemap.xml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(sitemap), "<loc>https://example.com/about</loc>")
+	urls, err := os.ReadFile(filepath.Join(outputDir, "urls.json"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(urls), `"url": "https://example.com/about"`)
+
+	outputFormat = "json,yaml"
+	assert.ErrorContains(t, writeResults(results, nil), "yaml")
+}
+
+func TestWriteResults_Markdown(t *testing.T) {
+	originalFormat := outputFormat
+	t.Cleanup(func() { outputFormat, outputDir = originalFormat, "" })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", Title: "Home"},
+		{URL: "https://example.com/about", Depth: 1, Parent: "https://example.com/", Title: "About"},
+	}
+
+	outputFormat = "markdown"
+	outputDir = t.TempDir()
+	assert.NoError(t, writeResults(results, nil))
+
+	tree, err := os.ReadFile(filepath.Join(outputDir, "urls.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "- [Home](https://example.com/)\n  - [About](https://example.com/about)\n", string(tree))
+}
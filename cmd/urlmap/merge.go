@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var mergeOutput string
+
+// mergeCmd combines the results of several crawls into one file
+var mergeCmd = &cobra.Command{
+	Use:   "merge <file>... [-o merged.json]",
+	Short: "Merge the results of several crawls",
+	Long: `Combine the results of crawls sharded by section or run from several regions
+into one file. Each input is a JSON document (--output-format json) or an
+ND-JSON file (--ndjson). URLs found by several crawls appear once: the result
+fetched last wins, so a page that failed in one crawl and succeeded in a later
+one is kept as crawled.
+
+The merged results are written as a JSON document, or as ND-JSON when the
+--output file ends in .ndjson or .jsonl, which keeps status codes and links.
+The provenance of the merged file lists the seeds of every input crawl.
+Totals are printed to stderr.
+
+Examples:
+  urlmap merge docs.json blog.json -o merged.json
+  urlmap merge eu.ndjson us.ndjson -o merged.ndjson`,
+	Args:         cobra.MinimumNArgs(1),
+	RunE:         runMerge,
+	SilenceUsage: true,
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	start := time.Now()
+
+	sets := make([]*output.ResultSet, 0, len(args))
+	for _, path := range args {
+		set, err := readResultSet(path)
+		if err != nil {
+			return err
+		}
+		sets = append(sets, set)
+	}
+	results, stats := output.MergeResultSets(sets)
+
+	provenance := output.MergeProvenance(sets)
+	provenance.Version, provenance.Commit = version, commit
+	for _, path := range args {
+		provenance.Config = append(provenance.Config, output.Setting{Name: "input", Value: path})
+	}
+	if provenance.StartTime.IsZero() {
+		provenance.StartTime, provenance.EndTime = start, time.Now()
+	}
+
+	w := cmd.OutOrStdout()
+	if mergeOutput != "" {
+		file, err := os.Create(mergeOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create merged file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := writeMerged(w, results, &provenance); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Merged %d files: %d results, %d duplicates, %d URLs (%d failed)\n",
+		stats.Sets, stats.Results, stats.Duplicates, stats.URLs, stats.Failed)
+	return nil
+}
+
+// readResultSet reads the stored results of one crawl
+func readResultSet(path string) (*output.ResultSet, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open results: %w", err)
+	}
+	defer file.Close()
+
+	set, err := output.ReadResultSet(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(set.Results) == 0 {
+		return nil, fmt.Errorf("no results in %s", path)
+	}
+	return set, nil
+}
+
+// writeMerged writes the merged results in the format of --output
+func writeMerged(w io.Writer, results []output.NDJSONResult, provenance *output.Provenance) error {
+	switch strings.ToLower(filepath.Ext(mergeOutput)) {
+	case ".ndjson", ".jsonl":
+		return output.WriteNDJSON(w, results, provenance)
+	}
+
+	urlResults := make([]output.URLResult, len(results))
+	for i, result := range results {
+		urlResults[i] = result.URLResult()
+	}
+	config := &output.OutputConfig{Format: output.FormatJSON, Provenance: provenance}
+	if err := output.WriteResults(w, urlResults, config); err != nil {
+		return fmt.Errorf("failed to write merged results: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMerge(t *testing.T) {
+	t.Cleanup(func() { mergeOutput = "" })
+	dir := t.TempDir()
+
+	docs := filepath.Join(dir, "docs.json")
+	require.NoError(t, os.WriteFile(docs, []byte(`{"urls":[
+		{"url":"https://example.com/","timestamp":"2024-05-01T12:00:00Z"},
+		{"url":"https://example.com/docs/","timestamp":"2024-05-01T12:00:01Z","depth":1}
+	],"timestamp":"2024-05-01T12:01:00Z","total":2}`), 0o644))
+	blog := filepath.Join(dir, "blog.ndjson")
+	require.NoError(t, os.WriteFile(blog, []byte(
+		`{"provenance":{"version":"v1","seeds":["https://example.com/blog/"],"start_time":"2024-05-01T11:00:00Z","end_time":"2024-05-01T11:05:00Z","config":[]}}
+{"url":"https://example.com/","depth":1,"status":200,"timestamp":"2024-05-01T11:00:01Z"}
+{"url":"https://example.com/blog/","depth":0,"status":200,"timestamp":"2024-05-01T11:00:00Z"}
+`), 0o644))
+
+	// JSON document on stdout
+	var stdout, stderr bytes.Buffer
+	mergeCmd.SetOut(&stdout)
+	mergeCmd.SetErr(&stderr)
+	t.Cleanup(func() { mergeCmd.SetOut(nil); mergeCmd.SetErr(nil) })
+	require.NoError(t, runMerge(mergeCmd, []string{docs, blog}))
+
+	var merged output.CrawlOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &merged))
+	assert.Equal(t, 3, merged.Total)
+	assert.Equal(t, "https://example.com/", merged.URLs[0].URL)
+	assert.Equal(t, 0, merged.URLs[0].Depth, "the later result of the docs crawl should win")
+	require.NotNil(t, merged.Provenance)
+	assert.Equal(t, []string{"https://example.com/blog/"}, merged.Provenance.Seeds)
+	assert.Equal(t, []output.Setting{{Name: "input", Value: docs}, {Name: "input", Value: blog}}, merged.Provenance.Config)
+	assert.Equal(t, "Merged 2 files: 4 results, 1 duplicates, 3 URLs (0 failed)\n", stderr.String())
+
+	// ND-JSON file keeping status codes
+	mergeOutput = filepath.Join(dir, "merged.ndjson")
+	require.NoError(t, runMerge(mergeCmd, []string{blog, docs}))
+	records, err := output.LoadNDJSON(mergeOutput)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, 200, records[1].StatusCode)
+
+	// Inputs without results are rejected
+	empty := filepath.Join(dir, "empty.ndjson")
+	require.NoError(t, os.WriteFile(empty, nil, 0o644))
+	assert.ErrorContains(t, runMerge(mergeCmd, []string{docs, empty}), "no results in")
+}
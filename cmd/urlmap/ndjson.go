@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// resultStream appends crawl results to the --ndjson file as they arrive
+type resultStream struct {
+	writer  *output.NDJSONWriter
+	resumed []crawler.CrawlResult // Results already in the file (with --resume)
+	logger  *slog.Logger
+}
+
+// openResultStream opens the --ndjson file, loading the results it holds when
+// --resume is set without --state-file, and records the provenance of the run in it. It returns
+// nil without --ndjson.
+func openResultStream(logger *slog.Logger, provenance *output.Provenance) (*resultStream, error) {
+	if resumeCrawl && ndjsonFile == "" && checkpointFile == "" {
+		return nil, fmt.Errorf("--resume requires --state-file or --ndjson")
+	}
+	if ndjsonFile == "" {
+		return nil, nil
+	}
+
+	stream := &resultStream{logger: logger}
+	if resumeCrawl && checkpointFile == "" {
+		records, err := output.LoadNDJSON(ndjsonFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			stream.resumed = append(stream.resumed, crawlResultFromNDJSON(record))
+		}
+	}
+
+	writer, err := output.OpenNDJSON(ndjsonFile)
+	if err != nil {
+		return nil, err
+	}
+	stream.writer = writer
+	if err := writer.WriteProvenance(*provenance); err != nil {
+		writer.Close()
+		return nil, err
+	}
+	return stream, nil
+}
+
+// configure makes crawlerConfig stream its results and continue the resumed run
+func (s *resultStream) configure(crawlerConfig *crawler.Config) {
+	if s == nil {
+		return
+	}
+	crawlerConfig.OnResult = s.write
+	crawlerConfig.Resume = s.resumed
+}
+
+// write appends one result; failures are logged so the crawl keeps going
+func (s *resultStream) write(result crawler.CrawlResult) {
+	if err := s.writer.Write(ndjsonFromCrawlResult(result)); err != nil {
+		s.logger.Warn("Failed to append result", "file", ndjsonFile, "url", result.URL, "error", err)
+	}
+}
+
+// merge returns the resumed results that were not crawled again, followed by results
+func (s *resultStream) merge(results []crawler.CrawlResult) []crawler.CrawlResult {
+	if s == nil {
+		return results
+	}
+	return mergeResumed(s.resumed, results)
+}
+
+// mergeResumed returns the resumed results that were not crawled again,
+// followed by results
+func mergeResumed(resumed, results []crawler.CrawlResult) []crawler.CrawlResult {
+	if len(resumed) == 0 {
+		return results
+	}
+
+	recrawled := make(map[string]bool, len(results))
+	for _, result := range results {
+		recrawled[result.URL] = true
+	}
+
+	var merged []crawler.CrawlResult
+	for _, result := range resumed {
+		if !recrawled[result.URL] {
+			merged = append(merged, result)
+		}
+	}
+	return append(merged, results...)
+}
+
+// Close closes the file
+func (s *resultStream) Close() error {
+	if s == nil {
+		return nil
+	}
+	return s.writer.Close()
+}
+
+// ndjsonFromCrawlResult converts a crawl result to an ND-JSON line
+func ndjsonFromCrawlResult(result crawler.CrawlResult) output.NDJSONResult {
+	record := output.NDJSONResult{
+		URL:           result.URL,
+		Depth:         result.Depth,
+		Parent:        result.Parent,
+		StatusCode:    result.StatusCode,
+		Hash:          result.ContentHash,
+		Title:         result.Title,
+		Links:         result.Links,
+		Timestamp:     result.FetchTime,
+		ScriptLinks:   result.ScriptLinks,
+		LowConfidence: result.LowConfidence,
+		Server:        result.Server,
+		ErrorBody:     result.ErrorBody,
+		ErrorKind:     result.ErrorKind,
+		BotProtection: result.BotProtection,
+		Historical:    result.Historical,
+		Tags:          result.Tags,
+		Egress:        result.Egress,
+
+		Language:         result.Language,
+		LanguageDetected: result.LanguageDetected,
+		Robots:           result.Robots,
+
+		RenderDiagnostics: outputRenderDiagnostics(result.RenderDiagnostics),
+		PartialRender:     result.PartialRender,
+	}
+	if result.Error != nil {
+		record.Error = result.Error.Error()
+	}
+	for _, external := range result.ExternalDomains {
+		record.ExternalDomains = append(record.ExternalDomains, output.ExternalDomain{Domain: external.Domain, Kinds: external.Kinds})
+	}
+	return record
+}
+
+// crawlResultFromNDJSON restores a crawl result from an ND-JSON line
+func crawlResultFromNDJSON(record output.NDJSONResult) crawler.CrawlResult {
+	result := crawler.CrawlResult{
+		URL:           record.URL,
+		Depth:         record.Depth,
+		Parent:        record.Parent,
+		StatusCode:    record.StatusCode,
+		ContentHash:   record.Hash,
+		Title:         record.Title,
+		Links:         record.Links,
+		FetchTime:     record.Timestamp,
+		ScriptLinks:   record.ScriptLinks,
+		LowConfidence: record.LowConfidence,
+		Server:        record.Server,
+		ErrorBody:     record.ErrorBody,
+		ErrorKind:     record.ErrorKind,
+		BotProtection: record.BotProtection,
+		Historical:    record.Historical,
+		Tags:          record.Tags,
+		Egress:        record.Egress,
+
+		Language:         record.Language,
+		LanguageDetected: record.LanguageDetected,
+		Robots:           record.Robots,
+
+		PartialRender: record.PartialRender,
+	}
+	if record.RenderDiagnostics != nil {
+		result.RenderDiagnostics = &client.RenderDiagnostics{
+			ConsoleErrors:  record.RenderDiagnostics.ConsoleErrors,
+			FailedRequests: record.RenderDiagnostics.FailedRequests,
+		}
+	}
+	if record.Error != "" {
+		result.Error = errors.New(record.Error)
+	}
+	for _, external := range record.ExternalDomains {
+		result.ExternalDomains = append(result.ExternalDomains, crawler.ExternalDomain{Domain: external.Domain, Kinds: external.Kinds})
+	}
+	return result
+}
+
+// outputRenderDiagnostics converts the diagnostics of a rendered page for
+// the results, nil if there are none
+func outputRenderDiagnostics(diagnostics *client.RenderDiagnostics) *output.RenderDiagnostics {
+	if diagnostics == nil {
+		return nil
+	}
+	return &output.RenderDiagnostics{
+		ConsoleErrors:  diagnostics.ConsoleErrors,
+		FailedRequests: diagnostics.FailedRequests,
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+func TestOpenResultStream(t *testing.T) {
+	t.Cleanup(func() { ndjsonFile, resumeCrawl = "", false })
+
+	stream, err := openResultStream(slog.Default(), &output.Provenance{Version: "test"})
+	assert.NoError(t, err)
+	assert.Nil(t, stream)
+
+	resumeCrawl = true
+	_, err = openResultStream(slog.Default(), &output.Provenance{Version: "test"})
+	assert.ErrorContains(t, err, "--ndjson")
+}
+
+func TestResultStream_Resume(t *testing.T) {
+	server := newSiteServer([]string{"/a", "/b"}, "")
+	defer server.Close()
+
+	originalProgress, originalJSRender := showProgress, jsRender
+	t.Cleanup(func() {
+		ndjsonFile, resumeCrawl = "", false
+		showProgress, jsRender = originalProgress, originalJSRender
+	})
+	showProgress, jsRender = false, false
+
+	// An interrupted run that only crawled the seed
+	ndjsonFile = filepath.Join(t.TempDir(), "results.ndjson")
+	interrupted := `{"url":"` + server.URL + `/","depth":0,"status":200,"links":["` + server.URL + `/a","` + server.URL + `/b"]}` + "\n"
+	require.NoError(t, os.WriteFile(ndjsonFile, []byte(interrupted), 0o644))
+
+	resumeCrawl = true
+	stream, err := openResultStream(slog.Default(), &output.Provenance{Version: "test"})
+	require.NoError(t, err)
+	require.Len(t, stream.resumed, 1)
+
+	clientOpts, err := loadClientOptions(slog.Default())
+	require.NoError(t, err)
+	crawlerConfig := newCrawlerConfig(slog.Default(), clientOpts)
+	stream.configure(crawlerConfig)
+
+	results, _, err := executeCrawl(context.Background(), crawlerConfig, server.URL, slog.Default())
+	require.NoError(t, err)
+	require.NoError(t, stream.Close())
+	assert.Len(t, results, 2)
+
+	var urls []string
+	for _, result := range stream.merge(results) {
+		urls = append(urls, result.URL)
+	}
+	sort.Strings(urls)
+	assert.Equal(t, []string{server.URL + "/", server.URL + "/a", server.URL + "/b"}, urls)
+
+	// The file now holds the whole crawl
+	records, err := output.LoadNDJSON(ndjsonFile)
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, server.URL+"/", records[0].URL)
+
+	// The resumed run recorded its provenance after the interrupted results
+	data, err := os.ReadFile(ndjsonFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), interrupted+`{"provenance":{"version":"test"`)
+}
+
+func TestNDJSONRenderDiagnostics(t *testing.T) {
+	result := crawler.CrawlResult{
+		URL: "https://example.com/app",
+		RenderDiagnostics: &client.RenderDiagnostics{
+			ConsoleErrors:  []string{"uncaught: TypeError: x is undefined"},
+			FailedRequests: []string{"GET https://example.com/api/items: 500"},
+		},
+	}
+
+	record := ndjsonFromCrawlResult(result)
+	require.NotNil(t, record.RenderDiagnostics)
+	assert.Equal(t, result.RenderDiagnostics.ConsoleErrors, record.RenderDiagnostics.ConsoleErrors)
+	assert.Equal(t, result.RenderDiagnostics, crawlResultFromNDJSON(record).RenderDiagnostics)
+
+	assert.Nil(t, ndjsonFromCrawlResult(crawler.CrawlResult{URL: "https://example.com/"}).RenderDiagnostics)
+}
+
+func TestNDJSONPartialRender(t *testing.T) {
+	record := ndjsonFromCrawlResult(crawler.CrawlResult{URL: "https://example.com/app", PartialRender: true})
+	assert.True(t, record.PartialRender)
+	assert.True(t, crawlResultFromNDJSON(record).PartialRender)
+	assert.True(t, record.URLResult().PartialRender)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"  // Register the GIF decoder for og:image dimensions
+	_ "image/jpeg" // Register the JPEG decoder for og:image dimensions
+	_ "image/png"  // Register the PNG decoder for og:image dimensions
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// Smallest og:image social networks show as a preview
+const (
+	ogImageMinWidth  = 200
+	ogImageMinHeight = 200
+)
+
+// ogImageHeaderSize is how many bytes of an og:image are fetched to read its
+// dimensions
+const ogImageHeaderSize = 64 * 1024
+
+// writeOGImageReport checks the og:image of every crawled page once per
+// image and writes the --report-og-images summary to w
+func writeOGImageReport(ctx context.Context, w io.Writer, results []crawler.CrawlResult, clientOpts *clientOptions) error {
+	if !reportOGImages {
+		return nil
+	}
+
+	httpClient := newReportClient(clientOpts)
+
+	var report output.OGImageReport
+	for _, use := range crawler.CollectOGImages(results) {
+		if ctx.Err() != nil {
+			break
+		}
+		result := checkOGImage(ctx, httpClient, use.URL)
+		result.Pages = use.Pages
+		report.Images = append(report.Images, result)
+	}
+	return output.WriteOGImageReport(w, report)
+}
+
+// checkOGImage checks with a HEAD request that an og:image resolves to an
+// image, then reads the dimensions of PNG, JPEG and GIF images from the
+// start of the file. Servers that refuse HEAD are asked with GET.
+func checkOGImage(ctx context.Context, httpClient *client.Client, imageURL string) output.OGImageResult {
+	result := output.OGImageResult{URL: imageURL}
+
+	response, err := httpClient.GetClient().R().SetContext(ctx).Head(imageURL)
+	if err == nil && response.StatusCode() == http.StatusMethodNotAllowed {
+		response, err = httpClient.Get(ctx, imageURL)
+	}
+	if err != nil {
+		result.Problem = err.Error()
+		return result
+	}
+
+	result.StatusCode = response.StatusCode()
+	result.ContentType = response.Header().Get("Content-Type")
+	if result.StatusCode != http.StatusOK {
+		result.Problem = fmt.Sprintf("HTTP error: %d", result.StatusCode)
+		return result
+	}
+	mediaType, _, _ := mime.ParseMediaType(result.ContentType)
+	if !strings.HasPrefix(mediaType, "image/") {
+		result.Problem = fmt.Sprintf("not an image: %q", result.ContentType)
+		return result
+	}
+
+	switch mediaType {
+	case "image/png", "image/jpeg", "image/gif":
+	default:
+		return result // Dimensions unknown, e.g. WebP
+	}
+	response, err = httpClient.GetWithHeaders(ctx, imageURL, map[string]string{
+		"Range": fmt.Sprintf("bytes=0-%d", ogImageHeaderSize-1),
+	})
+	if err != nil {
+		return result
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(response.Body()))
+	if err != nil {
+		return result // Dimensions beyond the fetched start of the file
+	}
+
+	result.Width, result.Height = config.Width, config.Height
+	if result.Width < ogImageMinWidth || result.Height < ogImageMinHeight {
+		result.Problem = fmt.Sprintf("too small: %dx%d (minimum %dx%d)", result.Width, result.Height, ogImageMinWidth, ogImageMinHeight)
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteOGImageReport(t *testing.T) {
+	t.Cleanup(func() { reportOGImages = false })
+
+	encode := func(width, height int) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))))
+		return buf.Bytes()
+	}
+	card, icon := encode(1200, 630), encode(32, 32)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/card.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(card)
+		case "/icon.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(icon)
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+		case "/head-refused.webp":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("Content-Type", "image/webp")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	results := []crawler.CrawlResult{
+		{URL: server.URL + "/a", OGImage: server.URL + "/card.png"},
+		{URL: server.URL + "/b", OGImage: server.URL + "/icon.png"},
+		{URL: server.URL + "/c", OGImage: server.URL + "/gone.png"},
+		{URL: server.URL + "/d", OGImage: server.URL + "/page.html"},
+		{URL: server.URL + "/e", OGImage: server.URL + "/head-refused.webp"},
+		{URL: server.URL + "/f", OGImage: server.URL + "/card.png"},
+		{URL: server.URL + "/g"},
+	}
+
+	clientOpts, err := loadClientOptions(slog.Default())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeOGImageReport(context.Background(), &buf, results, clientOpts))
+	assert.Empty(t, buf.String())
+
+	reportOGImages = true
+	assert.NoError(t, writeOGImageReport(context.Background(), &buf, results, clientOpts))
+	assert.Equal(t, "Open Graph images: 5 on 6 pages, 3 broken\n"+
+		"  "+server.URL+"/gone.png: HTTP error: 404 (1 pages)\n    "+server.URL+"/c\n"+
+		"  "+server.URL+"/icon.png: too small: 32x32 (minimum 200x200) (1 pages)\n    "+server.URL+"/b\n"+
+		"  "+server.URL+"/page.html: not an image: \"text/html\" (1 pages)\n    "+server.URL+"/d\n", buf.String())
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aoshimash/urlmap/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// presetsCmd lists the available crawl presets
+var presetsCmd = &cobra.Command{
+	Use:   "presets",
+	Short: "List available crawl presets",
+	Long: `List the named presets that can be passed to --preset.
+
+Built-in presets can be overridden, and new presets added, in the "presets"
+section of the configuration file (see --config).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fileConfig, err := config.LoadFileConfig(configFile)
+		if err != nil {
+			return err
+		}
+
+		presets := fileConfig.AllPresets()
+		out := cmd.OutOrStdout()
+		for _, name := range config.PresetNames(presets) {
+			preset := presets[name]
+			source := "config"
+			if preset.Builtin {
+				source = "builtin"
+			}
+			fmt.Fprintf(out, "%s (%s)\n", name, source)
+			if preset.Description != "" {
+				fmt.Fprintf(out, "  %s\n", preset.Description)
+			}
+			fmt.Fprintf(out, "  flags: %s\n", formatPresetFlags(preset.Flags))
+		}
+		return nil
+	},
+}
+
+// applyPreset sets the flags bundled in the named preset on cmd.
+// Flags given explicitly on the command line take precedence over the preset.
+func applyPreset(cmd *cobra.Command, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	fileConfig, err := config.LoadFileConfig(configFile)
+	if err != nil {
+		return err
+	}
+
+	preset, err := fileConfig.Preset(name)
+	if err != nil {
+		return err
+	}
+
+	for flagName, value := range preset.Flags {
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil {
+			return fmt.Errorf("preset %s: unknown flag --%s", name, flagName)
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(flagName, value); err != nil {
+			return fmt.Errorf("preset %s: invalid value for --%s: %w", name, flagName, err)
+		}
+	}
+
+	return nil
+}
+
+// formatPresetFlags renders preset flags in a stable, command-line like form
+func formatPresetFlags(flags map[string]string) string {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("--%s=%s", name, flags[name]))
+	}
+	return strings.Join(parts, " ")
+}
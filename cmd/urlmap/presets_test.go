@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newPresetTestCommand() *cobra.Command {
+	cmd := &cobra.Command{Use: "urlmap"}
+	cmd.Flags().IntVarP(&depth, "depth", "d", -1, "Maximum crawl depth (-1 = unlimited)")
+	cmd.Flags().IntVarP(&concurrent, "concurrent", "c", 10, "Number of concurrent requests")
+	cmd.Flags().StringVarP(&outputFormat, "output-format", "f", "text", "Output format")
+	cmd.Flags().BoolVar(&jsRender, "js-render", false, "Enable JavaScript rendering")
+	return cmd
+}
+
+func TestApplyPreset(t *testing.T) {
+	configFile = filepath.Join(t.TempDir(), "missing.json")
+	t.Cleanup(func() {
+		newPresetTestCommand() // Restore flag defaults for other tests
+	})
+
+	t.Run("Applies preset flags", func(t *testing.T) {
+		cmd := newPresetTestCommand()
+		if err := applyPreset(cmd, "link-check"); err != nil {
+			t.Fatalf("applyPreset() unexpected error: %v", err)
+		}
+		if outputFormat != "csv" {
+			t.Errorf("outputFormat = %s, want csv", outputFormat)
+		}
+		if concurrent != 20 {
+			t.Errorf("concurrent = %d, want 20", concurrent)
+		}
+	})
+
+	t.Run("Explicit flags win over preset", func(t *testing.T) {
+		cmd := newPresetTestCommand()
+		if err := cmd.Flags().Parse([]string{"-f", "json"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := applyPreset(cmd, "link-check"); err != nil {
+			t.Fatalf("applyPreset() unexpected error: %v", err)
+		}
+		if outputFormat != "json" {
+			t.Errorf("outputFormat = %s, want json", outputFormat)
+		}
+	})
+
+	t.Run("Unknown preset", func(t *testing.T) {
+		cmd := newPresetTestCommand()
+		if err := applyPreset(cmd, "nope"); err == nil {
+			t.Error("expected error for unknown preset")
+		}
+	})
+
+	t.Run("Preset referencing unknown flag", func(t *testing.T) {
+		configFile = filepath.Join(t.TempDir(), "config.json")
+		content := `{"presets": {"bad": {"flags": {"no-such-flag": "1"}}}}`
+		if err := os.WriteFile(configFile, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cmd := newPresetTestCommand()
+		if err := applyPreset(cmd, "bad"); err == nil {
+			t.Error("expected error for unknown flag in preset")
+		}
+	})
+}
+
+func TestPresetsCommand(t *testing.T) {
+	configFile = filepath.Join(t.TempDir(), "missing.json")
+
+	var buf bytes.Buffer
+	presetsCmd.SetOut(&buf)
+	defer presetsCmd.SetOut(nil)
+
+	if err := presetsCmd.RunE(presetsCmd, nil); err != nil {
+		t.Fatalf("presets command failed: %v", err)
+	}
+
+	output := buf.String()
+	for _, name := range []string{"seo-audit", "link-check", "archive"} {
+		if !strings.Contains(output, name) {
+			t.Errorf("presets output should contain %q, got: %s", name, output)
+		}
+	}
+	if !strings.Contains(output, "--output-format=csv") {
+		t.Errorf("presets output should list flags, got: %s", output)
+	}
+}
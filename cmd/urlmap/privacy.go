@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+// errorBodySize is how many bytes of HTTP error bodies results keep for
+// diagnostics: none with --no-store-content
+func errorBodySize() int {
+	if noStoreContent {
+		return 0
+	}
+	return client.DefaultErrorBodySize
+}
+
+// validateNoStoreContent rejects options that would keep page content or
+// cookies despite --no-store-content
+func validateNoStoreContent() error {
+	conflicts := []struct {
+		set    bool
+		flag   string
+		reason string
+	}{
+		{cookieJarFile != "", "--cookie-jar", "saves cookies"},
+		{cacheDir != "", "--cache-dir", "saves pages to disk"},
+		{jsRender, "--js-render", "keeps cookies and storage in reused browser contexts"},
+		{jsAuto, "--js-auto", "keeps cookies and storage in reused browser contexts"},
+		{jsAutoStrict, "--js-auto-strict", "keeps cookies and storage in reused browser contexts"},
+		{compareRender, "--compare-render", "keeps cookies and storage in reused browser contexts"},
+		{jsOnChallenge, "--js-on-challenge", "keeps cookies and storage in reused browser contexts"},
+	}
+	for _, conflict := range conflicts {
+		if conflict.set {
+			return fmt.Errorf("--no-store-content cannot be combined with %s, which %s", conflict.flag, conflict.reason)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/redact"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// newProvenance describes the run for its output: the urlmap build, the
+// seeds, when it started and the effective value of every flag, including
+// values from presets and the configuration file. Provenance is kept with the
+// results and shared with them, so URL passwords and common secret query
+// parameters are always masked, along with any --redact names; with
+// --deterministic the run has no times.
+func newProvenance(cmd *cobra.Command, seeds []string, start time.Time) *output.Provenance {
+	redactor := redact.New(append(append([]string(nil), redactNames...), redact.DefaultPatterns...))
+	provenance := &output.Provenance{Version: version, Commit: commit}
+	if !deterministic {
+		provenance.StartTime = start
+	}
+
+	for _, seed := range seeds {
+		provenance.Seeds = append(provenance.Seeds, redactor.URL(seed))
+	}
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Name == "help" {
+			return
+		}
+		provenance.Config = append(provenance.Config, output.Setting{Name: flag.Name, Value: redactFlag(redactor, flag.Value)})
+	})
+	return provenance
+}
+
+// redactFlag masks the URLs in a flag's value. Slices are masked one element
+// at a time so a URL never runs into the next element or the closing bracket.
+func redactFlag(redactor *redact.Redactor, value pflag.Value) string {
+	slice, ok := value.(pflag.SliceValue)
+	if !ok {
+		return redactor.Text(value.String())
+	}
+	elements := append([]string(nil), slice.GetSlice()...)
+	for i, element := range elements {
+		elements[i] = redactor.Text(element)
+	}
+	return "[" + strings.Join(elements, ",") + "]"
+}
+
+// finished returns provenance for output written at end, or nil without provenance
+func finished(provenance *output.Provenance, end time.Time) *output.Provenance {
+	if provenance == nil {
+		return nil
+	}
+	done := *provenance
+	if !deterministic {
+		done.EndTime = end
+	}
+	return &done
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+var (
+	renderHeadful bool
+	renderPause   bool
+)
+
+// renderCmd renders a single URL the way the crawler does, for debugging
+var renderCmd = &cobra.Command{
+	Use:   "render <URL>",
+	Short: "Render a single URL with JavaScript the way the crawler does",
+	Long: `Render a single page in a browser with the crawler's settings (browser,
+user agent, header rules, wait condition, timeout and blocked images, media
+and fonts) and print the rendered HTML to stdout. The number of links found
+in it is logged to stderr.
+
+With --headful the page is shown in a visible browser window. --pause keeps
+it open once loaded, so it can be inspected with the browser's developer
+tools, until Enter is pressed or the page is closed.
+
+Use this to debug why a page renders differently under urlmap than in your
+own browser.
+
+Examples:
+  urlmap render https://example.com/app/ > rendered.html
+  urlmap render --headful --pause https://example.com/app/`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runRender,
+	SilenceUsage: true,
+}
+
+// renderFlags are the root command flags that also apply to render
+var renderFlags = []string{
+	"verbose", "user-agent", "headers-file",
+	"js-browser", "js-headless", "js-timeout", "js-wait", "js-dismiss-consent", "js-block-service-workers",
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	targetURL := args[0]
+	if err := validateTargetURL(targetURL); err != nil {
+		return err
+	}
+	if renderPause && !renderHeadful {
+		return usageError(fmt.Errorf("--pause requires --headful"))
+	}
+
+	var problems optionProblems
+	checkJSOptions(&problems)
+	if err := problems.err(); err != nil {
+		return err
+	}
+
+	logger := setupLogging()
+
+	var headerRules *client.HeaderRules
+	if headersFile != "" {
+		rules, err := client.LoadHeaderRules(headersFile)
+		if err != nil {
+			return err
+		}
+		headerRules = rules
+	}
+
+	jsClient, err := client.NewJSClient(&client.JSConfig{
+		Enabled:     true,
+		BrowserType: jsBrowser,
+		Headless:    jsHeadless && !renderHeadful,
+		Timeout:     jsTimeout,
+		WaitFor:     jsWaitType,
+		UserAgent:   userAgent,
+		PoolSize:    1,
+		HeaderRules: headerRules,
+
+		DismissConsent:      jsConsent,
+		BlockServiceWorkers: jsBlockSW,
+	}, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create JS client: %w", err)
+	}
+	defer jsClient.Close()
+
+	ctx, stop := notifyShutdown(context.Background())
+	defer stop()
+
+	var resume <-chan struct{}
+	if renderPause {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Press Enter to close the page once it has loaded")
+		resume = waitForEnter(cmd.InOrStdin())
+	}
+
+	content, err := jsClient.InspectPage(ctx, targetURL, resume)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", targetURL, err)
+	}
+
+	links, err := parser.NewLinkExtractor(logger).ExtractLinks(targetURL, content)
+	if err != nil {
+		return fmt.Errorf("failed to extract links: %w", err)
+	}
+	logger.Info("Rendered page", "url", targetURL, "html_bytes", len(content), "links", len(links))
+
+	_, err = io.WriteString(cmd.OutOrStdout(), content)
+	return err
+}
+
+// waitForEnter returns a channel closed once a line (or EOF) is read from r
+func waitForEnter(r io.Reader) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		bufio.NewReader(r).ReadString('\n')
+	}()
+	return done
+}
+
+// renderResults converts the compared pages of a crawl to render report rows, sorted by URL
+func renderResults(results []crawler.CrawlResult) []output.RenderResult {
+	rows := make([]output.RenderResult, 0, len(results))
+	for _, result := range results {
+		if result.Render == nil {
+			continue
+		}
+		rows = append(rows, output.RenderResult{
+			URL:           result.URL,
+			StaticLinks:   result.Render.StaticLinks,
+			RenderedLinks: result.Render.RenderedLinks,
+			Difference:    result.Render.Difference(),
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].URL < rows[j].URL
+	})
+	return rows
+}
+
+// writeRenderComparison outputs the --compare-render report and logs how
+// much of the site needs rendering
+func writeRenderComparison(results []crawler.CrawlResult, logger *slog.Logger) error {
+	rows := renderResults(results)
+
+	needsRendering := output.CountNeedsRendering(rows)
+	percent := 0.0
+	if len(rows) > 0 {
+		percent = float64(needsRendering) * 100 / float64(len(rows))
+	}
+	logger.Info("Render comparison complete",
+		"compared_pages", len(rows),
+		"needs_rendering", needsRendering,
+		"needs_rendering_percent", fmt.Sprintf("%.1f", percent))
+
+	outputConfig := &output.OutputConfig{Format: output.OutputFormat(outputFormat)}
+	if err := output.OutputRenderResults(rows, outputConfig); err != nil {
+		return fmt.Errorf("failed to output render comparison: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestRenderResults(t *testing.T) {
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/b", Render: &crawler.RenderComparison{StaticLinks: 2, RenderedLinks: 7}},
+		{URL: "https://example.com/failed"},
+		{URL: "https://example.com/a", Render: &crawler.RenderComparison{StaticLinks: 4, RenderedLinks: 4}},
+	}
+
+	rows := renderResults(results)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "https://example.com/a", rows[0].URL)
+	assert.Equal(t, 5, rows[1].Difference)
+}
+
+func TestRunRender_PauseRequiresHeadful(t *testing.T) {
+	t.Cleanup(func() { renderHeadful, renderPause = false, false })
+
+	renderPause = true
+	err := runRender(renderCmd, []string{"https://example.com/"})
+	assert.EqualError(t, err, "--pause requires --headful")
+	assert.Equal(t, exitUsage, exitCode(err))
+}
+
+func TestRenderCommandFlags(t *testing.T) {
+	for _, name := range append([]string{"headful", "pause"}, renderFlags...) {
+		assert.NotNil(t, renderCmd.Flags().Lookup(name), "render should accept --%s", name)
+	}
+}
+
+func TestWaitForEnter(t *testing.T) {
+	select {
+	case <-waitForEnter(strings.NewReader("\n")):
+	case <-time.After(time.Second):
+		t.Fatal("waitForEnter() did not return after a line was read")
+	}
+}
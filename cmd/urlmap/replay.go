@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+// validateReplay rejects options that would reach the network despite --replay-from
+func validateReplay() error {
+	conflicts := []struct {
+		set  bool
+		flag string
+	}{
+		{jsRender, "--js-render"},
+		{jsAuto, "--js-auto"},
+		{jsAutoStrict, "--js-auto-strict"},
+		{len(jsOnlyPaths) > 0, "--js-only-paths"},
+		{compareRender, "--compare-render"},
+		{jsOnChallenge, "--js-on-challenge"},
+		{respectRobots, "--respect-robots"},
+		{politeness == crawler.PolitenessStrict, "--politeness strict"},
+		{dnsPrefetch, "--dns-prefetch"},
+		{len(egressProxies) > 0, "--egress"},
+		{seedWayback, "--seed-wayback"},
+	}
+	for _, conflict := range conflicts {
+		if conflict.set {
+			return fmt.Errorf("--replay-from cannot be combined with %s, which needs the network", conflict.flag)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReplay(t *testing.T) {
+	t.Cleanup(func() { jsRender, respectRobots = false, false })
+
+	assert.NoError(t, validateReplay())
+
+	jsRender = true
+	assert.ErrorContains(t, validateReplay(), "--js-render")
+
+	jsRender, respectRobots = false, true
+	assert.ErrorContains(t, validateReplay(), "--respect-robots")
+}
+
+func TestReplayCrawl(t *testing.T) {
+	originalProgress := showProgress
+	t.Cleanup(func() {
+		replayFrom = ""
+		showProgress = originalProgress
+	})
+	showProgress = false
+
+	dir := t.TempDir()
+	pages := map[string]string{
+		"example.com/index.html":      `<html><body><a href="/docs/">Docs</a><a href="/gone">Gone</a></body></html>`,
+		"example.com/docs/index.html": `<html><body><a href="/docs/intro.html">Intro</a></body></html>`,
+		"example.com/docs/intro.html": `<html><body>Intro</body></html>`,
+	}
+	for name, content := range pages {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	replayFrom = dir
+	clientOpts, err := loadClientOptions(slog.Default())
+	require.NoError(t, err)
+
+	results, _, err := executeCrawl(context.Background(), newCrawlerConfig(slog.Default(), clientOpts), "https://example.com/", slog.Default())
+	require.NoError(t, err)
+
+	statuses := make(map[string]int)
+	var urls []string
+	for _, result := range results {
+		statuses[result.URL] = result.StatusCode
+		urls = append(urls, result.URL)
+	}
+	sort.Strings(urls)
+	assert.Equal(t, []string{
+		"https://example.com/",
+		"https://example.com/docs",
+		"https://example.com/docs/intro.html",
+		"https://example.com/gone",
+	}, urls)
+	assert.Equal(t, 404, statuses["https://example.com/gone"])
+
+	replayFrom = filepath.Join(dir, "missing.warc")
+	_, err = loadClientOptions(slog.Default())
+	assert.Error(t, err)
+}
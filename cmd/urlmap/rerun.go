@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// rerunCmd repeats a crawl recorded in the manifest of a working directory
+var rerunCmd = &cobra.Command{
+	Use:   "rerun <workdir> [<new workdir>]",
+	Short: "Repeat a crawl recorded in a --workdir manifest",
+	Long: `Run urlmap again with the command line recorded in the manifest.json of a
+working directory (see --workdir), from the directory it was started in.
+
+The new run writes to the new working directory, by default a directory
+next to the old one named after it and the current time, so the artifacts
+of both runs can be compared. Files the command line names, such as
+--headers-file or the configuration file, are read again; a different
+urlmap version is reported as a warning.
+
+Examples:
+  urlmap rerun runs/2024-06-01/
+  urlmap rerun runs/2024-06-01/ runs/2024-07-01/`,
+	Args:         cobra.RangeArgs(1, 2),
+	RunE:         runRerun,
+	SilenceUsage: true, // Crawl failures are not a usage error
+}
+
+func runRerun(cmd *cobra.Command, args []string) error {
+	manifest, err := output.ReadManifest(args[0])
+	if err != nil {
+		return err
+	}
+	if manifest.Invocation == nil || len(manifest.Invocation.Args) == 0 {
+		return fmt.Errorf("%s does not record the command line of its run", filepath.Join(args[0], output.ManifestFile))
+	}
+
+	target := filepath.Clean(args[0]) + "-rerun-" + time.Now().UTC().Format("20060102-150405")
+	if len(args) > 1 {
+		target = args[1]
+	}
+	// The run starts in the recorded directory
+	target, err = filepath.Abs(target)
+	if err != nil {
+		return err
+	}
+
+	logger := setupLogging()
+	if manifest.Provenance != nil && manifest.Provenance.Version != version {
+		logger.Warn("The run was recorded by another urlmap version", "recorded", manifest.Provenance.Version, "current", version)
+	}
+
+	if dir := manifest.Invocation.Dir; dir != "" {
+		previous, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		if err := os.Chdir(dir); err != nil {
+			return fmt.Errorf("failed to enter the directory of the run: %w", err)
+		}
+		defer os.Chdir(previous)
+	}
+
+	rerunArgs := withWorkdir(manifest.Invocation.Args, target)
+	logger.Info("Repeating run", "args", strings.Join(rerunArgs, " "))
+
+	commandLine = rerunArgs
+	rootCmd.SetArgs(rerunArgs)
+	defer rootCmd.SetArgs(nil)
+	_, err = rootCmd.ExecuteC()
+	return err
+}
+
+// withWorkdir returns args with the value of --workdir replaced by dir
+func withWorkdir(args []string, dir string) []string {
+	rerunArgs := make([]string, 0, len(args)+2)
+	replaced := false
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--":
+			rerunArgs = append(rerunArgs, args[i:]...)
+			i = len(args)
+		case args[i] == "--workdir" && i+1 < len(args):
+			rerunArgs = append(rerunArgs, args[i], dir)
+			replaced = true
+			i++
+		case strings.HasPrefix(args[i], "--workdir="):
+			rerunArgs = append(rerunArgs, "--workdir="+dir)
+			replaced = true
+		default:
+			rerunArgs = append(rerunArgs, args[i])
+		}
+	}
+	if !replaced {
+		rerunArgs = append([]string{"--workdir", dir}, rerunArgs...)
+	}
+	return rerunArgs
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+func TestWithWorkdir(t *testing.T) {
+	assert.Equal(t, []string{"--workdir", "new", "-d", "2", "https://example.com"},
+		withWorkdir([]string{"--workdir", "old", "-d", "2", "https://example.com"}, "new"))
+	assert.Equal(t, []string{"--workdir=new", "https://example.com"},
+		withWorkdir([]string{"--workdir=old", "https://example.com"}, "new"))
+	assert.Equal(t, []string{"--workdir", "new", "https://example.com", "--", "--workdir"},
+		withWorkdir([]string{"https://example.com", "--", "--workdir"}, "new"))
+}
+
+func TestRunRerun(t *testing.T) {
+	server := newSiteServer([]string{"/a"}, "")
+	defer server.Close()
+
+	t.Cleanup(func() {
+		commandLine = nil
+		for _, name := range []string{"workdir", "progress", "output-dir", "state-file", "ndjson"} {
+			flag := rootCmd.Flags().Lookup(name)
+			require.NoError(t, flag.Value.Set(flag.DefValue))
+			flag.Changed = false
+		}
+	})
+
+	// A run recorded in another directory, with a path relative to it
+	recordedIn := t.TempDir()
+	old := filepath.Join(t.TempDir(), "2024-06-01")
+	require.NoError(t, os.MkdirAll(old, 0o755))
+	require.NoError(t, output.WriteManifest(old, output.Manifest{
+		Provenance: &output.Provenance{Version: version},
+		Invocation: &output.Invocation{Args: []string{"--progress=false", "--ndjson", "results.ndjson", "--workdir", old, server.URL + "/"}, Dir: recordedIn},
+	}))
+
+	_, err := os.Stat(filepath.Join(recordedIn, "results.ndjson"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	target := filepath.Join(t.TempDir(), "2024-07-01")
+	require.NoError(t, runRerun(rerunCmd, []string{old, target}))
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, cwd, wd, "the working directory is restored")
+
+	urls, err := os.ReadFile(filepath.Join(target, "output", "urls.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/\n"+server.URL+"/a\n", string(urls))
+	assert.FileExists(t, filepath.Join(recordedIn, "results.ndjson"))
+
+	manifest, err := output.ReadManifest(target)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--progress=false", "--ndjson", "results.ndjson", "--workdir", target, server.URL + "/"}, manifest.Invocation.Args)
+	assert.Equal(t, 0, manifest.ExitCode)
+
+	// Manifests without a command line cannot be repeated
+	require.NoError(t, output.WriteManifest(old, output.Manifest{}))
+	assert.ErrorContains(t, runRerun(rerunCmd, []string{old}), "does not record the command line")
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// writeRetryReport writes the --report-retries summary to w
+func writeRetryReport(w io.Writer, results []crawler.CrawlResult) error {
+	if !reportRetries {
+		return nil
+	}
+
+	summary := crawler.SummarizeRetries(results)
+	var report output.RetryReport
+	for _, host := range summary.Hosts {
+		report.Hosts = append(report.Hosts, output.HostRetryResult{
+			Host:       host.Host,
+			URLs:       host.URLs,
+			Attempts:   host.Attempts,
+			Failed:     host.Failed,
+			AvgLatency: host.AvgLatency,
+		})
+	}
+	for _, retried := range summary.URLs {
+		result := output.RetriedURLResult{URL: retried.URL, Failed: retried.Failed}
+		for _, attempt := range retried.Attempts {
+			result.Attempts = append(result.Attempts, output.RetryAttempt{
+				StatusCode: attempt.StatusCode,
+				Error:      attempt.Error,
+				Duration:   attempt.Duration,
+			})
+		}
+		report.URLs = append(report.URLs, result)
+	}
+	return output.WriteRetryReport(w, report)
+}
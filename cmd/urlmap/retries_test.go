@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteRetryReport(t *testing.T) {
+	t.Cleanup(func() { reportRetries = false })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", Attempts: []client.Attempt{{StatusCode: 200}}},
+		{URL: "https://example.com/busy", Error: errors.New("HTTP error: 429"), Attempts: []client.Attempt{
+			{StatusCode: 429, Duration: 10 * time.Millisecond},
+			{StatusCode: 429, Duration: 30 * time.Millisecond},
+		}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeRetryReport(&buf, results))
+	assert.Empty(t, buf.String())
+
+	reportRetries = true
+	assert.NoError(t, writeRetryReport(&buf, results))
+	assert.Contains(t, buf.String(), "Retried URLs: 1\n  example.com: 1 URLs, 2 attempts, 1 still failed, avg 20ms per attempt\n")
+	assert.Contains(t, buf.String(), "https://example.com/busy (failed)\n  #1 429 in 10ms\n  #2 429 in 30ms\n")
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// writeSampleReport writes the --sample-per-pattern template estimates to w
+func writeSampleReport(w io.Writer, counts []crawler.TemplateCount) error {
+	if samplePerPattern < 1 {
+		return nil
+	}
+
+	report := make([]output.TemplateCountResult, 0, len(counts))
+	for _, count := range counts {
+		report = append(report, output.TemplateCountResult{
+			Template:   count.Template,
+			Discovered: count.Discovered,
+			Sampled:    count.Sampled,
+		})
+	}
+	return output.WriteSampleReport(w, report)
+}
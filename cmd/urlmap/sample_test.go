@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteSampleReport(t *testing.T) {
+	t.Cleanup(func() { samplePerPattern = 0 })
+
+	counts := []crawler.TemplateCount{
+		{Template: "example.com/products/{id}", Discovered: 40, Sampled: 5},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeSampleReport(&buf, counts))
+	assert.Empty(t, buf.String())
+
+	samplePerPattern = 5
+	assert.NoError(t, writeSampleReport(&buf, counts))
+	assert.Contains(t, buf.String(), "      40  example.com/products/{id} (crawled 5)\n")
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	neturl "net/url"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+// writeServiceWorkerReport fetches the service worker scripts registered by
+// the crawled pages and writes the --report-service-workers summary to w,
+// listing the URLs each worker precaches for offline use
+func writeServiceWorkerReport(ctx context.Context, w io.Writer, results []crawler.CrawlResult, clientOpts *clientOptions) error {
+	if !reportSW {
+		return nil
+	}
+
+	crawled := make(map[string]bool, len(results))
+	origins := make(map[string]bool)
+	for _, result := range results {
+		crawled[result.URL] = true
+		if page, err := neturl.Parse(result.URL); err == nil && page.Host != "" {
+			origins[page.Scheme+"://"+page.Host] = true
+		}
+	}
+
+	httpClient := newReportClient(clientOpts)
+
+	report := output.ServiceWorkerReport{Origins: len(origins)}
+	for _, use := range crawler.CollectServiceWorkers(results) {
+		if ctx.Err() != nil {
+			break
+		}
+		worker := output.ServiceWorkerResult{Origin: use.Origin, Script: use.Script, Pages: len(use.Pages)}
+		report.Workers = append(report.Workers, checkServiceWorker(ctx, httpClient, worker, crawled))
+	}
+	return output.WriteServiceWorkerReport(w, report)
+}
+
+// checkServiceWorker fetches the script of worker and lists the URLs it
+// precaches, marking those found by the crawl
+func checkServiceWorker(ctx context.Context, httpClient *client.Client, worker output.ServiceWorkerResult, crawled map[string]bool) output.ServiceWorkerResult {
+	response, err := httpClient.Get(ctx, worker.Script)
+	if err != nil {
+		worker.Error = err.Error()
+		return worker
+	}
+	worker.StatusCode = response.StatusCode()
+	if worker.StatusCode < 200 || worker.StatusCode >= 400 {
+		worker.Error = fmt.Sprintf("HTTP error: %d", worker.StatusCode)
+		return worker
+	}
+
+	for _, u := range parser.ExtractPrecacheURLs(worker.Script, string(response.Body())) {
+		worker.Precached = append(worker.Precached, output.PrecachedURL{URL: u, Crawled: crawled[u]})
+	}
+	return worker
+}
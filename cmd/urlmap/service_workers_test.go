@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteServiceWorkerReport(t *testing.T) {
+	t.Cleanup(func() { reportSW = false })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sw.js":
+			w.Header().Set("Content-Type", "application/javascript")
+			fmt.Fprint(w, `precacheAndRoute([{url: "/", revision: "1"}, {url: "/offline.html", revision: "2"}]);`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	results := []crawler.CrawlResult{
+		{URL: server.URL + "/", ServiceWorker: server.URL + "/sw.js"},
+		{URL: server.URL + "/about", ServiceWorker: server.URL + "/sw.js"},
+		{URL: server.URL + "/blog/", ServiceWorker: server.URL + "/blog/sw.js"},
+		{URL: server.URL + "/contact"},
+	}
+
+	clientOpts, err := loadClientOptions(slog.Default())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeServiceWorkerReport(context.Background(), &buf, results, clientOpts))
+	assert.Empty(t, buf.String())
+
+	reportSW = true
+	assert.NoError(t, writeServiceWorkerReport(context.Background(), &buf, results, clientOpts))
+	assert.Equal(t, "Service workers: 1 of 1 origins, 2 precached URLs (1 not found by the crawl)\n"+
+		server.URL+": "+server.URL+"/blog/sw.js (1 pages): error: HTTP error: 404\n"+
+		server.URL+": "+server.URL+"/sw.js (2 pages): 200\n"+
+		"  precached "+server.URL+"/\n"+
+		"  precached "+server.URL+"/offline.html (not crawled)\n", buf.String())
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+var simulateMaxPages int
+
+// simulateCmd predicts which URLs a page budget would cover
+var simulateCmd = &cobra.Command{
+	Use:   "simulate --max-pages <N> <URL>",
+	Short: "Predict which URLs a crawl limited to N pages would cover",
+	Long: `Fetch the seed URL and the pages it links to, then order them and the links
+found on them the way a breadth-first crawl would and report which URLs a
+budget of --max-pages pages would cover. Scope, filters, --max-per-dir,
+--sample-per-pattern and --warm-cache (known URLs are crawled last) are applied
+as in a real crawl. Links deeper than the pages linked from the seed are not
+fetched, so budget left over goes to pages the simulation cannot see.
+
+Examples:
+  urlmap simulate --max-pages 500 https://example.com
+  urlmap simulate --max-pages 50 -f json --exclude '/tag/*' https://example.com`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runSimulate,
+	SilenceUsage: true,
+}
+
+func runSimulate(cmd *cobra.Command, args []string) error {
+	targetURL := args[0]
+	if err := validateTargetURL(targetURL); err != nil {
+		return err
+	}
+
+	if simulateMaxPages < 1 {
+		return fmt.Errorf("--max-pages must be at least 1")
+	}
+	if outputFormat != "text" && outputFormat != "json" {
+		return fmt.Errorf("unsupported output format for simulate: %s (supported: text, json)", outputFormat)
+	}
+
+	if err := applyPreset(cmd, preset); err != nil {
+		return err
+	}
+
+	logger := setupLogging()
+
+	urlFilter, err := newURLFilter()
+	if err != nil {
+		return err
+	}
+
+	clientOpts, err := loadClientOptions(logger)
+	if err != nil {
+		return err
+	}
+
+	detectorConfig, err := loadDetectorConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	knownURLs, err := loadKnownURLs()
+	if err != nil {
+		return err
+	}
+
+	crawlerConfig := newCrawlerConfig(logger, clientOpts)
+	crawlerConfig.URLFilter = urlFilter
+	crawlerConfig.DetectorConfig = detectorConfig
+	crawlerConfig.KnownURLs = knownURLs
+
+	c, err := crawler.New(crawlerConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create crawler: %w", err)
+	}
+
+	simulation, err := c.Simulate(targetURL, simulateMaxPages)
+	if err != nil {
+		return fmt.Errorf("simulation failed: %w", err)
+	}
+
+	if err := clientOpts.saveCookies(); err != nil {
+		return err
+	}
+
+	return writeSimulation(cmd.OutOrStdout(), simulation)
+}
+
+// writeSimulation writes the simulated coverage as text or JSON
+func writeSimulation(w io.Writer, simulation *crawler.Simulation) error {
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(simulation)
+	}
+
+	fetched := 0
+	for _, urls := range [][]crawler.PlannedURL{simulation.Covered, simulation.Uncovered} {
+		for _, planned := range urls {
+			if planned.Fetched {
+				fetched++
+			}
+		}
+	}
+	known := len(simulation.Covered) + len(simulation.Uncovered)
+
+	fmt.Fprintf(w, "Budget:      %d pages\n", simulation.MaxPages)
+	fmt.Fprintf(w, "Known URLs:  %d (%d fetched, %d in the frontier)\n", known, fetched, known-fetched)
+	fmt.Fprintf(w, "Covered:     %d\n", len(simulation.Covered))
+	fmt.Fprintf(w, "Not covered: %d\n", len(simulation.Uncovered))
+	if simulation.Unused > 0 {
+		fmt.Fprintf(w, "Unused:      %d (left for pages beyond the frontier)\n", simulation.Unused)
+	}
+
+	writePlannedURLs(w, "Covered URLs", simulation.Covered)
+	writePlannedURLs(w, "Not covered URLs", simulation.Uncovered)
+	return nil
+}
+
+// writePlannedURLs writes a labelled list of planned URLs with their depth
+func writePlannedURLs(w io.Writer, label string, urls []crawler.PlannedURL) {
+	if len(urls) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%s:\n", label)
+	for _, planned := range urls {
+		fmt.Fprintf(w, "  [%d] %s\n", planned.Depth, planned.URL)
+	}
+}
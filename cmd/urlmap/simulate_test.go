@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestRunSimulate(t *testing.T) {
+	server := newSiteServer([]string{"/a", "/b", "/c"}, "")
+	defer server.Close()
+
+	originalFormat, originalConfigFile := outputFormat, configFile
+	t.Cleanup(func() {
+		outputFormat, configFile = originalFormat, originalConfigFile
+		simulateMaxPages = 500
+	})
+	outputFormat = "json"
+	configFile = ""
+	simulateMaxPages = 3
+
+	var buf bytes.Buffer
+	simulateCmd.SetOut(&buf)
+	defer simulateCmd.SetOut(nil)
+
+	require.NoError(t, runSimulate(simulateCmd, []string{server.URL}))
+
+	var simulation crawler.Simulation
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &simulation))
+	assert.Equal(t, 3, simulation.MaxPages)
+	assert.Len(t, simulation.Covered, 3)
+	require.Len(t, simulation.Uncovered, 1)
+	assert.Equal(t, server.URL+"/c", simulation.Uncovered[0].URL)
+
+	simulateMaxPages = 0
+	assert.Error(t, runSimulate(simulateCmd, []string{server.URL}))
+}
+
+func TestWriteSimulation(t *testing.T) {
+	originalFormat := outputFormat
+	t.Cleanup(func() { outputFormat = originalFormat })
+	outputFormat = "text"
+
+	simulation := crawler.SimulateBudget(
+		[]crawler.CrawlResult{{URL: "https://example.com/"}},
+		[]string{"https://example.com/a"}, 1, 5)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeSimulation(&buf, simulation))
+	assert.Contains(t, buf.String(), "Known URLs:  2 (1 fetched, 1 in the frontier)\n")
+	assert.Contains(t, buf.String(), "Unused:      3 (left for pages beyond the frontier)\n")
+	assert.Contains(t, buf.String(), "Covered URLs:\n  [0] https://example.com/\n  [1] https://example.com/a\n")
+}
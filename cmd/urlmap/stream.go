@@ -0,0 +1,90 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// urlStream prints each result to stdout as soon as it is crawled (--stream)
+// instead of once the crawl is done
+type urlStream struct {
+	stream *output.ResultStream
+	logger *slog.Logger
+}
+
+// openURLStream starts printing results to w in the output format. It
+// returns nil without --stream.
+func openURLStream(w io.Writer, logger *slog.Logger, provenance *output.Provenance) (*urlStream, error) {
+	if !streamOutput {
+		return nil, nil
+	}
+
+	formats, err := output.ParseFormats(outputFormat)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := output.NewResultStream(w, &output.OutputConfig{
+		Format:      formats[0],
+		ShowDepth:   showDepth,
+		IndentDepth: indentDepth,
+		Limit:       outputLimit,
+		ShowHash:    hashAlgo != "",
+		Confidence:  scriptURLs,
+		Provenance:  provenance,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &urlStream{stream: stream, logger: logger}, nil
+}
+
+// configure makes crawlerConfig print its results, after any other OnResult.
+// Results are not kept unless something needs them after the crawl.
+func (s *urlStream) configure(crawlerConfig *crawler.Config) {
+	if s == nil {
+		return
+	}
+	crawlerConfig.DiscardResults = !resultsNeeded()
+	next := crawlerConfig.OnResult
+	crawlerConfig.OnResult = func(result crawler.CrawlResult) {
+		if next != nil {
+			next(result)
+		}
+		s.write(result)
+	}
+}
+
+// write prints one result; failures are logged so the crawl keeps going
+func (s *urlStream) write(result crawler.CrawlResult) {
+	record := ndjsonFromCrawlResult(result)
+	if hashAlgo == "" {
+		record.Hash = ""
+	}
+	if err := s.stream.Write(record); err != nil {
+		s.logger.Warn("Failed to print result", "url", result.URL, "error", err)
+	}
+}
+
+// writeKept prints the results merged with crawled, the results of a crawl:
+// pages of the resumed or previous run that were not crawled again
+func (s *urlStream) writeKept(merged, crawled []crawler.CrawlResult) {
+	if s == nil {
+		return
+	}
+	for _, result := range merged[:len(merged)-len(crawled)] {
+		s.write(result)
+	}
+}
+
+// resultsNeeded reports whether the results of a streamed crawl are used
+// once it is done: by a report, to record --state, to merge the pages of a
+// resumed run or to save them in the --state-file checkpoint
+func resultsNeeded() bool {
+	return stateFile != "" || checkpointFile != "" || resumeCrawl ||
+		reportStructure || reportLinking != 0 || reportCycles || reportDeadEnds || reportTemplates ||
+		reportContent || reportRetries || reportIndex != "" || reportHints || reportIcons ||
+		reportOGImages || reportSW || reportDomains || reportLanguages || reportRobots
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestOpenURLStream(t *testing.T) {
+	originalFormat := outputFormat
+	t.Cleanup(func() { streamOutput, outputFormat = false, originalFormat })
+
+	live, err := openURLStream(&bytes.Buffer{}, slog.Default(), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, live)
+
+	streamOutput, outputFormat = true, "csv"
+	_, err = openURLStream(&bytes.Buffer{}, slog.Default(), nil)
+	assert.ErrorContains(t, err, "streaming supports text and json")
+	assert.ErrorContains(t, validateCrawlOptions(), "--stream supports text and json output, got csv")
+}
+
+func TestURLStream_PrintsWhileCrawling(t *testing.T) {
+	server := newSiteServer([]string{"/a", "/b"}, "")
+	defer server.Close()
+
+	originalFormat, originalProgress, originalJSRender := outputFormat, showProgress, jsRender
+	t.Cleanup(func() {
+		streamOutput, outputFormat = false, originalFormat
+		showProgress, jsRender = originalProgress, originalJSRender
+	})
+	streamOutput, outputFormat = true, "text"
+	showProgress, jsRender = false, false
+
+	var out bytes.Buffer
+	live, err := openURLStream(&out, slog.Default(), nil)
+	require.NoError(t, err)
+
+	clientOpts, err := loadClientOptions(slog.Default())
+	require.NoError(t, err)
+	crawlerConfig := newCrawlerConfig(slog.Default(), clientOpts)
+
+	// Other result handlers still get every result
+	var handled int
+	crawlerConfig.OnResult = func(crawler.CrawlResult) { handled++ }
+	live.configure(crawlerConfig)
+
+	results, _, err := executeCrawl(context.Background(), crawlerConfig, server.URL, slog.Default())
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.ElementsMatch(t, []string{server.URL + "/", server.URL + "/a", server.URL + "/b"}, lines)
+	assert.Equal(t, 3, handled)
+	assert.Empty(t, results, "printed results are not kept when nothing else needs them")
+}
+
+func TestURLStream_WriteKept(t *testing.T) {
+	originalFormat := outputFormat
+	t.Cleanup(func() { streamOutput, outputFormat, stateFile = false, originalFormat, "" })
+	streamOutput, outputFormat = true, "text"
+
+	var out bytes.Buffer
+	live, err := openURLStream(&out, slog.Default(), nil)
+	require.NoError(t, err)
+
+	// Pages of the previous run that were not crawled again are printed too
+	kept := []crawler.CrawlResult{{URL: "https://example.com/"}, {URL: "https://example.com/a"}}
+	crawled := []crawler.CrawlResult{{URL: "https://example.com/b"}}
+	live.writeKept(mergeResumed(kept, crawled), crawled)
+	assert.Equal(t, "https://example.com/\nhttps://example.com/a\n", out.String())
+
+	// Results recorded in --state are kept
+	stateFile = "state.json"
+	crawlerConfig := &crawler.Config{}
+	live.configure(crawlerConfig)
+	assert.False(t, crawlerConfig.DiscardResults)
+}
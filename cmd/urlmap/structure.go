@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/sitegraph"
+)
+
+// sitePages converts crawl results to the pages of the site graph
+func sitePages(results []crawler.CrawlResult) []sitegraph.Page {
+	pages := make([]sitegraph.Page, 0, len(results))
+	for _, result := range results {
+		pages = append(pages, sitegraph.Page{
+			URL:    result.URL,
+			Depth:  result.Depth,
+			Parent: result.Parent,
+			Links:  result.Links,
+			Failed: result.Error != nil,
+
+			RedirectTo:   result.FinalURL,
+			RedirectLoop: errors.Is(result.Error, client.ErrRedirectLoop),
+		})
+	}
+	return pages
+}
+
+// writeStructureReport writes the --report-structure statistics to w
+func writeStructureReport(w io.Writer, results []crawler.CrawlResult) error {
+	if !reportStructure {
+		return nil
+	}
+
+	structure := sitegraph.AnalyzeStructure(sitePages(results))
+	return output.WriteStructureReport(w, output.StructureReport{
+		Pages:        structure.Pages,
+		DepthCounts:  structure.DepthCounts,
+		AvgLinks:     structure.AvgLinks,
+		MaxBreadth:   structure.MaxBreadth,
+		BreadthDepth: structure.BreadthDepth,
+		LongestChain: structure.LongestChain,
+	})
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteStructureReport(t *testing.T) {
+	t.Cleanup(func() { reportStructure = false })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", Links: []string{"https://example.com/a", "https://example.com/b"}},
+		{URL: "https://example.com/a", Depth: 1, Parent: "https://example.com/"},
+		{URL: "https://example.com/b", Depth: 1, Parent: "https://example.com/", Error: errors.New("HTTP error: 500")},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeStructureReport(&buf, results))
+	assert.Empty(t, buf.String())
+
+	reportStructure = true
+	assert.NoError(t, writeStructureReport(&buf, results))
+	assert.Contains(t, buf.String(), "Site structure (3 pages):")
+	assert.Contains(t, buf.String(), "Max breadth:     2 pages at depth 1")
+	assert.Contains(t, buf.String(), "-> https://example.com/a")
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"io"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/sitegraph"
+)
+
+// writeTemplateReport writes the --report-templates analysis to w
+func writeTemplateReport(w io.Writer, results []crawler.CrawlResult) error {
+	if !reportTemplates {
+		return nil
+	}
+
+	templates := sitegraph.ClusterTemplates(sitePages(results))
+	report := make([]output.TemplateResult, 0, len(templates))
+	for _, template := range templates {
+		report = append(report, output.TemplateResult{
+			Pattern: template.Pattern,
+			Count:   template.Count,
+			Example: template.Example,
+		})
+	}
+	return output.WriteTemplateReport(w, report)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+)
+
+func TestWriteTemplateReport(t *testing.T) {
+	t.Cleanup(func() { reportTemplates = false })
+
+	results := []crawler.CrawlResult{
+		{URL: "https://example.com/", Links: []string{"https://example.com/items/1", "https://example.com/items/2"}},
+		{URL: "https://example.com/items/1", Depth: 1, Parent: "https://example.com/"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeTemplateReport(&buf, results))
+	assert.Empty(t, buf.String())
+
+	reportTemplates = true
+	assert.NoError(t, writeTemplateReport(&buf, results))
+	assert.Contains(t, buf.String(), "URL templates: 2 covering 3 URLs\n")
+	assert.Contains(t, buf.String(), "       2  example.com/items/{id} (e.g. https://example.com/items/1)\n")
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// optionProblems collects invalid options, so all of them are reported at
+// once before anything is fetched
+type optionProblems []string
+
+func (p *optionProblems) add(format string, args ...any) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+// err returns the problems as a single usage error, or nil if there are none
+func (p optionProblems) err() error {
+	switch len(p) {
+	case 0:
+		return nil
+	case 1:
+		return usageError(errors.New(p[0]))
+	}
+	return usageError(fmt.Errorf("%d invalid options:\n  %s", len(p), strings.Join(p, "\n  ")))
+}
+
+// validateCrawlOptions checks the crawl flags before the crawl starts
+func validateCrawlOptions() error {
+	var problems optionProblems
+
+	if concurrent < 1 {
+		problems.add("--concurrent must be at least 1, got %d", concurrent)
+	}
+	if depth < -1 {
+		problems.add("--depth must be -1 (unlimited) or more, got %d", depth)
+	}
+	if rateLimit < 0 {
+		problems.add("--rate-limit must not be negative, got %g", rateLimit)
+	}
+	if rateLimitPerHost < 0 {
+		problems.add("--rate-limit-per-host must not be negative, got %g", rateLimitPerHost)
+	}
+	if maxPerDir < 0 {
+		problems.add("--max-per-dir must not be negative, got %d", maxPerDir)
+	}
+	if maxPagination < 0 {
+		problems.add("--max-pagination must not be negative, got %d", maxPagination)
+	}
+	if samplePerPattern < 0 {
+		problems.add("--sample-per-pattern must not be negative, got %d", samplePerPattern)
+	}
+	if !slices.Contains(crawler.PolitenessProfiles, politeness) {
+		problems.add("--politeness %s is not supported (supported: %s)", politeness, strings.Join(crawler.PolitenessProfiles, ", "))
+	}
+
+	// Output options are otherwise only checked once the crawl is done
+	formats, err := output.ParseFormats(outputFormat)
+	if err != nil {
+		problems.add("%v", err)
+	} else if len(formats) > 1 && outputDir == "" {
+		problems.add("writing several output formats (%s) requires --output-dir", outputFormat)
+	}
+	if outputLimit < 0 {
+		problems.add("--limit must not be negative, got %d", outputLimit)
+	}
+	if sampleRate < 0 || sampleRate > 1 {
+		problems.add("--sample must be between 0 and 1, got %g", sampleRate)
+	}
+	if streamOutput {
+		if err == nil && !slices.Contains(output.StreamFormats, formats[0]) {
+			problems.add("--stream supports text and json output, got %s", formats[0])
+		}
+		if outputDir != "" || sampleRate > 0 || compareRender || dualUA {
+			problems.add("--stream prints URLs as they are crawled and cannot be combined with --output-dir, --sample, --compare-render or --dual-ua")
+		}
+	}
+	if hashAlgo != "" && hashAlgo != "sha256" {
+		problems.add("--hash %s is not supported (supported: sha256)", hashAlgo)
+	}
+
+	checkJSOptions(&problems)
+	if jsRender && (jsAuto || jsAutoStrict) {
+		problems.add("--js-render renders every page and cannot be combined with --js-auto or --js-auto-strict, which decide per page")
+	}
+	if jsHashRoutes && !(jsRender || jsAuto || jsAutoStrict) {
+		problems.add("--js-hash-routes requires --js-render, --js-auto or --js-auto-strict, as hash routes only differ once rendered")
+	}
+
+	// Scope filters that cancel each other out
+	for _, pattern := range includePatterns {
+		if slices.Contains(excludePatterns, pattern) {
+			problems.add("%q is given to both --include and --exclude", pattern)
+		}
+	}
+	if changesReport != "" && stateFile == "" {
+		problems.add("--changes-report requires --state")
+	}
+	if incrementalCrawl && stateFile == "" {
+		problems.add("--incremental requires --state")
+	}
+	if incrementalCrawl && resumeCrawl {
+		problems.add("--incremental cannot be combined with --resume")
+	}
+	if incrementalSample < 0 || incrementalSample > 1 {
+		problems.add("--incremental-sample must be between 0 and 1, got %v", incrementalSample)
+	}
+	if checkpointFile != "" && checkpointEvery <= 0 {
+		problems.add("--checkpoint-interval must be positive, got %v", checkpointEvery)
+	}
+
+	return problems.err()
+}
+
+// checkJSOptions checks the JavaScript rendering flags. They are checked
+// even when rendering is off, since they were given on purpose.
+func checkJSOptions(problems *optionProblems) {
+	if !slices.Contains(client.BrowserTypes, jsBrowser) {
+		problems.add("--js-browser %s is not supported (supported: %s)", jsBrowser, strings.Join(client.BrowserTypes, ", "))
+	}
+	if !slices.Contains(client.WaitConditions, jsWaitType) {
+		problems.add("--js-wait %s is not supported (supported: %s)", jsWaitType, strings.Join(client.WaitConditions, ", "))
+	}
+	if jsTimeout <= 0 {
+		problems.add("--js-timeout must be positive, got %v", jsTimeout)
+	}
+	if jsThreshold < 0 || jsThreshold > 1 {
+		problems.add("--js-threshold must be between 0 and 1, got %g", jsThreshold)
+	}
+	if jsPoolSize < 1 {
+		problems.add("--js-pool-size must be at least 1, got %d", jsPoolSize)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCrawlOptions(t *testing.T) {
+	originalConcurrent, originalBrowser, originalWait := concurrent, jsBrowser, jsWaitType
+	originalRender, originalAuto, originalPoliteness := jsRender, jsAuto, politeness
+	t.Cleanup(func() {
+		concurrent, jsBrowser, jsWaitType = originalConcurrent, originalBrowser, originalWait
+		jsRender, jsAuto, politeness = originalRender, originalAuto, originalPoliteness
+		includePatterns, excludePatterns = nil, nil
+	})
+
+	assert.NoError(t, validateCrawlOptions())
+
+	// A single problem is reported as is
+	concurrent = 0
+	err := validateCrawlOptions()
+	assert.EqualError(t, err, "--concurrent must be at least 1, got 0")
+	assert.Equal(t, exitUsage, exitCode(err))
+
+	// Several problems are reported together
+	jsBrowser, jsWaitType = "chrome", "idle"
+	jsRender, jsAuto = true, true
+	politeness = "rude"
+	includePatterns, excludePatterns = []string{"/docs/*"}, []string{"/docs/*"}
+	err = validateCrawlOptions()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "6 invalid options:\n")
+	for _, want := range []string{
+		"--concurrent must be at least 1",
+		"--js-browser chrome is not supported (supported: chromium, firefox, webkit)",
+		"--js-wait idle is not supported (supported: networkidle, domcontentloaded, load)",
+		"--js-render renders every page",
+		"--politeness rude is not supported (supported: strict, normal, aggressive)",
+		`"/docs/*" is given to both --include and --exclude`,
+	} {
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+func TestValidateCrawlOptions_HashRoutes(t *testing.T) {
+	originalRender := jsRender
+	t.Cleanup(func() { jsHashRoutes, jsRender = false, originalRender })
+
+	jsHashRoutes, jsRender = true, false
+	assert.ErrorContains(t, validateCrawlOptions(), "--js-hash-routes requires --js-render, --js-auto or --js-auto-strict")
+
+	jsRender = true
+	assert.NoError(t, validateCrawlOptions())
+}
+
+func TestValidateCrawlOptions_Incremental(t *testing.T) {
+	t.Cleanup(func() { incrementalCrawl, incrementalSample, stateFile, resumeCrawl = false, 0.05, "", false })
+
+	incrementalCrawl, incrementalSample = true, 1.5
+	err := validateCrawlOptions()
+	assert.ErrorContains(t, err, "--incremental requires --state")
+	assert.ErrorContains(t, err, "--incremental-sample must be between 0 and 1, got 1.5")
+
+	stateFile, incrementalSample, resumeCrawl = "state.json", 0.1, true
+	assert.EqualError(t, validateCrawlOptions(), "--incremental cannot be combined with --resume")
+
+	resumeCrawl = false
+	assert.NoError(t, validateCrawlOptions())
+}
+
+func TestRunCrawl_InvalidOptions(t *testing.T) {
+	originalPoolSize := jsPoolSize
+	t.Cleanup(func() { jsPoolSize = originalPoolSize })
+	jsPoolSize = 0
+
+	// Rejected before the seed is fetched
+	err := runCrawl(rootCmd, []string{"https://example.invalid/"})
+	assert.EqualError(t, err, "--js-pool-size must be at least 1, got 0")
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/aoshimash/urlmap/internal/progress"
+	"github.com/aoshimash/urlmap/internal/url"
+	"github.com/aoshimash/urlmap/internal/verify"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd fetches a fixed list of URLs without discovering new ones
+var verifyCmd = &cobra.Command{
+	Use:   "verify <file> | --stdin",
+	Short: "Check the status of a list of URLs without following links",
+	Long: `Fetch exactly the URLs listed in the given file (one per line, '#' starts
+a comment) concurrently and report status codes, redirect targets and
+response times. No links are followed.
+
+The command exits with an error if any URL fails or returns a 4xx/5xx status.
+
+Examples:
+  urlmap verify urls.txt
+  urlmap verify -f csv -c 20 urls.txt > status.csv
+  grep /products/ urls.txt | urlmap verify --stdin`,
+	Args:         seedArgs,
+	RunE:         runVerify,
+	SilenceUsage: true, // Failed URLs are not a usage error
+}
+
+// verifyFlags are the root command flags that also apply to verify
+var verifyFlags = []string{
+	"stdin", "verbose", "user-agent", "concurrent", "progress", "plain", "no-color", "rate-limit", "rate-limit-per-host", "output-format",
+	"headers-file", "cookie-jar", "dns-cache-ttl", "cache-dir", "accept-language", "debug-requests", "redact", "redact-secrets", "no-store-content", "replay-from",
+	"chaos", "chaos-latency", "chaos-seed",
+	"connect-timeout", "header-timeout", "read-timeout", "request-timeout",
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	if readStdin {
+		urls, err := url.ReadURLList(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		return verifyURLs(urls)
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open URL list: %w", err)
+	}
+	defer file.Close()
+
+	urls, err := url.ReadURLList(file)
+	if err != nil {
+		return err
+	}
+
+	return verifyURLs(urls)
+}
+
+// verifyURLs verifies the given URLs and writes the status report to stdout
+func verifyURLs(urls []string) error {
+	outputConfig := &output.OutputConfig{Format: output.OutputFormat(outputFormat)}
+	switch outputConfig.Format {
+	case output.FormatText, output.FormatJSON, output.FormatCSV, output.FormatXML:
+		// Valid format
+	default:
+		return fmt.Errorf("unsupported output format: %s (supported: text, json, csv, xml)", outputFormat)
+	}
+
+	if len(urls) == 0 {
+		return fmt.Errorf("no URLs to verify")
+	}
+
+	logger := setupLogging()
+
+	clientOpts, err := loadClientOptions(logger)
+	if err != nil {
+		return err
+	}
+
+	clientConfig := client.DefaultConfig()
+	clientConfig.UserAgent = userAgent
+	clientConfig.HeaderRules = clientOpts.headerRules
+	clientConfig.Headers = clientOpts.headers
+	clientConfig.CookieJar = clientOpts.cookieJar
+	clientConfig.DNSCache = clientOpts.dnsCache
+	clientConfig.RequestLog = clientOpts.requestLog
+	clientConfig.Redactor = clientOpts.redactor
+	clientConfig.Transport = clientOpts.transport
+	clientConfig.Chaos = clientOpts.chaos
+	clientConfig.HTTPCache = clientOpts.httpCache
+	clientConfig.Timeout = requestTimeout
+	clientConfig.ConnectTimeout = connectTimeout
+	clientConfig.ResponseHeaderTimeout = headerTimeout
+	clientConfig.ReadTimeout = readTimeout
+
+	reporter := progress.NewProgressReporter(newProgressConfig(logger))
+
+	verifier := verify.New(&verify.Config{
+		Client:   client.NewClient(clientConfig),
+		Workers:  concurrent,
+		Progress: reporter,
+		Logger:   logger,
+
+		ErrorBodySize: errorBodySize(),
+	})
+
+	ctx, stop := notifyShutdown(context.Background())
+	defer stop()
+
+	reporter.Start()
+	results := verifier.Verify(ctx, urls)
+	reporter.Stop()
+
+	clientOpts.logCacheStats(logger)
+
+	if err := clientOpts.saveCookies(); err != nil {
+		return err
+	}
+
+	statusResults := make([]output.StatusResult, len(results))
+	failed := 0
+	for i, result := range results {
+		statusResults[i] = output.StatusResult{
+			URL:          result.URL,
+			FinalURL:     result.FinalURL,
+			StatusCode:   result.StatusCode,
+			ResponseTime: result.ResponseTime.Milliseconds(),
+			Server:       result.Server,
+			ErrorBody:    result.ErrorBody,
+			ErrorKind:    result.ErrorKind,
+		}
+		if result.Error != nil {
+			statusResults[i].Error = result.Error.Error()
+			failed++
+		}
+	}
+
+	if err := output.OutputStatusResults(statusResults, outputConfig); err != nil {
+		return fmt.Errorf("failed to output results: %w", err)
+	}
+
+	return failedURLsError(failed, len(results), "failed verification")
+}
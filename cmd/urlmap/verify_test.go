@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	showProgress = false
+	outputFormat = "text"
+	concurrent = 2
+	defer func() {
+		showProgress = true
+		concurrent = 10
+	}()
+
+	dir := t.TempDir()
+
+	okList := filepath.Join(dir, "ok.txt")
+	if err := os.WriteFile(okList, []byte(server.URL+"/a\n# comment\n"+server.URL+"/b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runVerify(verifyCmd, []string{okList}); err != nil {
+		t.Errorf("runVerify() unexpected error: %v", err)
+	}
+
+	badList := filepath.Join(dir, "bad.txt")
+	if err := os.WriteFile(badList, []byte(server.URL+"/a\n"+server.URL+"/missing\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := runVerify(verifyCmd, []string{badList})
+	if err == nil || !strings.Contains(err.Error(), "1 of 2") {
+		t.Errorf("expected failure summary error, got: %v", err)
+	}
+
+	if err := runVerify(verifyCmd, []string{filepath.Join(dir, "missing.txt")}); err == nil {
+		t.Error("expected error for missing URL list")
+	}
+
+	emptyList := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(emptyList, []byte("# nothing\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runVerify(verifyCmd, []string{emptyList}); err == nil {
+		t.Error("expected error for empty URL list")
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/aoshimash/urlmap/internal/crawler"
+	"github.com/aoshimash/urlmap/internal/wayback"
+)
+
+// historicalURLs returns the URLs the Wayback Machine archived under seed
+// with --seed-wayback. The crawl goes on without them if the archive cannot
+// be queried.
+func historicalURLs(ctx context.Context, seed string, logger *slog.Logger) []string {
+	if !seedWayback {
+		return nil
+	}
+
+	archive := &wayback.Client{UserAgent: userAgent, Logger: logger}
+	urls, err := archive.URLs(ctx, seed)
+	if err != nil {
+		logger.Warn("Failed to query the Wayback Machine, crawling without historical URLs", "error", err)
+		return nil
+	}
+	logger.Info("Found historical URLs in the Wayback Machine", "seed", seed, "count", len(urls))
+	return urls
+}
+
+// logHistorical reports how many archived URLs the site no longer links to
+// and how many of them still resolve
+func logHistorical(stats *crawler.CrawlStats, logger *slog.Logger) {
+	if stats.Historical == 0 {
+		return
+	}
+	logger.Info("Checked historical URLs not linked from the site",
+		"checked", stats.Historical, "resolved", stats.Historical-stats.HistoricalGone, "gone", stats.HistoricalGone)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// logOutput receives log records; --workdir adds its log file
+var logOutput io.Writer = os.Stderr
+
+// commandLine holds the arguments urlmap was started with, recorded in the
+// manifest of --workdir so the run can be repeated
+var commandLine []string
+
+// workdirLogFile is the copy of the log in the working directory
+const workdirLogFile = "urlmap.log"
+
+// workdirArtifacts are the flags --workdir sets, with the path in the working
+// directory each defaults to
+var workdirArtifacts = []struct {
+	flag string
+	path string
+}{
+	{"output-dir", "output"},
+	{"state-file", "state.json"},
+	{"ndjson", "results.ndjson"},
+}
+
+// workdirRun is a run writing its artifacts to --workdir
+type workdirRun struct {
+	dir string
+	log *os.File
+}
+
+// openWorkdir creates --workdir and points the artifact flags not given
+// explicitly at it, so state, results and logs of one run end up in one
+// place. It returns nil without --workdir.
+//
+// --state-file is only set for a single seed URL, as it records the crawl of
+// one seed, and --output-dir not with --stream, which prints results instead.
+func openWorkdir(cmd *cobra.Command, args []string) (*workdirRun, error) {
+	if workdir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(workdir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create working directory: %w", err)
+	}
+
+	for _, artifact := range workdirArtifacts {
+		flag := cmd.Flags().Lookup(artifact.flag)
+		if flag.Changed || flag.Value.String() != "" {
+			continue
+		}
+		if artifact.flag == "state-file" && (readStdin || len(args) != 1) {
+			continue
+		}
+		if artifact.flag == "output-dir" && streamOutput {
+			continue
+		}
+		if err := cmd.Flags().Set(artifact.flag, filepath.Join(workdir, artifact.path)); err != nil {
+			return nil, err
+		}
+	}
+
+	logFile, err := os.OpenFile(filepath.Join(workdir, workdirLogFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	logOutput = io.MultiWriter(os.Stderr, logFile)
+	return &workdirRun{dir: workdir, log: logFile}, nil
+}
+
+// writeManifest lists the files of the working directory in its manifest,
+// with the provenance of the run and the error it ended with, if any
+func (r *workdirRun) writeManifest(provenance *output.Provenance, runErr error, logger *slog.Logger) {
+	if r == nil {
+		return
+	}
+	manifest := output.Manifest{Provenance: finished(provenance, time.Now()), Invocation: newInvocation()}
+	if runErr != nil {
+		manifest.ExitCode = exitCode(runErr)
+		manifest.Error = runErr.Error()
+	}
+	if err := output.WriteManifest(r.dir, manifest); err != nil {
+		logger.Warn("Failed to write working directory manifest", "error", err)
+	}
+}
+
+// newInvocation records the command line and the environment of the run
+func newInvocation() *output.Invocation {
+	invocation := &output.Invocation{
+		Args:      commandLine,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+	if dir, err := os.Getwd(); err == nil {
+		invocation.Dir = dir
+	}
+	return invocation
+}
+
+// Close stops copying the log to the working directory
+func (r *workdirRun) Close() error {
+	if r == nil {
+		return nil
+	}
+	logOutput = os.Stderr
+	return r.log.Close()
+}
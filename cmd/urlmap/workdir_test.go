@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+func TestOpenWorkdir(t *testing.T) {
+	t.Cleanup(func() { workdir = "" })
+
+	run, err := openWorkdir(rootCmd, []string{"https://example.com"})
+	assert.NoError(t, err)
+	assert.Nil(t, run)
+	assert.NoError(t, run.Close())
+}
+
+func TestRunCrawl_Workdir(t *testing.T) {
+	server := newSiteServer([]string{"/a"}, "")
+	defer server.Close()
+
+	originalProgress, originalJSRender := showProgress, jsRender
+	t.Cleanup(func() {
+		showProgress, jsRender = originalProgress, originalJSRender
+		workdir = ""
+		for _, artifact := range workdirArtifacts {
+			flag := rootCmd.Flags().Lookup(artifact.flag)
+			require.NoError(t, flag.Value.Set(""))
+			flag.Changed = false
+		}
+	})
+	showProgress, jsRender = false, false
+
+	// An explicit --ndjson is kept
+	ndjsonPath := filepath.Join(t.TempDir(), "results.ndjson")
+	require.NoError(t, rootCmd.Flags().Set("ndjson", ndjsonPath))
+	workdir = filepath.Join(t.TempDir(), "runs", "2024-06-01")
+	require.NoError(t, runCrawl(rootCmd, []string{server.URL + "/"}))
+
+	assert.Equal(t, filepath.Join(workdir, "output"), outputDir)
+	assert.Equal(t, filepath.Join(workdir, "state.json"), checkpointFile)
+	assert.Equal(t, ndjsonPath, ndjsonFile)
+	assert.Equal(t, os.Stderr, logOutput)
+
+	urls, err := os.ReadFile(filepath.Join(workdir, "output", "urls.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/\n"+server.URL+"/a\n", string(urls))
+
+	data, err := os.ReadFile(filepath.Join(workdir, output.ManifestFile))
+	require.NoError(t, err)
+	var manifest output.Manifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Equal(t, 0, manifest.ExitCode)
+	assert.Equal(t, []string{server.URL + "/"}, manifest.Provenance.Seeds)
+	var paths []string
+	for _, file := range manifest.Files {
+		paths = append(paths, file.Path)
+	}
+	assert.Equal(t, []string{"output/urls.txt", "state.json", "urlmap.log"}, paths)
+}
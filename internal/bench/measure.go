@@ -0,0 +1,75 @@
+package bench
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// heapSampleInterval is how often the heap is sampled for its peak
+const heapSampleInterval = 10 * time.Millisecond
+
+// Result holds the throughput and memory use of a benchmark run
+type Result struct {
+	Pages       int           `json:"pages"`         // Pages crawled
+	Duration    time.Duration `json:"duration_ns"`   // Wall time of the run
+	PagesPerSec float64       `json:"pages_per_sec"` // Crawl throughput
+	TotalAlloc  uint64        `json:"total_alloc"`   // Bytes allocated during the run
+	Mallocs     uint64        `json:"mallocs"`       // Heap objects allocated during the run
+	PeakHeap    uint64        `json:"peak_heap"`     // Largest heap in use while running, in bytes
+	GCCycles    uint32        `json:"gc_cycles"`     // Garbage collections during the run
+}
+
+// Measure runs fn, which returns the number of pages it crawled, and reports
+// its throughput and allocations
+func Measure(fn func() (int, error)) (Result, error) {
+	runtime.GC()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	var (
+		mu       sync.Mutex
+		peakHeap = before.HeapInuse
+		done     = make(chan struct{})
+		sampled  = make(chan struct{})
+	)
+	go func() {
+		defer close(sampled)
+		ticker := time.NewTicker(heapSampleInterval)
+		defer ticker.Stop()
+		var stats runtime.MemStats
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				runtime.ReadMemStats(&stats)
+				mu.Lock()
+				peakHeap = max(peakHeap, stats.HeapInuse)
+				mu.Unlock()
+			}
+		}
+	}()
+
+	start := time.Now()
+	pages, err := fn()
+	duration := time.Since(start)
+	close(done)
+	<-sampled
+
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+
+	result := Result{
+		Pages:      pages,
+		Duration:   duration,
+		TotalAlloc: after.TotalAlloc - before.TotalAlloc,
+		Mallocs:    after.Mallocs - before.Mallocs,
+		PeakHeap:   max(peakHeap, after.HeapInuse),
+		GCCycles:   after.NumGC - before.NumGC,
+	}
+	if duration > 0 {
+		result.PagesPerSec = float64(pages) / duration.Seconds()
+	}
+	return result, err
+}
@@ -0,0 +1,35 @@
+package bench
+
+import "testing"
+
+func TestMeasure(t *testing.T) {
+	var sink [][]byte
+	result, err := Measure(func() (int, error) {
+		for i := 0; i < 100; i++ {
+			sink = append(sink, make([]byte, 1024))
+		}
+		return 10, nil
+	})
+	if err != nil {
+		t.Fatalf("Measure() error = %v", err)
+	}
+	if len(sink) != 100 {
+		t.Fatalf("sink has %d entries", len(sink))
+	}
+
+	if result.Pages != 10 {
+		t.Errorf("Pages = %d, want 10", result.Pages)
+	}
+	if result.TotalAlloc < 100*1024 {
+		t.Errorf("TotalAlloc = %d, want at least %d", result.TotalAlloc, 100*1024)
+	}
+	if result.Mallocs < 100 {
+		t.Errorf("Mallocs = %d, want at least 100", result.Mallocs)
+	}
+	if result.PagesPerSec <= 0 {
+		t.Errorf("PagesPerSec = %f, want > 0", result.PagesPerSec)
+	}
+	if result.PeakHeap == 0 {
+		t.Error("PeakHeap = 0")
+	}
+}
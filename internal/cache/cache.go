@@ -0,0 +1,215 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// Cache is an on-disk HTTP cache shared by the runs that use the same
+// directory. Successful GET responses carrying an ETag or Last-Modified
+// validator are stored by URL; when a stored URL is requested again the
+// request is sent with If-None-Match and If-Modified-Since, and a 304
+// response is answered from the disk instead of downloading the page again.
+type Cache struct {
+	dir string
+
+	revalidated atomic.Int64
+	fetched     atomic.Int64
+	stored      atomic.Int64
+}
+
+// Stats holds cache statistics
+type Stats struct {
+	Revalidated int64 // Requests the server confirmed unchanged (304), answered from the disk
+	Fetched     int64 // Requests downloaded from the server
+	Stored      int64 // Responses written to the disk
+}
+
+// Open opens the cache in dir, creating the directory if needed
+func Open(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// Wrap returns a transport that sends requests through base and answers
+// them from the cache when the server reports the stored response unchanged
+func (c *Cache) Wrap(base http.RoundTripper) http.RoundTripper {
+	return &transport{cache: c, base: base}
+}
+
+// Stats returns the cache statistics of this run
+func (c *Cache) Stats() Stats {
+	return Stats{
+		Revalidated: c.revalidated.Load(),
+		Fetched:     c.fetched.Load(),
+		Stored:      c.stored.Load(),
+	}
+}
+
+// unstoredHeaders are never written to the disk: cookies belong to the
+// session that received them and must not be replayed to later runs, and
+// hop-by-hop headers only describe the connection they arrived on
+var unstoredHeaders = []string{
+	"Set-Cookie", "Set-Cookie2",
+	"Connection", "Keep-Alive", "Proxy-Connection", "Proxy-Authenticate", "Proxy-Authorization",
+	"TE", "Trailer", "Transfer-Encoding", "Upgrade",
+}
+
+// storedHeader returns a copy of header without the fields that must not be
+// stored, including those the Connection header lists as hop-by-hop
+func storedHeader(header http.Header) http.Header {
+	stored := header.Clone()
+	for _, value := range header.Values("Connection") {
+		for _, name := range strings.Split(value, ",") {
+			stored.Del(strings.TrimSpace(name))
+		}
+	}
+	for _, name := range unstoredHeaders {
+		stored.Del(name)
+	}
+	return stored
+}
+
+// path returns the file the response for rawURL is stored in, spread over
+// subdirectories named after the first byte of the key
+func (c *Cache) path(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	key := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, key[:2], key)
+}
+
+// load reads the stored response for req, if any
+func (c *Cache) load(req *http.Request) (*http.Response, []byte, bool) {
+	data, err := os.ReadFile(c.path(req.URL.String()))
+	if err != nil {
+		return nil, nil, false
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	if err != nil {
+		return nil, nil, false
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, false
+	}
+	// Entries written by older versions may still hold cookies
+	resp.Header = storedHeader(resp.Header)
+	return resp, body, true
+}
+
+// store writes resp with body to the disk, replacing the file atomically so
+// concurrent runs never read a partial entry
+func (c *Cache) store(resp *http.Response, body []byte) error {
+	path := c.path(resp.Request.URL.String())
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	stored := *resp
+	stored.Header = storedHeader(resp.Header)
+	stored.TransferEncoding = nil
+	stored.ContentLength = int64(len(body))
+	stored.Body = io.NopCloser(bytes.NewReader(body))
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := stored.Write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// cacheable reports whether resp may be stored: a complete 200 response to
+// a GET with a validator to revalidate it with, which the server does not
+// forbid storing
+func cacheable(resp *http.Response) bool {
+	if resp.Request == nil || resp.Request.Method != http.MethodGet || resp.StatusCode != http.StatusOK {
+		return false
+	}
+	if resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+		return false
+	}
+	return !strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store")
+}
+
+// transport revalidates stored responses and stores new ones
+type transport struct {
+	cache *Cache
+	base  http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Range and conditional requests of the caller are passed through as they are
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" ||
+		req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	cached, cachedBody, ok := t.cache.load(req)
+	if ok {
+		conditional := req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			conditional.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			conditional.Header.Set("If-Modified-Since", lastModified)
+		}
+		req = conditional
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		t.cache.revalidated.Add(1)
+		// The 304 carries the current metadata, including cookies for this
+		// session; only the stored body's length is kept (RFC 9111 §4.3.4)
+		for name, values := range resp.Header {
+			if name != "Content-Length" {
+				cached.Header[name] = values
+			}
+		}
+		cached.Request = req
+		cached.Body = io.NopCloser(bytes.NewReader(cachedBody))
+		return cached, nil
+	}
+	t.cache.fetched.Add(1)
+
+	if !cacheable(resp) {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	// A response that cannot be stored is still returned, it is only fetched again next time
+	if err := t.cache.store(resp, body); err == nil {
+		t.cache.stored.Add(1)
+	}
+	return resp, nil
+}
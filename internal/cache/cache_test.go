@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// get fetches rawURL through client and returns the response and its body
+func get(t *testing.T, client *http.Client, rawURL string) (*http.Response, string) {
+	t.Helper()
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		t.Fatalf("Get(%s) failed: %v", rawURL, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return resp, string(body)
+}
+
+func TestCache_Revalidates(t *testing.T) {
+	var downloads atomic.Int64
+	version := "v1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			etag := `"` + version + `"`
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			downloads.Add(1)
+			io.WriteString(w, "<html>"+version+"</html>")
+		case "/dated":
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+			if r.Header.Get("If-Modified-Since") != "" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			downloads.Add(1)
+			io.WriteString(w, "dated")
+		default:
+			downloads.Add(1)
+			io.WriteString(w, "no validators")
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cache, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	client := &http.Client{Transport: cache.Wrap(http.DefaultTransport)}
+
+	for _, path := range []string{"/page", "/dated", "/plain"} {
+		get(t, client, server.URL+path)
+	}
+
+	// A later run with the same directory only downloads what has no validators
+	cache, err = Open(dir)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	client = &http.Client{Transport: cache.Wrap(http.DefaultTransport)}
+
+	resp, body := get(t, client, server.URL+"/page")
+	if resp.StatusCode != http.StatusOK || body != "<html>v1</html>" {
+		t.Errorf("cached /page = %d %q, want 200 %q", resp.StatusCode, body, "<html>v1</html>")
+	}
+	if _, body := get(t, client, server.URL+"/dated"); body != "dated" {
+		t.Errorf("cached /dated body = %q, want %q", body, "dated")
+	}
+	get(t, client, server.URL+"/plain")
+	if got := downloads.Load(); got != 4 {
+		t.Errorf("downloads = %d, want 4", got)
+	}
+
+	// A changed page is downloaded and stored again
+	version = "v2"
+	if _, body := get(t, client, server.URL+"/page"); body != "<html>v2</html>" {
+		t.Errorf("changed /page body = %q, want %q", body, "<html>v2</html>")
+	}
+	if _, body := get(t, client, server.URL+"/page"); body != "<html>v2</html>" {
+		t.Errorf("cached /page body = %q, want %q", body, "<html>v2</html>")
+	}
+
+	stats := cache.Stats()
+	if stats.Revalidated != 3 || stats.Fetched != 2 || stats.Stored != 1 {
+		t.Errorf("Stats() = %+v, want 3 revalidated, 2 fetched, 1 stored", stats)
+	}
+}
+
+func TestCache_NotStored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"x"`)
+		switch r.URL.Path {
+		case "/private":
+			w.Header().Set("Cache-Control", "no-store")
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		}
+		io.WriteString(w, "body")
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cache, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	client := &http.Client{Transport: cache.Wrap(http.DefaultTransport)}
+	get(t, client, server.URL+"/private")
+	get(t, client, server.URL+"/missing")
+
+	if _, err := os.Stat(filepath.Dir(cache.path(server.URL + "/private"))); !os.IsNotExist(err) {
+		t.Errorf("expected no entry for a no-store response")
+	}
+	if _, err := os.Stat(filepath.Dir(cache.path(server.URL + "/missing"))); !os.IsNotExist(err) {
+		t.Errorf("expected no entry for an error response")
+	}
+}
+
+func TestCache_DoesNotReplayCookies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"x"`)
+		if r.Header.Get("If-None-Match") == `"x"` {
+			w.Header().Set("X-Revision", "2")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "first-run"})
+		w.Header().Set("X-Revision", "1")
+		io.WriteString(w, "page")
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cache, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	client := &http.Client{Transport: cache.Wrap(http.DefaultTransport)}
+	get(t, client, server.URL+"/")
+
+	// A later run gets the page from the disk, but not the first run's session
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("cookiejar.New() failed: %v", err)
+	}
+	client = &http.Client{Transport: cache.Wrap(http.DefaultTransport), Jar: jar}
+	resp, body := get(t, client, server.URL+"/")
+	if body != "page" {
+		t.Errorf("cached body = %q, want %q", body, "page")
+	}
+	serverURL, _ := url.Parse(server.URL)
+	if cookies := jar.Cookies(serverURL); len(cookies) != 0 {
+		t.Errorf("cached Set-Cookie reached the jar: %v", cookies)
+	}
+	// Headers of the 304 replace the stored ones
+	if got := resp.Header.Get("X-Revision"); got != "2" {
+		t.Errorf("X-Revision = %q, want the 304's %q", got, "2")
+	}
+	if cache.Stats().Revalidated != 1 {
+		t.Errorf("expected the page to be revalidated, got %+v", cache.Stats())
+	}
+}
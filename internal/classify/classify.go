@@ -0,0 +1,186 @@
+// Package classify runs an external command that decides which discovered
+// URLs are crawled and tags them, so business rules can be applied without
+// changing urlmap.
+package classify
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is how long the command may take to answer a request
+const DefaultTimeout = 10 * time.Second
+
+// Actions a command may answer with
+const (
+	ActionEnqueue = "enqueue" // Crawl the URL (also when the action is empty)
+	ActionSkip    = "skip"    // Do not crawl the URL
+)
+
+// ErrStopped is returned once the command exited or failed to answer in time
+var ErrStopped = errors.New("classifier command stopped")
+
+// Request describes a discovered URL. It is written to the command's stdin
+// as one JSON object per line.
+type Request struct {
+	URL    string `json:"url"`
+	Parent string `json:"parent,omitempty"` // Page the URL was found on
+	Depth  int    `json:"depth"`            // Depth the URL would be crawled at
+}
+
+// Decision is the command's answer, one JSON object per line on stdout in
+// the order of the requests, e.g. {"action":"skip"} or
+// {"action":"enqueue","tags":["product"]}
+type Decision struct {
+	Action string   `json:"action"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// Skip reports whether the URL should not be crawled
+func (d Decision) Skip() bool {
+	return d.Action == ActionSkip
+}
+
+// Command is a running classifier process. It is safe for concurrent use;
+// requests are answered one at a time.
+type Command struct {
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	lines   chan []byte // Lines read from stdout, closed when the command exits
+	timeout time.Duration
+	stopped bool
+}
+
+// Start runs command, a program and its arguments separated by spaces, with
+// its stderr going to stderr. A timeout of 0 means DefaultTimeout.
+func Start(command string, stderr io.Writer, timeout time.Duration) (*Command, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty classifier command")
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stderr = stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create classifier stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create classifier stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start classifier command: %w", err)
+	}
+
+	c := &Command{cmd: cmd, stdin: stdin, lines: make(chan []byte), timeout: timeout}
+	go func() {
+		defer close(c.lines)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			c.lines <- append([]byte(nil), scanner.Bytes()...)
+		}
+	}()
+	return c, nil
+}
+
+// Classify sends req to the command and returns its decision. A command
+// that exits or does not answer within the timeout is stopped, and every
+// later request fails with ErrStopped.
+func (c *Command) Classify(req Request) (Decision, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stopped {
+		return Decision{}, ErrStopped
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to encode classifier request: %w", err)
+	}
+	if _, err := c.stdin.Write(append(data, '\n')); err != nil {
+		c.stop()
+		return Decision{}, fmt.Errorf("%w: %v", ErrStopped, err)
+	}
+
+	var line []byte
+	select {
+	case l, ok := <-c.lines:
+		if !ok {
+			c.stop()
+			return Decision{}, fmt.Errorf("%w: command exited", ErrStopped)
+		}
+		line = l
+	case <-time.After(c.timeout):
+		c.stop()
+		return Decision{}, fmt.Errorf("%w: no answer for %s within %s", ErrStopped, req.URL, c.timeout)
+	}
+
+	var decision Decision
+	if err := json.Unmarshal(line, &decision); err != nil {
+		return Decision{}, fmt.Errorf("invalid classifier answer for %s: %q", req.URL, line)
+	}
+	switch decision.Action {
+	case "", ActionEnqueue, ActionSkip:
+	default:
+		return Decision{}, fmt.Errorf("unknown classifier action %q for %s", decision.Action, req.URL)
+	}
+	return decision, nil
+}
+
+// stop kills the command; the caller holds c.mu
+func (c *Command) stop() {
+	if c.stopped {
+		return
+	}
+	c.stopped = true
+	c.stdin.Close()
+	c.cmd.Process.Kill()
+	go func() {
+		// Drain stdout so the reader goroutine ends
+		for range c.lines {
+		}
+	}()
+	c.cmd.Wait()
+}
+
+// Close ends the command's input and waits for it to exit, killing it if it
+// does not exit within the timeout
+func (c *Command) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stopped {
+		return nil
+	}
+	c.stopped = true
+	c.stdin.Close()
+
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+	for open := true; open; {
+		select {
+		case _, open = <-c.lines:
+		case <-timer.C:
+			c.cmd.Process.Kill()
+		}
+	}
+
+	if err := c.cmd.Wait(); err != nil {
+		return fmt.Errorf("classifier command failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package classify
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestHelperClassifier is the classifier command run by the tests: it skips
+// /admin URLs, tags /products URLs and misbehaves on request
+func TestHelperClassifier(t *testing.T) {
+	if os.Getenv("URLMAP_TEST_CLASSIFIER") != "1" {
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var req Request
+		json.Unmarshal(scanner.Bytes(), &req)
+		switch {
+		case strings.Contains(req.URL, "/admin"):
+			fmt.Println(`{"action":"skip"}`)
+		case strings.Contains(req.URL, "/products"):
+			fmt.Printf(`{"action":"enqueue","tags":["product","depth-%d"]}`+"\n", req.Depth)
+		case strings.Contains(req.URL, "/garbage"):
+			fmt.Println("not json")
+		case strings.Contains(req.URL, "/hang"):
+			time.Sleep(time.Minute)
+		case strings.Contains(req.URL, "/exit"):
+			os.Exit(1)
+		default:
+			fmt.Println(`{}`)
+		}
+	}
+	os.Exit(0)
+}
+
+func startHelper(t *testing.T, timeout time.Duration) *Command {
+	t.Helper()
+	t.Setenv("URLMAP_TEST_CLASSIFIER", "1")
+	command, err := Start(os.Args[0]+" -test.run=^TestHelperClassifier$", io.Discard, timeout)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	return command
+}
+
+func TestCommandClassify(t *testing.T) {
+	command := startHelper(t, 0)
+
+	decision, err := command.Classify(Request{URL: "https://example.com/admin/users", Depth: 1})
+	if err != nil || !decision.Skip() {
+		t.Errorf("expected skip for /admin, got %+v, %v", decision, err)
+	}
+
+	decision, err = command.Classify(Request{URL: "https://example.com/products/1", Parent: "https://example.com/", Depth: 2})
+	if err != nil || decision.Skip() || !reflect.DeepEqual(decision.Tags, []string{"product", "depth-2"}) {
+		t.Errorf("expected tagged enqueue for /products, got %+v, %v", decision, err)
+	}
+
+	decision, err = command.Classify(Request{URL: "https://example.com/about", Depth: 1})
+	if err != nil || decision.Skip() || decision.Tags != nil {
+		t.Errorf("expected plain enqueue for an empty answer, got %+v, %v", decision, err)
+	}
+
+	if _, err := command.Classify(Request{URL: "https://example.com/garbage"}); err == nil {
+		t.Error("expected error for an invalid answer")
+	}
+	// An invalid answer does not stop the command
+	if _, err := command.Classify(Request{URL: "https://example.com/about"}); err != nil {
+		t.Errorf("expected the command to keep answering, got %v", err)
+	}
+
+	if err := command.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
+
+func TestCommandStopped(t *testing.T) {
+	command := startHelper(t, 200*time.Millisecond)
+	if _, err := command.Classify(Request{URL: "https://example.com/hang"}); !errors.Is(err, ErrStopped) {
+		t.Errorf("expected ErrStopped for a command that does not answer, got %v", err)
+	}
+	if _, err := command.Classify(Request{URL: "https://example.com/about"}); !errors.Is(err, ErrStopped) {
+		t.Errorf("expected ErrStopped after the command was stopped, got %v", err)
+	}
+	command.Close()
+
+	command = startHelper(t, 0)
+	if _, err := command.Classify(Request{URL: "https://example.com/exit"}); !errors.Is(err, ErrStopped) {
+		t.Errorf("expected ErrStopped for a command that exited, got %v", err)
+	}
+	command.Close()
+}
+
+func TestStartInvalid(t *testing.T) {
+	if _, err := Start("  ", io.Discard, 0); err == nil {
+		t.Error("expected error for an empty command")
+	}
+	if _, err := Start("/nonexistent/classifier", io.Discard, 0); err == nil {
+		t.Error("expected error for a missing command")
+	}
+}
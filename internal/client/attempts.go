@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Attempt is the outcome of a single request attempt
+type Attempt struct {
+	StatusCode int           // HTTP status code (0 if the request failed)
+	Error      string        // Request error, if any
+	Duration   time.Duration // Time taken by the attempt
+}
+
+// AttemptLog collects the attempts of requests made with its context
+type AttemptLog struct {
+	mu       sync.Mutex
+	attempts []Attempt
+}
+
+type attemptLogKey struct{}
+
+// WithAttemptLog returns a context whose requests record their attempts in log
+func WithAttemptLog(ctx context.Context, log *AttemptLog) context.Context {
+	return context.WithValue(ctx, attemptLogKey{}, log)
+}
+
+// Attempts returns the recorded attempts in order
+func (l *AttemptLog) Attempts() []Attempt {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Attempt(nil), l.attempts...)
+}
+
+// record adds an attempt to the log
+func (l *AttemptLog) record(attempt Attempt) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.attempts = append(l.attempts, attempt)
+}
+
+// recordAttempt stores the outcome of a finished attempt of req in the
+// request's attempt log, if it has one
+func recordAttempt(req *resty.Request, statusCode int, err error) {
+	if req == nil || req.Time.IsZero() {
+		return
+	}
+	log, ok := req.Context().Value(attemptLogKey{}).(*AttemptLog)
+	if !ok {
+		return
+	}
+
+	attempt := Attempt{StatusCode: statusCode, Duration: time.Since(req.Time)}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	log.record(attempt)
+}
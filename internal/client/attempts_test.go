@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientRecordsAttempts(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.RetryWaitTime = time.Millisecond
+	config.RetryMaxWaitTime = time.Millisecond
+	c := NewClient(config)
+
+	log := &AttemptLog{}
+	if _, err := c.Get(WithAttemptLog(context.Background(), log), server.URL); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+
+	attempts := log.Attempts()
+	if len(attempts) != 3 {
+		t.Fatalf("expected 3 attempts, got %+v", attempts)
+	}
+	for i, want := range []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusOK} {
+		if attempts[i].StatusCode != want || attempts[i].Error != "" {
+			t.Errorf("attempt %d = %+v, want status %d", i+1, attempts[i], want)
+		}
+	}
+
+	// Requests without a log are not recorded
+	if _, err := c.Get(context.Background(), server.URL); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if len(log.Attempts()) != 3 {
+		t.Error("requests without an attempt log should not be recorded")
+	}
+}
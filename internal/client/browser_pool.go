@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -266,7 +267,8 @@ func (p *BrowserPool) createNewContext() (*BrowserContext, error) {
 	}
 
 	context, err := browser.NewContext(playwright.BrowserNewContextOptions{
-		UserAgent: playwright.String(p.config.UserAgent),
+		UserAgent:      playwright.String(p.config.UserAgent),
+		ServiceWorkers: p.serviceWorkers(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create browser context: %w", err)
@@ -290,43 +292,146 @@ func (p *BrowserPool) RenderPage(ctx context.Context, targetURL string) (string,
 		return "", fmt.Errorf("JavaScript rendering is not enabled")
 	}
 
+	page, err := p.renderPooled(targetURL)
+	return page.content, err
+}
+
+// renderPooled renders a page using a context from the pool, returning its
+// diagnostics along with the HTML
+func (p *BrowserPool) renderPooled(targetURL string) (renderedPage, error) {
 	browserCtx, err := p.AcquireContext()
 	if err != nil {
-		return "", fmt.Errorf("failed to acquire browser context: %w", err)
+		return renderedPage{}, fmt.Errorf("failed to acquire browser context: %w", err)
 	}
 	defer browserCtx.ReleaseContext()
 
+	return p.render(browserCtx.Context, targetURL, nil)
+}
+
+// InspectPage renders a page in a fresh browser context set up like those of
+// RenderPage. The page is kept open until resume is closed, the page is
+// closed in the browser or ctx is done, so it can be inspected in a visible
+// browser (nil resume = closed right away).
+func (p *BrowserPool) InspectPage(ctx context.Context, targetURL string, resume <-chan struct{}) (string, error) {
+	if !p.config.Enabled {
+		return "", fmt.Errorf("JavaScript rendering is not enabled")
+	}
+
+	browserCtx, err := p.createNewContext()
+	if err != nil {
+		return "", err
+	}
+	defer browserCtx.Context.Close()
+
+	var hold func(playwright.Page)
+	if resume != nil {
+		hold = func(page playwright.Page) {
+			closed := make(chan struct{})
+			page.OnClose(func(playwright.Page) { close(closed) })
+
+			p.logger.Info("Page loaded, paused for inspection", "url", targetURL)
+			select {
+			case <-resume:
+			case <-closed:
+			case <-ctx.Done():
+			}
+		}
+	}
+	page, err := p.render(browserCtx.Context, targetURL, hold)
+	return page.content, err
+}
+
+// RenderMobilePage renders a page in a fresh browser context emulating a
+// mobile device with the given user agent and a phone-sized viewport
+func (p *BrowserPool) RenderMobilePage(ctx context.Context, targetURL, userAgent string) (string, error) {
+	if !p.config.Enabled {
+		return "", fmt.Errorf("JavaScript rendering is not enabled")
+	}
+
+	browser, err := p.getBrowser()
+	if err != nil {
+		return "", fmt.Errorf("failed to get browser from pool: %w", err)
+	}
+
+	browserContext, err := browser.NewContext(playwright.BrowserNewContextOptions{
+		UserAgent:      playwright.String(userAgent),
+		Viewport:       &playwright.Size{Width: MobileViewportWidth, Height: MobileViewportHeight},
+		IsMobile:       playwright.Bool(true),
+		HasTouch:       playwright.Bool(true),
+		ServiceWorkers: p.serviceWorkers(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create mobile browser context: %w", err)
+	}
+	defer browserContext.Close()
+
+	page, err := p.render(browserContext, targetURL, nil)
+	return page.content, err
+}
+
+// renderedPage is the outcome of rendering a page
+type renderedPage struct {
+	content       string             // Rendered HTML
+	diagnostics   *RenderDiagnostics // Only with JSConfig.Diagnostics
+	partial       bool               // Navigation timed out, content is what had loaded by then
+	shadowLinks   []string           // Links inside open shadow roots, missing from content
+	routes        []string           // Routes declared by the client-side router (only with JSConfig.EnumerateRoutes)
+	serviceWorker string             // Script of the page's service worker (only with JSConfig.DetectServiceWorkers)
+}
+
+// render loads targetURL in a new page of browserContext and returns the
+// rendered HTML, with the page's diagnostics if JSConfig.Diagnostics is set.
+// hold, if set, is called with the loaded page before it is closed.
+func (p *BrowserPool) render(browserContext playwright.BrowserContext, targetURL string, hold func(playwright.Page)) (renderedPage, error) {
 	p.logger.Debug("Starting JavaScript rendering", "url", targetURL)
 
 	// Create a new page
-	page, err := browserCtx.Context.NewPage()
+	page, err := browserContext.NewPage()
 	if err != nil {
-		return "", fmt.Errorf("failed to create page: %w", err)
+		return renderedPage{}, fmt.Errorf("failed to create page: %w", err)
 	}
 	defer page.Close()
 
 	// Apply minimal performance optimizations
 	// Block only the most resource-intensive content types
+	blocked := func(string) bool { return false }
 	if !testing.Testing() {
+		blocked = isHeavyResource
 		page.Route("**/*", func(route playwright.Route) {
-			resourceType := route.Request().ResourceType()
-			switch resourceType {
-			case "image", "media", "font":
-				// Block only heavy resources
+			if isHeavyResource(route.Request().ResourceType()) {
 				route.Abort()
 				return
-			default:
-				route.Continue()
 			}
+			route.Continue()
 		})
 	}
 
+	// Collect console errors and failed requests for the results
+	var diagnostics *diagnosticsRecorder
+	if p.config.Diagnostics {
+		diagnostics = recordDiagnostics(page, blocked)
+	}
+
 	// Setup debug handlers if running in test mode
 	var consoleLogs, networkLogs []string
 	if testing.Testing() {
 		consoleLogs, networkLogs = SetupPageDebugHandlers(page)
 	}
 
+	// Apply per-URL headers from the rules file
+	if headers := p.config.HeaderRules.HeadersFor(targetURL); len(headers) > 0 {
+		if err := page.SetExtraHTTPHeaders(headers); err != nil {
+			return renderedPage{}, fmt.Errorf("failed to set extra headers: %w", err)
+		}
+	}
+
+	// Tell consent management platforms the visitor already agreed
+	if p.config.DismissConsent {
+		if err := setConsentCookies(browserContext, targetURL); err != nil {
+			p.logger.Debug("Failed to set consent cookies", "url", targetURL, "error", err)
+		}
+	}
+
 	// Set timeout
 	page.SetDefaultTimeout(float64(p.config.Timeout.Milliseconds()))
 
@@ -347,6 +452,11 @@ func (p *BrowserPool) RenderPage(ctx context.Context, targetURL string) (string,
 		WaitUntil: waitUntil,
 		Timeout:   playwright.Float(float64(p.config.Timeout.Milliseconds())),
 	})
+	partial := false
+	if err != nil && p.keepPartial(page, err) {
+		p.logger.Warn("Navigation timed out, keeping the partially rendered page", "url", targetURL, "timeout", p.config.Timeout)
+		partial, err = true, nil
+	}
 	if err != nil {
 		// Log debug info when running in test mode
 		if testing.Testing() && (len(consoleLogs) > 0 || len(networkLogs) > 0) {
@@ -356,7 +466,15 @@ func (p *BrowserPool) RenderPage(ctx context.Context, targetURL string) (string,
 				"console_logs", consoleLogs,
 				"network_logs", networkLogs)
 		}
-		return "", fmt.Errorf("failed to navigate to URL %s: %w", targetURL, err)
+		return renderedPage{}, diagnostics.withDiagnostics(fmt.Errorf("failed to navigate to URL %s: %w", targetURL, err))
+	}
+
+	// Show the client-side route the URL asks for
+	p.followHashRoute(page, targetURL)
+
+	// Accept a consent banner still shown, so the content it hides is rendered
+	if p.config.DismissConsent {
+		dismissConsent(page, p.logger)
 	}
 
 	// Get the final HTML content
@@ -370,14 +488,96 @@ func (p *BrowserPool) RenderPage(ctx context.Context, targetURL string) (string,
 				"console_logs", consoleLogs,
 				"network_logs", networkLogs)
 		}
-		return "", fmt.Errorf("failed to get page content: %w", err)
+		return renderedPage{}, diagnostics.withDiagnostics(fmt.Errorf("failed to get page content: %w", err))
+	}
+
+	shadowLinks := p.shadowLinks(page)
+	var routes []string
+	if p.config.EnumerateRoutes {
+		routes = p.declaredRoutes(page)
+	}
+	var serviceWorker string
+	if p.config.DetectServiceWorkers {
+		serviceWorker = p.serviceWorker(page)
 	}
 
 	p.logger.Debug("JavaScript rendering completed",
 		"url", targetURL,
-		"content_length", len(content))
+		"content_length", len(content),
+		"shadow_links", len(shadowLinks),
+		"routes", len(routes),
+		"service_worker", serviceWorker)
+
+	if hold != nil {
+		hold(page)
+	}
+
+	return renderedPage{content: content, diagnostics: diagnostics.result(), partial: partial, shadowLinks: shadowLinks, routes: routes, serviceWorker: serviceWorker}, nil
+}
 
-	return content, nil
+// shadowLinksScript returns the resolved hrefs of the links inside open
+// shadow roots, at any depth, which the page's HTML does not include
+const shadowLinksScript = `() => {
+	const links = [];
+	const visit = (root) => {
+		for (const element of root.querySelectorAll('*')) {
+			if (!element.shadowRoot) continue;
+			for (const link of element.shadowRoot.querySelectorAll('a[href], area[href]')) links.push(link.href);
+			visit(element.shadowRoot);
+		}
+	};
+	visit(document);
+	return links;
+}`
+
+// shadowLinks returns the links inside the open shadow roots of page, as
+// used by web components (Lit, Stencil, ...)
+func (p *BrowserPool) shadowLinks(page playwright.Page) []string {
+	links, err := evaluateStrings(page, shadowLinksScript)
+	if err != nil {
+		p.logger.Debug("Failed to read shadow DOM links", "url", page.URL(), "error", err)
+		return nil
+	}
+	return links
+}
+
+// evaluateStrings runs script in page and returns the non-empty strings of
+// the array it returns
+func evaluateStrings(page playwright.Page, script string) ([]string, error) {
+	value, err := page.Evaluate(script)
+	if err != nil {
+		return nil, err
+	}
+
+	values, _ := value.([]interface{})
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		if str, ok := v.(string); ok && str != "" {
+			strs = append(strs, str)
+		}
+	}
+	return strs, nil
+}
+
+// keepPartial reports whether a page whose navigation failed with err is
+// kept as it is: only with JSConfig.PartialOnTimeout, when the navigation
+// timed out after the page already had content
+func (p *BrowserPool) keepPartial(page playwright.Page, err error) bool {
+	if !p.config.PartialOnTimeout || !errors.Is(err, playwright.ErrTimeout) {
+		return false
+	}
+	hasContent, evalErr := page.Evaluate("() => !!document.body && document.body.childElementCount > 0")
+	return evalErr == nil && hasContent == true
+}
+
+// isHeavyResource reports whether requests of resourceType are blocked while
+// rendering, as they do not affect the links of a page
+func isHeavyResource(resourceType string) bool {
+	switch resourceType {
+	case "image", "media", "font":
+		return true
+	}
+	return false
 }
 
 // GetPoolStats returns statistics about the browser pool
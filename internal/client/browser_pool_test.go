@@ -2,12 +2,15 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
-	"github.com/aoshimash/urlmap/test/shared"
+	"github.com/aoshimash/urlmap/pkg/testsite"
 )
 
 func TestNewBrowserPool(t *testing.T) {
@@ -82,7 +85,7 @@ func TestBrowserPool_AcquireContext(t *testing.T) {
 
 func TestBrowserPool_RenderPage(t *testing.T) {
 	// Create test server for more reliable testing in CI
-	testServer := shared.CreateBasicTestServer()
+	testServer := testsite.StartBasic()
 	defer testServer.Close()
 
 	logger := slog.Default()
@@ -370,3 +373,118 @@ func TestBrowserPool_OnDemandCreation(t *testing.T) {
 	ctx1.ReleaseContext()
 	ctx2.ReleaseContext()
 }
+
+func TestBrowserPool_DismissConsent(t *testing.T) {
+	// The navigation is only shown once the banner is accepted, and the
+	// server greets visitors who sent a consent cookie
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		greeting := "new visitor"
+		if _, err := r.Cookie("OptanonAlertBoxClosed"); err == nil {
+			greeting = "returning visitor"
+		}
+		fmt.Fprintf(w, `<html><body><p>%s</p><nav id="nav"></nav>
+<div id="banner"><button id="onetrust-accept-btn-handler"
+  onclick="document.getElementById('nav').innerHTML='<a href=/docs>Docs</a>'; document.getElementById('banner').remove()">Accept</button></div>
+</body></html>`, greeting)
+	}))
+	defer server.Close()
+
+	pool, err := NewBrowserPool(&JSConfig{
+		Enabled:        true,
+		BrowserType:    "chromium",
+		Headless:       true,
+		Timeout:        30 * time.Second,
+		DismissConsent: true,
+	}, slog.Default())
+	if err != nil {
+		t.Fatalf("Failed to create browser pool: %v", err)
+	}
+	defer pool.Close()
+
+	content, err := pool.RenderPage(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Failed to render page: %v", err)
+	}
+
+	if !strings.Contains(content, "returning visitor") {
+		t.Error("consent cookies were not sent")
+	}
+	if !strings.Contains(content, `href="/docs"`) {
+		t.Error("consent banner was not dismissed")
+	}
+	if strings.Contains(content, "onetrust-accept-btn-handler") {
+		t.Error("consent banner is still in the page")
+	}
+}
+
+func TestBrowserPool_ShadowLinks(t *testing.T) {
+	// A web component rendering its links into nested open shadow roots
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><a href="/light">Light</a><site-nav></site-nav>
+<script>
+customElements.define('nav-item', class extends HTMLElement {
+  connectedCallback() { this.attachShadow({mode: 'open'}).innerHTML = '<a href="/nested">Nested</a>'; }
+});
+customElements.define('site-nav', class extends HTMLElement {
+  connectedCallback() { this.attachShadow({mode: 'open'}).innerHTML = '<a href="/docs">Docs</a><nav-item></nav-item>'; }
+});
+</script></body></html>`)
+	}))
+	defer server.Close()
+
+	config := DefaultJSConfig()
+	config.Enabled = true
+	client, err := NewJSClient(config, slog.Default())
+	if err != nil {
+		t.Fatalf("Failed to create JS client: %v", err)
+	}
+	defer client.Close()
+
+	response, err := client.Get(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Failed to render page: %v", err)
+	}
+
+	want := []string{server.URL + "/docs", server.URL + "/nested"}
+	if fmt.Sprint(response.ShadowLinks) != fmt.Sprint(want) {
+		t.Errorf("ShadowLinks = %v, want %v", response.ShadowLinks, want)
+	}
+}
+
+func TestBrowserPool_DeclaredRoutes(t *testing.T) {
+	// A Vue 2 style app whose router declares pages nothing links to
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><div id="app"><a href="/">Home</a></div>
+<script>
+document.getElementById('app').__vue__ = {$root: {$router: {mode: 'history', options: {base: '/', routes: [
+  {path: '/'},
+  {path: '/pricing'},
+  {path: '/users', children: [{path: ':id'}, {path: 'new'}]},
+  {path: '*'},
+]}}}};
+</script></body></html>`)
+	}))
+	defer server.Close()
+
+	config := DefaultJSConfig()
+	config.Enabled = true
+	config.EnumerateRoutes = true
+	client, err := NewJSClient(config, slog.Default())
+	if err != nil {
+		t.Fatalf("Failed to create JS client: %v", err)
+	}
+	defer client.Close()
+
+	response, err := client.Get(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Failed to render page: %v", err)
+	}
+
+	want := []string{server.URL + "/", server.URL + "/pricing", server.URL + "/users", server.URL + "/users/new"}
+	if fmt.Sprint(response.Routes) != fmt.Sprint(want) {
+		t.Errorf("Routes = %v, want %v", response.Routes, want)
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultChaosMaxLatency is the longest delay injected by ChaosConfig when MaxLatency is unset
+const DefaultChaosMaxLatency = 2 * time.Second
+
+// ErrChaosDrop is returned for requests dropped by chaos injection
+var ErrChaosDrop = errors.New("connection dropped by chaos injection")
+
+// ChaosConfig injects faults into HTTP requests for resilience testing
+type ChaosConfig struct {
+	Rate       float64       // Probability of a fault per request (0-1)
+	MaxLatency time.Duration // Longest injected delay (0 = DefaultChaosMaxLatency)
+	Seed       int64         // Random seed for reproducible runs (0 = random)
+}
+
+// chaosTransport decorates a transport with random latency, dropped
+// connections and 5xx responses. Each faulty request gets one of the three.
+type chaosTransport struct {
+	next       http.RoundTripper
+	rate       float64
+	maxLatency time.Duration
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// newChaosTransport wraps next with the faults of config
+func newChaosTransport(next http.RoundTripper, config *ChaosConfig) *chaosTransport {
+	maxLatency := config.MaxLatency
+	if maxLatency <= 0 {
+		maxLatency = DefaultChaosMaxLatency
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &chaosTransport{
+		next:       next,
+		rate:       config.Rate,
+		maxLatency: maxLatency,
+		rng:        rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Chaos fault kinds
+const (
+	chaosLatency = iota
+	chaosDrop
+	chaosServerError
+)
+
+// RoundTrip sends req, possibly after a delay, or fails it instead
+func (t *chaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	faulty := t.rng.Float64() < t.rate
+	fault := t.rng.Intn(3)
+	delay := time.Duration(t.rng.Int63n(int64(t.maxLatency)) + 1)
+	t.mu.Unlock()
+
+	if !faulty {
+		return t.next.RoundTrip(req)
+	}
+
+	switch fault {
+	case chaosLatency:
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		return t.next.RoundTrip(req)
+	case chaosDrop:
+		return nil, ErrChaosDrop
+	default:
+		body := "service unavailable (chaos injection)\n"
+		return &http.Response{
+			Status:        "503 Service Unavailable",
+			StatusCode:    http.StatusServiceUnavailable,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChaosTransport(t *testing.T) {
+	var passed int
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		passed++
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok")), Request: req}, nil
+	})
+
+	transport := newChaosTransport(next, &ChaosConfig{Rate: 1, MaxLatency: time.Millisecond, Seed: 42})
+	var drops, serverErrors int
+	const requests = 300
+	for i := 0; i < requests; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com/", nil)
+		resp, err := transport.RoundTrip(req)
+		switch {
+		case errors.Is(err, ErrChaosDrop):
+			drops++
+		case err != nil:
+			t.Fatalf("unexpected error: %v", err)
+		case resp.StatusCode == http.StatusServiceUnavailable:
+			serverErrors++
+		}
+	}
+	if drops == 0 || serverErrors == 0 || passed == 0 {
+		t.Errorf("expected all fault kinds, got %d drops, %d 5xx, %d delayed", drops, serverErrors, passed)
+	}
+	if drops+serverErrors+passed != requests {
+		t.Errorf("every request should get exactly one fault")
+	}
+
+	// No faults at rate 0
+	passed = 0
+	transport = newChaosTransport(next, &ChaosConfig{Rate: 0})
+	for i := 0; i < 50; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com/", nil)
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatalf("unexpected error at rate 0: %v", err)
+		}
+	}
+	if passed != 50 {
+		t.Errorf("expected all 50 requests passed through, got %d", passed)
+	}
+}
+
+func TestClientChaos(t *testing.T) {
+	config := DefaultConfig()
+	config.RetryCount = 0
+	config.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok")), Request: req}, nil
+	})
+	config.Chaos = &ChaosConfig{Rate: 1, MaxLatency: time.Millisecond, Seed: 1}
+
+	c := NewClient(config)
+	failures := 0
+	for i := 0; i < 30; i++ {
+		resp, err := c.Get(context.Background(), "https://example.invalid/")
+		if err != nil || resp.StatusCode() != http.StatusOK {
+			failures++
+		}
+	}
+	if failures == 0 {
+		t.Error("expected chaos injection to fail some requests")
+	}
+}
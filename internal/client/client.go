@@ -2,10 +2,16 @@ package client
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+
+	"github.com/aoshimash/urlmap/internal/cache"
+	"github.com/aoshimash/urlmap/internal/redact"
 )
 
 const (
@@ -19,25 +25,61 @@ const (
 	DefaultRetryWaitTime = 1 * time.Second
 	// DefaultRetryMaxWaitTime is the maximum wait time between retries
 	DefaultRetryMaxWaitTime = 5 * time.Second
+	// DefaultConnectTimeout is the default time allowed to establish a connection
+	DefaultConnectTimeout = 10 * time.Second
+	// DefaultResponseHeaderTimeout is the default time allowed to wait for response headers
+	DefaultResponseHeaderTimeout = 30 * time.Second
+	// DefaultReadTimeout is the default time a response body may stall without sending data
+	DefaultReadTimeout = 30 * time.Second
 )
 
 // Config holds the HTTP client configuration
 type Config struct {
 	UserAgent        string
-	Timeout          time.Duration
+	Timeout          time.Duration // Total time per request attempt (0 = no limit)
 	RetryCount       int
 	RetryWaitTime    time.Duration
 	RetryMaxWaitTime time.Duration
+
+	// Timeouts for the individual phases of a request (0 = no limit)
+	ConnectTimeout        time.Duration // Establishing the connection, including TLS
+	ResponseHeaderTimeout time.Duration // Waiting for response headers after sending the request
+	ReadTimeout           time.Duration // Response body stalling without sending data
+
+	Headers     map[string]string // Headers sent with every request (optional)
+	HeaderRules *HeaderRules      // Extra headers per URL pattern (optional)
+	CookieJar   http.CookieJar    // Cookie jar shared across clients (optional)
+	DNSCache    *DNSCache         // Shared DNS cache (optional)
+
+	// RequestLog receives every request as a curl command line (optional)
+	RequestLog io.Writer
+
+	// Redactor masks secrets in RequestLog lines (optional)
+	Redactor *redact.Redactor
+
+	// Transport replaces the network transport, e.g. to replay archived
+	// responses (optional; the timeouts and DNS cache above are then unused)
+	Transport http.RoundTripper
+
+	// Chaos injects latency, dropped connections and 5xx responses (optional)
+	Chaos *ChaosConfig
+
+	// HTTPCache stores responses on disk and revalidates them, so unchanged
+	// pages are not downloaded again (optional)
+	HTTPCache *cache.Cache
 }
 
 // DefaultConfig returns the default client configuration
 func DefaultConfig() *Config {
 	return &Config{
-		UserAgent:        DefaultUserAgent,
-		Timeout:          DefaultTimeout,
-		RetryCount:       DefaultRetryCount,
-		RetryWaitTime:    DefaultRetryWaitTime,
-		RetryMaxWaitTime: DefaultRetryMaxWaitTime,
+		UserAgent:             DefaultUserAgent,
+		Timeout:               DefaultTimeout,
+		RetryCount:            DefaultRetryCount,
+		RetryWaitTime:         DefaultRetryWaitTime,
+		RetryMaxWaitTime:      DefaultRetryMaxWaitTime,
+		ConnectTimeout:        DefaultConnectTimeout,
+		ResponseHeaderTimeout: DefaultResponseHeaderTimeout,
+		ReadTimeout:           DefaultReadTimeout,
 	}
 }
 
@@ -56,8 +98,21 @@ func NewClient(config *Config) *Client {
 	client := resty.New()
 
 	// Basic configuration
+	transport := config.Transport
+	if transport == nil {
+		transport = newTransport(config)
+	}
+	if config.Chaos != nil && config.Chaos.Rate > 0 {
+		transport = newChaosTransport(transport, config.Chaos)
+	}
+	if config.HTTPCache != nil {
+		transport = config.HTTPCache.Wrap(transport)
+	}
+	client.SetTransport(transport)
 	client.SetTimeout(config.Timeout)
 	client.SetHeader("User-Agent", config.UserAgent)
+	client.SetHeaders(config.Headers)
+	client.SetRedirectPolicy(redirectLoopPolicy, resty.FlexibleRedirectPolicy(maxRedirects))
 
 	// Retry configuration
 	client.SetRetryCount(config.RetryCount)
@@ -66,12 +121,23 @@ func NewClient(config *Config) *Client {
 
 	// Retry conditions - only retry on server errors (5xx)
 	client.AddRetryCondition(func(r *resty.Response, err error) bool {
+		// A redirect loop fails the same way every time
+		if errors.Is(err, ErrRedirectLoop) {
+			return false
+		}
+
 		// Retry on network errors
 		if err != nil {
 			slog.Debug("Retrying due to network error", "error", err)
 			return true
 		}
 
+		// Leave throttled responses with Retry-After to the caller,
+		// which can delay the host instead of retrying immediately
+		if IsThrottled(r.StatusCode()) && r.Header().Get("Retry-After") != "" {
+			return false
+		}
+
 		// Retry on 5xx server errors, but not on 4xx client errors
 		if r.StatusCode() >= 500 {
 			slog.Debug("Retrying due to server error", "status_code", r.StatusCode())
@@ -82,6 +148,34 @@ func NewClient(config *Config) *Client {
 		return false
 	})
 
+	// Share the cookie jar so cookies persist across clients and crawls
+	if config.CookieJar != nil {
+		client.SetCookieJar(config.CookieJar)
+	}
+
+	// Per-URL headers from the rules file
+	if config.HeaderRules.Len() > 0 {
+		rules := config.HeaderRules
+		client.OnBeforeRequest(func(c *resty.Client, req *resty.Request) error {
+			for name, value := range rules.HeadersFor(req.URL) {
+				// Explicitly set request headers take precedence
+				if req.Header.Get(name) == "" {
+					req.SetHeader(name, value)
+				}
+			}
+			return nil
+		})
+	}
+
+	// Log requests as curl commands once their final headers are known
+	if config.RequestLog != nil {
+		requestLog, redactor := config.RequestLog, config.Redactor
+		client.SetPreRequestHook(func(c *resty.Client, req *http.Request) error {
+			writeCurlCommand(requestLog, req, c.GetClient().Jar, redactor)
+			return nil
+		})
+	}
+
 	// Request and response hooks for logging
 	client.OnBeforeRequest(func(c *resty.Client, req *resty.Request) error {
 		slog.Debug("HTTP request starting",
@@ -112,6 +206,21 @@ func NewClient(config *Config) *Client {
 		)
 	})
 
+	// Record every attempt for callers that pass an attempt log: responses
+	// after each attempt, errors before each retry and after the last attempt
+	client.OnAfterResponse(func(c *resty.Client, resp *resty.Response) error {
+		recordAttempt(resp.Request, resp.StatusCode(), nil)
+		return nil
+	})
+	client.AddRetryHook(func(resp *resty.Response, err error) {
+		if err != nil && resp != nil {
+			recordAttempt(resp.Request, 0, err)
+		}
+	})
+	client.OnError(func(req *resty.Request, err error) {
+		recordAttempt(req, 0, err)
+	})
+
 	return &Client{
 		client: client,
 		config: config,
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// consentCookies are set before a page is loaded, so consent management
+// platforms that find them do not show their banner at all
+var consentCookies = []struct{ name, value string }{
+	{"cookieconsent_status", "allow"},                 // Osano cookieconsent
+	{"OptanonAlertBoxClosed", "2020-01-01T00:00:00Z"}, // OneTrust
+	{"CONSENT", "YES+"},                               // Google
+	{"cookie_consent", "accepted"},
+	{"cookies_accepted", "true"},
+}
+
+// consentSelectors match the accept buttons of common consent banners, tried
+// in order when a page has loaded
+var consentSelectors = []string{
+	"#onetrust-accept-btn-handler",                           // OneTrust
+	"#CybotCookiebotDialogBodyLevelButtonLevelOptinAllowAll", // Cookiebot
+	"#CybotCookiebotDialogBodyButtonAccept",                  // Cookiebot (legacy)
+	"#didomi-notice-agree-button",                            // Didomi
+	"[data-testid='uc-accept-all-button']",                   // Usercentrics
+	".qc-cmp2-summary-buttons button[mode='primary']",        // Quantcast
+	"#truste-consent-button",                                 // TrustArc
+	".fc-cta-consent",                                        // Google Funding Choices
+	".cc-allow, .cc-dismiss",                                 // Osano cookieconsent
+	"button:has-text('Accept all'), button:has-text('Accept cookies'), button:has-text('Alle akzeptieren'), button:has-text('Tout accepter')",
+}
+
+// consentClickTimeout bounds clicking a banner button, and consentSettleTimeout
+// waiting for the page to settle after the banner is gone
+const (
+	consentClickTimeout  = 2 * time.Second
+	consentSettleTimeout = 5 * time.Second
+)
+
+// setConsentCookies sets consentCookies for targetURL in browserContext
+func setConsentCookies(browserContext playwright.BrowserContext, targetURL string) error {
+	cookies := make([]playwright.OptionalCookie, 0, len(consentCookies))
+	for _, cookie := range consentCookies {
+		cookies = append(cookies, playwright.OptionalCookie{
+			Name:  cookie.name,
+			Value: cookie.value,
+			URL:   playwright.String(targetURL),
+		})
+	}
+	return browserContext.AddCookies(cookies)
+}
+
+// dismissConsent clicks the accept button of the first consent banner shown
+// on page and waits for the page to settle. It reports whether a banner was
+// dismissed; pages without one are left as they are.
+func dismissConsent(page playwright.Page, logger *slog.Logger) bool {
+	for _, selector := range consentSelectors {
+		button := page.Locator(selector).First()
+		if visible, err := button.IsVisible(); err != nil || !visible {
+			continue
+		}
+
+		if err := button.Click(playwright.LocatorClickOptions{
+			Timeout: playwright.Float(float64(consentClickTimeout.Milliseconds())),
+		}); err != nil {
+			logger.Debug("Failed to dismiss consent banner", "url", page.URL(), "selector", selector, "error", err)
+			continue
+		}
+
+		// Content hidden by the banner may load once it is accepted
+		page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+			State:   playwright.LoadStateNetworkidle,
+			Timeout: playwright.Float(float64(consentSettleTimeout.Milliseconds())),
+		})
+		logger.Debug("Dismissed consent banner", "url", page.URL(), "selector", selector)
+		return true
+	}
+	return false
+}
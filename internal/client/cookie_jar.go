@@ -0,0 +1,166 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// CookieJar is an http.CookieJar that remembers every cookie it receives so
+// the jar can be saved to disk and restored for a later crawl
+type CookieJar struct {
+	jar     *cookiejar.Jar
+	mu      sync.Mutex
+	cookies map[string]savedCookie // keyed by host, path and name
+	discard bool                   // Drop every cookie received
+}
+
+// savedCookie is the on-disk representation of a cookie
+type savedCookie struct {
+	URL      string    `json:"url"`
+	Name     string    `json:"name"`
+	Value    string    `json:"value"`
+	Domain   string    `json:"domain,omitempty"`
+	Path     string    `json:"path,omitempty"`
+	Expires  time.Time `json:"expires,omitempty"`
+	Secure   bool      `json:"secure,omitempty"`
+	HttpOnly bool      `json:"http_only,omitempty"`
+}
+
+// NewCookieJar creates an empty in-memory cookie jar
+func NewCookieJar() *CookieJar {
+	// cookiejar.New only fails on invalid options, and nil options are valid
+	jar, _ := cookiejar.New(nil)
+	return &CookieJar{
+		jar:     jar,
+		cookies: make(map[string]savedCookie),
+	}
+}
+
+// NewDiscardCookieJar creates a cookie jar that never stores a cookie, so no
+// session state is kept between requests
+func NewDiscardCookieJar() *CookieJar {
+	jar := NewCookieJar()
+	jar.discard = true
+	return jar
+}
+
+// LoadCookieJar creates a cookie jar populated from the given file.
+// A missing file yields an empty jar.
+func LoadCookieJar(path string) (*CookieJar, error) {
+	jar := NewCookieJar()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return jar, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cookie jar: %w", err)
+	}
+
+	var saved []savedCookie
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse cookie jar %s: %w", path, err)
+	}
+
+	now := time.Now()
+	for _, sc := range saved {
+		if !sc.Expires.IsZero() && sc.Expires.Before(now) {
+			continue
+		}
+		u, err := url.Parse(sc.URL)
+		if err != nil {
+			continue
+		}
+		jar.SetCookies(u, []*http.Cookie{{
+			Name:     sc.Name,
+			Value:    sc.Value,
+			Domain:   sc.Domain,
+			Path:     sc.Path,
+			Expires:  sc.Expires,
+			Secure:   sc.Secure,
+			HttpOnly: sc.HttpOnly,
+		}})
+	}
+
+	return jar, nil
+}
+
+// SetCookies implements http.CookieJar
+func (j *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	if j.discard {
+		return
+	}
+	j.jar.SetCookies(u, cookies)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	for _, c := range cookies {
+		key := u.Hostname() + "|" + c.Domain + "|" + c.Path + "|" + c.Name
+		if c.MaxAge < 0 || (!c.Expires.IsZero() && c.Expires.Before(now)) {
+			delete(j.cookies, key)
+			continue
+		}
+
+		expires := c.Expires
+		if c.MaxAge > 0 {
+			expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+		}
+
+		j.cookies[key] = savedCookie{
+			URL:      (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String(),
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			Expires:  expires,
+			Secure:   c.Secure,
+			HttpOnly: c.HttpOnly,
+		}
+	}
+}
+
+// Cookies implements http.CookieJar
+func (j *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+// Len returns the number of cookies that would be saved
+func (j *CookieJar) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.cookies)
+}
+
+// Save writes the unexpired cookies to the given file
+func (j *CookieJar) Save(path string) error {
+	j.mu.Lock()
+	now := time.Now()
+	saved := make([]savedCookie, 0, len(j.cookies))
+	for _, sc := range j.cookies {
+		if sc.Expires.IsZero() || sc.Expires.After(now) {
+			saved = append(saved, sc)
+		}
+	}
+	j.mu.Unlock()
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cookie jar: %w", err)
+	}
+
+	// Cookies may hold session credentials, so keep the file private
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cookie jar: %w", err)
+	}
+
+	return nil
+}
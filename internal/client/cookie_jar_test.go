@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+)
+
+func newCookieTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1", Path: "/", MaxAge: 3600})
+		http.SetCookie(w, &http.Cookie{Name: "tmp", Value: "t", Path: "/"})
+	})
+	mux.HandleFunc("/logout", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Path: "/", MaxAge: -1})
+	})
+	mux.HandleFunc("/whoami", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("session"); err == nil {
+			w.Write([]byte(c.Value))
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestCookieJarSharedAcrossClients(t *testing.T) {
+	server := newCookieTestServer()
+	defer server.Close()
+
+	jar := NewCookieJar()
+	newClient := func() *Client {
+		config := DefaultConfig()
+		config.RetryCount = 0
+		config.CookieJar = jar
+		return NewClient(config)
+	}
+
+	ctx := context.Background()
+	if _, err := newClient().Get(ctx, server.URL+"/login"); err != nil {
+		t.Fatalf("login request failed: %v", err)
+	}
+
+	resp, err := newClient().Get(ctx, server.URL+"/whoami")
+	if err != nil {
+		t.Fatalf("whoami request failed: %v", err)
+	}
+	if resp.String() != "s1" {
+		t.Errorf("session cookie not sent by second client, got %q", resp.String())
+	}
+}
+
+func TestCookieJarSaveAndLoad(t *testing.T) {
+	server := newCookieTestServer()
+	defer server.Close()
+
+	jar := NewCookieJar()
+	config := DefaultConfig()
+	config.RetryCount = 0
+	config.CookieJar = jar
+	if _, err := NewClient(config).Get(context.Background(), server.URL+"/login"); err != nil {
+		t.Fatalf("login request failed: %v", err)
+	}
+	if jar.Len() != 2 {
+		t.Fatalf("expected 2 cookies, got %d", jar.Len())
+	}
+
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	if err := jar.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := LoadCookieJar(path)
+	if err != nil {
+		t.Fatalf("LoadCookieJar() error: %v", err)
+	}
+
+	u, _ := url.Parse(server.URL + "/whoami")
+	cookies := loaded.Cookies(u)
+	if len(cookies) != 2 {
+		t.Errorf("expected 2 restored cookies, got %d", len(cookies))
+	}
+}
+
+func TestCookieJarDeletedCookie(t *testing.T) {
+	server := newCookieTestServer()
+	defer server.Close()
+
+	jar := NewCookieJar()
+	config := DefaultConfig()
+	config.RetryCount = 0
+	config.CookieJar = jar
+	c := NewClient(config)
+
+	ctx := context.Background()
+	c.Get(ctx, server.URL+"/login")
+	c.Get(ctx, server.URL+"/logout")
+
+	if jar.Len() != 1 {
+		t.Errorf("expected deleted session cookie to be dropped, got %d cookies", jar.Len())
+	}
+}
+
+func TestLoadCookieJarMissingFile(t *testing.T) {
+	jar, err := LoadCookieJar(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("LoadCookieJar() error: %v", err)
+	}
+	if jar.Len() != 0 {
+		t.Errorf("expected empty jar, got %d cookies", jar.Len())
+	}
+}
+
+func TestDiscardCookieJar(t *testing.T) {
+	server := newCookieTestServer()
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.RetryCount = 0
+	config.CookieJar = NewDiscardCookieJar()
+	c := NewClient(config)
+
+	if _, err := c.Get(context.Background(), server.URL+"/login"); err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	resp, err := c.Get(context.Background(), server.URL+"/whoami")
+	if err != nil {
+		t.Fatalf("whoami failed: %v", err)
+	}
+	if resp.String() != "" {
+		t.Errorf("discard jar sent a session cookie: %q", resp.String())
+	}
+
+	u, _ := url.Parse(server.URL)
+	if cookies := config.CookieJar.Cookies(u); len(cookies) != 0 {
+		t.Errorf("discard jar holds %d cookies", len(cookies))
+	}
+}
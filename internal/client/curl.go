@@ -0,0 +1,84 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aoshimash/urlmap/internal/redact"
+)
+
+// CurlCommand returns a curl command line that repeats req, including the
+// cookies jar would send with it (jar may be nil)
+func CurlCommand(req *http.Request, jar http.CookieJar) string {
+	var b strings.Builder
+	b.WriteString("curl")
+	if req.Method != http.MethodGet {
+		fmt.Fprintf(&b, " -X %s", req.Method)
+	}
+	fmt.Fprintf(&b, " -L --max-redirs %d", maxRedirects)
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			fmt.Fprintf(&b, " -H %s", shellQuote(name+": "+value))
+		}
+	}
+
+	if jar != nil && req.Header.Get("Cookie") == "" {
+		var cookies []string
+		for _, cookie := range jar.Cookies(req.URL) {
+			cookies = append(cookies, cookie.Name+"="+cookie.Value)
+		}
+		if len(cookies) > 0 {
+			fmt.Fprintf(&b, " -H %s", shellQuote("Cookie: "+strings.Join(cookies, "; ")))
+		}
+	}
+
+	fmt.Fprintf(&b, " %s", shellQuote(req.URL.String()))
+	return b.String()
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// writeCurlCommand logs req as a curl command line to w, masking secrets
+// with redactor (which may be nil)
+func writeCurlCommand(w io.Writer, req *http.Request, jar http.CookieJar, redactor *redact.Redactor) {
+	if redactor != nil {
+		req, jar = redactRequest(req, jar, redactor), nil
+	}
+	// One write per line keeps concurrent requests from interleaving
+	io.WriteString(w, CurlCommand(req, jar)+"\n")
+}
+
+// redactRequest returns a copy of req with the cookies of jar added and
+// matching headers and query parameters masked
+func redactRequest(req *http.Request, jar http.CookieJar, redactor *redact.Redactor) *http.Request {
+	redacted := req.Clone(req.Context())
+
+	if jar != nil && redacted.Header.Get("Cookie") == "" {
+		for _, cookie := range jar.Cookies(req.URL) {
+			redacted.AddCookie(cookie)
+		}
+	}
+	for name, values := range redacted.Header {
+		for i, value := range values {
+			values[i] = redactor.Header(name, value)
+		}
+	}
+
+	if u, err := url.Parse(redactor.URL(req.URL.String())); err == nil {
+		redacted.URL = u
+	}
+	return redacted
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/redact"
+)
+
+func TestCurlCommand(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/search?q=it's", nil)
+	req.Header.Set("User-Agent", "urlmap/1.0")
+	req.Header.Set("Accept-Language", "en")
+
+	jar, _ := cookiejar.New(nil)
+	jar.SetCookies(&url.URL{Scheme: "https", Host: "example.com"}, []*http.Cookie{{Name: "session", Value: "abc"}})
+
+	want := `curl -L --max-redirs 10 -H 'Accept-Language: en' -H 'User-Agent: urlmap/1.0' -H 'Cookie: session=abc' 'https://example.com/search?q=it'\''s'`
+	if got := CurlCommand(req, jar); got != want {
+		t.Errorf("CurlCommand() = %s\nwant %s", got, want)
+	}
+
+	req.Method = http.MethodHead
+	if got := CurlCommand(req, nil); !strings.HasPrefix(got, "curl -X HEAD -L") || strings.Contains(got, "Cookie") {
+		t.Errorf("unexpected command for HEAD without a jar: %s", got)
+	}
+}
+
+func TestClientRequestLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var log bytes.Buffer
+	config := DefaultConfig()
+	config.UserAgent = "test-agent"
+	config.Headers = map[string]string{"X-Env": "staging"}
+	config.RequestLog = &log
+
+	if _, err := NewClient(config).Get(context.Background(), server.URL+"/page"); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+
+	line := log.String()
+	for _, want := range []string{"curl ", "-H 'User-Agent: test-agent'", "-H 'X-Env: staging'", "'" + server.URL + "/page'\n"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("request log missing %q: %s", want, line)
+		}
+	}
+}
+
+func TestClientRequestLog_Redacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	jar, _ := cookiejar.New(nil)
+	serverURL, _ := url.Parse(server.URL)
+	jar.SetCookies(serverURL, []*http.Cookie{{Name: "session", Value: "abc"}})
+
+	var log bytes.Buffer
+	config := DefaultConfig()
+	config.Headers = map[string]string{"Authorization": "Bearer s3cr3t", "X-Env": "staging"}
+	config.CookieJar = jar
+	config.RequestLog = &log
+	config.Redactor = redact.New(redact.DefaultPatterns)
+
+	if _, err := NewClient(config).Get(context.Background(), server.URL+"/page?page=2&token=t0k3n"); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+
+	line := log.String()
+	for _, secret := range []string{"s3cr3t", "abc", "t0k3n"} {
+		if strings.Contains(line, secret) {
+			t.Errorf("request log leaks %q: %s", secret, line)
+		}
+	}
+	for _, want := range []string{"-H 'Authorization: REDACTED'", "-H 'Cookie: REDACTED'", "-H 'X-Env: staging'", "/page?page=2&token=REDACTED'\n"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("request log missing %q: %s", want, line)
+		}
+	}
+}
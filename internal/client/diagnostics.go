@@ -0,0 +1,19 @@
+package client
+
+import "strings"
+
+// DefaultErrorBodySize is how many bytes of an HTTP error response body are
+// kept for diagnostics, enough to recognize a WAF block page or an API error
+const DefaultErrorBodySize = 512
+
+// BodySnippet returns the first size bytes of body for display, cut at a
+// character boundary and with runs of whitespace collapsed to single spaces
+func BodySnippet(body string, size int) string {
+	if size <= 0 {
+		return ""
+	}
+	if len(body) > size {
+		body = strings.ToValidUTF8(body[:size], "")
+	}
+	return strings.Join(strings.Fields(body), " ")
+}
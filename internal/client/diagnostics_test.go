@@ -0,0 +1,33 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBodySnippet(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		size int
+		want string
+	}{
+		{"whitespace collapsed", "<html>\n  <title>Just a moment...</title>\n</html>\n", 512, "<html> <title>Just a moment...</title> </html>"},
+		{"cut at size", "0123456789", 4, "0123"},
+		{"cut inside a character", "abcé", 4, "abc"},
+		{"disabled", "error", 0, ""},
+		{"empty body", "", 512, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BodySnippet(tt.body, tt.size); got != tt.want {
+				t.Errorf("BodySnippet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := BodySnippet(strings.Repeat("x", 1000), DefaultErrorBodySize); len(got) != DefaultErrorBodySize {
+		t.Errorf("BodySnippet() kept %d bytes, want %d", len(got), DefaultErrorBodySize)
+	}
+}
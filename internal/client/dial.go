@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// defaultFallbackDelay is how long a connection attempt to the preferred
+// address family may take before the other family is tried in parallel, as
+// recommended by RFC 8305 ("Happy Eyeballs")
+const defaultFallbackDelay = 300 * time.Millisecond
+
+// dialDualStack connects to one of the resolved addresses of a host. The
+// addresses of the first address's family are tried in turn; when they fail,
+// or have not connected within the dialer's fallback delay, the addresses of
+// the other family are tried in parallel and the first connection wins. A
+// negative FallbackDelay tries all addresses in turn.
+func dialDualStack(ctx context.Context, dialer *net.Dialer, network, port string, addrs []string) (net.Conn, error) {
+	primaries, fallbacks := splitAddressFamilies(addrs)
+	if len(fallbacks) == 0 || dialer.FallbackDelay < 0 {
+		return dialSerial(ctx, dialer, network, port, addrs)
+	}
+	delay := dialer.FallbackDelay
+	if delay == 0 {
+		delay = defaultFallbackDelay
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+	results := make(chan dialResult, 2) // Buffered so the losing attempt never blocks
+	start := func(addrs []string) {
+		go func() {
+			conn, err := dialSerial(ctx, dialer, network, port, addrs)
+			results <- dialResult{conn: conn, err: err}
+		}()
+	}
+
+	start(primaries)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	pending, fallbackStarted := 1, false
+	var firstErr error
+	for {
+		select {
+		case <-timer.C:
+			if !fallbackStarted {
+				fallbackStarted = true
+				pending++
+				start(fallbacks)
+			}
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				if pending > 0 {
+					// Close the other connection should it still succeed
+					go func() {
+						if late := <-results; late.conn != nil {
+							late.conn.Close()
+						}
+					}()
+				}
+				return result.conn, nil
+			}
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			if !fallbackStarted {
+				fallbackStarted = true
+				pending++
+				start(fallbacks)
+				continue
+			}
+			if pending == 0 {
+				return nil, firstErr
+			}
+		}
+	}
+}
+
+// dialSerial tries each address in turn and returns the first connection,
+// or the error of the last attempt
+func dialSerial(ctx context.Context, dialer *net.Dialer, network, port string, addrs []string) (net.Conn, error) {
+	var lastErr error
+	for _, ip := range addrs {
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// splitAddressFamilies splits addrs into the addresses of the same family as
+// the first one and the others
+func splitAddressFamilies(addrs []string) (primaries, fallbacks []string) {
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	isIPv4 := func(addr string) bool {
+		ip := net.ParseIP(addr)
+		return ip != nil && ip.To4() != nil
+	}
+	primaryIPv4 := isIPv4(addrs[0])
+	for _, addr := range addrs {
+		if isIPv4(addr) == primaryIPv4 {
+			primaries = append(primaries, addr)
+		} else {
+			fallbacks = append(fallbacks, addr)
+		}
+	}
+	return primaries, fallbacks
+}
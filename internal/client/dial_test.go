@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSplitAddressFamilies(t *testing.T) {
+	primaries, fallbacks := splitAddressFamilies([]string{"2001:db8::1", "192.0.2.1", "2001:db8::2", "192.0.2.2"})
+	if !reflect.DeepEqual(primaries, []string{"2001:db8::1", "2001:db8::2"}) || !reflect.DeepEqual(fallbacks, []string{"192.0.2.1", "192.0.2.2"}) {
+		t.Errorf("unexpected split %v / %v", primaries, fallbacks)
+	}
+
+	primaries, fallbacks = splitAddressFamilies([]string{"192.0.2.1"})
+	if !reflect.DeepEqual(primaries, []string{"192.0.2.1"}) || fallbacks != nil {
+		t.Errorf("unexpected split %v / %v", primaries, fallbacks)
+	}
+}
+
+func TestDialDualStack(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	// The IPv6 loopback refuses the connection (or is unavailable), so the
+	// IPv4 address is tried right away instead of after the fallback delay
+	dialer := &net.Dialer{Timeout: time.Second, FallbackDelay: time.Minute}
+	start := time.Now()
+	conn, err := dialDualStack(context.Background(), dialer, "tcp", port, []string{"::1", "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("dialDualStack: %v", err)
+	}
+	conn.Close()
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("fallback waited %v", elapsed)
+	}
+
+	// Every address failing returns an error
+	listener.Close()
+	if _, err := dialDualStack(context.Background(), dialer, "tcp", port, []string{"127.0.0.1", "::1"}); err == nil {
+		t.Error("expected error when no address accepts the connection")
+	}
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultDNSCacheTTL is how long resolved addresses are reused.
+// The standard resolver does not expose record TTLs, so a fixed TTL is used.
+const DefaultDNSCacheTTL = time.Minute
+
+// DNSCache caches host lookups so concurrent requests to the same host
+// share a single resolver query
+type DNSCache struct {
+	resolver *net.Resolver
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*dnsEntry
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// dnsEntry is a cached (or in-flight) lookup result
+type dnsEntry struct {
+	ready   chan struct{} // Closed once the lookup completes
+	addrs   []string
+	err     error
+	expires time.Time
+}
+
+// DNSCacheStats holds cache statistics
+type DNSCacheStats struct {
+	Hits    int64 // Lookups answered from the cache
+	Misses  int64 // Lookups sent to the resolver
+	Entries int   // Hosts currently cached
+}
+
+// NewDNSCache creates a DNS cache whose entries expire after ttl
+func NewDNSCache(ttl time.Duration) *DNSCache {
+	if ttl <= 0 {
+		ttl = DefaultDNSCacheTTL
+	}
+	return &DNSCache{
+		resolver: net.DefaultResolver,
+		ttl:      ttl,
+		entries:  make(map[string]*dnsEntry),
+	}
+}
+
+// LookupHost returns the addresses of host, using the cache when possible.
+// Failed lookups are not cached.
+func (c *DNSCache) LookupHost(ctx context.Context, host string) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	if ok {
+		select {
+		case <-entry.ready:
+			if time.Now().After(entry.expires) {
+				ok = false
+			}
+		default:
+			// Lookup in flight, wait for it below
+		}
+	}
+	if !ok {
+		entry = &dnsEntry{ready: make(chan struct{})}
+		c.entries[host] = entry
+		c.mu.Unlock()
+
+		c.misses.Add(1)
+		c.resolve(ctx, host, entry)
+		return entry.addrs, entry.err
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-entry.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if entry.err != nil {
+		// The shared lookup failed; retry with our own context
+		return c.LookupHost(ctx, host)
+	}
+
+	c.hits.Add(1)
+	return entry.addrs, nil
+}
+
+// resolve performs the lookup for entry and publishes the result
+func (c *DNSCache) resolve(ctx context.Context, host string, entry *dnsEntry) {
+	entry.addrs, entry.err = c.resolver.LookupHost(ctx, host)
+	entry.expires = time.Now().Add(c.ttl)
+
+	if entry.err != nil {
+		c.mu.Lock()
+		if c.entries[host] == entry {
+			delete(c.entries, host)
+		}
+		c.mu.Unlock()
+	}
+	close(entry.ready)
+}
+
+// Prefetch resolves host ahead of the first request
+func (c *DNSCache) Prefetch(ctx context.Context, host string) error {
+	if _, err := c.LookupHost(ctx, host); err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	return nil
+}
+
+// Stats returns the cache statistics
+func (c *DNSCache) Stats() DNSCacheStats {
+	c.mu.Lock()
+	entries := len(c.entries)
+	c.mu.Unlock()
+
+	return DNSCacheStats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Entries: entries,
+	}
+}
+
+// DialContext returns a dial function that resolves hosts through the cache
+// and connects to the addresses with an IPv4/IPv6 fallback, like the standard
+// dialer does for hosts it resolves itself
+func (c *DNSCache) DialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		if net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		addrs, err := c.LookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		if len(addrs) == 0 {
+			return nil, &net.DNSError{Err: "no addresses found", Name: host, IsNotFound: true}
+		}
+		return dialDualStack(ctx, dialer, network, port, addrs)
+	}
+}
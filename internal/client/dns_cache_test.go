@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDNSCacheLookupHost(t *testing.T) {
+	cache := NewDNSCache(time.Minute)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addrs, err := cache.LookupHost(ctx, "localhost")
+			if err != nil || len(addrs) == 0 {
+				t.Errorf("LookupHost(localhost) = %v, %v", addrs, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := cache.Stats()
+	if stats.Misses != 1 || stats.Hits != 9 || stats.Entries != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestDNSCacheExpiry(t *testing.T) {
+	cache := NewDNSCache(10 * time.Millisecond)
+	ctx := context.Background()
+
+	if err := cache.Prefetch(ctx, "localhost"); err != nil {
+		t.Fatalf("Prefetch() error: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, err := cache.LookupHost(ctx, "localhost"); err != nil {
+		t.Fatalf("LookupHost() error: %v", err)
+	}
+
+	if stats := cache.Stats(); stats.Misses != 2 || stats.Hits != 0 {
+		t.Errorf("expired entry should be resolved again: %+v", stats)
+	}
+}
+
+func TestDNSCacheFailureNotCached(t *testing.T) {
+	cache := NewDNSCache(time.Minute)
+	if err := cache.Prefetch(context.Background(), "does-not-exist.invalid"); err == nil {
+		t.Fatal("expected error for unresolvable host")
+	}
+	if stats := cache.Stats(); stats.Entries != 0 {
+		t.Errorf("failed lookups should not be cached: %+v", stats)
+	}
+}
+
+func TestClientUsesDNSCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	target := "http://localhost:" + port + "/"
+
+	cache := NewDNSCache(time.Minute)
+	config := DefaultConfig()
+	config.RetryCount = 0
+	config.DNSCache = cache
+
+	// Separate clients do not share connections, so each dial goes through the cache
+	for i := 0; i < 3; i++ {
+		resp, err := NewClient(config).Get(context.Background(), target)
+		if err != nil || resp.String() != "ok" {
+			t.Fatalf("Get() = %v, %v", resp, err)
+		}
+	}
+
+	if stats := cache.Stats(); stats.Misses != 1 || stats.Hits != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
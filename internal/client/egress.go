@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Egress is a proxy that requests leave through, tagged with the region it
+// is in, so geo-dependent sites can be fetched from a given locale
+type Egress struct {
+	Region string   // Region name, e.g. "eu"
+	Proxy  *url.URL // Proxy URL (http, https or socks5)
+}
+
+// ParseEgress parses "region=proxy" specs, e.g. "eu=http://proxy-eu.example.com:8080".
+// A region may have several proxies.
+func ParseEgress(specs []string) ([]Egress, error) {
+	egresses := make([]Egress, 0, len(specs))
+	for _, spec := range specs {
+		region, rawProxy, ok := strings.Cut(spec, "=")
+		region, rawProxy = strings.ToLower(strings.TrimSpace(region)), strings.TrimSpace(rawProxy)
+		if !ok || region == "" || rawProxy == "" {
+			return nil, fmt.Errorf("invalid egress %q (expected REGION=PROXY_URL, e.g. eu=http://proxy-eu.example.com:8080)", spec)
+		}
+
+		proxy, err := url.Parse(rawProxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid egress proxy URL %q for region %s", rawProxy, region)
+		}
+		switch proxy.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("unsupported egress proxy scheme %q for region %s (use http, https or socks5)", proxy.Scheme, region)
+		}
+		egresses = append(egresses, Egress{Region: region, Proxy: proxy})
+	}
+	return egresses, nil
+}
+
+type egressKey struct{}
+
+// WithEgress returns a context whose HTTP requests go through egress
+func WithEgress(ctx context.Context, egress Egress) context.Context {
+	return context.WithValue(ctx, egressKey{}, egress)
+}
+
+// egressRegion returns the region of the egress of ctx, or "" without one
+func egressRegion(ctx context.Context) string {
+	egress, _ := ctx.Value(egressKey{}).(Egress)
+	return egress.Region
+}
+
+// egressProxy returns the proxy of the request's egress, or the proxy from
+// the environment (HTTP_PROXY, HTTPS_PROXY, NO_PROXY) for requests without one
+func egressProxy(req *http.Request) (*url.URL, error) {
+	if egress, ok := req.Context().Value(egressKey{}).(Egress); ok && egress.Proxy != nil {
+		return egress.Proxy, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseEgress(t *testing.T) {
+	egresses, err := ParseEgress([]string{"EU=http://proxy-eu.example.com:8080", "us = socks5://proxy-us.example.com:1080"})
+	if err != nil {
+		t.Fatalf("ParseEgress: %v", err)
+	}
+	if len(egresses) != 2 {
+		t.Fatalf("expected 2 egresses, got %d", len(egresses))
+	}
+	if egresses[0].Region != "eu" || egresses[0].Proxy.Host != "proxy-eu.example.com:8080" {
+		t.Errorf("unexpected first egress: %+v", egresses[0])
+	}
+	if egresses[1].Region != "us" || egresses[1].Proxy.Scheme != "socks5" {
+		t.Errorf("unexpected second egress: %+v", egresses[1])
+	}
+
+	for _, spec := range []string{"eu", "=http://proxy.example.com", "eu=", "eu=proxy.example.com", "eu=ftp://proxy.example.com"} {
+		if _, err := ParseEgress([]string{spec}); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestEgressProxy(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("direct"))
+	}))
+	defer target.Close()
+
+	// A plain HTTP proxy receives the absolute URL of the target
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	egresses, err := ParseEgress([]string{"eu=" + proxy.URL})
+	if err != nil {
+		t.Fatalf("ParseEgress: %v", err)
+	}
+
+	c := newTimeoutTestClient(&Config{})
+	resp, err := c.Get(WithEgress(context.Background(), egresses[0]), target.URL+"/page")
+	if err != nil {
+		t.Fatalf("Get through egress: %v", err)
+	}
+	if resp.String() != "proxied" || proxied != target.URL+"/page" {
+		t.Errorf("expected request for %s through the proxy, got %q (proxy saw %q)", target.URL+"/page", resp.String(), proxied)
+	}
+
+	resp, err = c.Get(context.Background(), target.URL)
+	if err != nil {
+		t.Fatalf("Get without egress: %v", err)
+	}
+	if resp.String() != "direct" {
+		t.Errorf("expected direct request without egress, got %q", resp.String())
+	}
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"strings"
+)
+
+// Kinds of connection failures returned by FailureKind
+const (
+	FailureDNS = "dns" // The host name could not be resolved
+	FailureTCP = "tcp" // The connection was refused, unreachable or timed out
+	FailureTLS = "tls" // The TLS handshake or certificate verification failed
+)
+
+// FailureKind tells whether err happened while resolving the host, opening
+// the TCP connection or during the TLS handshake, so failures on flaky
+// networks can be told apart. It returns "" for other errors, e.g. timeouts
+// waiting for the response.
+func FailureKind(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return FailureDNS
+	}
+
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		recordErr    tls.RecordHeaderError
+		alertErr     tls.AlertError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+	if errors.As(err, &verifyErr) || errors.As(err, &recordErr) || errors.As(err, &alertErr) ||
+		errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return FailureTLS
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		switch opErr.Op {
+		case "dial", "proxyconnect":
+			return FailureTCP
+		case "remote error":
+			return FailureTLS // TLS alert sent by the server
+		}
+	}
+
+	// net/http does not export its TLS handshake timeout error
+	if strings.Contains(err.Error(), "TLS handshake timeout") {
+		return FailureTLS
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFailureKind(t *testing.T) {
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tlsServer.Close()
+
+	// A port nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	closedURL := "http://" + listener.Addr().String() + "/"
+	listener.Close()
+
+	dnsCache := NewDNSCache(time.Minute)
+	dnsCache.resolver = &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return nil, &net.DNSError{Err: "no such host", Name: "missing.example", IsNotFound: true}
+		},
+	}
+
+	config := DefaultConfig()
+	config.RetryCount = 0
+	config.Timeout = 5 * time.Second
+	config.DNSCache = dnsCache
+	client := NewClient(config)
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"dns", "http://missing.example/", FailureDNS},
+		{"tcp", closedURL, FailureTCP},
+		{"tls", tlsServer.URL, FailureTLS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Get(context.Background(), tt.url)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if got := FailureKind(fmt.Errorf("failed to fetch URL: %w", err)); got != tt.want {
+				t.Errorf("FailureKind(%v) = %q, want %q", err, got, tt.want)
+			}
+		})
+	}
+
+	if got := FailureKind(errors.New("HTTP error: 500")); got != "" {
+		t.Errorf("expected no kind for an HTTP error, got %q", got)
+	}
+	if got := FailureKind(ErrReadTimeout); got != "" {
+		t.Errorf("expected no kind for a read timeout, got %q", got)
+	}
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// hashRouteSettleTimeout bounds waiting for a page to render the route set
+// in its fragment
+const hashRouteSettleTimeout = 5 * time.Second
+
+// followHashRoute makes sure the hash router of page shows the route in the
+// fragment of targetURL, such as #/about. Routers read the route when the app
+// starts, but some redirect to their default route first or only follow
+// hashchange events; the route is then set again and the page is given time
+// to render it. URLs without a hash route are left alone.
+func (p *BrowserPool) followHashRoute(page playwright.Page, targetURL string) {
+	target, err := url.Parse(targetURL)
+	if err != nil || !(strings.HasPrefix(target.Fragment, "/") || strings.HasPrefix(target.Fragment, "!/")) {
+		return
+	}
+	route := "#" + target.EscapedFragment()
+
+	if hash, err := page.Evaluate("() => location.hash"); err == nil && hash == route {
+		return
+	}
+	if _, err := page.Evaluate("route => { location.hash = route }", route); err != nil {
+		p.logger.Debug("Failed to navigate hash route", "url", targetURL, "error", err)
+		return
+	}
+	page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+		State:   playwright.LoadStateNetworkidle,
+		Timeout: playwright.Float(float64(hashRouteSettleTimeout.Milliseconds())),
+	})
+	p.logger.Debug("Navigated hash route", "url", targetURL, "route", route)
+}
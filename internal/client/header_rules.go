@@ -0,0 +1,86 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/aoshimash/urlmap/internal/filter"
+)
+
+// HeaderRule maps a URL pattern to extra request headers
+type HeaderRule struct {
+	Pattern string            `json:"pattern"` // URL pattern (see filter.Compile)
+	Headers map[string]string `json:"headers"` // Headers added to matching requests
+}
+
+// HeaderRules applies extra headers to requests based on their URL
+type HeaderRules struct {
+	rules    []HeaderRule
+	patterns []*filter.Pattern
+}
+
+// NewHeaderRules compiles the given rules
+func NewHeaderRules(rules []HeaderRule) (*HeaderRules, error) {
+	hr := &HeaderRules{}
+	for i, rule := range rules {
+		pattern, err := filter.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("header rule %d: %w", i+1, err)
+		}
+		if len(rule.Headers) == 0 {
+			return nil, fmt.Errorf("header rule %d (%s): no headers specified", i+1, rule.Pattern)
+		}
+		hr.rules = append(hr.rules, rule)
+		hr.patterns = append(hr.patterns, pattern)
+	}
+	return hr, nil
+}
+
+// LoadHeaderRules reads header rules from a JSON file containing a list of
+// {"pattern": "...", "headers": {"Name": "value"}} objects
+func LoadHeaderRules(path string) (*HeaderRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header rules file: %w", err)
+	}
+
+	var rules []HeaderRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse header rules file %s: %w", path, err)
+	}
+
+	return NewHeaderRules(rules)
+}
+
+// HeadersFor returns the headers to add for the given URL.
+// Rules are applied in order, so later rules override earlier ones.
+// It returns nil if no rule matches.
+func (hr *HeaderRules) HeadersFor(rawURL string) map[string]string {
+	if hr == nil {
+		return nil
+	}
+
+	var headers map[string]string
+	for i, pattern := range hr.patterns {
+		if !pattern.Match(rawURL) {
+			continue
+		}
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		for name, value := range hr.rules[i].Headers {
+			headers[http.CanonicalHeaderKey(name)] = value
+		}
+	}
+	return headers
+}
+
+// Len returns the number of rules
+func (hr *HeaderRules) Len() int {
+	if hr == nil {
+		return 0
+	}
+	return len(hr.rules)
+}
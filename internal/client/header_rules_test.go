@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHeaderRulesHeadersFor(t *testing.T) {
+	rules, err := NewHeaderRules([]HeaderRule{
+		{Pattern: "/preview/*", Headers: map[string]string{"x-preview-token": "abc"}},
+		{Pattern: "/preview/secret/*", Headers: map[string]string{"X-Preview-Token": "xyz", "Authorization": "Bearer t"}},
+	})
+	if err != nil {
+		t.Fatalf("NewHeaderRules() error: %v", err)
+	}
+
+	tests := []struct {
+		url      string
+		expected map[string]string
+	}{
+		{"https://example.com/docs/", nil},
+		{"https://example.com/preview/page", map[string]string{"X-Preview-Token": "abc"}},
+		{"https://example.com/preview/secret/page", map[string]string{"X-Preview-Token": "xyz", "Authorization": "Bearer t"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			headers := rules.HeadersFor(tt.url)
+			if len(headers) != len(tt.expected) {
+				t.Fatalf("HeadersFor(%q) = %v, want %v", tt.url, headers, tt.expected)
+			}
+			for name, value := range tt.expected {
+				if headers[name] != value {
+					t.Errorf("HeadersFor(%q)[%s] = %q, want %q", tt.url, name, headers[name], value)
+				}
+			}
+		})
+	}
+
+	var nilRules *HeaderRules
+	if nilRules.HeadersFor("https://example.com/") != nil || nilRules.Len() != 0 {
+		t.Error("nil HeaderRules should match nothing")
+	}
+}
+
+func TestNewHeaderRulesInvalid(t *testing.T) {
+	if _, err := NewHeaderRules([]HeaderRule{{Pattern: "", Headers: map[string]string{"A": "b"}}}); err == nil {
+		t.Error("expected error for empty pattern")
+	}
+	if _, err := NewHeaderRules([]HeaderRule{{Pattern: "/a/*"}}); err == nil {
+		t.Error("expected error for rule without headers")
+	}
+}
+
+func TestLoadHeaderRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "headers.json")
+	content := `[{"pattern": "/preview/*", "headers": {"X-Preview-Token": "abc"}}]`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := LoadHeaderRules(path)
+	if err != nil {
+		t.Fatalf("LoadHeaderRules() error: %v", err)
+	}
+	if rules.Len() != 1 {
+		t.Errorf("expected 1 rule, got %d", rules.Len())
+	}
+
+	if _, err := LoadHeaderRules(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestClientAppliesHeaderRules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Preview-Token")))
+	}))
+	defer server.Close()
+
+	rules, err := NewHeaderRules([]HeaderRule{
+		{Pattern: "/preview/*", Headers: map[string]string{"X-Preview-Token": "abc"}},
+	})
+	if err != nil {
+		t.Fatalf("NewHeaderRules() error: %v", err)
+	}
+
+	config := DefaultConfig()
+	config.RetryCount = 0
+	config.HeaderRules = rules
+	c := NewClient(config)
+
+	resp, err := c.Get(context.Background(), server.URL+"/preview/page")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if resp.String() != "abc" {
+		t.Errorf("expected preview token on /preview/page, got %q", resp.String())
+	}
+
+	resp, err = c.Get(context.Background(), server.URL+"/public")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if resp.String() != "" {
+		t.Errorf("expected no preview token on /public, got %q", resp.String())
+	}
+
+	resp, err = c.GetWithHeaders(context.Background(), server.URL+"/preview/page", map[string]string{"X-Preview-Token": "explicit"})
+	if err != nil {
+		t.Fatalf("GetWithHeaders() error: %v", err)
+	}
+	if resp.String() != "explicit" {
+		t.Errorf("explicit header should take precedence, got %q", resp.String())
+	}
+}
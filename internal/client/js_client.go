@@ -52,9 +52,23 @@ func (c *JSClient) RenderPage(ctx context.Context, targetURL string) (string, er
 	return c.pool.RenderPage(ctx, targetURL)
 }
 
+// InspectPage renders a page like RenderPage and keeps it open until resume
+// is closed, see BrowserPool.InspectPage
+func (c *JSClient) InspectPage(ctx context.Context, targetURL string, resume <-chan struct{}) (string, error) {
+	if !c.config.Enabled {
+		return "", fmt.Errorf("JavaScript rendering is not enabled")
+	}
+
+	return c.pool.InspectPage(ctx, targetURL, resume)
+}
+
 // Get implements a similar interface to the HTTP client for compatibility
 func (c *JSClient) Get(ctx context.Context, targetURL string) (*JSResponse, error) {
-	content, err := c.RenderPage(ctx, targetURL)
+	if !c.config.Enabled {
+		return nil, fmt.Errorf("JavaScript rendering is not enabled")
+	}
+
+	page, err := c.pool.renderPooled(targetURL)
 	if err != nil {
 		return nil, err
 	}
@@ -67,10 +81,17 @@ func (c *JSClient) Get(ctx context.Context, targetURL string) (*JSResponse, erro
 
 	return &JSResponse{
 		URL:     targetURL,
-		Content: content,
+		Content: page.content,
 		Status:  200, // Assume success if we got content
-		Headers: make(map[string]string),
+		Headers: map[string]string{"Content-Type": "text/html; charset=utf-8"},
 		Host:    parsedURL.Host,
+
+		Diagnostics: page.diagnostics,
+		Partial:     page.partial,
+		ShadowLinks: page.shadowLinks,
+		Routes:      page.routes,
+
+		ServiceWorker: page.serviceWorker,
 	}, nil
 }
 
@@ -101,6 +122,26 @@ type JSResponse struct {
 	Status  int
 	Headers map[string]string
 	Host    string
+
+	// Diagnostics holds the console errors and failed requests of the page
+	// (only with JSConfig.Diagnostics)
+	Diagnostics *RenderDiagnostics
+
+	// Partial is set when the navigation timed out and Content is what the
+	// page had rendered by then (only with JSConfig.PartialOnTimeout)
+	Partial bool
+
+	// ShadowLinks are the links inside open shadow roots of the page, which
+	// Content does not include
+	ShadowLinks []string
+
+	// Routes are the URLs of the static routes declared by the page's
+	// client-side router (only with JSConfig.EnumerateRoutes)
+	Routes []string
+
+	// ServiceWorker is the script URL of the service worker the page
+	// registered (only with JSConfig.DetectServiceWorkers)
+	ServiceWorker string
 }
 
 // String returns the rendered HTML content
@@ -112,3 +153,13 @@ func (r *JSResponse) String() string {
 func (r *JSResponse) StatusCode() int {
 	return r.Status
 }
+
+// Header returns the value of the named response header
+func (r *JSResponse) Header(name string) string {
+	return r.Headers[name]
+}
+
+// FinalURL returns the rendered page URL
+func (r *JSResponse) FinalURL() string {
+	return r.URL
+}
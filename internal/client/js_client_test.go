@@ -7,7 +7,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/aoshimash/urlmap/test/shared"
+	"github.com/aoshimash/urlmap/pkg/testsite"
 )
 
 func TestNewJSClient(t *testing.T) {
@@ -39,7 +39,7 @@ func TestNewJSClient(t *testing.T) {
 
 func TestJSClient_RenderPage(t *testing.T) {
 	// Create test server for more reliable testing in CI
-	testServer := shared.CreateBasicTestServer()
+	testServer := testsite.StartBasic()
 	defer testServer.Close()
 
 	logger := slog.Default()
@@ -81,7 +81,7 @@ func TestJSClient_RenderPage(t *testing.T) {
 
 func TestJSClient_Get(t *testing.T) {
 	// Create test server for more reliable testing in CI
-	testServer := shared.CreateBasicTestServer()
+	testServer := testsite.StartBasic()
 	defer testServer.Close()
 
 	logger := slog.Default()
@@ -40,8 +40,41 @@ type JSConfig struct {
 
 	// PoolSize specifies the number of browser instances in the pool
 	PoolSize int
+
+	// HeaderRules adds extra headers per URL pattern (optional)
+	HeaderRules *HeaderRules
+
+	// Diagnostics collects the console errors and failed requests of each
+	// rendered page, see RenderDiagnostics
+	Diagnostics bool
+
+	// PartialOnTimeout keeps the HTML of a page whose navigation timed out
+	// after it rendered some content, instead of failing it (JSResponse.Partial)
+	PartialOnTimeout bool
+
+	// DismissConsent sets common consent cookies before a page is loaded and
+	// clicks the accept button of a consent banner the page still shows
+	DismissConsent bool
+
+	// EnumerateRoutes reads the route table of the client-side router of each
+	// rendered page (Vue Router, React Router), see JSResponse.Routes
+	EnumerateRoutes bool
+
+	// DetectServiceWorkers records the service worker each rendered page
+	// registers, see JSResponse.ServiceWorker
+	DetectServiceWorkers bool
+
+	// BlockServiceWorkers keeps pages from registering service workers, so
+	// every page is loaded from the network instead of a worker's cache
+	BlockServiceWorkers bool
 }
 
+// BrowserTypes are the supported values of JSConfig.BrowserType
+var BrowserTypes = []string{"chromium", "firefox", "webkit"}
+
+// WaitConditions are the supported values of JSConfig.WaitFor
+var WaitConditions = []string{"networkidle", "domcontentloaded", "load"}
+
 // DefaultJSConfig returns a default JavaScript configuration
 func DefaultJSConfig() *JSConfig {
 	return &JSConfig{
@@ -66,7 +99,7 @@ func (c *JSConfig) Validate() error {
 	}
 
 	// Validate browser type
-	validBrowsers := []string{"chromium", "firefox", "webkit"}
+	validBrowsers := BrowserTypes
 	validBrowser := false
 	for _, browser := range validBrowsers {
 		if c.BrowserType == browser {
@@ -79,7 +112,7 @@ func (c *JSConfig) Validate() error {
 	}
 
 	// Validate wait condition
-	validWaitConditions := []string{"networkidle", "domcontentloaded", "load"}
+	validWaitConditions := WaitConditions
 	validWaitCondition := false
 	for _, condition := range validWaitConditions {
 		if c.WaitFor == condition {
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+const (
+	// DefaultMobileUserAgent is the User-Agent of the mobile pass of a dual crawl
+	DefaultMobileUserAgent = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1 urlmap"
+	// MobileViewportWidth is the viewport width used when rendering as a mobile device
+	MobileViewportWidth = 390
+	// MobileViewportHeight is the viewport height used when rendering as a mobile device
+	MobileViewportHeight = 844
+)
+
+// GetMobile renders a page as a mobile device with the given user agent
+func (c *JSClient) GetMobile(ctx context.Context, targetURL, userAgent string) (*JSResponse, error) {
+	if !c.config.Enabled {
+		return nil, fmt.Errorf("JavaScript rendering is not enabled")
+	}
+
+	content, err := c.pool.RenderMobilePage(ctx, targetURL, userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedURL, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	return &JSResponse{
+		URL:     targetURL,
+		Content: content,
+		Status:  200, // Assume success if we got content
+		Headers: map[string]string{"Content-Type": "text/html; charset=utf-8"},
+		Host:    parsedURL.Host,
+	}, nil
+}
+
+// FetchMobile fetches url with a mobile user agent, rendering it as a mobile
+// device if render is set. Mobile responses bypass the response cache,
+// which holds the desktop versions.
+func (c *UnifiedClient) FetchMobile(ctx context.Context, url, userAgent string, render bool) (UnifiedResponse, error) {
+	if render {
+		if c.jsClient == nil {
+			return nil, fmt.Errorf("JavaScript client not available")
+		}
+		return c.jsClient.GetMobile(ctx, url, userAgent)
+	}
+
+	response, err := c.httpClient.GetWithHeaders(ctx, url, map[string]string{"User-Agent": userAgent})
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPResponseWrapper{response: response}, nil
+}
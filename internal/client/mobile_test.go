@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedClient_FetchMobile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("User-Agent")))
+	}))
+	defer server.Close()
+
+	client, err := NewUnifiedClient(&UnifiedConfig{
+		UserAgent:     "desktop-agent",
+		JSConfig:      &JSConfig{Enabled: false},
+		ResponseCache: NewResponseCache(DefaultResponseCacheTTL, DefaultResponseCacheSize),
+	}, slog.Default())
+	require.NoError(t, err)
+
+	desktop, err := client.Get(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "desktop-agent", desktop.String())
+
+	// The cached desktop response must not be served to the mobile pass
+	mobile, err := client.FetchMobile(context.Background(), server.URL, DefaultMobileUserAgent, false)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMobileUserAgent, mobile.String())
+
+	_, err = client.FetchMobile(context.Background(), server.URL, DefaultMobileUserAgent, true)
+	assert.Error(t, err, "rendering requires the JS client")
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// maxRedirects is the number of redirects followed per request
+const maxRedirects = 10
+
+// ErrRedirectLoop is returned when a redirect leads back to a URL already
+// visited by the same request
+var ErrRedirectLoop = errors.New("redirect loop")
+
+// redirectLoopPolicy stops following redirects as soon as a URL repeats,
+// instead of running into the redirect limit
+var redirectLoopPolicy = resty.RedirectPolicyFunc(func(req *http.Request, via []*http.Request) error {
+	next := req.URL.String()
+	for _, previous := range via {
+		if previous.URL.String() == next {
+			return ErrRedirectLoop
+		}
+	}
+	return nil
+})
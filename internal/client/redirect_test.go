@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClientRedirectLoop(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/a":
+			http.Redirect(w, r, "/b", http.StatusFound)
+		case "/b":
+			http.Redirect(w, r, "/a", http.StatusFound)
+		default:
+			// An endless chain of distinct URLs
+			http.Redirect(w, r, r.URL.Path+"x", http.StatusMovedPermanently)
+		}
+	}))
+	defer server.Close()
+
+	c := NewDefaultClient()
+
+	_, err := c.Get(context.Background(), server.URL+"/a")
+	if !errors.Is(err, ErrRedirectLoop) {
+		t.Fatalf("expected ErrRedirectLoop, got %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected the loop to be detected without retries after 2 requests, got %d", got)
+	}
+
+	config := DefaultConfig()
+	config.RetryCount = 0
+	resp, err := NewClient(config).Get(context.Background(), server.URL+"/chain")
+	if err == nil {
+		t.Fatalf("expected the redirect limit to be reached, got %d", resp.StatusCode())
+	}
+	if errors.Is(err, ErrRedirectLoop) {
+		t.Error("a redirect chain without a repeated URL is not a loop")
+	}
+}
+
+func TestHTTPResponseWrapperFinalURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	resp, err := NewDefaultClient().Get(context.Background(), server.URL+"/old")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+
+	wrapper := &HTTPResponseWrapper{response: resp}
+	if got := wrapper.FinalURL(); got != server.URL+"/new" {
+		t.Errorf("FinalURL() = %q, want %q", got, server.URL+"/new")
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// maxDiagnostics caps the console errors and the failed requests kept per
+// page, so a page logging in a loop does not bloat the results
+const maxDiagnostics = 50
+
+// RenderDiagnostics holds what went wrong in the browser while a page was
+// rendered (only with JSConfig.Diagnostics)
+type RenderDiagnostics struct {
+	ConsoleErrors  []string // console.error messages and uncaught exceptions
+	FailedRequests []string // e.g. "GET https://example.com/api: 404" or "...: net::ERR_CONNECTION_REFUSED"
+}
+
+// RenderError is returned when a page could not be rendered, with the
+// diagnostics collected until then
+type RenderError struct {
+	Err         error
+	Diagnostics *RenderDiagnostics
+}
+
+func (e *RenderError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
+
+// diagnosticsRecorder collects the diagnostics of a page as its events arrive
+type diagnosticsRecorder struct {
+	mu          sync.Mutex
+	diagnostics RenderDiagnostics
+}
+
+// recordDiagnostics starts collecting the console errors and failed requests
+// of page. Requests for resource types the page blocks on purpose are not
+// reported as failed.
+func recordDiagnostics(page playwright.Page, blocked func(resourceType string) bool) *diagnosticsRecorder {
+	r := &diagnosticsRecorder{}
+
+	page.OnConsole(func(msg playwright.ConsoleMessage) {
+		if msg.Type() == "error" {
+			r.add(&r.diagnostics.ConsoleErrors, msg.Text())
+		}
+	})
+	page.OnPageError(func(err error) {
+		r.add(&r.diagnostics.ConsoleErrors, "uncaught: "+err.Error())
+	})
+	page.OnRequestFailed(func(req playwright.Request) {
+		if blocked(req.ResourceType()) {
+			return
+		}
+		reason := "failed"
+		if err := req.Failure(); err != nil {
+			reason = err.Error()
+		}
+		r.add(&r.diagnostics.FailedRequests, fmt.Sprintf("%s %s: %s", req.Method(), req.URL(), reason))
+	})
+	page.OnResponse(func(resp playwright.Response) {
+		if resp.Status() >= 400 {
+			r.add(&r.diagnostics.FailedRequests, fmt.Sprintf("%s %s: %d", resp.Request().Method(), resp.URL(), resp.Status()))
+		}
+	})
+
+	return r
+}
+
+func (r *diagnosticsRecorder) add(list *[]string, entry string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(*list) < maxDiagnostics {
+		*list = append(*list, entry)
+	}
+}
+
+// result returns the diagnostics collected so far, or nil if there are none
+// (or r is nil)
+func (r *diagnosticsRecorder) result() *RenderDiagnostics {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.diagnostics.ConsoleErrors) == 0 && len(r.diagnostics.FailedRequests) == 0 {
+		return nil
+	}
+	return &RenderDiagnostics{
+		ConsoleErrors:  append([]string(nil), r.diagnostics.ConsoleErrors...),
+		FailedRequests: append([]string(nil), r.diagnostics.FailedRequests...),
+	}
+}
+
+// withDiagnostics attaches the diagnostics collected by r to err, if any
+func (r *diagnosticsRecorder) withDiagnostics(err error) error {
+	if diagnostics := r.result(); diagnostics != nil {
+		return &RenderError{Err: err, Diagnostics: diagnostics}
+	}
+	return err
+}
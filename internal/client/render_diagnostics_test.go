@@ -0,0 +1,63 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestDiagnosticsRecorder(t *testing.T) {
+	var nilRecorder *diagnosticsRecorder
+	if nilRecorder.result() != nil {
+		t.Error("expected no diagnostics without a recorder")
+	}
+
+	r := &diagnosticsRecorder{}
+	if r.result() != nil {
+		t.Error("expected no diagnostics before any event")
+	}
+
+	// Entries beyond the cap are dropped
+	for i := range maxDiagnostics + 10 {
+		r.add(&r.diagnostics.ConsoleErrors, fmt.Sprintf("error %d", i))
+	}
+	r.add(&r.diagnostics.FailedRequests, "GET https://example.com/api: 500")
+
+	diagnostics := r.result()
+	if diagnostics == nil {
+		t.Fatal("expected diagnostics")
+	}
+	if len(diagnostics.ConsoleErrors) != maxDiagnostics {
+		t.Errorf("expected %d console errors, got %d", maxDiagnostics, len(diagnostics.ConsoleErrors))
+	}
+	if len(diagnostics.FailedRequests) != 1 {
+		t.Errorf("expected 1 failed request, got %v", diagnostics.FailedRequests)
+	}
+}
+
+func TestDiagnosticsRecorder_WithDiagnostics(t *testing.T) {
+	cause := errors.New("timeout")
+
+	var nilRecorder *diagnosticsRecorder
+	if err := nilRecorder.withDiagnostics(cause); err != cause {
+		t.Errorf("expected the error unchanged without diagnostics, got %v", err)
+	}
+
+	r := &diagnosticsRecorder{}
+	r.add(&r.diagnostics.ConsoleErrors, "uncaught: TypeError: x is undefined")
+	err := r.withDiagnostics(fmt.Errorf("failed to navigate: %w", cause))
+
+	var renderErr *RenderError
+	if !errors.As(err, &renderErr) {
+		t.Fatalf("expected a RenderError, got %T", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("RenderError should wrap the cause")
+	}
+	if err.Error() != "failed to navigate: timeout" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if len(renderErr.Diagnostics.ConsoleErrors) != 1 {
+		t.Errorf("unexpected diagnostics %+v", renderErr.Diagnostics)
+	}
+}
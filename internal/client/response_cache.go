@@ -0,0 +1,176 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Default response cache settings
+const (
+	DefaultResponseCacheTTL  = 5 * time.Minute
+	DefaultResponseCacheSize = 200
+)
+
+// FetchStrategy identifies how a response was obtained
+type FetchStrategy string
+
+const (
+	StrategyHTTP FetchStrategy = "http" // Plain HTTP request
+	StrategyJS   FetchStrategy = "js"   // JavaScript rendering
+)
+
+// ResponseCache is an LRU cache of successful responses shared by the HTTP
+// and JavaScript clients, keyed by URL, fetch strategy and egress region
+type ResponseCache struct {
+	ttl     time.Duration
+	maxSize int
+
+	mu      sync.Mutex
+	entries map[responseCacheKey]*list.Element
+	lru     *list.List // Front = most recently used
+
+	stats ResponseCacheStats
+}
+
+// responseCacheKey identifies a cached response
+type responseCacheKey struct {
+	strategy FetchStrategy
+	region   string // Egress region the response was fetched through ("" = none)
+	url      string
+}
+
+// responseCacheEntry is a cached response and its expiry
+type responseCacheEntry struct {
+	key      responseCacheKey
+	response UnifiedResponse
+	expires  time.Time
+}
+
+// ResponseCacheStats holds combined cache statistics
+type ResponseCacheStats struct {
+	HTTPHits   int64 // HTTP fetches answered from the cache
+	HTTPMisses int64 // HTTP fetches sent to the network
+	JSHits     int64 // Renders answered from the cache
+	JSMisses   int64 // Renders performed in the browser
+	Evictions  int64 // Entries dropped to stay within the size limit
+	Entries    int   // Responses currently cached
+}
+
+// NewResponseCache creates a response cache holding up to maxSize responses for ttl
+func NewResponseCache(ttl time.Duration, maxSize int) *ResponseCache {
+	if ttl <= 0 {
+		ttl = DefaultResponseCacheTTL
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultResponseCacheSize
+	}
+	return &ResponseCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: make(map[responseCacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the cached response for url fetched with strategy
+func (c *ResponseCache) Get(strategy FetchStrategy, url string) (UnifiedResponse, bool) {
+	return c.get(responseCacheKey{strategy: strategy, url: url})
+}
+
+// get returns the cached response for key
+func (c *ResponseCache) get(key responseCacheKey) (UnifiedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if ok && time.Now().After(elem.Value.(*responseCacheEntry).expires) {
+		c.removeElement(elem)
+		ok = false
+	}
+
+	c.count(key.strategy, ok)
+	if !ok {
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*responseCacheEntry).response, true
+}
+
+// Set caches response for url fetched with strategy.
+// Only successful (2xx) responses are cached.
+func (c *ResponseCache) Set(strategy FetchStrategy, url string, response UnifiedResponse) {
+	c.set(responseCacheKey{strategy: strategy, url: url}, response)
+}
+
+// set caches response under key if it is successful
+func (c *ResponseCache) set(key responseCacheKey, response UnifiedResponse) {
+	if response == nil || response.StatusCode() < 200 || response.StatusCode() >= 300 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &responseCacheEntry{key: key, response: response, expires: time.Now().Add(c.ttl)}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxSize {
+		c.removeElement(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// Stats returns the cache statistics
+func (c *ResponseCache) Stats() ResponseCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// count records a hit or miss for strategy
+func (c *ResponseCache) count(strategy FetchStrategy, hit bool) {
+	switch {
+	case strategy == StrategyJS && hit:
+		c.stats.JSHits++
+	case strategy == StrategyJS:
+		c.stats.JSMisses++
+	case hit:
+		c.stats.HTTPHits++
+	default:
+		c.stats.HTTPMisses++
+	}
+}
+
+// removeElement drops an entry from the cache
+func (c *ResponseCache) removeElement(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*responseCacheEntry).key)
+}
+
+// lookup is a nil-safe Get of the response fetched through the egress
+// region of ctx, so pages fetched from one region are not served as another
+func (c *ResponseCache) lookup(ctx context.Context, strategy FetchStrategy, url string) (UnifiedResponse, bool) {
+	if c == nil {
+		return nil, false
+	}
+	return c.get(responseCacheKey{strategy: strategy, region: egressRegion(ctx), url: url})
+}
+
+// store is a nil-safe Set under the egress region of ctx
+func (c *ResponseCache) store(ctx context.Context, strategy FetchStrategy, url string, response UnifiedResponse) {
+	if c != nil {
+		c.set(responseCacheKey{strategy: strategy, region: egressRegion(ctx), url: url}, response)
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCache_KeyedByStrategy(t *testing.T) {
+	cache := NewResponseCache(time.Minute, 10)
+	page := &JSResponse{Content: "rendered", Status: 200}
+
+	cache.Set(StrategyJS, "https://example.com/", page)
+
+	if _, ok := cache.Get(StrategyHTTP, "https://example.com/"); ok {
+		t.Error("JS response should not be returned for HTTP fetches")
+	}
+	got, ok := cache.Get(StrategyJS, "https://example.com/")
+	if !ok || got.String() != "rendered" {
+		t.Fatalf("expected cached JS response, got %v, %v", got, ok)
+	}
+
+	stats := cache.Stats()
+	if stats.JSHits != 1 || stats.HTTPMisses != 1 || stats.Entries != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestResponseCache_KeyedByEgressRegion(t *testing.T) {
+	cache := NewResponseCache(time.Minute, 10)
+	eu := WithEgress(context.Background(), Egress{Region: "eu"})
+	us := WithEgress(context.Background(), Egress{Region: "us"})
+
+	cache.store(eu, StrategyHTTP, "https://example.com/", &JSResponse{Content: "eu", Status: 200})
+
+	if _, ok := cache.lookup(us, StrategyHTTP, "https://example.com/"); ok {
+		t.Error("page fetched through eu should not be served for us")
+	}
+	if _, ok := cache.lookup(context.Background(), StrategyHTTP, "https://example.com/"); ok {
+		t.Error("page fetched through eu should not be served without egress")
+	}
+	if got, ok := cache.lookup(eu, StrategyHTTP, "https://example.com/"); !ok || got.String() != "eu" {
+		t.Errorf("expected the page cached for eu, got %v, %v", got, ok)
+	}
+}
+
+func TestResponseCache_Eviction(t *testing.T) {
+	cache := NewResponseCache(time.Minute, 2)
+	for i := 0; i < 3; i++ {
+		cache.Set(StrategyJS, fmt.Sprintf("https://example.com/%d", i), &JSResponse{Status: 200})
+	}
+
+	if _, ok := cache.Get(StrategyJS, "https://example.com/0"); ok {
+		t.Error("least recently used entry should have been evicted")
+	}
+	if stats := cache.Stats(); stats.Evictions != 1 || stats.Entries != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestResponseCache_ExpiryAndErrors(t *testing.T) {
+	cache := NewResponseCache(time.Minute, 10)
+	cache.ttl = time.Millisecond
+
+	cache.Set(StrategyJS, "https://example.com/", &JSResponse{Status: 200})
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get(StrategyJS, "https://example.com/"); ok {
+		t.Error("expired entry should not be returned")
+	}
+
+	cache.Set(StrategyJS, "https://example.com/missing", &JSResponse{Status: 404})
+	if cache.Stats().Entries != 0 {
+		t.Error("error responses should not be cached")
+	}
+}
+
+func TestUnifiedClient_FetchHTTPUsesCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	cache := NewResponseCache(time.Minute, 10)
+	unified, err := NewUnifiedClient(&UnifiedConfig{UserAgent: "test", ResponseCache: cache}, slog.Default())
+	if err != nil {
+		t.Fatalf("NewUnifiedClient() error: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		response, err := unified.Get(context.Background(), server.URL)
+		if err != nil || response.String() != "ok" {
+			t.Fatalf("Get() = %v, %v", response, err)
+		}
+	}
+
+	if requests.Load() != 1 {
+		t.Errorf("expected 1 request to the server, got %d", requests.Load())
+	}
+	if stats := cache.Stats(); stats.HTTPHits != 2 || stats.HTTPMisses != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsThrottled(t *testing.T) {
+	for code, expected := range map[int]bool{200: false, 404: false, 429: true, 500: false, 503: true} {
+		if IsThrottled(code) != expected {
+			t.Errorf("IsThrottled(%d) = %v; want %v", code, !expected, expected)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"Seconds", "120", 2 * time.Minute, true},
+		{"Zero seconds", "0", 0, true},
+		{"HTTP date", now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{"Past HTTP date", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"Empty", "", 0, false},
+		{"Negative", "-5", 0, false},
+		{"Garbage", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := ParseRetryAfter(tt.value, now)
+			if ok != tt.ok || delay != tt.expected {
+				t.Errorf("ParseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, delay, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
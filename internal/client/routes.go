@@ -0,0 +1,95 @@
+package client
+
+import "github.com/playwright-community/playwright-go"
+
+// declaredRoutesScript returns the URLs of the static routes declared in the
+// route table of the page's client-side router. It reads Vue Router 4 (Vue 3
+// apps), Vue Router 3 (Vue 2 and Nuxt 2 apps), the route manifest of React
+// Router framework mode and Remix, and the routes of React Router found in
+// the React fiber tree (RouterProvider or <Routes> with <Route> elements).
+// Routes with parameters or wildcards (/users/:id, *) are left out, as their
+// URLs are not known.
+const declaredRoutesScript = `() => {
+	const urls = new Set();
+	const add = (path, prefix) => {
+		if (typeof path !== 'string' || path === '' || /[:*(\[]/.test(path)) return;
+		try {
+			urls.add(new URL(prefix + path, location.href).href);
+		} catch (e) {}
+	};
+	const join = (parent, path) => path.startsWith('/') ? path : parent.replace(/\/$/, '') + '/' + path;
+	const walk = (routes, parent, prefix) => {
+		for (const route of Array.isArray(routes) ? routes : []) {
+			if (!route) continue;
+			const path = typeof route.path === 'string' ? join(parent, route.path) : parent;
+			add(path, prefix);
+			walk(route.children, path, prefix);
+		}
+	};
+
+	// Vue Router 4: history bases of hash routers end with '#'
+	const vue3 = document.querySelector('[data-v-app]');
+	const router4 = vue3 && vue3.__vue_app__ && vue3.__vue_app__.config.globalProperties.$router;
+	if (router4 && router4.getRoutes) {
+		const base = (router4.options.history && router4.options.history.base) || '';
+		walk(router4.getRoutes(), '/', base.includes('#') ? base : base.replace(/\/$/, ''));
+	}
+
+	// Vue Router 3
+	let vue2 = window.$nuxt;
+	for (const el of document.querySelectorAll('body *')) {
+		if (vue2) break;
+		vue2 = el.__vue__;
+	}
+	const router3 = vue2 && vue2.$root && vue2.$root.$router;
+	if (router3 && router3.options) {
+		const prefix = router3.mode === 'hash' ? location.pathname + '#' : (router3.options.base || '').replace(/\/$/, '');
+		walk(router3.options.routes, '/', prefix);
+	}
+
+	// React Router framework mode and Remix route manifests
+	const manifest = window.__reactRouterManifest || window.__remixManifest;
+	if (manifest && manifest.routes) {
+		const fullPath = (route) => {
+			const parentRoute = route.parentId && manifest.routes[route.parentId];
+			const parent = parentRoute ? fullPath(parentRoute) : '/';
+			return typeof route.path === 'string' ? join(parent, route.path) : parent;
+		};
+		for (const route of Object.values(manifest.routes)) add(fullPath(route), '');
+	}
+
+	// React Router in the fiber tree of React roots
+	const routeElements = (children) => [].concat(children || []).flat(Infinity)
+		.filter((child) => child && child.props && (typeof child.props.path === 'string' || child.props.index));
+	const elementRoutes = (children) => routeElements(children)
+		.map((child) => ({ path: child.props.path, children: elementRoutes(child.props.children) }));
+	for (const el of document.querySelectorAll('body, body > *')) {
+		const key = Object.keys(el).find((k) => k.startsWith('__reactContainer$'));
+		const legacy = el._reactRootContainer && el._reactRootContainer._internalRoot;
+		const stack = key ? [el[key]] : legacy ? [legacy.current] : [];
+		for (let seen = 0; stack.length > 0 && seen < 20000; seen++) {
+			const fiber = stack.pop();
+			const props = fiber.memoizedProps;
+			if (props && props.router && Array.isArray(props.router.routes)) {
+				walk(props.router.routes, '/', (props.router.basename || '').replace(/\/$/, ''));
+			} else if (props && routeElements(props.children).some((child) => typeof child.props.path === 'string')) {
+				walk(elementRoutes(props.children), '/', '');
+			}
+			if (fiber.sibling) stack.push(fiber.sibling);
+			if (fiber.child) stack.push(fiber.child);
+		}
+	}
+
+	return [...urls];
+}`
+
+// declaredRoutes returns the URLs of the routes declared by the client-side
+// router of page, which may have no links pointing to them
+func (p *BrowserPool) declaredRoutes(page playwright.Page) []string {
+	routes, err := evaluateStrings(page, declaredRoutesScript)
+	if err != nil {
+		p.logger.Debug("Failed to read router routes", "url", page.URL(), "error", err)
+		return nil
+	}
+	return routes
+}
@@ -0,0 +1,33 @@
+package client
+
+import "github.com/playwright-community/playwright-go"
+
+// serviceWorkerScript returns the script URL of the service worker
+// controlling the page's scope, or ” if it has none
+const serviceWorkerScript = `async () => {
+	if (!('serviceWorker' in navigator)) return '';
+	const registration = await navigator.serviceWorker.getRegistration();
+	const worker = registration && (registration.active || registration.waiting || registration.installing);
+	return worker ? worker.scriptURL : '';
+}`
+
+// serviceWorkers returns the service worker policy of new browser contexts:
+// blocked with JSConfig.BlockServiceWorkers, so pages come from the network
+// rather than a worker's cache
+func (p *BrowserPool) serviceWorkers() *playwright.ServiceWorkerPolicy {
+	if p.config.BlockServiceWorkers {
+		return playwright.ServiceWorkerPolicyBlock
+	}
+	return playwright.ServiceWorkerPolicyAllow
+}
+
+// serviceWorker returns the script URL of the service worker page registered
+func (p *BrowserPool) serviceWorker(page playwright.Page) string {
+	value, err := page.Evaluate(serviceWorkerScript)
+	if err != nil {
+		p.logger.Debug("Failed to read service worker registration", "url", page.URL(), "error", err)
+		return ""
+	}
+	script, _ := value.(string)
+	return script
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// ErrReadTimeout is returned when a response body stalls for longer than the read timeout
+var ErrReadTimeout = errors.New("response body read timed out")
+
+// ErrBodyTruncated is returned when the connection closes before the whole
+// response body arrived, e.g. in the middle of a chunked body
+var ErrBodyTruncated = errors.New("response body truncated")
+
+// defaultTLSHandshakeTimeout is used when no connect timeout is configured
+const defaultTLSHandshakeTimeout = 10 * time.Second
+
+// newTransport builds the HTTP transport with the configured connect,
+// response header and body read timeouts. With a read timeout, response
+// bodies are wrapped so stalled and truncated bodies fail with
+// ErrReadTimeout and ErrBodyTruncated.
+func newTransport(config *Config) http.RoundTripper {
+	dialer := &net.Dialer{
+		Timeout:   config.ConnectTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	tlsHandshakeTimeout := defaultTLSHandshakeTimeout
+	if config.ConnectTimeout > 0 {
+		tlsHandshakeTimeout = config.ConnectTimeout
+	}
+
+	dialContext := dialer.DialContext
+	if config.DNSCache != nil {
+		dialContext = config.DNSCache.DialContext(dialer)
+	}
+
+	transport := &http.Transport{
+		Proxy:                 egressProxy,
+		DialContext:           dialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   runtime.GOMAXPROCS(0) + 1,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+		ResponseHeaderTimeout: config.ResponseHeaderTimeout,
+	}
+
+	if config.ReadTimeout <= 0 {
+		return transport
+	}
+	return &bodyTransport{base: transport, readTimeout: config.ReadTimeout}
+}
+
+// bodyTransport wraps response bodies to enforce the read timeout and to
+// report bodies cut short
+type bodyTransport struct {
+	base        http.RoundTripper
+	readTimeout time.Duration
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *bodyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// http.Client cancels requests through a wrapping transport with the
+	// legacy Request.Cancel as well as the context deadline, and a closed
+	// Cancel turns its timeout into a canceled request. The deadline alone
+	// keeps it a timeout (context.DeadlineExceeded).
+	if req.Cancel != nil {
+		req = req.WithContext(req.Context())
+		req.Cancel = nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &bodyReader{body: resp.Body, timeout: t.readTimeout}
+	return resp, nil
+}
+
+// bodyReader closes the underlying body when a single Read takes longer than
+// the timeout. Every Read gets the full timeout, so the deadline applies to
+// each chunk of a streamed body and slow-but-steady downloads are not
+// interrupted.
+type bodyReader struct {
+	body     io.ReadCloser
+	timeout  time.Duration
+	received int64 // Bytes read so far
+	timedOut atomic.Bool
+}
+
+// Read implements io.Reader
+func (b *bodyReader) Read(p []byte) (int, error) {
+	var timer *time.Timer
+	if b.timeout > 0 {
+		timer = time.AfterFunc(b.timeout, func() {
+			b.timedOut.Store(true)
+			b.body.Close()
+		})
+	}
+	n, err := b.body.Read(p)
+	if timer != nil {
+		timer.Stop()
+	}
+	b.received += int64(n)
+
+	switch {
+	case err == nil || err == io.EOF:
+		return n, err
+	case b.timedOut.Load():
+		return n, fmt.Errorf("%w after %d bytes (no data for %v)", ErrReadTimeout, b.received, b.timeout)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return n, fmt.Errorf("%w after %d bytes: connection closed before the end of the body", ErrBodyTruncated, b.received)
+	}
+	return n, err
+}
+
+// Close implements io.Closer
+func (b *bodyReader) Close() error {
+	return b.body.Close()
+}
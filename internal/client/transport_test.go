@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTimeoutTestClient(config *Config) *Client {
+	config.RetryCount = 0
+	return NewClient(config)
+}
+
+func TestResponseHeaderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	c := newTimeoutTestClient(&Config{ResponseHeaderTimeout: 50 * time.Millisecond})
+	if _, err := c.Get(context.Background(), server.URL); err == nil {
+		t.Error("expected response header timeout")
+	}
+}
+
+func TestReadTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		switch r.URL.Path {
+		case "/stalled":
+			w.Write([]byte("start"))
+			flusher.Flush()
+			time.Sleep(300 * time.Millisecond)
+			w.Write([]byte("end"))
+		case "/steady":
+			// Slow overall, but never stalls longer than the read timeout
+			for i := 0; i < 5; i++ {
+				w.Write([]byte("chunk"))
+				flusher.Flush()
+				time.Sleep(30 * time.Millisecond)
+			}
+		}
+	}))
+	defer server.Close()
+
+	c := newTimeoutTestClient(&Config{ReadTimeout: 100 * time.Millisecond})
+
+	_, err := c.Get(context.Background(), server.URL+"/stalled")
+	if !errors.Is(err, ErrReadTimeout) {
+		t.Errorf("expected ErrReadTimeout for stalled body, got %v", err)
+	}
+
+	resp, err := c.Get(context.Background(), server.URL+"/steady")
+	if err != nil {
+		t.Fatalf("steady body should not time out: %v", err)
+	}
+	if !strings.HasPrefix(resp.String(), "chunkchunk") {
+		t.Errorf("unexpected body: %q", resp.String())
+	}
+}
+
+func TestConnectTimeoutDefaults(t *testing.T) {
+	transport, ok := newTransport(DefaultConfig()).(*bodyTransport)
+	if !ok || transport.readTimeout != DefaultReadTimeout {
+		t.Fatal("default transport should enforce the read timeout")
+	}
+	base := transport.base.(*http.Transport)
+	if base.ResponseHeaderTimeout != DefaultResponseHeaderTimeout {
+		t.Errorf("ResponseHeaderTimeout = %v; want %v", base.ResponseHeaderTimeout, DefaultResponseHeaderTimeout)
+	}
+	if base.TLSHandshakeTimeout != DefaultConnectTimeout {
+		t.Errorf("TLSHandshakeTimeout = %v; want %v", base.TLSHandshakeTimeout, DefaultConnectTimeout)
+	}
+
+	if _, ok := newTransport(&Config{}).(*http.Transport); !ok {
+		t.Error("transport without read timeout should not wrap response bodies")
+	}
+}
+
+func TestClientTimeoutWithReadTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	// The body wrapper must not turn the client timeout into a canceled request
+	c := newTimeoutTestClient(&Config{Timeout: 50 * time.Millisecond, ReadTimeout: time.Second})
+	_, err := c.Get(context.Background(), server.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+}
+
+func TestReadTimeoutAcrossChunks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		for i := 0; i < 3; i++ {
+			w.Write([]byte("chunk"))
+			flusher.Flush()
+			time.Sleep(30 * time.Millisecond)
+		}
+		// Stall after several chunks
+		time.Sleep(300 * time.Millisecond)
+		w.Write([]byte("end"))
+	}))
+	defer server.Close()
+
+	c := newTimeoutTestClient(&Config{ReadTimeout: 100 * time.Millisecond})
+	_, err := c.Get(context.Background(), server.URL)
+	if !errors.Is(err, ErrReadTimeout) {
+		t.Fatalf("expected ErrReadTimeout for a body stalling between chunks, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "after 15 bytes") {
+		t.Errorf("expected the error to tell how much was received, got %q", err)
+	}
+}
+
+func TestBodyTruncated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/length" {
+			w.Header().Set("Content-Length", "100")
+		}
+		w.Write([]byte("hello"))
+		w.(http.Flusher).Flush()
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer server.Close()
+
+	// Cut bodies are reported as such, not as read timeouts
+	c := newTimeoutTestClient(&Config{ReadTimeout: time.Second})
+	for _, path := range []string{"/chunked", "/length"} {
+		_, err := c.Get(context.Background(), server.URL+path)
+		if !errors.Is(err, ErrBodyTruncated) {
+			t.Errorf("%s: expected ErrBodyTruncated, got %v", path, err)
+		}
+	}
+}
+
+func TestPartialContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Range", "bytes 0-4/5")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	resp, err := newTimeoutTestClient(DefaultConfig()).Get(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("206 response should not fail: %v", err)
+	}
+	if !IsSuccess(resp) || resp.String() != "hello" {
+		t.Errorf("unexpected response: %d %q", resp.StatusCode(), resp.String())
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClientCustomTransport(t *testing.T) {
+	config := DefaultConfig()
+	config.RetryCount = 0
+	config.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"text/html"}},
+			Body:       io.NopCloser(strings.NewReader("replayed " + req.URL.Path)),
+			Request:    req,
+		}, nil
+	})
+
+	resp, err := NewClient(config).Get(context.Background(), "https://example.invalid/page")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if resp.String() != "replayed /page" {
+		t.Errorf("expected the custom transport to answer, got %q", resp.String())
+	}
+}
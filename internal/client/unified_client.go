@@ -3,15 +3,22 @@ package client
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
+	"time"
 
 	"github.com/go-resty/resty/v2"
+
+	"github.com/aoshimash/urlmap/internal/cache"
+	"github.com/aoshimash/urlmap/internal/redact"
 )
 
 // UnifiedClient provides both HTTP and JavaScript rendering capabilities
 type UnifiedClient struct {
 	httpClient *Client
 	jsClient   *JSClient
+	cache      *ResponseCache
 	config     *UnifiedConfig
 	logger     *slog.Logger
 }
@@ -21,14 +28,56 @@ type UnifiedConfig struct {
 	// HTTP client configuration
 	UserAgent string
 
+	// Headers are sent with every HTTP request (optional)
+	Headers map[string]string
+
+	// HeaderRules adds extra headers per URL pattern (optional)
+	HeaderRules *HeaderRules
+
+	// CookieJar is shared by HTTP requests (optional)
+	CookieJar http.CookieJar
+
+	// DNSCache is shared by HTTP requests (optional)
+	DNSCache *DNSCache
+
+	// RequestLog receives every HTTP request as a curl command line (optional)
+	RequestLog io.Writer
+
+	// Redactor masks secrets in RequestLog lines (optional)
+	Redactor *redact.Redactor
+
+	// Transport replaces the network transport of HTTP requests (optional)
+	Transport http.RoundTripper
+
+	// Chaos injects faults into HTTP requests for resilience testing (optional)
+	Chaos *ChaosConfig
+
+	// HTTPCache stores HTTP responses on disk and revalidates them (optional)
+	HTTPCache *cache.Cache
+
+	// HTTP timeouts (0 = no limit), see Config
+	Timeout               time.Duration
+	ConnectTimeout        time.Duration
+	ResponseHeaderTimeout time.Duration
+	ReadTimeout           time.Duration
+
 	// JavaScript client configuration
 	JSConfig *JSConfig
+
+	// ResponseCache is shared by HTTP requests and JS rendering (optional)
+	ResponseCache *ResponseCache
+
+	// CompareRender creates the JS client even when rendering is disabled,
+	// so pages can be fetched both ways (Get still uses HTTP)
+	CompareRender bool
 }
 
 // UnifiedResponse represents a response from either HTTP or JS client
 type UnifiedResponse interface {
 	String() string
 	StatusCode() int
+	Header(name string) string
+	FinalURL() string
 }
 
 // NewUnifiedClient creates a new unified client that can use both HTTP and JS rendering
@@ -46,20 +95,40 @@ func NewUnifiedClient(config *UnifiedConfig, logger *slog.Logger) (*UnifiedClien
 
 	// Create HTTP client
 	httpConfig := &Config{
-		UserAgent: config.UserAgent,
+		UserAgent:             config.UserAgent,
+		Timeout:               config.Timeout,
+		ConnectTimeout:        config.ConnectTimeout,
+		ResponseHeaderTimeout: config.ResponseHeaderTimeout,
+		ReadTimeout:           config.ReadTimeout,
+		Headers:               config.Headers,
+		HeaderRules:           config.HeaderRules,
+		CookieJar:             config.CookieJar,
+		DNSCache:              config.DNSCache,
+		RequestLog:            config.RequestLog,
+		Redactor:              config.Redactor,
+		Transport:             config.Transport,
+		Chaos:                 config.Chaos,
+		HTTPCache:             config.HTTPCache,
 	}
 	httpClient := NewClient(httpConfig)
 
-	// Create JS client (only if enabled)
+	// Create JS client (only if enabled or needed for render comparison)
 	var jsClient *JSClient
 	var err error
-	if config.JSConfig != nil && config.JSConfig.Enabled {
+	if config.JSConfig != nil && (config.JSConfig.Enabled || config.CompareRender) {
 		// Ensure UserAgent consistency
 		if config.JSConfig.UserAgent == "" {
 			config.JSConfig.UserAgent = config.UserAgent
 		}
+		if config.JSConfig.HeaderRules == nil {
+			config.JSConfig.HeaderRules = config.HeaderRules
+		}
+
+		// The JS client itself must be enabled even when only used for comparison
+		jsConfig := *config.JSConfig
+		jsConfig.Enabled = true
 
-		jsClient, err = NewJSClient(config.JSConfig, logger)
+		jsClient, err = NewJSClient(&jsConfig, logger)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create JS client: %w", err)
 		}
@@ -68,6 +137,7 @@ func NewUnifiedClient(config *UnifiedConfig, logger *slog.Logger) (*UnifiedClien
 	return &UnifiedClient{
 		httpClient: httpClient,
 		jsClient:   jsClient,
+		cache:      config.ResponseCache,
 		config:     config,
 		logger:     logger,
 	}, nil
@@ -78,17 +148,12 @@ func (c *UnifiedClient) Get(ctx context.Context, url string) (UnifiedResponse, e
 	// If JS rendering is enabled, use JS client
 	if c.jsClient != nil && c.config.JSConfig.Enabled {
 		c.logger.Debug("Using JavaScript client", "url", url)
-		return c.jsClient.Get(ctx, url)
+		return c.FetchJS(ctx, url)
 	}
 
 	// Otherwise, use HTTP client
 	c.logger.Debug("Using HTTP client", "url", url)
-	response, err := c.httpClient.Get(ctx, url)
-	if err != nil {
-		return nil, err
-	}
-
-	return &HTTPResponseWrapper{response: response}, nil
+	return c.FetchHTTP(ctx, url)
 }
 
 // GetWithFallback attempts JS rendering first, falls back to HTTP on error
@@ -97,18 +162,18 @@ func (c *UnifiedClient) GetWithFallback(ctx context.Context, url string) (Unifie
 	if c.jsClient != nil && c.config.JSConfig.Enabled && c.config.JSConfig.Fallback {
 		c.logger.Debug("Attempting JavaScript rendering with fallback", "url", url)
 
-		jsResponse, err := c.jsClient.Get(ctx, url)
+		jsResponse, err := c.FetchJS(ctx, url)
 		if err != nil {
 			c.logger.Warn("JavaScript rendering failed, falling back to HTTP",
 				"url", url, "error", err)
 
 			// Fallback to HTTP client
-			response, httpErr := c.httpClient.Get(ctx, url)
+			response, httpErr := c.FetchHTTP(ctx, url)
 			if httpErr != nil {
 				return nil, fmt.Errorf("both JS and HTTP clients failed - JS error: %w, HTTP error: %v", err, httpErr)
 			}
 
-			return &HTTPResponseWrapper{response: response}, nil
+			return response, nil
 		}
 
 		return jsResponse, nil
@@ -118,6 +183,44 @@ func (c *UnifiedClient) GetWithFallback(ctx context.Context, url string) (Unifie
 	return c.Get(ctx, url)
 }
 
+// FetchHTTP fetches url with the HTTP client, using the response cache if configured
+func (c *UnifiedClient) FetchHTTP(ctx context.Context, url string) (UnifiedResponse, error) {
+	if cached, ok := c.cache.lookup(ctx, StrategyHTTP, url); ok {
+		return cached, nil
+	}
+
+	response, err := c.httpClient.Get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped := &HTTPResponseWrapper{response: response}
+	c.cache.store(ctx, StrategyHTTP, url, wrapped)
+	return wrapped, nil
+}
+
+// FetchJS renders url with the JS client, using the response cache if configured
+func (c *UnifiedClient) FetchJS(ctx context.Context, url string) (UnifiedResponse, error) {
+	if c.jsClient == nil {
+		return nil, fmt.Errorf("JavaScript client not available")
+	}
+
+	if cached, ok := c.cache.lookup(ctx, StrategyJS, url); ok {
+		return cached, nil
+	}
+
+	response, err := c.jsClient.Get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	// A partial page is not cached, so fetching it again can render it fully
+	if !response.Partial {
+		c.cache.store(ctx, StrategyJS, url, response)
+	}
+	return response, nil
+}
+
 // Close cleans up resources for both clients
 func (c *UnifiedClient) Close() error {
 	var errors []error
@@ -175,3 +278,16 @@ func (w *HTTPResponseWrapper) String() string {
 func (w *HTTPResponseWrapper) StatusCode() int {
 	return w.response.StatusCode()
 }
+
+// Header returns the value of the named response header
+func (w *HTTPResponseWrapper) Header(name string) string {
+	return w.response.Header().Get(name)
+}
+
+// FinalURL returns the URL the response was served from after redirects
+func (w *HTTPResponseWrapper) FinalURL() string {
+	if w.response.RawResponse == nil || w.response.RawResponse.Request == nil {
+		return w.response.Request.URL
+	}
+	return w.response.RawResponse.Request.URL.String()
+}
@@ -0,0 +1,121 @@
+package compare
+
+import (
+	"fmt"
+	neturl "net/url"
+	"sort"
+	"strings"
+)
+
+// Page is a URL found in one environment
+type Page struct {
+	URL        string // Absolute URL
+	StatusCode int    // HTTP status code (0 = unknown, e.g. loaded from stored results)
+}
+
+// Kind describes how the environments differ for a path
+type Kind string
+
+const (
+	OnlyBase       Kind = "only-base"       // Found in the base environment only
+	OnlyTarget     Kind = "only-target"     // Found in the target environment only
+	StatusMismatch Kind = "status-mismatch" // Found in both with different status codes
+)
+
+// Difference is a path that differs between the environments
+type Difference struct {
+	Path         string // Path relative to the seed, including the query
+	Kind         Kind
+	BaseURL      string
+	TargetURL    string
+	BaseStatus   int
+	TargetStatus int
+}
+
+// Environment is the crawl of one site
+type Environment struct {
+	Seed  string // Seed URL the paths are aligned on
+	Pages []Page
+}
+
+// Compare aligns the pages of both environments by their path relative to
+// the seed and returns the differences, sorted by path
+func Compare(base, target Environment) ([]Difference, error) {
+	basePages, err := alignPages(base)
+	if err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+	targetPages, err := alignPages(target)
+	if err != nil {
+		return nil, fmt.Errorf("target: %w", err)
+	}
+
+	var diffs []Difference
+	for path, basePage := range basePages {
+		targetPage, ok := targetPages[path]
+		switch {
+		case !ok:
+			diffs = append(diffs, Difference{Path: path, Kind: OnlyBase, BaseURL: basePage.URL, BaseStatus: basePage.StatusCode})
+		case basePage.StatusCode != 0 && targetPage.StatusCode != 0 && basePage.StatusCode != targetPage.StatusCode:
+			diffs = append(diffs, Difference{
+				Path:         path,
+				Kind:         StatusMismatch,
+				BaseURL:      basePage.URL,
+				TargetURL:    targetPage.URL,
+				BaseStatus:   basePage.StatusCode,
+				TargetStatus: targetPage.StatusCode,
+			})
+		}
+	}
+	for path, targetPage := range targetPages {
+		if _, ok := basePages[path]; !ok {
+			diffs = append(diffs, Difference{Path: path, Kind: OnlyTarget, TargetURL: targetPage.URL, TargetStatus: targetPage.StatusCode})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs, nil
+}
+
+// alignPages indexes pages by their path relative to the seed path
+func alignPages(env Environment) (map[string]Page, error) {
+	seed, err := neturl.Parse(env.Seed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed URL %s: %w", env.Seed, err)
+	}
+	prefix := strings.TrimSuffix(seed.Path, "/")
+
+	pages := make(map[string]Page, len(env.Pages))
+	for _, page := range env.Pages {
+		path, ok := RelativePath(page.URL, prefix)
+		if !ok {
+			continue
+		}
+		pages[path] = page
+	}
+	return pages, nil
+}
+
+// RelativePath returns the path and query of rawURL with prefix removed.
+// It returns false for URLs that cannot be parsed or lie outside prefix.
+func RelativePath(rawURL, prefix string) (string, bool) {
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+
+	path := u.Path
+	if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+		return "", false
+	}
+	path = strings.TrimPrefix(path, prefix)
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return path, true
+}
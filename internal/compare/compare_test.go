@@ -0,0 +1,63 @@
+package compare
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	base := Environment{
+		Seed: "https://staging.example.com/",
+		Pages: []Page{
+			{URL: "https://staging.example.com/", StatusCode: 200},
+			{URL: "https://staging.example.com/new-feature", StatusCode: 200},
+			{URL: "https://staging.example.com/pricing", StatusCode: 404},
+			{URL: "https://staging.example.com/about", StatusCode: 200},
+		},
+	}
+	target := Environment{
+		Seed: "https://example.com/",
+		Pages: []Page{
+			{URL: "https://example.com/", StatusCode: 200},
+			{URL: "https://example.com/pricing", StatusCode: 200},
+			{URL: "https://example.com/legacy?page=2", StatusCode: 200},
+			{URL: "https://example.com/about"}, // Unknown status is not a mismatch
+		},
+	}
+
+	diffs, err := Compare(base, target)
+	if err != nil {
+		t.Fatalf("Compare() error: %v", err)
+	}
+
+	expected := []Difference{
+		{Path: "/legacy?page=2", Kind: OnlyTarget, TargetURL: "https://example.com/legacy?page=2", TargetStatus: 200},
+		{Path: "/new-feature", Kind: OnlyBase, BaseURL: "https://staging.example.com/new-feature", BaseStatus: 200},
+		{Path: "/pricing", Kind: StatusMismatch, BaseURL: "https://staging.example.com/pricing", TargetURL: "https://example.com/pricing", BaseStatus: 404, TargetStatus: 200},
+	}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Errorf("Compare() = %+v\nwant %+v", diffs, expected)
+	}
+}
+
+func TestRelativePath(t *testing.T) {
+	tests := []struct {
+		url      string
+		prefix   string
+		expected string
+		ok       bool
+	}{
+		{"https://example.com/docs/intro", "/docs", "/intro", true},
+		{"https://example.com/docs", "/docs", "/", true},
+		{"https://example.com/blog/post", "/docs", "", false},
+		{"https://example.com/docs-old/intro", "/docs", "", false},
+		{"https://example.com/search?q=go", "", "/search?q=go", true},
+	}
+
+	for _, tt := range tests {
+		got, ok := RelativePath(tt.url, tt.prefix)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("RelativePath(%q, %q) = %q, %v; want %q, %v", tt.url, tt.prefix, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
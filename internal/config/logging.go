@@ -1,14 +1,23 @@
 package config
 
 import (
+	"io"
 	"log/slog"
 	"os"
+
+	"github.com/aoshimash/urlmap/internal/redact"
 )
 
 // LoggingConfig holds the configuration for the logging system
 type LoggingConfig struct {
 	Level   slog.Level
 	Verbose bool
+
+	// Redactor masks secrets in log records (optional)
+	Redactor *redact.Redactor
+
+	// Output receives the log records (default: stderr)
+	Output io.Writer
 }
 
 // NewLoggingConfig creates a new logging configuration
@@ -26,12 +35,19 @@ func NewLoggingConfig(verbose bool) *LoggingConfig {
 
 // SetupLogger configures the global logger with the given configuration
 func (c *LoggingConfig) SetupLogger() {
-	// Create a text handler that outputs to stderr
+	// Create a text handler that outputs to stderr unless set otherwise
 	opts := &slog.HandlerOptions{
 		Level: c.Level,
 	}
+	if c.Redactor != nil {
+		opts.ReplaceAttr = c.Redactor.ReplaceAttr
+	}
 
-	handler := slog.NewTextHandler(os.Stderr, opts)
+	output := c.Output
+	if output == nil {
+		output = os.Stderr
+	}
+	handler := slog.NewTextHandler(output, opts)
 	logger := slog.New(handler)
 
 	// Set as the default logger
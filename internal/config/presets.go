@@ -0,0 +1,130 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/aoshimash/urlmap/internal/detector"
+)
+
+// Preset is a named bundle of command line flag values
+type Preset struct {
+	Name        string            `json:"-"`
+	Description string            `json:"description"`
+	Flags       map[string]string `json:"flags"` // Flag name (without dashes) -> value
+	Builtin     bool              `json:"-"`
+}
+
+// FileConfig represents the contents of the urlmap configuration file
+type FileConfig struct {
+	Presets  map[string]Preset `json:"presets,omitempty"`
+	Detector *detector.Config  `json:"detector,omitempty"` // SPA detection tuning and custom signatures
+}
+
+// builtinPresets are shipped with urlmap and can be overridden from the config file
+var builtinPresets = map[string]Preset{
+	"seo-audit": {
+		Description: "Respect robots.txt, auto-detect SPAs and emit JSON for audit tooling",
+		Flags: map[string]string{
+			"respect-robots": "true",
+			"js-auto":        "true",
+			"output-format":  "json",
+		},
+	},
+	"link-check": {
+		Description: "Fast HTTP-only crawl of the whole site with CSV output",
+		Flags: map[string]string{
+			"depth":         "-1",
+			"concurrent":    "20",
+			"js-render":     "false",
+			"output-format": "csv",
+		},
+	},
+	"archive": {
+		Description: "Render every page with JavaScript and emit XML for archival",
+		Flags: map[string]string{
+			"js-render":     "true",
+			"js-wait":       "networkidle",
+			"rate-limit":    "2",
+			"output-format": "xml",
+		},
+	},
+}
+
+// DefaultConfigPath returns the default location of the configuration file
+// ($XDG_CONFIG_HOME/urlmap/config.json or the platform equivalent)
+func DefaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "urlmap", "config.json")
+}
+
+// LoadFileConfig reads the configuration file at path.
+// A missing file is not an error and yields an empty configuration.
+func LoadFileConfig(path string) (*FileConfig, error) {
+	cfg := &FileConfig{}
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// AllPresets returns all available presets, built-in ones first overridden by
+// presets of the same name from the configuration file
+func (c *FileConfig) AllPresets() map[string]Preset {
+	presets := make(map[string]Preset, len(builtinPresets))
+	for name, preset := range builtinPresets {
+		preset.Name = name
+		preset.Builtin = true
+		presets[name] = preset
+	}
+
+	if c != nil {
+		for name, preset := range c.Presets {
+			preset.Name = name
+			preset.Builtin = false
+			presets[name] = preset
+		}
+	}
+
+	return presets
+}
+
+// Preset looks up a preset by name
+func (c *FileConfig) Preset(name string) (Preset, error) {
+	presets := c.AllPresets()
+	preset, ok := presets[name]
+	if !ok {
+		return Preset{}, fmt.Errorf("unknown preset: %s (available: %v)", name, PresetNames(presets))
+	}
+	return preset, nil
+}
+
+// PresetNames returns the sorted names of the given presets
+func PresetNames(presets map[string]Preset) []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
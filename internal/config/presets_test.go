@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFileConfig(t *testing.T) {
+	t.Run("Missing file yields empty config", func(t *testing.T) {
+		cfg, err := LoadFileConfig(filepath.Join(t.TempDir(), "missing.json"))
+		if err != nil {
+			t.Fatalf("LoadFileConfig() unexpected error: %v", err)
+		}
+		if len(cfg.Presets) != 0 {
+			t.Errorf("expected no presets, got %d", len(cfg.Presets))
+		}
+	})
+
+	t.Run("Empty path yields empty config", func(t *testing.T) {
+		cfg, err := LoadFileConfig("")
+		if err != nil {
+			t.Fatalf("LoadFileConfig() unexpected error: %v", err)
+		}
+		if cfg == nil {
+			t.Fatal("expected non-nil config")
+		}
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadFileConfig(path); err == nil {
+			t.Error("expected error for invalid JSON")
+		}
+	})
+
+	t.Run("Presets are parsed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		content := `{"presets": {"docs": {"description": "Docs only", "flags": {"depth": "2"}}}}`
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := LoadFileConfig(path)
+		if err != nil {
+			t.Fatalf("LoadFileConfig() unexpected error: %v", err)
+		}
+		if cfg.Presets["docs"].Flags["depth"] != "2" {
+			t.Errorf("expected docs preset depth=2, got %v", cfg.Presets["docs"].Flags)
+		}
+	})
+
+	t.Run("Detector settings are parsed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		content := `{"detector": {"threshold": 0.7, "signatures": [{"name": "qwik", "pattern": "q:container"}]}}`
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := LoadFileConfig(path)
+		if err != nil {
+			t.Fatalf("LoadFileConfig() unexpected error: %v", err)
+		}
+		if cfg.Detector == nil || cfg.Detector.Threshold != 0.7 || len(cfg.Detector.Signatures) != 1 {
+			t.Errorf("unexpected detector config: %+v", cfg.Detector)
+		}
+	})
+}
+
+func TestAllPresets(t *testing.T) {
+	cfg := &FileConfig{
+		Presets: map[string]Preset{
+			"archive": {Description: "custom archive", Flags: map[string]string{"depth": "1"}},
+			"custom":  {Flags: map[string]string{"concurrent": "1"}},
+		},
+	}
+
+	presets := cfg.AllPresets()
+
+	for _, name := range []string{"seo-audit", "link-check", "archive", "custom"} {
+		if _, ok := presets[name]; !ok {
+			t.Errorf("expected preset %q to be available", name)
+		}
+	}
+
+	if presets["archive"].Builtin {
+		t.Error("config file preset should override builtin preset")
+	}
+	if presets["archive"].Description != "custom archive" {
+		t.Errorf("unexpected archive description: %s", presets["archive"].Description)
+	}
+	if !presets["seo-audit"].Builtin {
+		t.Error("seo-audit should be marked as builtin")
+	}
+	if presets["custom"].Name != "custom" {
+		t.Errorf("preset name should be populated, got %q", presets["custom"].Name)
+	}
+}
+
+func TestPresetLookup(t *testing.T) {
+	var cfg *FileConfig
+
+	preset, err := cfg.Preset("link-check")
+	if err != nil {
+		t.Fatalf("Preset() unexpected error: %v", err)
+	}
+	if preset.Flags["output-format"] != "csv" {
+		t.Errorf("expected link-check to use csv output, got %v", preset.Flags)
+	}
+
+	if _, err := cfg.Preset("does-not-exist"); err == nil {
+		t.Error("expected error for unknown preset")
+	}
+}
+
+func TestPresetNames(t *testing.T) {
+	names := PresetNames(map[string]Preset{"b": {}, "a": {}, "c": {}})
+	expected := []string{"a", "b", "c"}
+	for i, name := range expected {
+		if names[i] != name {
+			t.Errorf("PresetNames()[%d] = %s, want %s", i, names[i], name)
+		}
+	}
+}
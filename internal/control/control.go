@@ -0,0 +1,196 @@
+package control
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/filter"
+)
+
+// connTimeout bounds how long a control connection may take to send its
+// command and read the reply
+const connTimeout = 5 * time.Second
+
+// Server accepts commands for a running crawl on a Unix socket.
+//
+// Each connection sends a single line and reads a single line back:
+//
+//	exclude <pattern>    add an exclude pattern to the crawl's URL filter
+//
+// The reply is "ok" or "error: <message>".
+type Server struct {
+	listener net.Listener
+	path     string // Socket file
+	filter   *filter.Filter
+	logger   *slog.Logger
+	wg       sync.WaitGroup
+}
+
+// Listen starts a control server on the socket at path. Commands change
+// urlFilter, which must be the filter the crawl uses. The socket is only
+// accessible to the user running the crawl. A socket file left behind by a
+// process that is no longer running is replaced.
+func Listen(path string, urlFilter *filter.Filter, logger *slog.Logger) (*Server, error) {
+	if urlFilter == nil {
+		return nil, fmt.Errorf("control socket requires a URL filter")
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	if conn, err := net.DialTimeout("unix", path, connTimeout); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("control socket %s is in use by another crawl", path)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to remove stale control socket: %w", err)
+	}
+
+	listener, err := listenPrivate(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{listener: listener, path: path, filter: urlFilter, logger: logger}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// listenPrivate listens on a socket at path that only the current user can
+// connect to. Commands change the crawl, so the socket is created in a
+// private (0700) directory, restricted to 0600 and only then moved to path:
+// created in place, it would be open to other users until its mode is set.
+func listenPrivate(path string) (*net.UnixListener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".urlmap-control-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create control socket: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	private := filepath.Join(dir, "control.sock")
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: private, Net: "unix"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on control socket: %w", err)
+	}
+	// The socket file moves, so Close removes it by its final path
+	listener.SetUnlinkOnClose(false)
+
+	if err := os.Chmod(private, 0o600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict control socket permissions: %w", err)
+	}
+	if err := os.Rename(private, path); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to create control socket: %w", err)
+	}
+	return listener, nil
+}
+
+// Close stops accepting commands, waits for open connections and removes
+// the socket file
+func (s *Server) Close() error {
+	if s == nil {
+		return nil
+	}
+	err := s.listener.Close()
+	s.wg.Wait()
+	if removeErr := os.Remove(s.path); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) && err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// serve accepts connections until the listener is closed
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+// handle reads one command from conn and writes the reply
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(connTimeout))
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && line == "" {
+		return
+	}
+
+	reply := "ok"
+	if err := s.execute(strings.TrimSpace(line)); err != nil {
+		reply = "error: " + err.Error()
+	}
+	fmt.Fprintln(conn, reply)
+}
+
+// execute runs a single command line
+func (s *Server) execute(line string) error {
+	command, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+
+	switch command {
+	case "exclude":
+		if err := s.filter.AddExclude(arg); err != nil {
+			return err
+		}
+		s.logger.Info("Added exclude pattern from control socket", "pattern", arg)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// Exclude asks the crawl listening on the socket at path to exclude URLs
+// matching pattern from now on
+func Exclude(path, pattern string) error {
+	if strings.ContainsAny(pattern, "\r\n") {
+		return fmt.Errorf("pattern cannot contain line breaks")
+	}
+	return send(path, "exclude "+pattern)
+}
+
+// send writes command to the socket at path and returns the server's error, if any
+func send(path, command string) error {
+	conn, err := net.DialTimeout("unix", path, connTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to control socket (is a crawl running with --control-socket?): %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(connTimeout))
+
+	if _, err := fmt.Fprintln(conn, command); err != nil {
+		return fmt.Errorf("failed to send command: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && reply == "" {
+		return fmt.Errorf("failed to read reply: %w", err)
+	}
+	reply = strings.TrimSpace(reply)
+	if message, ok := strings.CutPrefix(reply, "error: "); ok {
+		return errors.New(message)
+	}
+	if reply != "ok" {
+		return fmt.Errorf("unexpected reply: %q", reply)
+	}
+	return nil
+}
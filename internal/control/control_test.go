@@ -0,0 +1,125 @@
+package control
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/filter"
+)
+
+func TestExclude(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "control.sock")
+	urlFilter, _ := filter.New(nil, nil)
+
+	server, err := Listen(path, urlFilter, nil)
+	if err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("socket file missing: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket permissions = %o, want 600", perm)
+	}
+
+	if err := Exclude(path, "/calendar/*"); err != nil {
+		t.Fatalf("Exclude() error: %v", err)
+	}
+	if urlFilter.Allow("https://example.com/calendar/2024") {
+		t.Error("excluded URL is still allowed")
+	}
+	if !urlFilter.Allow("https://example.com/about") {
+		t.Error("other URL is no longer allowed")
+	}
+
+	if err := Exclude(path, "re:("); err == nil || !strings.Contains(err.Error(), "invalid regex") {
+		t.Errorf("Exclude() with invalid pattern error = %v", err)
+	}
+	if err := send(path, "pause"); err == nil || !strings.Contains(err.Error(), "unknown command") {
+		t.Errorf("send() with unknown command error = %v", err)
+	}
+
+	if err := server.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket file not removed: %v", err)
+	}
+	if err := Exclude(path, "/tag/*"); err == nil {
+		t.Error("Exclude() without a running crawl succeeded")
+	}
+}
+
+func TestListen_SocketInUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "control.sock")
+	urlFilter, _ := filter.New(nil, nil)
+
+	server, err := Listen(path, urlFilter, nil)
+	if err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	defer server.Close()
+
+	if _, err := Listen(path, urlFilter, nil); err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Errorf("second Listen() error = %v, want in use", err)
+	}
+}
+
+func TestListen_StaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "control.sock")
+
+	// Leave a socket file behind without anyone listening
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("net.Listen() error: %v", err)
+	}
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	listener.Close()
+
+	urlFilter, _ := filter.New(nil, nil)
+	server, err := Listen(path, urlFilter, nil)
+	if err != nil {
+		t.Fatalf("Listen() over stale socket error: %v", err)
+	}
+	defer server.Close()
+
+	if err := Exclude(path, "/tag/*"); err != nil {
+		t.Errorf("Exclude() error: %v", err)
+	}
+}
+
+func TestListen_OwnerOnly(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "control.sock")
+	urlFilter, _ := filter.New(nil, nil)
+
+	server, err := Listen(path, urlFilter, nil)
+	if err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket permissions = %o, want 600", perm)
+	}
+	// The private directory the socket was created in is gone
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the socket", len(entries))
+	}
+
+	if err := server.Close(); err != nil {
+		t.Errorf("Close() error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket file still exists after Close(): %v", err)
+	}
+}
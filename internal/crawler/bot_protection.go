@@ -0,0 +1,98 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+// ErrBlockedByBotProtection marks pages answered with a bot protection
+// challenge instead of their content
+var ErrBlockedByBotProtection = errors.New("blocked-by-bot-protection")
+
+// botProtectionDelay is how long a host is held back after a challenge
+const botProtectionDelay = 30 * time.Second
+
+// botChallengeMarkers are body snippets of challenge pages, per vendor
+var botChallengeMarkers = []struct {
+	vendor  string
+	markers []string
+}{
+	{"cloudflare", []string{"/cdn-cgi/challenge-platform/", "cf-chl-", "<title>Just a moment...</title>", "Attention Required! | Cloudflare"}},
+	{"datadome", []string{"captcha-delivery.com"}},
+	{"perimeterx", []string{"px-captcha", "_pxCaptcha"}},
+	{"captcha", []string{"g-recaptcha", "h-captcha", "hcaptcha.com/1/api.js", "challenges.cloudflare.com/turnstile"}},
+}
+
+// detectBotProtection returns the bot protection that answered an error
+// response with a challenge or block page, or "" for ordinary errors. Only
+// 403, 429 and 503 responses are considered, so genuine server errors and
+// missing pages are never mistaken for blocks.
+func detectBotProtection(statusCode int, header func(string) string, body string) string {
+	switch statusCode {
+	case http.StatusForbidden, http.StatusTooManyRequests, http.StatusServiceUnavailable:
+	default:
+		return ""
+	}
+
+	if header("cf-mitigated") == "challenge" {
+		return "cloudflare"
+	}
+	for _, vendor := range botChallengeMarkers {
+		for _, marker := range vendor.markers {
+			if strings.Contains(body, marker) {
+				return vendor.vendor
+			}
+		}
+	}
+
+	// Akamai answers blocked clients with a bare "Access Denied" page
+	if statusCode == http.StatusForbidden && strings.Contains(header("Server"), "AkamaiGHost") {
+		return "akamai"
+	}
+	return ""
+}
+
+// httpError returns the error of an unsuccessful response, telling pages
+// blocked by bot protection apart from other HTTP errors
+func httpError(meta responseMeta) error {
+	if meta.botProtection != "" {
+		return fmt.Errorf("%w (%s): HTTP error: %d", ErrBlockedByBotProtection, meta.botProtection, meta.statusCode)
+	}
+	return fmt.Errorf("HTTP error: %d", meta.statusCode)
+}
+
+// renderBlocked fetches a page blocked by bot protection again with
+// JavaScript rendering, as a browser may pass the challenge, when
+// RenderBlocked is set and the page was not rendered already. It returns the
+// original response if rendering is off or fails.
+func (c *Crawler) renderBlocked(ctx context.Context, fetchURL string, response client.UnifiedResponse, meta responseMeta, rendered bool) (client.UnifiedResponse, responseMeta) {
+	if meta.botProtection == "" || rendered || c.client.IsJSEnabled() || !c.renderOnBlock || c.client.GetJSClient() == nil {
+		return response, meta
+	}
+
+	c.logger.Info("Bot protection challenge, retrying with JavaScript rendering", "url", fetchURL, "protection", meta.botProtection)
+	renderedResponse, err := c.client.FetchJS(ctx, fetchURL)
+	if err != nil {
+		c.logger.Warn("JavaScript rendering of blocked page failed", "url", fetchURL, "error", err)
+		return response, meta
+	}
+	return renderedResponse, newResponseMeta(renderedResponse, c.errorBodySize)
+}
+
+// slowBlockedHost holds back a host that answered with a bot protection
+// challenge, as further requests at full speed would only be blocked too
+func (s *crawlSession) slowBlockedHost(host string, result CrawlResult) {
+	s.mu.Lock()
+	s.stats.BotBlocked++
+	s.mu.Unlock()
+
+	s.throttle.Delay(host, botProtectionDelay)
+	s.logger.Warn("Blocked by bot protection, slowing down host",
+		"url", result.URL, "protection", result.BotProtection, "host", host, "delay", botProtectionDelay)
+}
@@ -0,0 +1,78 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDetectBotProtection(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		headers    map[string]string
+		body       string
+		want       string
+	}{
+		{"cloudflare header", 403, map[string]string{"cf-mitigated": "challenge"}, "", "cloudflare"},
+		{"cloudflare challenge page", 503, nil, "<html><title>Just a moment...</title><script src=\"/cdn-cgi/challenge-platform/h/b/orchestrate/jsch/v1\"></script></html>", "cloudflare"},
+		{"cloudflare block page", 403, nil, "<title>Attention Required! | Cloudflare</title>", "cloudflare"},
+		{"akamai", 403, map[string]string{"Server": "AkamaiGHost"}, "<H1>Access Denied</H1>", "akamai"},
+		{"datadome", 403, nil, `<script src="https://ct.captcha-delivery.com/c.js"></script>`, "datadome"},
+		{"perimeterx", 403, nil, `<div id="px-captcha"></div>`, "perimeterx"},
+		{"captcha on rate limit", 429, nil, `<div class="g-recaptcha"></div>`, "captcha"},
+		{"plain forbidden", 403, map[string]string{"Server": "nginx"}, "<h1>403 Forbidden</h1>", ""},
+		{"akamai not found", 404, map[string]string{"Server": "AkamaiGHost"}, "Not Found", ""},
+		{"server error mentioning captcha", 500, nil, `<div class="g-recaptcha"></div>`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := func(name string) string { return tt.headers[name] }
+			if got := detectBotProtection(tt.statusCode, header, tt.body); got != tt.want {
+				t.Errorf("detectBotProtection() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcurrentCrawler_BotProtection(t *testing.T) {
+	// The challenge holds back the host, so it is linked last
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><a href="/forbidden">forbidden</a><a href="/challenge">challenge</a></body></html>`)
+		case "/challenge":
+			w.Header().Set("cf-mitigated", "challenge")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "<html><title>Just a moment...</title></html>")
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, Workers: 1})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	for _, result := range results {
+		blocked := result.URL == server.URL+"/challenge"
+		if blocked != errors.Is(result.Error, ErrBlockedByBotProtection) {
+			t.Errorf("%s: error %v", result.URL, result.Error)
+		}
+		if blocked && result.BotProtection != "cloudflare" {
+			t.Errorf("%s: BotProtection = %q, want cloudflare", result.URL, result.BotProtection)
+		}
+	}
+	if stats.BotBlocked != 1 || stats.FailedURLs != 2 {
+		t.Errorf("BotBlocked = %d, FailedURLs = %d; want 1, 2", stats.BotBlocked, stats.FailedURLs)
+	}
+}
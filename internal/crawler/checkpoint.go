@@ -0,0 +1,184 @@
+package crawler
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// DefaultCheckpointInterval is how often a running crawl is passed to
+// Config.OnCheckpoint
+const DefaultCheckpointInterval = 30 * time.Second
+
+// Checkpoint is the progress of a crawl, from which an interrupted crawl can
+// continue where it left off, see Config.OnCheckpoint and Config.ResumeFrom
+type Checkpoint struct {
+	Visited []string      // URLs queued, crawled or skipped so far, sorted
+	Pending []CrawlJob    // URLs queued or being fetched, sorted by URL
+	Results []CrawlResult // Pages crawled so far, including resumed ones
+	Stats   CrawlStats    // Statistics so far; TotalTime is the time crawled
+}
+
+// trackPending records a queued job until it is done
+func (s *crawlSession) trackPending(job CrawlJob) {
+	if s.onCheckpoint == nil {
+		return
+	}
+	s.pendingMu.Lock()
+	s.pending[job.URL] = job
+	s.pendingMu.Unlock()
+}
+
+// donePending forgets a processed job, unless it was rescheduled meanwhile or
+// the crawl was stopped before the job could finish
+func (s *crawlSession) donePending(job CrawlJob) {
+	if s.onCheckpoint == nil || s.ctx.Err() != nil {
+		return
+	}
+	s.pendingMu.Lock()
+	if pending, ok := s.pending[job.URL]; ok && pending.Attempt == job.Attempt {
+		delete(s.pending, job.URL)
+	}
+	s.pendingMu.Unlock()
+}
+
+// checkpoint takes a snapshot of the crawl's progress
+func (s *crawlSession) checkpoint() Checkpoint {
+	var cp Checkpoint
+	s.visited.Range(func(key, _ any) bool {
+		cp.Visited = append(cp.Visited, key.(string))
+		return true
+	})
+	slices.Sort(cp.Visited)
+
+	s.pendingMu.Lock()
+	for _, job := range s.pending {
+		cp.Pending = append(cp.Pending, job)
+	}
+	s.pendingMu.Unlock()
+	s.deferredMu.Lock()
+	cp.Pending = append(cp.Pending, s.deferred...)
+	s.deferredMu.Unlock()
+	slices.SortFunc(cp.Pending, func(a, b CrawlJob) int { return cmp.Compare(a.URL, b.URL) })
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	crawled := make(map[string]bool, len(s.resultsList))
+	for _, result := range s.resultsList {
+		crawled[result.URL] = true
+	}
+	for _, result := range s.resume {
+		if !crawled[result.URL] {
+			cp.Results = append(cp.Results, result)
+		}
+	}
+	cp.Results = append(cp.Results, s.resultsList...)
+
+	// A page whose result was just collected is no longer pending
+	for _, result := range cp.Results {
+		crawled[result.URL] = true
+	}
+	cp.Pending = slices.DeleteFunc(cp.Pending, func(job CrawlJob) bool { return crawled[job.URL] })
+
+	cp.Stats = s.stats
+	cp.Stats.SkippedAlternates = slices.Clone(s.stats.SkippedAlternates)
+	cp.Stats.Frontier = slices.Clone(s.stats.Frontier)
+	cp.Stats.TotalTime = time.Since(s.stats.StartTime)
+	return cp
+}
+
+// startCheckpoints passes the crawl's progress to OnCheckpoint every
+// interval. The returned func stops it and passes the final progress.
+func (s *crawlSession) startCheckpoints() (stop func()) {
+	if s.onCheckpoint == nil {
+		return func() {}
+	}
+
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(s.checkpointEvery)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.onCheckpoint(s.checkpoint())
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+		s.onCheckpoint(s.checkpoint())
+	}
+}
+
+// checkpointJobs restores the progress recorded in cp and returns the jobs
+// that continue it: the pending URLs, and links of crawled pages that were
+// not queued yet. Failed pages are not retried. The seed is queued only if
+// the checkpoint is empty.
+func (s *crawlSession) checkpointJobs(seed string, cp *Checkpoint) []CrawlJob {
+	s.mu.Lock()
+	startTime := s.stats.StartTime
+	s.stats = cp.Stats
+	s.stats.SkippedAlternates = slices.Clone(cp.Stats.SkippedAlternates)
+	s.stats.Frontier = slices.Clone(cp.Stats.Frontier)
+	s.stats.StartTime = startTime.Add(-cp.Stats.TotalTime)
+	s.stats.TotalTime = 0
+	s.mu.Unlock()
+
+	for _, visited := range cp.Visited {
+		s.visited.Store(visited, true)
+	}
+	for _, result := range cp.Results {
+		s.visited.Store(result.URL, true)
+		s.sampler.Allow(result.URL)
+	}
+
+	var jobs []CrawlJob
+	for _, job := range cp.Pending {
+		s.visited.Store(job.URL, true)
+		s.sampler.Allow(job.URL)
+		jobs = append(jobs, job)
+	}
+
+	if _, loaded := s.visited.LoadOrStore(seed, true); !loaded {
+		s.sampler.Allow(seed)
+		jobs = append(jobs, CrawlJob{URL: seed, Depth: 0})
+	}
+
+	// Links of pages crawled just before the checkpoint may not be queued yet
+	queued := 0
+	for _, result := range cp.Results {
+		if result.Error != nil {
+			continue
+		}
+		for _, link := range result.Links {
+			if !s.admitLink(link) {
+				continue
+			}
+			crawl, tags := s.classify(link, result.URL, result.Depth+1)
+			if !crawl {
+				continue
+			}
+			jobs = append(jobs, CrawlJob{URL: link, Depth: result.Depth + 1, Parent: result.URL,
+				LowConfidence: slices.Contains(result.ScriptLinks, link), Tags: tags})
+			queued++
+		}
+	}
+
+	s.mu.Lock()
+	s.stats.TotalURLs += queued
+	if s.stats.TotalURLs == 0 {
+		s.stats.TotalURLs = len(jobs)
+	}
+	s.mu.Unlock()
+
+	s.logger.Info("Resuming crawl from checkpoint", "completed", len(cp.Results), "queued", len(jobs))
+	return jobs
+}
@@ -0,0 +1,231 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newCheckpointServer serves a seed linking to /a and /b, where /a links to
+// /a/1, and counts the requests per path
+func newCheckpointServer() (*httptest.Server, func(string) int) {
+	var mu sync.Mutex
+	fetched := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetched[r.URL.Path]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><a href="/a">A</a><a href="/b">B</a></body></html>`)
+		case "/a":
+			fmt.Fprint(w, `<html><body><a href="/a/1">A1</a></body></html>`)
+		default:
+			fmt.Fprint(w, `<html><body>leaf</body></html>`)
+		}
+	}))
+	return server, func(path string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return fetched[path]
+	}
+}
+
+func TestConcurrentCrawler_OnCheckpoint(t *testing.T) {
+	server, _ := newCheckpointServer()
+	defer server.Close()
+
+	var mu sync.Mutex
+	var checkpoints []Checkpoint
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:           -1,
+		SameDomain:         true,
+		Workers:            2,
+		CheckpointInterval: time.Millisecond,
+		OnCheckpoint: func(cp Checkpoint) {
+			mu.Lock()
+			checkpoints = append(checkpoints, cp)
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	if _, _, err := cc.CrawlConcurrent(server.URL); err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	if len(checkpoints) == 0 {
+		t.Fatal("expected at least the final checkpoint")
+	}
+	final := checkpoints[len(checkpoints)-1]
+	if len(final.Pending) != 0 {
+		t.Errorf("finished crawl should have nothing pending, got %+v", final.Pending)
+	}
+	if len(final.Results) != 4 || len(final.Visited) != 4 {
+		t.Errorf("expected 4 results and visited URLs, got %d and %d", len(final.Results), len(final.Visited))
+	}
+	if final.Stats.CrawledURLs != 4 || final.Stats.TotalTime <= 0 {
+		t.Errorf("unexpected checkpoint stats: %+v", final.Stats)
+	}
+}
+
+func TestConcurrentCrawler_ResumeFrom(t *testing.T) {
+	server, fetched := newCheckpointServer()
+	defer server.Close()
+
+	// The interrupted crawl fetched the seed and /a; /b was still queued and
+	// the links of /a were not queued yet
+	checkpoint := &Checkpoint{
+		Visited: []string{server.URL + "/", server.URL + "/a", server.URL + "/b"},
+		Pending: []CrawlJob{{URL: server.URL + "/b", Depth: 1, Parent: server.URL + "/"}},
+		Results: []CrawlResult{
+			{URL: server.URL + "/", Links: []string{server.URL + "/a", server.URL + "/b"}},
+			{URL: server.URL + "/a", Depth: 1, Parent: server.URL + "/", Links: []string{server.URL + "/a/1"}},
+		},
+		Stats: CrawlStats{TotalURLs: 3, CrawledURLs: 2, TotalTime: time.Minute},
+	}
+
+	var last Checkpoint
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:     -1,
+		SameDomain:   true,
+		Workers:      2,
+		ResumeFrom:   checkpoint,
+		OnCheckpoint: func(cp Checkpoint) { last = cp },
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	var urls []string
+	for _, result := range results {
+		urls = append(urls, result.URL)
+	}
+	sort.Strings(urls)
+	want := []string{server.URL + "/a/1", server.URL + "/b"}
+	if fmt.Sprint(urls) != fmt.Sprint(want) {
+		t.Errorf("crawled %v, want %v", urls, want)
+	}
+	if fetched("/") != 0 || fetched("/a") != 0 {
+		t.Error("pages in the checkpoint were fetched again")
+	}
+	if stats.CrawledURLs != 4 || stats.TotalURLs != 4 {
+		t.Errorf("statistics should continue the checkpoint's, got %+v", stats)
+	}
+	if stats.TotalTime < time.Minute {
+		t.Errorf("TotalTime should include the time crawled before, got %v", stats.TotalTime)
+	}
+	if len(last.Results) != 4 {
+		t.Errorf("final checkpoint should hold all 4 results, got %d", len(last.Results))
+	}
+}
+
+func TestAddJob_FullQueueKeepsJobs(t *testing.T) {
+	cc, err := NewConcurrentCrawler(&Config{Workers: 1, OnCheckpoint: func(Checkpoint) {}})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	s := cc.newSession(context.Background())
+	defer s.cancel()
+
+	// No worker takes jobs, so the third one finds the queue full
+	for _, path := range []string{"/a", "/b", "/c"} {
+		s.addJob(CrawlJob{URL: "https://example.com" + path, Depth: 1})
+	}
+
+	var pending []string
+	for _, job := range s.checkpoint().Pending {
+		pending = append(pending, job.URL)
+	}
+	sort.Strings(pending)
+	want := []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"}
+	if fmt.Sprint(pending) != fmt.Sprint(want) {
+		t.Errorf("pending = %v, want %v", pending, want)
+	}
+
+	// The job that did not fit is queued once there is space
+	var queued []string
+	for range want {
+		queued = append(queued, (<-s.jobs).URL)
+	}
+	sort.Strings(queued)
+	if fmt.Sprint(queued) != fmt.Sprint(want) {
+		t.Errorf("queued = %v, want %v", queued, want)
+	}
+}
+
+func TestConcurrentCrawler_ResumeOverflowedQueue(t *testing.T) {
+	const leaves = 20
+
+	// The seed links to more pages than the queue of a single worker holds;
+	// the crawl is interrupted while fetching the first of them
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var interrupt sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `<html><body>`)
+			for i := range leaves {
+				fmt.Fprintf(w, `<a href="/%d">%d</a>`, i, i)
+			}
+			fmt.Fprint(w, `</body></html>`)
+			return
+		}
+		interrupt.Do(cancel)
+		fmt.Fprint(w, `<html><body>leaf</body></html>`)
+	}))
+	defer server.Close()
+
+	var last Checkpoint
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:     -1,
+		SameDomain:   true,
+		Workers:      1,
+		OnCheckpoint: func(cp Checkpoint) { last = cp },
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	if _, _, err := cc.CrawlConcurrentContext(ctx, server.URL); err != nil && ctx.Err() == nil {
+		t.Fatalf("CrawlConcurrentContext() failed: %v", err)
+	}
+
+	resumed, err := NewConcurrentCrawler(&Config{
+		MaxDepth:     -1,
+		SameDomain:   true,
+		Workers:      1,
+		ResumeFrom:   &last,
+		OnCheckpoint: func(Checkpoint) {},
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	results, _, err := resumed.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	crawled := make(map[string]bool)
+	for _, result := range append(last.Results, results...) {
+		crawled[result.URL] = true
+	}
+	for i := range leaves {
+		if url := fmt.Sprintf("%s/%d", server.URL, i); !crawled[url] {
+			t.Errorf("%s was never crawled", url)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package crawler
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBreakerThreshold is the number of consecutive failures that opens a host's circuit
+	DefaultBreakerThreshold = 5
+	// DefaultBreakerCoolOff is how long an open circuit blocks requests to its host
+	DefaultBreakerCoolOff = time.Minute
+)
+
+// hostCircuit holds the breaker state of a single host
+type hostCircuit struct {
+	failures  int       // Consecutive failures
+	openUntil time.Time // Requests are blocked until this time
+}
+
+// circuitBreaker stops requests to hosts that keep failing at the connection level
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	coolOff   time.Duration
+	hosts     map[string]*hostCircuit
+}
+
+// newCircuitBreaker creates a breaker that opens after threshold consecutive
+// failures. A threshold below 1 disables the breaker.
+func newCircuitBreaker(threshold int, coolOff time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		coolOff:   coolOff,
+		hosts:     make(map[string]*hostCircuit),
+	}
+}
+
+// Allow reports whether a request to host may be attempted.
+// Once the cool-off has passed, requests are allowed again; a further
+// failure re-opens the circuit immediately.
+func (b *circuitBreaker) Allow(host string) bool {
+	if b.threshold < 1 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	circuit, ok := b.hosts[host]
+	return !ok || time.Now().After(circuit.openUntil)
+}
+
+// RecordSuccess resets the failure count of host
+func (b *circuitBreaker) RecordSuccess(host string) {
+	if b.threshold < 1 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.hosts, host)
+}
+
+// RecordFailure counts a failure for host and reports whether it opened the circuit
+func (b *circuitBreaker) RecordFailure(host string) bool {
+	if b.threshold < 1 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	circuit, ok := b.hosts[host]
+	if !ok {
+		circuit = &hostCircuit{}
+		b.hosts[host] = circuit
+	}
+
+	circuit.failures++
+	if circuit.failures < b.threshold || time.Now().Before(circuit.openUntil) {
+		return false
+	}
+
+	circuit.openUntil = time.Now().Add(b.coolOff)
+	return true
+}
@@ -0,0 +1,97 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := newCircuitBreaker(2, 50*time.Millisecond)
+
+	if breaker.RecordFailure("a.example.com") {
+		t.Error("circuit should stay closed below the threshold")
+	}
+	breaker.RecordSuccess("a.example.com")
+	if breaker.RecordFailure("a.example.com") {
+		t.Error("success should reset the failure count")
+	}
+	if !breaker.RecordFailure("a.example.com") {
+		t.Error("circuit should open at the threshold")
+	}
+	if breaker.Allow("a.example.com") {
+		t.Error("open circuit should block requests")
+	}
+	if !breaker.Allow("b.example.com") {
+		t.Error("other hosts should not be affected")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if !breaker.Allow("a.example.com") {
+		t.Error("circuit should allow requests after the cool-off")
+	}
+	if !breaker.RecordFailure("a.example.com") {
+		t.Error("a failure after the cool-off should re-open the circuit")
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	breaker := newCircuitBreaker(-1, time.Minute)
+	for i := 0; i < 10; i++ {
+		if breaker.RecordFailure("example.com") {
+			t.Fatal("disabled breaker should never open")
+		}
+	}
+	if !breaker.Allow("example.com") {
+		t.Error("disabled breaker should always allow requests")
+	}
+}
+
+func TestConcurrentCrawler_CircuitBreaker(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		var links strings.Builder
+		for i := 0; i < 10; i++ {
+			fmt.Fprintf(&links, `<a href="/dead/%d">dead</a>`, i)
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprintf(w, "<html><body>%s</body></html>", links.String())
+	})
+	mux.HandleFunc("/dead/", func(w http.ResponseWriter, r *http.Request) {
+		// Drop the connection to simulate a dead backend
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:         -1,
+		SameDomain:       true,
+		Workers:          5,
+		BreakerThreshold: 3,
+		BreakerCoolOff:   time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	_, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	// Requests already in flight may fail after the circuit opens, but at most
+	// two jobs can be picked up before the third failure is recorded
+	if stats.FailedURLs < 3 || stats.FailedURLs+stats.CircuitSkipped != 10 {
+		t.Errorf("unexpected stats: failed=%d circuit_skipped=%d", stats.FailedURLs, stats.CircuitSkipped)
+	}
+	if stats.CircuitSkipped < 3 {
+		t.Errorf("expected at least 3 URLs skipped by the circuit breaker, got %d", stats.CircuitSkipped)
+	}
+}
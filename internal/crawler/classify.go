@@ -0,0 +1,31 @@
+package crawler
+
+// Classifier decides whether a link found on parent is crawled at depth and
+// which tags its result carries, e.g. by asking an external command
+type Classifier func(link, parent string, depth int) (crawl bool, tags []string, err error)
+
+// classify runs the classifier on a link admitted to the crawl. Links the
+// classifier fails on are crawled without tags, so a broken classifier does
+// not end the crawl.
+func (s *crawlSession) classify(link, parent string, depth int) (bool, []string) {
+	if s.classifier == nil {
+		return true, nil
+	}
+
+	crawl, tags, err := s.classifier(link, parent, depth)
+	if err != nil {
+		s.logger.Warn("Failed to classify URL, crawling it", "url", link, "error", err)
+		return true, nil
+	}
+	if !crawl {
+		s.logger.Debug("Skipping link rejected by classifier", "link", link)
+		s.mu.Lock()
+		s.stats.SkippedURLs++
+		s.stats.ClassifySkipped++
+		s.mu.Unlock()
+		if s.progress != nil {
+			s.progress.IncrementSkipped()
+		}
+	}
+	return crawl, tags
+}
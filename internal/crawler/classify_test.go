@@ -0,0 +1,60 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestConcurrentCrawler_Classify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `<html><body><a href="/products/1">P</a><a href="/admin">A</a><a href="/about">About</a><a href="/broken">B</a></body></html>`)
+			return
+		}
+		fmt.Fprint(w, "<html><body>page</body></html>")
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:   2,
+		SameDomain: true,
+		Workers:    2,
+		Classify: func(link, parent string, depth int) (bool, []string, error) {
+			switch {
+			case strings.HasSuffix(link, "/admin"):
+				return false, nil, nil
+			case strings.Contains(link, "/products/"):
+				return true, []string{"product", parent[len(server.URL):]}, nil
+			case strings.HasSuffix(link, "/broken"):
+				return false, nil, errors.New("classifier failed")
+			}
+			return true, nil, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL + "/")
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	tags := make(map[string]string)
+	for _, result := range results {
+		tags[result.URL[len(server.URL):]] = strings.Join(result.Tags, ",")
+	}
+	// Links the classifier fails on are crawled without tags
+	expected := map[string]string{"/": "", "/products/1": "product,/", "/about": "", "/broken": ""}
+	if fmt.Sprint(tags) != fmt.Sprint(expected) {
+		t.Errorf("expected results %v, got %v", expected, tags)
+	}
+	if stats.ClassifySkipped != 1 {
+		t.Errorf("expected 1 URL skipped by the classifier, got %d", stats.ClassifySkipped)
+	}
+}
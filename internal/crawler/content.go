@@ -0,0 +1,167 @@
+package crawler
+
+import (
+	"mime"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// Content kinds reported by SummarizeContent
+const (
+	ContentHTML    = "html"
+	ContentJSON    = "json"
+	ContentXML     = "xml"
+	ContentText    = "text"
+	ContentOther   = "other"
+	ContentUnknown = "unknown" // No Content-Type header
+)
+
+// sizeBuckets are the upper bounds (exclusive) of the response size histogram;
+// the last bucket holds everything larger
+var sizeBuckets = []struct {
+	label string
+	max   int
+}{
+	{"< 10 KB", 10 << 10},
+	{"10-100 KB", 100 << 10},
+	{"100 KB-1 MB", 1 << 20},
+	{">= 1 MB", 0},
+}
+
+// KindCount is the number of responses of a content kind
+type KindCount struct {
+	Kind  string
+	Count int
+}
+
+// SizeCount is the number of responses in a size range
+type SizeCount struct {
+	Range string
+	Count int
+}
+
+// ContentSummary is the distribution of content types and sizes of a crawl
+type ContentSummary struct {
+	Responses   int         // Responses with a body (failed fetches excluded)
+	Kinds       []KindCount // Responses per content kind, most common first
+	Sizes       []SizeCount // Responses per size range, smallest range first
+	TotalBytes  int64       // Sum of all body sizes
+	LargestURL  string      // URL of the largest response
+	LargestSize int         // Body size of the largest response
+}
+
+// recordContent stores the content type and body size of a response
+func recordContent(result *CrawlResult, meta responseMeta) {
+	result.ContentType = meta.contentType
+	result.Size = meta.size
+}
+
+// ContentKind classifies a Content-Type header value
+func ContentKind(contentType string) string {
+	if strings.TrimSpace(contentType) == "" {
+		return ContentUnknown
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		return ContentHTML
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return ContentJSON
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return ContentXML
+	case strings.HasPrefix(mediaType, "text/"):
+		return ContentText
+	default:
+		return ContentOther
+	}
+}
+
+// SummarizeContent computes the content type and size distribution of results.
+// Results without a response (connection errors, timeouts) are not counted.
+func SummarizeContent(results []CrawlResult) ContentSummary {
+	var summary ContentSummary
+	kinds := make(map[string]int)
+	sizes := make([]int, len(sizeBuckets))
+
+	for _, result := range results {
+		if result.StatusCode == 0 {
+			continue
+		}
+		summary.Responses++
+		kinds[ContentKind(result.ContentType)]++
+		sizes[sizeBucket(result.Size)]++
+		summary.TotalBytes += int64(result.Size)
+		if summary.LargestURL == "" || result.Size > summary.LargestSize {
+			summary.LargestURL = result.URL
+			summary.LargestSize = result.Size
+		}
+	}
+
+	for _, kind := range []string{ContentHTML, ContentJSON, ContentXML, ContentText, ContentOther, ContentUnknown} {
+		if kinds[kind] > 0 {
+			summary.Kinds = append(summary.Kinds, KindCount{Kind: kind, Count: kinds[kind]})
+		}
+	}
+	sort.SliceStable(summary.Kinds, func(i, j int) bool {
+		return summary.Kinds[i].Count > summary.Kinds[j].Count
+	})
+
+	for i, bucket := range sizeBuckets {
+		summary.Sizes = append(summary.Sizes, SizeCount{Range: bucket.label, Count: sizes[i]})
+	}
+	return summary
+}
+
+// sizeBucket returns the index of the size histogram bucket for size
+func sizeBucket(size int) int {
+	for i, bucket := range sizeBuckets {
+		if bucket.max == 0 || size < bucket.max {
+			return i
+		}
+	}
+	return len(sizeBuckets) - 1
+}
+
+// extractResponseLinks extracts links from a response body according to its
+// content type: XML documents (sitemaps, feeds) and, with ExtractJSON, JSON
+// documents have their own extractors, anything else is parsed as HTML.
+// Malformed XML or JSON only loses the links that could not be read.
+func (c *Crawler) extractResponseLinks(pageURL, contentType, body string) ([]string, error) {
+	var links []string
+	var err error
+	switch kind := ContentKind(contentType); {
+	case kind == ContentXML:
+		links, err = c.parser.ExtractXMLLinks(pageURL, body)
+	case kind == ContentJSON && c.extractJSON:
+		links, err = c.parser.ExtractJSONLinks(pageURL, body)
+	default:
+		return c.extractLinks(pageURL, body)
+	}
+	if err != nil {
+		c.logger.Warn("Failed to read links", "url", pageURL, "content_type", contentType, "links_found", len(links), "error", err)
+	}
+	return c.scopeLinks(pageURL, links), nil
+}
+
+// scopeLinks applies the same-domain setting, host rewrites and session-ID
+// stripping to links that were not extracted by extractLinks
+func (c *Crawler) scopeLinks(pageURL string, links []string) []string {
+	if c.sameDomain && c.rewrites == nil {
+		links = slices.DeleteFunc(links, func(link string) bool {
+			isSame, err := url.IsSameDomain(pageURL, link)
+			return err != nil || !isSame
+		})
+	}
+	if c.rewrites != nil {
+		links = c.restoreLinks(pageURL, links)
+	}
+	return c.stripLinkSessionIDs(links)
+}
@@ -0,0 +1,167 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestContentKind(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"text/html; charset=utf-8", ContentHTML},
+		{"application/xhtml+xml", ContentHTML},
+		{"application/json", ContentJSON},
+		{"application/ld+json", ContentJSON},
+		{"application/rss+xml", ContentXML},
+		{"text/xml", ContentXML},
+		{"text/plain", ContentText},
+		{"image/png", ContentOther},
+		{"", ContentUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ContentKind(tt.input); got != tt.expected {
+			t.Errorf("ContentKind(%q) = %q; want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestSummarizeContent(t *testing.T) {
+	results := []CrawlResult{
+		{URL: "https://example.com/", StatusCode: 200, ContentType: "text/html", Size: 5 << 10},
+		{URL: "https://example.com/a", StatusCode: 200, ContentType: "text/html", Size: 50 << 10},
+		{URL: "https://example.com/api", StatusCode: 200, ContentType: "application/json", Size: 2 << 20},
+		{URL: "https://example.com/missing", StatusCode: 404, ContentType: "text/html", Size: 100},
+		{URL: "https://example.com/down"}, // Connection error: not counted
+	}
+
+	summary := SummarizeContent(results)
+
+	if summary.Responses != 4 {
+		t.Errorf("Responses = %d, want 4", summary.Responses)
+	}
+	wantKinds := []KindCount{{Kind: ContentHTML, Count: 3}, {Kind: ContentJSON, Count: 1}}
+	if !reflect.DeepEqual(summary.Kinds, wantKinds) {
+		t.Errorf("Kinds = %+v, want %+v", summary.Kinds, wantKinds)
+	}
+	wantSizes := []SizeCount{{"< 10 KB", 2}, {"10-100 KB", 1}, {"100 KB-1 MB", 0}, {">= 1 MB", 1}}
+	if !reflect.DeepEqual(summary.Sizes, wantSizes) {
+		t.Errorf("Sizes = %+v, want %+v", summary.Sizes, wantSizes)
+	}
+	if summary.LargestURL != "https://example.com/api" || summary.LargestSize != 2<<20 {
+		t.Errorf("largest = %s (%d), want https://example.com/api", summary.LargestURL, summary.LargestSize)
+	}
+	if summary.TotalBytes != int64(5<<10+50<<10+2<<20+100) {
+		t.Errorf("TotalBytes = %d", summary.TotalBytes)
+	}
+}
+
+func TestCrawler_RecordsContent(t *testing.T) {
+	const body = `<html><body>hello</body></html>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	c, err := New(&Config{MaxDepth: 0, SameDomain: true})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	results, _, err := c.CrawlRecursive(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlRecursive() failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if !strings.HasPrefix(results[0].ContentType, "text/html") || results[0].Size != len(body) {
+		t.Errorf("unexpected content: type %q, size %d", results[0].ContentType, results[0].Size)
+	}
+}
+
+func TestConcurrentCrawler_StructuredLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, `<html><body><a href="/sitemap.xml">sitemap</a><a href="/api/pages">api</a></body></html>`)
+		case "/sitemap.xml":
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<urlset><url><loc>/from-sitemap</loc></url><url><loc>https://other.example/</loc></url></urlset>`)
+		case "/api/pages":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"pages": [{"url": "/from-api"}]}`)
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, `<html><body>leaf</body></html>`)
+		}
+	}))
+	defer server.Close()
+
+	crawl := func(extractJSON bool) []string {
+		cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 2, ExtractJSON: extractJSON})
+		if err != nil {
+			t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+		}
+		results, _, err := cc.CrawlConcurrent(server.URL)
+		if err != nil {
+			t.Fatalf("CrawlConcurrent() failed: %v", err)
+		}
+		var paths []string
+		for _, result := range results {
+			paths = append(paths, strings.TrimPrefix(result.URL, server.URL))
+		}
+		sort.Strings(paths)
+		return paths
+	}
+
+	// Sitemap links are always followed, API links only with ExtractJSON
+	want := []string{"/", "/api/pages", "/from-sitemap", "/sitemap.xml"}
+	if got := crawl(false); !reflect.DeepEqual(got, want) {
+		t.Errorf("crawled %v, want %v", got, want)
+	}
+	want = []string{"/", "/api/pages", "/from-api", "/from-sitemap", "/sitemap.xml"}
+	if got := crawl(true); !reflect.DeepEqual(got, want) {
+		t.Errorf("with ExtractJSON crawled %v, want %v", got, want)
+	}
+}
+
+func TestConcurrentCrawler_PartialContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusPartialContent)
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `<html><body><a href="/next">next</a></body></html>`)
+			return
+		}
+		fmt.Fprint(w, `<html><body>leaf</body></html>`)
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 2})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	// 206 pages are successes whose links are followed
+	if len(results) != 2 || stats.FailedURLs != 0 {
+		t.Fatalf("crawled %d pages with %d failures, want 2 pages without failures", len(results), stats.FailedURLs)
+	}
+	for _, result := range results {
+		if result.StatusCode != http.StatusPartialContent || result.Error != nil {
+			t.Errorf("%s: status %d, error %v", result.URL, result.StatusCode, result.Error)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package crawler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aoshimash/urlmap/internal/progress"
+)
+
+// crawlSession holds the state of one crawl of a ConcurrentCrawler. The
+// crawler itself only holds configuration and state shared by its crawls
+// (clients, robots.txt cache, per-host throttling and circuit breaker).
+type crawlSession struct {
+	*ConcurrentCrawler                            // Configuration of the crawl
+	baseDomain         string                     // Start URL for same-domain filtering
+	jobs               chan CrawlJob              // Channel for distributing jobs
+	results            chan CrawlResult           // Channel for collecting results
+	visited            sync.Map                   // Thread-safe visited URLs tracker
+	mu                 sync.RWMutex               // Mutex for stats and resultsList
+	wg                 sync.WaitGroup             // WaitGroup for worker synchronization
+	ctx                context.Context            // Context for cancellation
+	cancel             context.CancelFunc         // Cancel function
+	stats              CrawlStats                 // Crawling statistics
+	resultsList        []CrawlResult              // Thread-safe results collection
+	activeJobs         int                        // Number of active jobs
+	activeJobsMu       sync.Mutex                 // Mutex for active jobs counter
+	progress           *progress.ProgressReporter // Progress reporter (optional)
+	jobsClosed         bool                       // Flag to track if jobs channel is closed
+	jobsCloseMu        sync.Mutex                 // Mutex for jobs closed flag
+	deferred           []CrawlJob                 // Jobs for known URLs, queued once new URLs are done
+	deferredMu         sync.Mutex                 // Mutex for deferred jobs
+	dirBudget          *dirBudget                 // Per-directory URL budget (optional)
+	sampler            *templateSampler           // Representative URLs per path template (optional)
+	dispatcher         *dispatcher                // Orders the jobs of a deterministic crawl (optional)
+	historical         []string                   // Archived URLs, queued once the crawl ran dry
+	historicalMu       sync.Mutex                 // Mutex for historical URLs
+	pending            map[string]CrawlJob        // Jobs queued or being processed, for checkpoints
+	pendingMu          sync.Mutex                 // Mutex for pending jobs
+	resume             []CrawlResult              // Results the crawl resumes from, including planned ones
+}
+
+// newSession creates the state of a new crawl, cancelled with ctx or when
+// the crawler is cancelled
+func (cc *ConcurrentCrawler) newSession(ctx context.Context) *crawlSession {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(cc.ctx, cancel)
+
+	session := &crawlSession{
+		ConcurrentCrawler: cc,
+		jobs:              make(chan CrawlJob, cc.workers*2), // Buffer for better performance
+		results:           make(chan CrawlResult, cc.workers*2),
+		ctx:               ctx,
+		cancel: func() {
+			stop()
+			cancel()
+		},
+		resultsList: make([]CrawlResult, 0),
+		dirBudget:   newDirBudget(cc.maxPerDir),
+		sampler:     newTemplateSampler(cc.samplePer),
+		historical:  cc.historical,
+		pending:     make(map[string]CrawlJob),
+		resume:      cc.resume,
+	}
+
+	if cc.deterministic {
+		session.dispatcher = newDispatcher()
+	}
+
+	if cc.progressConfig != nil {
+		session.progress = progress.NewProgressReporter(cc.progressConfig)
+	}
+
+	return session
+}
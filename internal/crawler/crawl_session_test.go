@@ -0,0 +1,126 @@
+package crawler
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aoshimash/urlmap/pkg/testsite"
+)
+
+func TestConcurrentCrawler_SequentialCrawls(t *testing.T) {
+	siteA := testsite.Site{Pages: 7, Fanout: 2}.Start()
+	defer siteA.Close()
+	siteB := testsite.Site{Pages: 4, Fanout: 3}.Start()
+	defer siteB.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 10, MaxPerDir: 5})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	// Crawling a site again finds it all again: visited URLs, budgets and
+	// stats start fresh with every crawl
+	for _, tc := range []struct {
+		url   string
+		pages int
+	}{{siteA.URL, 6}, {siteB.URL, 4}, {siteA.URL, 6}} {
+		results, stats, err := cc.CrawlConcurrent(tc.url)
+		if err != nil {
+			t.Fatalf("CrawlConcurrent(%s) failed: %v", tc.url, err)
+		}
+		if len(results) != tc.pages || stats.CrawledURLs != tc.pages {
+			t.Errorf("CrawlConcurrent(%s): %d results, %d crawled; want %d", tc.url, len(results), stats.CrawledURLs, tc.pages)
+		}
+		if got := len(cc.GetResults()); got != tc.pages {
+			t.Errorf("GetResults() returned %d results, want %d", got, tc.pages)
+		}
+	}
+}
+
+func TestConcurrentCrawler_ParallelCrawls(t *testing.T) {
+	sizes := []int{5, 9, 13}
+	servers := make([]string, len(sizes))
+	for i, pages := range sizes {
+		server := testsite.Site{Pages: pages, Fanout: 3}.Start()
+		defer server.Close()
+		servers[i] = server.URL
+	}
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 10})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, stats, err := cc.CrawlConcurrent(servers[i])
+			if err != nil {
+				t.Errorf("CrawlConcurrent(%s) failed: %v", servers[i], err)
+				return
+			}
+			if len(results) != sizes[i] || stats.CrawledURLs != sizes[i] {
+				t.Errorf("CrawlConcurrent(%s): %d results, %d crawled; want %d", servers[i], len(results), stats.CrawledURLs, sizes[i])
+			}
+			for _, result := range results {
+				if !strings.HasPrefix(result.URL, servers[i]) {
+					t.Errorf("crawl of %s returned %s", servers[i], result.URL)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentCrawler_CrawlContext(t *testing.T) {
+	server := testsite.Site{Pages: 9, Fanout: 3}.Start()
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 10})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, _, err := cc.CrawlConcurrentContext(ctx, server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrentContext() failed: %v", err)
+	}
+	if len(results) > 1 {
+		t.Errorf("cancelled crawl returned %d results, want at most 1", len(results))
+	}
+
+	// Cancelling one crawl leaves the crawler usable
+	results, _, err = cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+	if len(results) != 9 {
+		t.Errorf("got %d results, want 9", len(results))
+	}
+}
+
+func TestCrawler_RepeatedCrawls(t *testing.T) {
+	server := testsite.Site{Pages: 7, Fanout: 2}.Start()
+	defer server.Close()
+
+	c, err := New(&Config{MaxDepth: -1, SameDomain: true, SamplePerPattern: 3})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		results, stats, err := c.CrawlRecursive(server.URL)
+		if err != nil {
+			t.Fatalf("CrawlRecursive() failed: %v", err)
+		}
+		if len(results) != 4 || stats.SampleSkipped != 3 {
+			t.Errorf("crawl %d: %d results, %d sample-skipped; want 4 and 3", i+1, len(results), stats.SampleSkipped)
+		}
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/aoshimash/urlmap/internal/client"
 	"github.com/aoshimash/urlmap/internal/detector"
+	"github.com/aoshimash/urlmap/internal/filter"
 	"github.com/aoshimash/urlmap/internal/parser"
 	"github.com/aoshimash/urlmap/internal/progress"
 	"github.com/aoshimash/urlmap/internal/robots"
@@ -17,19 +20,129 @@ import (
 
 // CrawlJob represents a job to be processed by a worker
 type CrawlJob struct {
-	URL   string // URL to crawl
-	Depth int    // Depth of this URL in the crawl tree
+	URL     string // URL to crawl
+	Depth   int    // Depth of this URL in the crawl tree
+	Parent  string // Page the URL was first found on (empty for the seed)
+	Attempt int    // Number of times this URL was rescheduled after throttling
+
+	// attempts holds the request attempts made before the job was rescheduled
+	attempts []client.Attempt
+
+	// LowConfidence is set when the URL was found in an inline script
+	LowConfidence bool
+
+	// Guessed is set when the URL is the guessed next page of its parent
+	Guessed bool
+
+	// Historical is set when the URL comes from a web archive
+	Historical bool
+
+	// Tags are the classifier's tags for the URL
+	Tags []string
 }
 
 // CrawlResult represents the result of crawling a single URL
 type CrawlResult struct {
 	URL          string        // The URL that was crawled
 	Depth        int           // The depth at which this URL was found
+	Parent       string        // Page this URL was first found on (empty for the seed)
+	FinalURL     string        // URL the page was served from after redirects (empty if not redirected)
 	Links        []string      // Links found on this page
 	Error        error         // Error if crawling failed
 	FetchTime    time.Time     // When this URL was crawled
 	ResponseTime time.Duration // Time taken to fetch this URL
 	StatusCode   int           // HTTP status code
+	ETag         string        // ETag response header, if any
+	LastModified string        // Last-Modified response header, if any
+	ContentHash  string        // SHA-256 of the response body (hex)
+	ContentType  string        // Content-Type response header, if any
+	Size         int           // Response body size in bytes
+	Title        string        // Page title (only with ExtractMetadata)
+
+	// Render holds static vs rendered link counts (only with CompareRender)
+	Render *RenderComparison
+
+	// Devices holds the desktop and mobile versions of the page (only with DualUA)
+	Devices *DeviceComparison
+
+	// Attempts holds every request attempt for the page, including attempts
+	// made before throttling rescheduled it
+	Attempts []client.Attempt
+
+	// ScriptLinks holds the links found only in inline scripts, which are
+	// also in Links (only with ExtractScriptURLs)
+	ScriptLinks []string
+
+	// LowConfidence is set when the URL was first found in an inline script
+	// rather than a link, so it may not be a page users can reach
+	LowConfidence bool
+
+	// Historical is set when the URL comes from a web archive
+	// (Config.HistoricalURLs) and is not linked from the live site
+	Historical bool
+
+	// Tags are the tags Config.Classify assigned to the URL
+	Tags []string
+
+	// Hints holds the resource hints of the page (only with ExtractHints)
+	Hints []parser.ResourceHint
+
+	// Icons holds the favicons and manifest of the page (only with ExtractIcons)
+	Icons []parser.Icon
+
+	// OGImage is the og:image URL the page declares (only with ExtractOGImage)
+	OGImage string
+
+	// ServiceWorker is the script URL of the service worker the page
+	// registers (only with ExtractServiceWorkers)
+	ServiceWorker string
+
+	// ExternalDomains are the third-party domains the page references, sorted
+	// (only with ExtractDomains)
+	ExternalDomains []ExternalDomain
+
+	// ExternalLinks are the links of the page to other sites, which are not
+	// in Links (only with ExtractExternalLinks)
+	ExternalLinks []string
+
+	// Language is the page's lang attribute, or the language its text is
+	// written in when LanguageDetected is set (only with DetectLanguage)
+	Language         string
+	LanguageDetected bool
+
+	// Robots holds the directives of the page's robots meta tags and
+	// X-Robots-Tag header, e.g. "noarchive" (only with ExtractRobots)
+	Robots []string
+
+	// Server and ErrorBody describe HTTP error responses: the Server header
+	// and the start of the body (only with ErrorBodySize)
+	Server    string
+	ErrorBody string
+
+	// ErrorKind tells whether the page could not be fetched because of a
+	// DNS, TCP or TLS failure (client.FailureDNS etc.), "" otherwise
+	ErrorKind string
+
+	// BotProtection names the bot protection (e.g. "cloudflare") that
+	// answered with a challenge instead of the page; Error then wraps
+	// ErrBlockedByBotProtection
+	BotProtection string
+
+	// Egress is the region of the egress proxy the page was fetched through
+	// (only with Config.Egress; empty for pages rendered in the browser)
+	Egress string
+
+	// RenderDiagnostics holds the console errors and failed requests of the
+	// rendered page (only with JSConfig.Diagnostics)
+	RenderDiagnostics *client.RenderDiagnostics
+
+	// PartialRender is set when the page's navigation timed out and its links
+	// come from what had rendered by then (only with JSConfig.PartialOnTimeout)
+	PartialRender bool
+
+	throttled  bool          // Server asked to back off (429, or 503 with Retry-After)
+	retryAfter time.Duration // Delay requested by the server, if any
+	nextPage   string        // Guessed next page, also in Links (only with MaxPagination)
 }
 
 // CrawlStats holds statistics about the crawling process
@@ -38,9 +151,25 @@ type CrawlStats struct {
 	CrawledURLs     int           // URLs successfully crawled
 	FailedURLs      int           // URLs that failed to crawl
 	SkippedURLs     int           // URLs skipped (duplicates, depth limit)
+	ThrottleEvents  int           // Responses asking to back off (429/503 with Retry-After)
+	CircuitSkipped  int           // URLs skipped because their host's circuit was open
+	DirLimitSkipped int           // URLs skipped because their directory reached --max-per-dir
+	LocaleSkipped   int           // URLs skipped because they belong to another locale
+	SampleSkipped   int           // URLs skipped because their path template was already sampled
+	KnownDeferred   int           // URLs from the previous run crawled after new ones
+	BotBlocked      int           // URLs answered with a bot protection challenge
+	Historical      int           // Archived URLs not linked from the live site
+	HistoricalGone  int           // Archived URLs that no longer resolve (not in results)
+	ClassifySkipped int           // URLs the classifier rejected
 	MaxDepthReached int           // Maximum depth reached
 	TotalTime       time.Duration // Total crawling time
 	StartTime       time.Time     // When crawling started
+
+	SkippedAlternates []string // Other-locale URLs that were not crawled
+	Frontier          []string // URLs discovered beyond the depth limit, in queue order
+
+	// TemplateCounts holds the estimated URL count per path template (only with SamplePerPattern)
+	TemplateCounts []TemplateCount
 }
 
 // Crawler represents a web crawler instance with recursive capabilities
@@ -48,35 +177,73 @@ type Crawler struct {
 	client         *client.UnifiedClient // Unified client for fetching pages (HTTP + JS)
 	parser         *parser.LinkExtractor // HTML parser for extracting links
 	logger         *slog.Logger          // Logger for structured logging
-	visited        map[string]bool       // Track visited URLs to prevent duplicates
 	maxDepth       int                   // Maximum crawling depth
 	sameDomain     bool                  // Whether to limit crawling to same domain
 	samePathPrefix bool                  // Whether to limit crawling to same path prefix
-	baseDomain     string                // Base domain for same-domain filtering
-	results        []CrawlResult         // Results of crawling operations
-	stats          CrawlStats            // Crawling statistics
+	keepSessionIDs bool                  // Keep session-ID parameters in URLs instead of stripping them
+	hashRoutes     bool                  // Crawl hash-router routes (#/about) as pages of their own
+	metadata       bool                  // Extract page titles
+	extractJSON    bool                  // Follow URLs found in JSON responses
+	scriptLinks    bool                  // Follow URLs that inline scripts navigate to
+	extractHints   bool                  // Record preload/prefetch/preconnect hints
+	extractIcons   bool                  // Record favicons and manifests
+	ogImage        bool                  // Record og:image URLs
+	serviceWorkers bool                  // Record service worker registrations
+	extractDomains bool                  // Record third-party domains of pages
+	externalLinks  bool                  // Record links to other sites
+	detectLanguage bool                  // Record the language of pages
+	robotsMeta     bool                  // Record robots meta tag and X-Robots-Tag directives
+	maxPagination  int                   // Highest page number guessed for paginated URLs (0 = off)
+	errorBodySize  int                   // Bytes of HTTP error bodies kept in results (0 = none)
+	renderOnBlock  bool                  // Retry pages blocked by bot protection with JS rendering
+	deterministic  bool                  // Crawl in a reproducible order without timings
+	egress         *egressPool           // Egress proxies pages are fetched through (optional)
+	mu             sync.RWMutex          // Mutex for results and stats
+	results        []CrawlResult         // Results of the last crawl
+	stats          CrawlStats            // Statistics of the last crawl
 	workers        int                   // Number of concurrent workers
 	robotsChecker  *robots.RobotsChecker // Robots.txt checker (optional)
 	spaDetector    *detector.SPADetector // SPA detection for automatic JS rendering
+	urlFilter      *filter.Filter        // Include/exclude pattern filter (optional)
+	renderFilter   *filter.Filter        // Pages that may be rendered with JavaScript (optional)
+	pageTimeout    time.Duration         // Overall deadline per page (0 = no limit)
+	maxPerDir      int                   // URLs crawled per path directory (0 = no limit)
+	localeScope    *localeScope          // Allowed locale path prefixes (optional)
+	samplePer      int                   // URLs crawled per path template (0 = all)
+	compareRender  bool                  // Compare static and rendered link counts per page
+	dualUA         bool                  // Fetch each page again as a mobile device and compare
+	mobileUA       string                // User agent of the mobile pass
+	known          knownURLs             // URLs from a previous run (optional)
+	historical     []string              // URLs from web archives (optional)
+	classifier     Classifier            // Decides which links are crawled (optional)
+	rewrites       *hostRewrites         // Hosts to fetch discovered URLs from (optional)
 }
 
-// ConcurrentCrawler handles concurrent crawling with worker pool
+// ConcurrentCrawler handles concurrent crawling with worker pool. Each crawl
+// runs in its own crawlSession, so one crawler can run several crawls one
+// after another or at the same time.
 type ConcurrentCrawler struct {
-	*Crawler                                 // Embed the original crawler
-	jobs          chan CrawlJob              // Channel for distributing jobs
-	results       chan CrawlResult           // Channel for collecting results
-	visited       sync.Map                   // Thread-safe visited URLs tracker
-	mu            sync.RWMutex               // Mutex for protecting shared state
-	wg            sync.WaitGroup             // WaitGroup for worker synchronization
-	ctx           context.Context            // Context for cancellation
-	cancel        context.CancelFunc         // Cancel function
-	resultsList   []CrawlResult              // Thread-safe results collection
-	activeJobs    int                        // Number of active jobs
-	activeJobsMu  sync.Mutex                 // Mutex for active jobs counter
-	progress      *progress.ProgressReporter // Progress reporter for tracking crawl progress
-	jobsClosed    bool                       // Flag to track if jobs channel is closed
-	jobsCloseMu   sync.Mutex                 // Mutex for jobs closed flag
-	robotsChecker *robots.RobotsChecker      // Robots.txt checker (optional)
+	*Crawler                             // Embed the original crawler
+	mu             sync.RWMutex          // Mutex for the last session
+	last           *crawlSession         // Session of the crawl started last
+	ctx            context.Context       // Cancelled by Cancel, stopping every crawl
+	cancel         context.CancelFunc    // Cancel function
+	progressConfig *progress.Config      // Progress reporting of each crawl (nil = disabled)
+	robotsChecker  *robots.RobotsChecker // Robots.txt checker (optional)
+	throttle       *hostThrottle         // Per-host back-off requested by servers, shared by crawls
+	maxThrottle    int                   // Times a throttled URL is rescheduled
+	politeness     politenessProfile     // How Crawl-delay and per-host concurrency combine
+	hostSlots      *hostSlots            // Per-host request slots of strict politeness (optional)
+	breaker        *circuitBreaker       // Per-host circuit breaker for failing hosts, shared by crawls
+	resume         []CrawlResult         // Results of the interrupted run being continued (optional)
+	onResult       func(CrawlResult)     // Called with each result as it is collected (optional)
+	discardResults bool                  // Results are only passed to onResult, not returned
+	resumeFrom     *Checkpoint           // Checkpoint of the interrupted crawl being continued (optional)
+
+	onCheckpoint    func(Checkpoint) // Called with the crawl's progress every checkpointEvery (optional)
+	checkpointEvery time.Duration    // Interval between checkpoints
+
+	planner func(context.Context, Gate) []CrawlResult // Decides which pages to resume before crawling (optional)
 }
 
 // Config holds configuration for the crawler
@@ -85,13 +252,182 @@ type Config struct {
 	SameDomain     bool                  // Whether to limit crawling to same domain
 	SamePathPrefix bool                  // Whether to limit crawling to same path prefix as start URL
 	UserAgent      string                // User agent to use for requests
-	Timeout        time.Duration         // Request timeout
+	Timeout        time.Duration         // Request timeout (used when JSConfig is nil)
+	PageTimeout    time.Duration         // Overall deadline for fetching a page, including retries (0 = no limit)
+	MaxPerDir      int                   // Maximum URLs crawled under each path directory (0 = no limit)
+	LangPrefixes   []string              // Only crawl these locale path prefixes, e.g. "en", "ja" (empty = all)
 	Logger         *slog.Logger          // Logger instance
 	Workers        int                   // Number of concurrent workers
 	ShowProgress   bool                  // Whether to show progress indicators
 	ProgressConfig *progress.Config      // Progress reporting configuration
 	JSConfig       *client.UnifiedConfig // JavaScript rendering configuration
 	RespectRobots  bool                  // Whether to respect robots.txt rules
+	URLFilter      *filter.Filter        // Include/exclude patterns applied to discovered links
+	RenderFilter   *filter.Filter        // Pages always (include) or never (exclude) rendered with JavaScript (optional)
+	CompareRender  bool                  // Fetch each page via both HTTP and JS rendering and compare link counts
+	DualUA         bool                  // Fetch each page with desktop and mobile user agents and compare
+	DetectorConfig *detector.Config      // SPA detection thresholds and custom signatures (optional)
+	KnownURLs      []string              // URLs from a previous run, crawled after newly discovered ones
+	HostRewrites   map[string]string     // Fetch URLs on these hosts from the mapped host (results keep the original URLs)
+
+	// MobileUserAgent is the user agent of the DualUA mobile pass
+	// (empty = client.DefaultMobileUserAgent)
+	MobileUserAgent string
+
+	// RobotsChecker is the robots.txt cache used with RespectRobots, shared
+	// with other crawls (optional; each crawl gets its own by default)
+	RobotsChecker *robots.RobotsChecker
+
+	// SamplePerPattern crawls only this many URLs per path template, e.g.
+	// /products/{id}, and counts the rest (0 = crawl everything)
+	SamplePerPattern int
+
+	// KeepSessionIDs keeps session-ID parameters such as jsessionid and
+	// PHPSESSID in discovered URLs instead of stripping them
+	KeepSessionIDs bool
+
+	// HashRoutes crawls the client-side routes of hash routers, such as
+	// https://example.com/#/about, as pages of their own: their fragment is
+	// kept, they are deduplicated on the full route and always rendered.
+	// It needs JavaScript rendering and is ignored without it.
+	HashRoutes bool
+
+	// ExtractMetadata records the title of each HTML page in its result
+	ExtractMetadata bool
+
+	// ExtractJSON follows URLs found in JSON responses, e.g. API endpoints.
+	// Links in XML responses (sitemaps, feeds) are always followed.
+	ExtractJSON bool
+
+	// ExtractScriptURLs follows URLs that inline scripts navigate to with
+	// window.location or router.push. Pages only found this way are marked
+	// LowConfidence, as the script may never run that code.
+	ExtractScriptURLs bool
+
+	// ExtractHints records the preload, prefetch and preconnect hints of each
+	// HTML page in its result, see SummarizeHints
+	ExtractHints bool
+
+	// ExtractIcons records the favicons and web app manifest of each HTML
+	// page in its result, see CollectIcons
+	ExtractIcons bool
+
+	// ExtractOGImage records the og:image of each HTML page in its result,
+	// see CollectOGImages
+	ExtractOGImage bool
+
+	// ExtractServiceWorkers records the service worker each HTML page
+	// registers in its result, see CollectServiceWorkers. Registrations in
+	// external scripts are only found on pages rendered with
+	// client.JSConfig.DetectServiceWorkers.
+	ExtractServiceWorkers bool
+
+	// ExtractDomains records the third-party domains the links, scripts,
+	// stylesheets, images and frames of each HTML page point to in its
+	// result, see SummarizeExternalDomains
+	ExtractDomains bool
+
+	// ExtractExternalLinks records the links of each HTML page to other
+	// sites in its result; Links only holds links the crawl may follow
+	ExtractExternalLinks bool
+
+	// DetectLanguage records the language of each HTML page in its result:
+	// the lang attribute of <html> or, without one, a guess from its text
+	DetectLanguage bool
+
+	// ExtractRobots records the directives of the robots meta tags and the
+	// X-Robots-Tag header of each page in its result
+	ExtractRobots bool
+
+	// MaxPagination queues the next page of paginated URLs (?page=N, /page/N/)
+	// even when the page does not link to it, e.g. because the "next" link is
+	// rendered client-side, up to this page number (0 = off). A guessed page
+	// that does not exist ends the series and is not reported as a failure.
+	MaxPagination int
+
+	// ErrorBodySize is how many bytes of the body of HTTP error responses
+	// are kept in results, to tell bot protection, rate limits and server
+	// errors apart (0 = none, e.g. client.DefaultErrorBodySize)
+	ErrorBodySize int
+
+	// RenderBlocked fetches pages answered with a bot protection challenge
+	// again with JavaScript rendering, which may pass the challenge. It
+	// starts a browser even when rendering is otherwise disabled.
+	RenderBlocked bool
+
+	// Deterministic crawls one page at a time in a reproducible order and
+	// leaves timings out of results, so runs against an unchanged site give
+	// identical results, e.g. for regression testing (concurrent crawler
+	// only; Workers is ignored)
+	Deterministic bool
+
+	// Egress routes HTTP requests through proxies tagged by region, so
+	// geo-dependent sites are mapped from the intended locale. Region selects
+	// the proxies of one region; without it pages rotate across all regions.
+	// JavaScript rendering does not go through egress proxies.
+	Egress []client.Egress
+	Region string
+
+	// HistoricalURLs holds URLs of the site found in web archives. Those the
+	// crawl did not reach are fetched once it ran dry: results of the ones
+	// that still resolve are tagged Historical, the others are left out
+	// (concurrent crawler only).
+	HistoricalURLs []string
+
+	// Classify decides which discovered links are crawled and tags them
+	// (concurrent crawler only, optional)
+	Classify Classifier
+
+	// MaxThrottleRetries is how often a URL answered with 429/503 is rescheduled
+	// (0 = set by Politeness, negative = never)
+	MaxThrottleRetries int
+
+	// Politeness is one of PolitenessProfiles and decides how the robots.txt
+	// Crawl-delay, requests in flight per host and rescheduling of throttled
+	// URLs combine (empty = PolitenessNormal; concurrent crawler only).
+	// PolitenessStrict fetches robots.txt even without RespectRobots.
+	Politeness string
+
+	// BreakerThreshold is the number of consecutive connection failures after
+	// which a host is skipped (0 = DefaultBreakerThreshold, negative = disabled)
+	BreakerThreshold int
+	// BreakerCoolOff is how long a failing host is skipped (0 = DefaultBreakerCoolOff)
+	BreakerCoolOff time.Duration
+
+	// Resume holds the results of an interrupted run of the same crawl. Their
+	// pages are not fetched again (failed ones are retried) and the links they
+	// found are queued instead of the seed (concurrent crawler only).
+	Resume []CrawlResult
+
+	// PlanResume, if set, is called when the crawl starts, before any page is
+	// fetched, to decide which pages of an earlier run need not be fetched
+	// again, e.g. by revalidating them. The requests it sends through gate
+	// are held back like those of the crawl; the results it returns are
+	// resumed as with Resume (concurrent crawler only, optional).
+	PlanResume func(ctx context.Context, gate Gate) []CrawlResult
+
+	// OnResult is called with each result as soon as it is collected, e.g. to
+	// stream results to a file (concurrent crawler only, optional)
+	OnResult func(CrawlResult)
+
+	// DiscardResults passes results only to OnResult instead of also
+	// returning them, so a crawl whose results are streamed does not hold
+	// every page in memory (concurrent crawler only; checkpoints then carry
+	// no results)
+	DiscardResults bool
+
+	// OnCheckpoint is called with the progress of the crawl every
+	// CheckpointInterval (0 = DefaultCheckpointInterval) and once more when it
+	// stops, e.g. to save it so a killed crawl can be resumed with ResumeFrom
+	// (concurrent crawler only, optional)
+	OnCheckpoint       func(Checkpoint)
+	CheckpointInterval time.Duration
+
+	// ResumeFrom continues the interrupted crawl whose last checkpoint is
+	// given, where it left off: its pending URLs are queued, its results and
+	// statistics are carried over and failed pages are not retried. It takes
+	// precedence over Resume (concurrent crawler only, optional).
+	ResumeFrom *Checkpoint
 }
 
 // DefaultConfig returns a default crawler configuration
@@ -124,79 +460,165 @@ func New(config *Config) (*Crawler, error) {
 	if unifiedConfig == nil {
 		unifiedConfig = &client.UnifiedConfig{
 			UserAgent: config.UserAgent,
+			Timeout:   config.Timeout,
 			JSConfig:  &client.JSConfig{Enabled: false}, // Default to HTTP only
 		}
 	}
 
+	// Render comparison, rendering blocked pages and pages selected by the
+	// render filter need a browser even when rendering is disabled
+	if config.CompareRender || config.RenderBlocked || renderOnly(config.RenderFilter) {
+		unifiedConfig.CompareRender = true
+		if unifiedConfig.JSConfig == nil || unifiedConfig.JSConfig.BrowserType == "" {
+			jsConfig := client.DefaultJSConfig()
+			jsConfig.UserAgent = unifiedConfig.UserAgent
+			unifiedConfig.JSConfig = jsConfig
+		}
+	}
+
+	egress, err := newEgressPool(config.Egress, config.Region)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create unified client
 	unifiedClient, err := client.NewUnifiedClient(unifiedConfig, config.Logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create unified client: %w", err)
 	}
 
-	// Create link extractor
+	// Create link extractor. Hash routes are only different pages in a browser.
 	linkExtractor := parser.NewLinkExtractor(config.Logger)
+	hashRoutes := config.HashRoutes && unifiedClient.GetJSClient() != nil
+	linkExtractor.SetHashRoutes(hashRoutes)
 
 	workers := config.Workers
 	if workers <= 0 {
 		workers = 10 // Default number of workers
 	}
+	if config.Deterministic {
+		workers = 1
+	}
 
 	// Initialize SPA detector if auto-detection is enabled
 	var spaDetector *detector.SPADetector
 	if unifiedConfig.JSConfig != nil && (unifiedConfig.JSConfig.AutoDetect || unifiedConfig.JSConfig.StrictMode) {
-		spaDetector = detector.NewSPADetector(config.Logger)
+		detectorConfig := detector.Config{}
+		if config.DetectorConfig != nil {
+			detectorConfig = *config.DetectorConfig
+		}
+		// The JS threshold applies unless the detector config sets its own
+		if detectorConfig.Threshold == 0 {
+			detectorConfig.Threshold = unifiedConfig.JSConfig.Threshold
+		}
+		spaDetector, err = detector.NewSPADetectorWithConfig(config.Logger, &detectorConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid detector configuration: %w", err)
+		}
+	}
+
+	mobileUA := config.MobileUserAgent
+	if mobileUA == "" {
+		mobileUA = client.DefaultMobileUserAgent
 	}
 
 	return &Crawler{
 		client:         unifiedClient,
 		parser:         linkExtractor,
 		logger:         config.Logger,
-		visited:        make(map[string]bool),
 		maxDepth:       config.MaxDepth,
 		sameDomain:     config.SameDomain,
 		samePathPrefix: config.SamePathPrefix,
-		baseDomain:     "", // Initialize baseDomain
+		keepSessionIDs: config.KeepSessionIDs,
+		hashRoutes:     hashRoutes,
+		metadata:       config.ExtractMetadata,
+		extractJSON:    config.ExtractJSON,
+		scriptLinks:    config.ExtractScriptURLs,
+		extractHints:   config.ExtractHints,
+		extractIcons:   config.ExtractIcons,
+		ogImage:        config.ExtractOGImage,
+		serviceWorkers: config.ExtractServiceWorkers,
+		extractDomains: config.ExtractDomains,
+		externalLinks:  config.ExtractExternalLinks,
+		detectLanguage: config.DetectLanguage,
+		robotsMeta:     config.ExtractRobots,
+		maxPagination:  config.MaxPagination,
+		errorBodySize:  config.ErrorBodySize,
+		renderOnBlock:  config.RenderBlocked,
+		deterministic:  config.Deterministic,
+		egress:         egress,
 		results:        make([]CrawlResult, 0),
 		stats:          CrawlStats{},
 		workers:        workers,
 		spaDetector:    spaDetector,
+		urlFilter:      config.URLFilter,
+		renderFilter:   config.RenderFilter,
+		pageTimeout:    config.PageTimeout,
+		maxPerDir:      config.MaxPerDir,
+		localeScope:    newLocaleScope(config.LangPrefixes),
+		samplePer:      config.SamplePerPattern,
+		compareRender:  config.CompareRender,
+		dualUA:         config.DualUA,
+		mobileUA:       mobileUA,
+		known:          newKnownURLs(config.KnownURLs),
+		historical:     config.HistoricalURLs,
+		classifier:     config.Classify,
+		rewrites:       newHostRewrites(config.HostRewrites),
 	}, nil
 }
 
 // CrawlRecursive performs recursive crawling starting from the given URL
 func (c *Crawler) CrawlRecursive(startURL string) ([]CrawlResult, *CrawlStats, error) {
 	c.logger.Info("Starting recursive crawl", "start_url", startURL, "max_depth", c.maxDepth)
-	c.stats.StartTime = time.Now()
+
+	// State of this crawl, so the crawler can be reused
+	stats := CrawlStats{StartTime: time.Now()}
+	results := make([]CrawlResult, 0)
+	visited := make(map[string]bool)
+	dirBudget := newDirBudget(c.maxPerDir)
+	sampler := newTemplateSampler(c.samplePer)
+	var baseDomain string
 
 	// Validate and normalize the start URL
 	if !url.IsValidURL(startURL) {
-		return nil, &c.stats, fmt.Errorf("invalid start URL: %s", startURL)
+		return nil, &stats, fmt.Errorf("invalid start URL: %s", startURL)
 	}
 
-	normalizedURL, err := url.NormalizeURL(startURL)
+	normalizedURL, err := c.normalizeURL(startURL)
 	if err != nil {
-		return nil, &c.stats, fmt.Errorf("failed to normalize start URL: %w", err)
+		return nil, &stats, fmt.Errorf("failed to normalize start URL: %w", err)
 	}
+	normalizedURL = c.stripSessionIDs(normalizedURL)
 
 	// Extract base domain for same-domain filtering
 	if c.sameDomain {
-		c.baseDomain = normalizedURL // Store the full URL instead of just the domain
-		c.logger.Debug("Same-domain filtering enabled", "base_url", c.baseDomain)
+		baseDomain = normalizedURL // Store the full URL instead of just the domain
+		c.logger.Debug("Same-domain filtering enabled", "base_url", baseDomain)
 	}
 
 	// Initialize crawling queue with the start URL
 	type queueItem struct {
-		url   string
-		depth int
+		url           string
+		depth         int
+		parent        string
+		lowConfidence bool
+		guessed       bool
 	}
 
 	queue := []queueItem{{url: normalizedURL, depth: 0}}
-	c.visited[normalizedURL] = true
-	c.stats.TotalURLs = 1
+	visited[normalizedURL] = true
+	stats.TotalURLs = 1
+	sampler.Allow(normalizedURL)
+
+	// URLs known from a previous run are crawled after all new ones
+	var deferred []queueItem
 
 	// Process queue until empty
-	for len(queue) > 0 {
+	for len(queue) > 0 || len(deferred) > 0 {
+		if len(queue) == 0 {
+			queue, deferred = deferred, nil
+		}
+
 		// Dequeue the next URL
 		current := queue[0]
 		queue = queue[1:]
@@ -206,26 +628,39 @@ func (c *Crawler) CrawlRecursive(startURL string) ([]CrawlResult, *CrawlStats, e
 		// Check depth limit
 		if c.maxDepth >= 0 && current.depth > c.maxDepth {
 			c.logger.Debug("Skipping URL due to depth limit", "url", current.url, "depth", current.depth)
-			c.stats.SkippedURLs++
+			stats.SkippedURLs++
+			stats.Frontier = append(stats.Frontier, current.url)
 			continue
 		}
 
 		// Crawl the current URL
 		result := c.crawlSingle(current.url, current.depth)
-		c.results = append(c.results, result)
+		result.Parent = current.parent
+		result.LowConfidence = current.lowConfidence
+
+		// A guessed next page that does not exist only ends its pagination
+		if current.guessed && missingPage(result) {
+			c.logger.Debug("Skipping missing guessed page", "url", current.url, "status", result.StatusCode)
+			stats.SkippedURLs++
+			continue
+		}
+		results = append(results, result)
 
 		// Update statistics
 		if result.Error != nil {
-			c.stats.FailedURLs++
-			c.logger.Warn("Failed to crawl URL", "url", current.url, "error", result.Error)
+			stats.FailedURLs++
+			c.logger.Warn("Failed to crawl URL", "url", current.url, "error", result.Error, "kind", result.ErrorKind)
+			if result.BotProtection != "" {
+				stats.BotBlocked++
+			}
 		} else {
-			c.stats.CrawledURLs++
+			stats.CrawledURLs++
 			c.logger.Info("Successfully crawled URL", "url", current.url, "links_found", len(result.Links))
 
 			// Add new links to queue
 			for _, link := range result.Links {
 				// Skip if already visited
-				if c.visited[link] {
+				if visited[link] {
 					continue
 				}
 
@@ -233,14 +668,14 @@ func (c *Crawler) CrawlRecursive(startURL string) ([]CrawlResult, *CrawlStats, e
 				if c.sameDomain {
 					if c.samePathPrefix {
 						// Use path prefix filtering (includes domain check)
-						isSame, err := url.IsSamePathPrefix(c.baseDomain, link)
+						isSame, err := url.IsSamePathPrefix(baseDomain, link)
 						if err != nil || !isSame {
-							c.logger.Debug("Skipping link outside path prefix", "link", link, "base", c.baseDomain)
+							c.logger.Debug("Skipping link outside path prefix", "link", link, "base", baseDomain)
 							continue
 						}
 					} else {
 						// Use domain-only filtering
-						isSame, err := url.IsSameDomain(c.baseDomain, link)
+						isSame, err := url.IsSameDomain(baseDomain, link)
 						if err != nil || !isSame {
 							c.logger.Debug("Skipping external domain link", "link", link)
 							continue
@@ -248,31 +683,84 @@ func (c *Crawler) CrawlRecursive(startURL string) ([]CrawlResult, *CrawlStats, e
 					}
 				}
 
+				// Apply include/exclude patterns
+				if !c.urlFilter.Allow(link) {
+					c.logger.Debug("Skipping link excluded by filter", "link", link)
+					stats.SkippedURLs++
+					continue
+				}
+
+				// Record other locales as alternates instead of crawling them
+				if !c.localeScope.Allow(link) {
+					c.logger.Debug("Skipping link in other locale", "link", link)
+					stats.SkippedURLs++
+					stats.LocaleSkipped++
+					stats.SkippedAlternates = append(stats.SkippedAlternates, link)
+					continue
+				}
+
+				// Apply the per-directory budget
+				if !dirBudget.Allow(link) {
+					c.logger.Debug("Skipping link over directory budget", "link", link)
+					stats.SkippedURLs++
+					stats.DirLimitSkipped++
+					continue
+				}
+
+				// Crawl only a sample of each path template
+				if !sampler.Allow(link) {
+					c.logger.Debug("Skipping link over template sample", "link", link)
+					stats.SkippedURLs++
+					stats.SampleSkipped++
+					continue
+				}
+
 				// Add to queue and mark as visited
-				queue = append(queue, queueItem{url: link, depth: current.depth + 1})
-				c.visited[link] = true
-				c.stats.TotalURLs++
+				item := queueItem{url: link, depth: current.depth + 1, parent: current.url,
+					lowConfidence: slices.Contains(result.ScriptLinks, link), guessed: link == result.nextPage}
+				if c.known.Contains(link) {
+					deferred = append(deferred, item)
+					stats.KnownDeferred++
+				} else {
+					queue = append(queue, item)
+				}
+				visited[link] = true
+				stats.TotalURLs++
 
 				c.logger.Debug("Added URL to queue", "url", link, "depth", current.depth+1)
 			}
 		}
 
 		// Update max depth reached
-		if current.depth > c.stats.MaxDepthReached {
-			c.stats.MaxDepthReached = current.depth
+		if current.depth > stats.MaxDepthReached {
+			stats.MaxDepthReached = current.depth
 		}
 	}
 
-	c.stats.TotalTime = time.Since(c.stats.StartTime)
+	stats.TotalTime = time.Since(stats.StartTime)
+	stats.TemplateCounts = sampler.Counts()
 	c.logger.Info("Crawling completed",
-		"total_urls", c.stats.TotalURLs,
-		"crawled_urls", c.stats.CrawledURLs,
-		"failed_urls", c.stats.FailedURLs,
-		"skipped_urls", c.stats.SkippedURLs,
-		"max_depth_reached", c.stats.MaxDepthReached,
-		"total_time", c.stats.TotalTime)
+		"total_urls", stats.TotalURLs,
+		"crawled_urls", stats.CrawledURLs,
+		"failed_urls", stats.FailedURLs,
+		"skipped_urls", stats.SkippedURLs,
+		"max_depth_reached", stats.MaxDepthReached,
+		"total_time", stats.TotalTime)
+
+	c.mu.Lock()
+	c.results, c.stats = results, stats
+	c.mu.Unlock()
+
+	return results, &stats, nil
+}
 
-	return c.results, &c.stats, nil
+// pageContext derives the context for fetching a single page,
+// applying the per-page deadline if one is configured
+func (c *Crawler) pageContext(parent context.Context) (context.Context, context.CancelFunc) {
+	if c.pageTimeout > 0 {
+		return context.WithTimeout(parent, c.pageTimeout)
+	}
+	return context.WithCancel(parent)
 }
 
 // crawlSingle crawls a single URL and returns the result
@@ -286,20 +774,27 @@ func (c *Crawler) crawlSingle(targetURL string, depth int) CrawlResult {
 	c.logger.Debug("Fetching URL", "url", targetURL, "depth", depth)
 	startTime := time.Now()
 
+	ctx, cancel := c.pageContext(context.Background())
+	defer cancel()
+	attempts := &client.AttemptLog{}
+	ctx = client.WithAttemptLog(ctx, attempts)
+	ctx, result.Egress = c.egress.route(ctx)
+
+	// Fetch from the rewritten host, if any, while reporting the original URL
+	fetchURL := c.rewrites.FetchURL(targetURL)
+
 	// Check if we should use JavaScript rendering for this URL
-	useJS := false
+	mode := c.renderMode(targetURL)
+	useJS := mode == renderAlways
 	var err error
-	if c.spaDetector != nil {
+	if c.spaDetector != nil && mode == renderDefault {
 		// First get the page with HTTP to check if it's a SPA
-		httpClient := c.client.GetHTTPClient()
-		if httpClient != nil {
-			httpResponse, httpErr := httpClient.Get(context.Background(), targetURL)
-			if httpErr == nil {
-				htmlContent := httpResponse.String()
-				useJS, err = c.shouldUseJSRendering(targetURL, htmlContent)
-				if err != nil {
-					c.logger.Warn("Failed to determine JS rendering need", "url", targetURL, "error", err)
-				}
+		httpResponse, httpErr := c.client.FetchHTTP(ctx, fetchURL)
+		if httpErr == nil {
+			htmlContent := httpResponse.String()
+			useJS, err = c.shouldUseJSRendering(targetURL, htmlContent)
+			if err != nil {
+				c.logger.Warn("Failed to determine JS rendering need", "url", targetURL, "error", err)
 			}
 		}
 	}
@@ -307,44 +802,64 @@ func (c *Crawler) crawlSingle(targetURL string, depth int) CrawlResult {
 	// Fetch the page with appropriate method
 	var response client.UnifiedResponse
 	if useJS {
-		jsClient := c.client.GetJSClient()
-		if jsClient != nil {
+		if c.client.GetJSClient() != nil {
 			c.logger.Info("Using JavaScript rendering", "url", targetURL)
-			response, err = jsClient.Get(context.Background(), targetURL)
+			response, err = c.client.FetchJS(ctx, fetchURL)
 		} else {
 			c.logger.Warn("JavaScript client not available, falling back to HTTP", "url", targetURL)
-			response, err = c.client.Get(context.Background(), targetURL)
+			response, err = c.client.Get(ctx, fetchURL)
 		}
+	} else if mode == renderNever {
+		response, err = c.client.FetchHTTP(ctx, fetchURL)
 	} else {
-		response, err = c.client.Get(context.Background(), targetURL)
+		response, err = c.client.Get(ctx, fetchURL)
 	}
 	result.ResponseTime = time.Since(startTime)
+	result.Attempts = attempts.Attempts()
 
 	if err != nil {
 		result.Error = fmt.Errorf("failed to fetch URL: %w", err)
+		result.ErrorKind = client.FailureKind(err)
+		recordRendering(&result, nil, err)
 		return result
 	}
 
-	result.StatusCode = response.StatusCode()
+	meta := newResponseMeta(response, c.errorBodySize)
+	response, meta = c.renderBlocked(ctx, fetchURL, response, meta, useJS)
+	recordRendering(&result, response, nil)
+	result.StatusCode = meta.statusCode
+	recordRedirect(&result, c.stripSessionIDs(c.rewrites.OriginalURL(meta.finalURL)))
+	recordContent(&result, meta)
 
 	// Check for successful response
 	if response.StatusCode() < 200 || response.StatusCode() >= 400 {
-		result.Error = fmt.Errorf("HTTP error: %d", response.StatusCode())
+		result.Error = httpError(meta)
+		recordErrorResponse(&result, meta)
 		return result
 	}
 
 	// Extract links from the page
-	htmlContent := response.String()
-	if c.sameDomain {
-		result.Links, err = c.parser.ExtractSameDomainLinks(targetURL, htmlContent)
-	} else {
-		result.Links, err = c.parser.ExtractLinks(targetURL, htmlContent)
-	}
-
+	recordValidators(&result, meta)
+	result.Links, err = c.extractResponseLinks(targetURL, meta.contentType, response.String())
 	if err != nil {
-		result.Error = fmt.Errorf("failed to extract links: %w", err)
+		result.Error = err
 		return result
 	}
+	c.recordShadowLinks(&result, response)
+	c.recordDeclaredRoutes(&result, response)
+	c.recordScriptLinks(&result, meta.contentType, response.String())
+	c.recordHints(&result, meta.contentType, response.String())
+	c.recordIcons(&result, meta.contentType, response.String())
+	c.recordOGImage(&result, meta.contentType, response.String())
+	c.recordServiceWorker(&result, meta.contentType, response)
+	c.recordExternalDomains(&result, meta.contentType, response.String())
+	c.recordExternalLinks(&result, meta.contentType, response.String())
+	c.recordLanguage(&result, meta.contentType, response.String())
+	c.recordRobotsDirectives(&result, meta, response.String())
+	c.recordNextPage(&result)
+	if c.metadata {
+		result.Title = parser.ExtractTitle(response.String())
+	}
 
 	c.logger.Debug("Extracted links", "url", targetURL, "link_count", len(result.Links))
 	return result
@@ -388,32 +903,40 @@ func (c *Crawler) shouldUseJSRendering(url, htmlContent string) (bool, error) {
 			}
 		}
 
-		return result.IsSPA && result.Confidence >= jsConfig.Threshold, nil
+		return result.IsSPA && result.Confidence >= c.spaDetector.Threshold(), nil
 	}
 
 	return false, nil
 }
 
-// GetResults returns the crawling results
+// GetResults returns the results of the last crawl
 func (c *Crawler) GetResults() []CrawlResult {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.results
 }
 
-// GetStats returns the crawling statistics
+// GetStats returns the statistics of the last crawl
 func (c *Crawler) GetStats() *CrawlStats {
-	return &c.stats
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	statsCopy := c.stats
+	return &statsCopy
 }
 
-// Reset clears the crawler state for a new crawling session
+// Reset clears the results and statistics of the last crawl. Every crawl
+// starts with fresh state, so Reset is not needed between crawls.
 func (c *Crawler) Reset() {
-	c.visited = make(map[string]bool)
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.results = make([]CrawlResult, 0)
 	c.stats = CrawlStats{}
-	c.baseDomain = ""
 }
 
 // GetAllURLs returns all discovered URLs (both crawled and failed)
 func (c *Crawler) GetAllURLs() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	urls := make([]string, 0, len(c.results))
 	for _, result := range c.results {
 		urls = append(urls, result.URL)
@@ -423,6 +946,8 @@ func (c *Crawler) GetAllURLs() []string {
 
 // GetSuccessfulURLs returns only successfully crawled URLs
 func (c *Crawler) GetSuccessfulURLs() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	urls := make([]string, 0)
 	for _, result := range c.results {
 		if result.Error == nil {
@@ -439,29 +964,78 @@ func NewConcurrentCrawler(config *Config) (*ConcurrentCrawler, error) {
 		return nil, err
 	}
 
+	politeness := politenessProfiles[PolitenessNormal]
+	if config != nil && config.Politeness != "" {
+		profile, ok := politenessProfiles[config.Politeness]
+		if !ok {
+			return nil, fmt.Errorf("unknown politeness profile %q", config.Politeness)
+		}
+		politeness = profile
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	cc := &ConcurrentCrawler{
-		Crawler:     crawler,
-		jobs:        make(chan CrawlJob, crawler.workers*2), // Buffer for better performance
-		results:     make(chan CrawlResult, crawler.workers*2),
-		visited:     sync.Map{},
-		ctx:         ctx,
-		cancel:      cancel,
-		resultsList: make([]CrawlResult, 0),
+		Crawler:    crawler,
+		ctx:        ctx,
+		cancel:     cancel,
+		throttle:   newHostThrottle(),
+		politeness: politeness,
+	}
+
+	if cc.politeness.hostConcurrency > 0 {
+		cc.hostSlots = newHostSlots(cc.politeness.hostConcurrency)
+	}
+
+	cc.maxThrottle = cc.politeness.throttleRetries
+	if config != nil && config.MaxThrottleRetries != 0 {
+		cc.maxThrottle = max(config.MaxThrottleRetries, 0)
+	}
+
+	breakerThreshold, breakerCoolOff := DefaultBreakerThreshold, DefaultBreakerCoolOff
+	if config != nil {
+		if config.BreakerThreshold != 0 {
+			breakerThreshold = config.BreakerThreshold
+		}
+		if config.BreakerCoolOff > 0 {
+			breakerCoolOff = config.BreakerCoolOff
+		}
+	}
+	if cc.deterministic {
+		breakerThreshold = -1
+	}
+	cc.breaker = newCircuitBreaker(breakerThreshold, breakerCoolOff)
+
+	if config != nil {
+		cc.resume = config.Resume
+		cc.planner = config.PlanResume
+		cc.onResult = config.OnResult
+		cc.discardResults = config.DiscardResults
+		cc.onCheckpoint = config.OnCheckpoint
+		cc.checkpointEvery = config.CheckpointInterval
+		if config.ResumeFrom != nil {
+			cc.resumeFrom = config.ResumeFrom
+			cc.resume = config.ResumeFrom.Results
+		}
+	}
+	if cc.checkpointEvery <= 0 {
+		cc.checkpointEvery = DefaultCheckpointInterval
 	}
 
 	// Initialize robots checker if enabled
-	if config != nil && config.RespectRobots {
+	if config != nil && (config.RespectRobots || config.Politeness == PolitenessStrict) {
 		userAgent := config.UserAgent
 		if userAgent == "" {
 			userAgent = "urlmap/1.0"
 		}
-		cc.robotsChecker = robots.NewRobotsChecker(userAgent, config.Logger)
+		cc.robotsChecker = config.RobotsChecker
+		if cc.robotsChecker == nil {
+			cc.robotsChecker = robots.NewRobotsChecker(userAgent, config.Logger)
+		}
 		cc.Crawler.robotsChecker = cc.robotsChecker
 	}
 
-	// Setup progress reporter if enabled
+	// Setup progress reporting if enabled; each crawl gets its own reporter
 	if config != nil && config.ShowProgress {
 		progressConfig := config.ProgressConfig
 		if progressConfig == nil {
@@ -471,232 +1045,447 @@ func NewConcurrentCrawler(config *Config) (*ConcurrentCrawler, error) {
 		if config.Logger != nil {
 			progressConfig.Logger = config.Logger
 		}
-		cc.progress = progress.NewProgressReporter(progressConfig)
+		cc.progressConfig = progressConfig
 	}
 
 	return cc, nil
 }
 
-// CrawlConcurrent performs concurrent crawling starting from the given URL
+// CrawlConcurrent performs concurrent crawling starting from the given URL.
+// Every call is a separate crawl with its own visited URLs, results and
+// statistics, so calls may run one after another or at the same time.
 func (cc *ConcurrentCrawler) CrawlConcurrent(startURL string) ([]CrawlResult, *CrawlStats, error) {
-	cc.logger.Info("Starting concurrent crawl", "start_url", startURL, "max_depth", cc.maxDepth, "workers", cc.workers)
+	return cc.CrawlConcurrentContext(context.Background(), startURL)
+}
+
+// CrawlConcurrentContext is CrawlConcurrent stopping gracefully when ctx is
+// cancelled, so one of several crawls can be stopped without the others
+func (cc *ConcurrentCrawler) CrawlConcurrentContext(ctx context.Context, startURL string) ([]CrawlResult, *CrawlStats, error) {
+	session := cc.newSession(ctx)
+	defer session.cancel()
+
 	cc.mu.Lock()
-	cc.stats.StartTime = time.Now()
+	cc.last = session
 	cc.mu.Unlock()
 
+	return session.crawl(startURL)
+}
+
+// crawl runs the session's crawl starting from the given URL
+func (s *crawlSession) crawl(startURL string) ([]CrawlResult, *CrawlStats, error) {
+	s.logger.Info("Starting concurrent crawl", "start_url", startURL, "max_depth", s.maxDepth, "workers", s.workers)
+	s.mu.Lock()
+	s.stats.StartTime = time.Now()
+	s.mu.Unlock()
+
 	// Start progress reporter if enabled
-	if cc.progress != nil {
-		cc.progress.Start()
-		defer cc.progress.Stop()
+	if s.progress != nil {
+		s.progress.Start()
+		defer s.progress.Stop()
 	}
 
 	// Validate and normalize the start URL
 	if !url.IsValidURL(startURL) {
-		return nil, &cc.stats, fmt.Errorf("invalid start URL: %s", startURL)
+		return nil, &s.stats, fmt.Errorf("invalid start URL: %s", startURL)
 	}
 
-	normalizedURL, err := url.NormalizeURL(startURL)
+	normalizedURL, err := s.normalizeURL(startURL)
 	if err != nil {
-		return nil, &cc.stats, fmt.Errorf("failed to normalize start URL: %w", err)
+		return nil, &s.stats, fmt.Errorf("failed to normalize start URL: %w", err)
 	}
+	normalizedURL = s.stripSessionIDs(normalizedURL)
 
 	// Extract base domain for same-domain filtering
-	if cc.sameDomain {
-		cc.baseDomain = normalizedURL // Store the full URL instead of just the domain
-		cc.logger.Debug("Same-domain filtering enabled", "base_url", cc.baseDomain)
+	if s.sameDomain {
+		s.baseDomain = normalizedURL // Store the full URL instead of just the domain
+		s.logger.Debug("Same-domain filtering enabled", "base_url", s.baseDomain)
 	}
 
-	// Start workers
-	for i := 0; i < cc.workers; i++ {
-		cc.wg.Add(1)
-		go cc.worker(i)
+	// Start workers, fed by the dispatcher in a deterministic crawl
+	if s.dispatcher != nil {
+		go s.dispatch()
+	}
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.worker(i)
 	}
 
 	// Start result collector
-	go cc.resultCollector()
+	collected := make(chan struct{})
+	go func() {
+		s.resultCollector()
+		close(collected)
+	}()
 
 	// Start progress updater if progress reporting is enabled
-	if cc.progress != nil {
-		go cc.progressUpdater()
+	if s.progress != nil {
+		go s.progressUpdater()
 	}
 
-	// Add the start URL to the job queue
-	cc.visited.Store(normalizedURL, true)
-	cc.mu.Lock()
-	cc.stats.TotalURLs = 1
-	cc.mu.Unlock()
-	cc.addJob(CrawlJob{URL: normalizedURL, Depth: 0})
-
-	// Wait for all jobs to complete
-	cc.wg.Wait()
-	close(cc.results)
+	// Leave out the pages found unchanged since an earlier run
+	s.planResume()
 
-	// Wait a bit for result collector to finish
-	time.Sleep(100 * time.Millisecond)
-
-	cc.mu.Lock()
-	startTime := cc.stats.StartTime
-	cc.stats.TotalTime = time.Since(startTime)
-	cc.mu.Unlock()
+	// Save the progress periodically so an interrupted crawl can be resumed
+	stopCheckpoints := s.startCheckpoints()
 
-	cc.logger.Info("Concurrent crawling completed",
-		"total_urls", cc.stats.TotalURLs,
-		"crawled_urls", cc.stats.CrawledURLs,
-		"failed_urls", cc.stats.FailedURLs,
-		"skipped_urls", cc.stats.SkippedURLs,
-		"max_depth_reached", cc.stats.MaxDepthReached,
-		"total_time", cc.stats.TotalTime)
+	if s.resumeFrom != nil || s.resume != nil {
+		// Continue an interrupted run; with nothing left to do, count and
+		// release a placeholder job so the jobs channel closes
+		var jobs []CrawlJob
+		if s.resumeFrom != nil {
+			jobs = s.checkpointJobs(normalizedURL, s.resumeFrom)
+		} else {
+			jobs = s.resumeJobs(normalizedURL, s.resume)
+		}
+		if len(jobs) == 0 {
+			s.activeJobsMu.Lock()
+			s.activeJobs++
+			s.activeJobsMu.Unlock()
+			s.checkAndCloseJobsChannel()
+		}
+		s.sendJobs(jobs)
+	} else {
+		// Add the start URL to the job queue
+		s.visited.Store(normalizedURL, true)
+		s.mu.Lock()
+		s.stats.TotalURLs = 1
+		s.mu.Unlock()
+		s.sampler.Allow(normalizedURL)
+		s.addJob(CrawlJob{URL: normalizedURL, Depth: 0})
+	}
 
-	return cc.resultsList, &cc.stats, nil
+	// Wait for all jobs to complete
+	s.wg.Wait()
+	close(s.results)
+
+	// Wait for the result collector to drain the remaining results
+	<-collected
+
+	s.mu.Lock()
+	startTime := s.stats.StartTime
+	s.stats.TotalTime = time.Since(startTime)
+	s.stats.TemplateCounts = s.sampler.Counts()
+	s.mu.Unlock()
+	stopCheckpoints()
+
+	s.logger.Info("Concurrent crawling completed",
+		"total_urls", s.stats.TotalURLs,
+		"crawled_urls", s.stats.CrawledURLs,
+		"failed_urls", s.stats.FailedURLs,
+		"skipped_urls", s.stats.SkippedURLs,
+		"throttle_events", s.stats.ThrottleEvents,
+		"circuit_skipped", s.stats.CircuitSkipped,
+		"dir_limit_skipped", s.stats.DirLimitSkipped,
+		"locale_skipped", s.stats.LocaleSkipped,
+		"sample_skipped", s.stats.SampleSkipped,
+		"known_deferred", s.stats.KnownDeferred,
+		"bot_blocked", s.stats.BotBlocked,
+		"historical", s.stats.Historical,
+		"historical_gone", s.stats.HistoricalGone,
+		"classify_skipped", s.stats.ClassifySkipped,
+		"max_depth_reached", s.stats.MaxDepthReached,
+		"total_time", s.stats.TotalTime)
+
+	return s.resultsList, &s.stats, nil
 }
 
 // worker processes jobs from the job queue
-func (cc *ConcurrentCrawler) worker(id int) {
-	defer cc.wg.Done()
-	cc.logger.Debug("Worker started", "worker_id", id)
+func (s *crawlSession) worker(id int) {
+	defer s.wg.Done()
+	s.logger.Debug("Worker started", "worker_id", id)
 
 	for {
 		select {
-		case job, ok := <-cc.jobs:
+		case job, ok := <-s.jobs:
 			if !ok {
-				cc.logger.Debug("Worker stopping - jobs channel closed", "worker_id", id)
+				s.logger.Debug("Worker stopping - jobs channel closed", "worker_id", id)
 				return
 			}
-			cc.processJob(job, id)
-		case <-cc.ctx.Done():
-			cc.logger.Debug("Worker stopping - context cancelled", "worker_id", id)
+			s.processJob(job, id)
+			s.donePending(job)
+		case <-s.ctx.Done():
+			s.logger.Debug("Worker stopping - context cancelled", "worker_id", id)
 			return
 		}
 	}
 }
 
 // processJob processes a single crawl job
-func (cc *ConcurrentCrawler) processJob(job CrawlJob, workerID int) {
-	cc.logger.Debug("Processing job", "worker_id", workerID, "url", job.URL, "depth", job.Depth)
+func (s *crawlSession) processJob(job CrawlJob, workerID int) {
+	s.logger.Debug("Processing job", "worker_id", workerID, "url", job.URL, "depth", job.Depth)
+
+	// Drop queued URLs matching exclude patterns added after they were queued
+	if job.Depth > 0 && !s.urlFilter.Allow(job.URL) {
+		s.logger.Debug("Skipping queued URL excluded by filter", "url", job.URL)
+		s.mu.Lock()
+		s.stats.SkippedURLs++
+		s.mu.Unlock()
+		if s.progress != nil {
+			s.progress.IncrementSkipped()
+		}
+		s.checkAndCloseJobsChannel()
+		return
+	}
 
 	// Apply rate limiting if progress reporter is configured with rate limiting
-	if cc.progress != nil {
-		cc.progress.WaitForRateLimit()
+	if s.progress != nil {
+		s.progress.WaitForRateLimit()
 	}
 
 	// Check robots.txt if enabled
-	if cc.robotsChecker != nil {
-		allowed, err := cc.robotsChecker.IsAllowed(job.URL)
+	var crawlDelay time.Duration
+	if s.robotsChecker != nil {
+		allowed, err := s.robotsChecker.IsAllowed(job.URL)
 		if err != nil {
-			cc.logger.Warn("Failed to check robots.txt, allowing by default",
+			s.logger.Warn("Failed to check robots.txt, allowing by default",
 				"url", job.URL, "error", err)
 		} else if !allowed {
-			cc.logger.Debug("URL disallowed by robots.txt", "url", job.URL)
-			cc.mu.Lock()
-			cc.stats.SkippedURLs++
-			cc.mu.Unlock()
+			s.logger.Debug("URL disallowed by robots.txt", "url", job.URL)
+			s.mu.Lock()
+			s.stats.SkippedURLs++
+			s.mu.Unlock()
 
 			// Update progress statistics
-			if cc.progress != nil {
-				cc.progress.IncrementSkipped()
+			if s.progress != nil {
+				s.progress.IncrementSkipped()
 			}
-			cc.checkAndCloseJobsChannel()
+			s.checkAndCloseJobsChannel()
 			return
 		}
 
-		// Apply crawl delay from robots.txt
-		if delay, err := cc.robotsChecker.GetCrawlDelay(job.URL); err == nil && delay > 0 {
-			cc.logger.Debug("Applying robots.txt crawl delay", "url", job.URL, "delay", delay)
-			time.Sleep(delay)
+		// Crawl delay from robots.txt, applied as the politeness profile requires
+		if delay, err := s.robotsChecker.GetCrawlDelay(job.URL); err == nil && delay > 0 {
+			crawlDelay = delay
 		}
 	}
 
 	// Check depth limit
-	if cc.maxDepth >= 0 && job.Depth > cc.maxDepth {
-		cc.logger.Debug("Skipping job due to depth limit", "url", job.URL, "depth", job.Depth)
-		cc.mu.Lock()
-		cc.stats.SkippedURLs++
-		cc.mu.Unlock()
+	if s.maxDepth >= 0 && job.Depth > s.maxDepth {
+		s.logger.Debug("Skipping job due to depth limit", "url", job.URL, "depth", job.Depth)
+		s.mu.Lock()
+		s.stats.SkippedURLs++
+		s.stats.Frontier = append(s.stats.Frontier, job.URL)
+		s.mu.Unlock()
 
 		// Update progress statistics
-		if cc.progress != nil {
-			cc.progress.IncrementSkipped()
+		if s.progress != nil {
+			s.progress.IncrementSkipped()
 		}
-		cc.checkAndCloseJobsChannel()
+		s.checkAndCloseJobsChannel()
+		return
+	}
+
+	// Honor any back-off requested by the host
+	host, _ := url.ExtractDomain(job.URL)
+	if err := s.throttle.Wait(s.ctx, host); err != nil {
+		s.checkAndCloseJobsChannel()
+		return
+	}
+
+	// Keep to the per-host rate limit
+	if s.progress != nil {
+		if err := s.progress.WaitForHostRateLimit(s.ctx, host); err != nil {
+			s.checkAndCloseJobsChannel()
+			return
+		}
+	}
+
+	// Skip hosts that keep failing
+	if !s.breaker.Allow(host) {
+		s.logger.Debug("Skipping URL", "url", job.URL, "reason", "circuit open for host", "host", host)
+		s.mu.Lock()
+		s.stats.SkippedURLs++
+		s.stats.CircuitSkipped++
+		s.mu.Unlock()
+		if s.progress != nil {
+			s.progress.IncrementSkipped()
+		}
+		s.checkAndCloseJobsChannel()
+		return
+	}
+
+	// Keep to the crawl delay and requests in flight allowed for the host
+	release, err := s.waitPolitely(host, crawlDelay)
+	if err != nil {
+		s.checkAndCloseJobsChannel()
 		return
 	}
 
 	// Crawl the URL
-	result := cc.crawlSingleConcurrent(job.URL, job.Depth)
+	result := s.crawlSingleConcurrent(job.URL, job.Depth)
+	release()
+	result.Parent = job.Parent
+	result.LowConfidence = job.LowConfidence
+	result.Historical = job.Historical
+	result.Tags = job.Tags
+	if len(job.attempts) > 0 {
+		result.Attempts = append(job.attempts, result.Attempts...)
+	}
+
+	// Track connection-level failures per host
+	if result.Error != nil && result.StatusCode == 0 {
+		if s.breaker.RecordFailure(host) {
+			s.logger.Warn("Too many consecutive failures, skipping host",
+				"host", host, "cool_off", s.breaker.coolOff, "error", result.Error)
+		}
+	} else {
+		s.breaker.RecordSuccess(host)
+	}
+
+	if result.BotProtection != "" {
+		s.slowBlockedHost(host, result)
+	}
+
+	// Reschedule throttled URLs instead of marking them failed
+	if result.throttled && s.rescheduleThrottled(job, host, result) {
+		s.checkAndCloseJobsChannel()
+		return
+	}
+
+	// A guessed next page that does not exist only ends its pagination
+	if job.Guessed && missingPage(result) {
+		s.logger.Debug("Skipping missing guessed page", "url", job.URL, "status", result.StatusCode)
+		s.mu.Lock()
+		s.stats.SkippedURLs++
+		s.mu.Unlock()
+		if s.progress != nil {
+			s.progress.IncrementSkipped()
+		}
+		s.checkAndCloseJobsChannel()
+		return
+	}
 
 	// Update progress statistics based on result
-	if cc.progress != nil {
-		cc.progress.IncrementProcessed()
+	if s.progress != nil {
+		s.progress.IncrementProcessed()
 		if result.Error != nil {
-			cc.progress.IncrementFailed()
+			s.progress.IncrementFailed()
 		}
 	}
 
 	// Send result to collector
 	select {
-	case cc.results <- result:
-	case <-cc.ctx.Done():
-		cc.checkAndCloseJobsChannel()
+	case s.results <- result:
+	case <-s.ctx.Done():
+		s.checkAndCloseJobsChannel()
 		return
 	}
 
 	// If successful, add new links to job queue
 	if result.Error == nil {
-		cc.addLinksToQueue(result.Links, job.Depth)
+		s.addLinksToQueue(result, job.Depth)
 	}
 
 	// Update max depth reached
-	cc.mu.Lock()
-	if job.Depth > cc.stats.MaxDepthReached {
-		cc.stats.MaxDepthReached = job.Depth
+	s.mu.Lock()
+	if job.Depth > s.stats.MaxDepthReached {
+		s.stats.MaxDepthReached = job.Depth
 	}
-	cc.mu.Unlock()
+	s.mu.Unlock()
+
+	s.checkAndCloseJobsChannel()
+}
+
+// rescheduleThrottled delays the host and queues the job again after the
+// requested back-off. It returns false once the job has used up its retries.
+func (s *crawlSession) rescheduleThrottled(job CrawlJob, host string, result CrawlResult) bool {
+	s.mu.Lock()
+	s.stats.ThrottleEvents++
+	s.mu.Unlock()
+
+	if job.Attempt >= s.maxThrottle {
+		return false
+	}
+
+	delay := min(result.retryAfter, maxThrottleDelay)
+
+	s.throttle.Delay(host, delay)
+	s.logger.Warn("Host is throttling requests, rescheduling URL",
+		"url", job.URL, "status_code", result.StatusCode, "delay", delay, "attempt", job.Attempt+1)
+
+	// Count the rescheduled job as active so the jobs channel stays open
+	s.activeJobsMu.Lock()
+	s.activeJobs++
+	s.activeJobsMu.Unlock()
+
+	job.Attempt++
+	job.attempts = result.Attempts
+	s.trackPending(job)
+	if s.dispatcher != nil {
+		s.dispatcher.queue(job)
+		return true
+	}
+	go func() {
+		if err := s.throttle.Wait(s.ctx, host); err != nil {
+			s.checkAndCloseJobsChannel()
+			return
+		}
+		select {
+		case s.jobs <- job:
+		case <-s.ctx.Done():
+			s.checkAndCloseJobsChannel()
+		}
+	}()
 
-	cc.checkAndCloseJobsChannel()
+	return true
 }
 
 // checkAndCloseJobsChannel safely checks if all jobs are done and closes the channel
-func (cc *ConcurrentCrawler) checkAndCloseJobsChannel() {
-	cc.activeJobsMu.Lock()
-	cc.activeJobs--
-	shouldClose := cc.activeJobs == 0
-	cc.activeJobsMu.Unlock()
+func (s *crawlSession) checkAndCloseJobsChannel() {
+	s.activeJobsMu.Lock()
+	s.activeJobs--
+	shouldClose := s.activeJobs == 0
+	s.activeJobsMu.Unlock()
 
 	if shouldClose {
-		cc.jobsCloseMu.Lock()
-		if !cc.jobsClosed {
-			cc.jobsClosed = true
-			close(cc.jobs)
-			cc.logger.Debug("Jobs channel closed - no more active jobs")
+		if s.flushDeferred() || s.flushHistorical() {
+			return
+		}
+
+		s.jobsCloseMu.Lock()
+		if !s.jobsClosed {
+			s.jobsClosed = true
+			close(s.jobs)
+			s.logger.Debug("Jobs channel closed - no more active jobs")
 		}
-		cc.jobsCloseMu.Unlock()
+		s.jobsCloseMu.Unlock()
 	}
 }
 
 // crawlSingleConcurrent crawls a single URL in concurrent mode
-func (cc *ConcurrentCrawler) crawlSingleConcurrent(targetURL string, depth int) CrawlResult {
+func (s *crawlSession) crawlSingleConcurrent(targetURL string, depth int) CrawlResult {
 	result := CrawlResult{
 		URL:       targetURL,
 		Depth:     depth,
 		FetchTime: time.Now(),
 	}
 
-	cc.logger.Debug("Fetching URL", "url", targetURL, "depth", depth)
+	s.logger.Debug("Fetching URL", "url", targetURL, "depth", depth)
 	startTime := time.Now()
 
+	ctx, cancel := s.pageContext(s.ctx)
+	defer cancel()
+	attempts := &client.AttemptLog{}
+	ctx = client.WithAttemptLog(ctx, attempts)
+	ctx, result.Egress = s.egress.route(ctx)
+
+	// Fetch from the rewritten host, if any, while reporting the original URL
+	fetchURL := s.rewrites.FetchURL(targetURL)
+
 	// Determine if JS rendering is needed (for SPA detection)
-	var useJS bool
+	mode := s.renderMode(targetURL)
+	useJS := mode == renderAlways
 	var err error
 
-	jsConfig := cc.client.GetJSConfig()
-	if jsConfig != nil && jsConfig.AutoDetect {
+	jsConfig := s.client.GetJSConfig()
+	if jsConfig != nil && jsConfig.AutoDetect && mode == renderDefault {
 		// First fetch with HTTP client to get static HTML for SPA detection
-		httpResponse, httpErr := cc.client.GetHTTPClient().Get(cc.ctx, targetURL)
+		httpResponse, httpErr := s.client.FetchHTTP(ctx, fetchURL)
 		if httpErr == nil {
 			staticHTML := httpResponse.String()
-			useJS, err = cc.shouldUseJSRendering(targetURL, staticHTML)
+			useJS, err = s.shouldUseJSRendering(targetURL, staticHTML)
 			if err != nil {
-				cc.logger.Warn("Failed to determine JS rendering need", "url", targetURL, "error", err)
+				s.logger.Warn("Failed to determine JS rendering need", "url", targetURL, "error", err)
 			}
 		}
 	}
@@ -704,202 +1493,356 @@ func (cc *ConcurrentCrawler) crawlSingleConcurrent(targetURL string, depth int)
 	// Fetch the page with appropriate method
 	var response client.UnifiedResponse
 	if useJS {
-		jsClient := cc.client.GetJSClient()
-		if jsClient != nil {
-			cc.logger.Info("Using JavaScript rendering", "url", targetURL)
-			response, err = jsClient.Get(cc.ctx, targetURL)
+		if s.client.GetJSClient() != nil {
+			s.logger.Info("Using JavaScript rendering", "url", targetURL)
+			response, err = s.client.FetchJS(ctx, fetchURL)
 		} else {
-			cc.logger.Warn("JavaScript client not available, falling back to HTTP", "url", targetURL)
-			response, err = cc.client.Get(cc.ctx, targetURL)
+			s.logger.Warn("JavaScript client not available, falling back to HTTP", "url", targetURL)
+			response, err = s.client.Get(ctx, fetchURL)
 		}
+	} else if mode == renderNever {
+		response, err = s.client.FetchHTTP(ctx, fetchURL)
 	} else {
-		response, err = cc.client.Get(cc.ctx, targetURL)
+		response, err = s.client.Get(ctx, fetchURL)
 	}
 	result.ResponseTime = time.Since(startTime)
+	result.Attempts = attempts.Attempts()
 
 	if err != nil {
 		result.Error = fmt.Errorf("failed to fetch URL: %w", err)
+		result.ErrorKind = client.FailureKind(err)
+		recordRendering(&result, nil, err)
 		return result
 	}
 
-	result.StatusCode = response.StatusCode()
+	meta := newResponseMeta(response, s.errorBodySize)
+	response, meta = s.renderBlocked(ctx, fetchURL, response, meta, useJS)
+	recordRendering(&result, response, nil)
+	result.StatusCode = meta.statusCode
+	recordRedirect(&result, s.stripSessionIDs(s.rewrites.OriginalURL(meta.finalURL)))
+	recordContent(&result, meta)
+
+	// Record back-off requests from the server
+	if client.IsThrottled(result.StatusCode) {
+		delay, ok := client.ParseRetryAfter(response.Header("Retry-After"), time.Now())
+		if !ok && result.StatusCode == http.StatusTooManyRequests {
+			delay, ok = defaultThrottleDelay, true
+		}
+		result.throttled = ok
+		result.retryAfter = delay
+	}
 
 	// Check for successful response
 	if response.StatusCode() < 200 || response.StatusCode() >= 400 {
-		result.Error = fmt.Errorf("HTTP error: %d", response.StatusCode())
+		result.Error = httpError(meta)
+		recordErrorResponse(&result, meta)
+		if s.dualUA && !result.throttled {
+			s.compareDevices(ctx, &result, response)
+		}
 		return result
 	}
 
 	// Extract links from the page
-	htmlContent := response.String()
-	if cc.sameDomain {
-		result.Links, err = cc.parser.ExtractSameDomainLinks(targetURL, htmlContent)
-	} else {
-		result.Links, err = cc.parser.ExtractLinks(targetURL, htmlContent)
-	}
-
+	recordValidators(&result, meta)
+	result.Links, err = s.extractResponseLinks(targetURL, meta.contentType, response.String())
 	if err != nil {
-		result.Error = fmt.Errorf("failed to extract links: %w", err)
+		result.Error = err
 		return result
 	}
+	s.recordShadowLinks(&result, response)
+	s.recordDeclaredRoutes(&result, response)
+	s.recordScriptLinks(&result, meta.contentType, response.String())
+	s.recordHints(&result, meta.contentType, response.String())
+	s.recordIcons(&result, meta.contentType, response.String())
+	s.recordOGImage(&result, meta.contentType, response.String())
+	s.recordServiceWorker(&result, meta.contentType, response)
+	s.recordExternalDomains(&result, meta.contentType, response.String())
+	s.recordExternalLinks(&result, meta.contentType, response.String())
+	s.recordLanguage(&result, meta.contentType, response.String())
+	s.recordRobotsDirectives(&result, meta, response.String())
+	s.recordNextPage(&result)
+	if s.metadata {
+		result.Title = parser.ExtractTitle(response.String())
+	}
+
+	if s.compareRender {
+		s.compareRendering(ctx, &result, response)
+	}
+	if s.dualUA {
+		s.compareDevices(ctx, &result, response)
+	}
 
-	cc.logger.Debug("Extracted links", "url", targetURL, "link_count", len(result.Links))
+	s.logger.Debug("Extracted links", "url", targetURL, "link_count", len(result.Links))
 	return result
 }
 
-// addLinksToQueue adds extracted links to the job queue
-func (cc *ConcurrentCrawler) addLinksToQueue(links []string, currentDepth int) {
-	for _, link := range links {
-		// Skip if already visited
-		if _, loaded := cc.visited.LoadOrStore(link, true); loaded {
-			continue
-		}
+// admitLink reports whether a discovered link should be crawled: it must be
+// new, in scope and within the filters and budgets. Skips are counted.
+func (s *crawlSession) admitLink(link string) bool {
+	// Skip if already visited
+	if _, loaded := s.visited.LoadOrStore(link, true); loaded {
+		return false
+	}
 
-		// Apply filtering based on configuration
-		if cc.sameDomain {
-			if cc.samePathPrefix {
-				// Use path prefix filtering (includes domain check)
-				isSame, err := url.IsSamePathPrefix(cc.baseDomain, link)
-				if err != nil || !isSame {
-					cc.logger.Debug("Skipping link outside path prefix", "link", link, "base", cc.baseDomain)
-					continue
-				}
-			} else {
-				// Use domain-only filtering
-				isSame, err := url.IsSameDomain(cc.baseDomain, link)
-				if err != nil || !isSame {
-					cc.logger.Debug("Skipping external domain link", "link", link)
-					continue
-				}
+	// Apply filtering based on configuration
+	if s.sameDomain {
+		if s.samePathPrefix {
+			// Use path prefix filtering (includes domain check)
+			isSame, err := url.IsSamePathPrefix(s.baseDomain, link)
+			if err != nil || !isSame {
+				s.logger.Debug("Skipping link outside path prefix", "link", link, "base", s.baseDomain)
+				return false
+			}
+		} else {
+			// Use domain-only filtering
+			isSame, err := url.IsSameDomain(s.baseDomain, link)
+			if err != nil || !isSame {
+				s.logger.Debug("Skipping external domain link", "link", link)
+				return false
 			}
 		}
+	}
 
-		// Add to job queue
-		cc.addJob(CrawlJob{URL: link, Depth: currentDepth + 1})
+	// Apply include/exclude patterns
+	if !s.urlFilter.Allow(link) {
+		s.logger.Debug("Skipping link excluded by filter", "link", link)
+		s.mu.Lock()
+		s.stats.SkippedURLs++
+		s.mu.Unlock()
+		if s.progress != nil {
+			s.progress.IncrementSkipped()
+		}
+		return false
+	}
+
+	// Record other locales as alternates instead of crawling them
+	if !s.localeScope.Allow(link) {
+		s.logger.Debug("Skipping link in other locale", "link", link)
+		s.mu.Lock()
+		s.stats.SkippedURLs++
+		s.stats.LocaleSkipped++
+		s.stats.SkippedAlternates = append(s.stats.SkippedAlternates, link)
+		s.mu.Unlock()
+		if s.progress != nil {
+			s.progress.IncrementSkipped()
+		}
+		return false
+	}
+
+	// Apply the per-directory budget
+	if !s.dirBudget.Allow(link) {
+		s.logger.Debug("Skipping link over directory budget", "link", link)
+		s.mu.Lock()
+		s.stats.SkippedURLs++
+		s.stats.DirLimitSkipped++
+		s.mu.Unlock()
+		if s.progress != nil {
+			s.progress.IncrementSkipped()
+		}
+		return false
+	}
 
-		cc.mu.Lock()
-		cc.stats.TotalURLs++
-		cc.mu.Unlock()
+	// Crawl only a sample of each path template
+	if !s.sampler.Allow(link) {
+		s.logger.Debug("Skipping link over template sample", "link", link)
+		s.mu.Lock()
+		s.stats.SkippedURLs++
+		s.stats.SampleSkipped++
+		s.mu.Unlock()
+		if s.progress != nil {
+			s.progress.IncrementSkipped()
+		}
+		return false
+	}
+
+	return true
+}
+
+// addLinksToQueue adds the links of a crawled page to the job queue
+func (s *crawlSession) addLinksToQueue(result CrawlResult, currentDepth int) {
+	for _, link := range s.queueOrder(result.Links) {
+		if !s.admitLink(link) {
+			continue
+		}
+		crawl, tags := s.classify(link, result.URL, currentDepth+1)
+		if !crawl {
+			continue
+		}
+
+		// Add to job queue, holding back URLs known from a previous run
+		job := CrawlJob{URL: link, Depth: currentDepth + 1, Parent: result.URL,
+			LowConfidence: slices.Contains(result.ScriptLinks, link), Guessed: link == result.nextPage, Tags: tags}
+		if s.known.Contains(link) {
+			s.deferJob(job)
+		} else {
+			s.addJob(job)
+		}
+
+		s.mu.Lock()
+		s.stats.TotalURLs++
+		s.mu.Unlock()
 
 		// Update progress statistics
-		if cc.progress != nil {
-			cc.progress.IncrementDiscovered()
+		if s.progress != nil {
+			s.progress.IncrementDiscovered()
 		}
 
-		cc.logger.Debug("Added URL to queue", "url", link, "depth", currentDepth+1)
+		s.logger.Debug("Added URL to queue", "url", link, "depth", currentDepth+1)
 	}
 }
 
 // addJob adds a job to the job queue with proper synchronization
-func (cc *ConcurrentCrawler) addJob(job CrawlJob) {
+func (s *crawlSession) addJob(job CrawlJob) {
 	// Check if jobs channel is already closed
-	cc.jobsCloseMu.Lock()
-	if cc.jobsClosed {
-		cc.jobsCloseMu.Unlock()
-		cc.logger.Debug("Cannot add job - jobs channel is closed", "url", job.URL)
+	s.jobsCloseMu.Lock()
+	if s.jobsClosed {
+		s.jobsCloseMu.Unlock()
+		s.logger.Debug("Cannot add job - jobs channel is closed", "url", job.URL)
 		return
 	}
-	cc.jobsCloseMu.Unlock()
+	s.jobsCloseMu.Unlock()
 
-	cc.activeJobsMu.Lock()
-	cc.activeJobs++
-	cc.activeJobsMu.Unlock()
+	s.activeJobsMu.Lock()
+	s.activeJobs++
+	s.activeJobsMu.Unlock()
+	s.trackPending(job)
+
+	if s.dispatcher != nil {
+		s.dispatcher.queue(job)
+		return
+	}
 
 	select {
-	case cc.jobs <- job:
+	case s.jobs <- job:
 		// Job added successfully
-	case <-cc.ctx.Done():
+	case <-s.ctx.Done():
 		// Context cancelled, decrement the counter
-		cc.activeJobsMu.Lock()
-		cc.activeJobs--
-		cc.activeJobsMu.Unlock()
+		s.activeJobsMu.Lock()
+		s.activeJobs--
+		s.activeJobsMu.Unlock()
 		return
 	default:
-		// Channel might be full or closed, handle gracefully
-		cc.activeJobsMu.Lock()
-		cc.activeJobs--
-		cc.activeJobsMu.Unlock()
-		cc.logger.Debug("Failed to add job - channel closed or full", "url", job.URL)
-		return
+		// The queue is full; wait for space instead of dropping the URL,
+		// which is already marked visited and would never be crawled
+		go func() {
+			select {
+			case s.jobs <- job:
+			case <-s.ctx.Done():
+				s.checkAndCloseJobsChannel()
+			}
+		}()
 	}
 }
 
 // resultCollector collects results from workers
-func (cc *ConcurrentCrawler) resultCollector() {
-	for result := range cc.results {
-		cc.mu.Lock()
-		cc.resultsList = append(cc.resultsList, result)
+func (s *crawlSession) resultCollector() {
+	for result := range s.results {
+		if s.deterministic {
+			result = withoutTimings(result)
+		}
+		if result.Historical && result.Error != nil {
+			s.mu.Lock()
+			s.stats.HistoricalGone++
+			s.mu.Unlock()
+			s.logger.Debug("Historical URL no longer resolves", "url", result.URL, "error", result.Error)
+			continue
+		}
+
+		s.mu.Lock()
+		if !s.discardResults {
+			s.resultsList = append(s.resultsList, result)
+		}
 
 		if result.Error != nil {
-			cc.stats.FailedURLs++
-			cc.logger.Warn("Failed to crawl URL", "url", result.URL, "error", result.Error)
+			s.stats.FailedURLs++
+			s.logger.Warn("Failed to crawl URL", "url", result.URL, "error", result.Error, "kind", result.ErrorKind)
 		} else {
-			cc.stats.CrawledURLs++
-			cc.logger.Info("Successfully crawled URL", "url", result.URL, "links_found", len(result.Links))
+			s.stats.CrawledURLs++
+			s.logger.Info("Successfully crawled URL", "url", result.URL, "links_found", len(result.Links))
+		}
+		s.mu.Unlock()
+
+		if s.onResult != nil {
+			s.onResult(result)
 		}
-		cc.mu.Unlock()
 	}
 }
 
-// Cancel cancels the crawling operation
+// Cancel stops every crawl running on the crawler. Crawls started afterwards
+// stop immediately; use CrawlConcurrentContext to stop a single crawl.
 func (cc *ConcurrentCrawler) Cancel() {
 	cc.cancel()
 }
 
-// GetResults returns the crawling results (thread-safe)
-func (cc *ConcurrentCrawler) GetResults() []CrawlResult {
+// lastSession returns the session of the crawl started last, or nil
+func (cc *ConcurrentCrawler) lastSession() *crawlSession {
 	cc.mu.RLock()
 	defer cc.mu.RUnlock()
-	results := make([]CrawlResult, len(cc.resultsList))
-	copy(results, cc.resultsList)
+	return cc.last
+}
+
+// GetResults returns the results of the crawl started last (thread-safe)
+func (cc *ConcurrentCrawler) GetResults() []CrawlResult {
+	session := cc.lastSession()
+	if session == nil {
+		return []CrawlResult{}
+	}
+	session.mu.RLock()
+	defer session.mu.RUnlock()
+	results := make([]CrawlResult, len(session.resultsList))
+	copy(results, session.resultsList)
 	return results
 }
 
-// GetStats returns the crawling statistics (thread-safe)
+// GetStats returns the statistics of the crawl started last (thread-safe)
 func (cc *ConcurrentCrawler) GetStats() *CrawlStats {
-	cc.mu.RLock()
-	defer cc.mu.RUnlock()
-	statsCopy := cc.stats
+	session := cc.lastSession()
+	if session == nil {
+		return &CrawlStats{}
+	}
+	session.mu.RLock()
+	defer session.mu.RUnlock()
+	statsCopy := session.stats
 	return &statsCopy
 }
 
 // progressUpdater periodically updates progress statistics
-func (cc *ConcurrentCrawler) progressUpdater() {
+func (s *crawlSession) progressUpdater() {
 	ticker := time.NewTicker(500 * time.Millisecond) // Update every 500ms
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			cc.updateProgressStats()
-		case <-cc.ctx.Done():
+			s.updateProgressStats()
+		case <-s.ctx.Done():
 			return
 		}
 	}
 }
 
 // updateProgressStats updates the progress reporter with current statistics
-func (cc *ConcurrentCrawler) updateProgressStats() {
-	if cc.progress == nil {
+func (s *crawlSession) updateProgressStats() {
+	if s.progress == nil {
 		return
 	}
 
-	cc.mu.RLock()
-	totalURLs := int64(cc.stats.TotalURLs)
-	crawledURLs := int64(cc.stats.CrawledURLs)
-	failedURLs := int64(cc.stats.FailedURLs)
-	skippedURLs := int64(cc.stats.SkippedURLs)
-	cc.mu.RUnlock()
+	s.mu.RLock()
+	totalURLs := int64(s.stats.TotalURLs)
+	crawledURLs := int64(s.stats.CrawledURLs)
+	failedURLs := int64(s.stats.FailedURLs)
+	skippedURLs := int64(s.stats.SkippedURLs)
+	s.mu.RUnlock()
 
-	cc.activeJobsMu.Lock()
-	activeJobs := cc.activeJobs
-	cc.activeJobsMu.Unlock()
+	s.activeJobsMu.Lock()
+	activeJobs := s.activeJobs
+	s.activeJobsMu.Unlock()
 
 	// Calculate queue size (approximation)
-	queueSize := len(cc.jobs)
+	queueSize := len(s.jobs)
 
 	// Update progress with current statistics
-	cc.progress.UpdateStats(
+	s.progress.UpdateStats(
 		crawledURLs, // URLs processed
 		totalURLs,   // URLs discovered
 		failedURLs,  // URLs failed
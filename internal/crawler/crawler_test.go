@@ -1,15 +1,20 @@
 package crawler
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/aoshimash/urlmap/internal/filter"
 	"github.com/aoshimash/urlmap/internal/progress"
+	"github.com/aoshimash/urlmap/pkg/testsite"
 )
 
 func TestMain(m *testing.M) {
@@ -43,8 +48,8 @@ func TestNew(t *testing.T) {
 		t.Error("Crawler logger is nil")
 	}
 
-	if crawler.visited == nil {
-		t.Error("Crawler visited map is nil")
+	if crawler.results == nil {
+		t.Error("Crawler results slice is nil")
 	}
 }
 
@@ -112,16 +117,11 @@ func TestCrawlerUtilityMethods(t *testing.T) {
 	}
 
 	// Test Reset
-	crawler.visited["test"] = true
 	crawler.results = append(crawler.results, CrawlResult{URL: "test"})
 	crawler.stats.TotalURLs = 5
 
 	crawler.Reset()
 
-	if len(crawler.visited) != 0 {
-		t.Error("Reset() did not clear visited map")
-	}
-
 	if len(crawler.results) != 0 {
 		t.Error("Reset() did not clear results")
 	}
@@ -169,12 +169,15 @@ func TestNewConcurrentCrawler(t *testing.T) {
 		t.Error("ConcurrentCrawler.Crawler is nil")
 	}
 
-	if cc.jobs == nil {
-		t.Error("ConcurrentCrawler.jobs channel is nil")
+	session := cc.newSession(context.Background())
+	defer session.cancel()
+
+	if session.jobs == nil {
+		t.Error("crawlSession.jobs channel is nil")
 	}
 
-	if cc.results == nil {
-		t.Error("ConcurrentCrawler.results channel is nil")
+	if session.results == nil {
+		t.Error("crawlSession.results channel is nil")
 	}
 
 	if cc.ctx == nil {
@@ -377,12 +380,16 @@ func TestConcurrentCrawler_WithProgress(t *testing.T) {
 		t.Fatalf("Failed to create concurrent crawler: %v", err)
 	}
 
+	// Each crawl gets its own progress reporter
+	session := crawler.newSession(context.Background())
+	defer session.cancel()
+
 	// Check that progress reporter is initialized
-	if crawler.progress == nil {
+	if session.progress == nil {
 		t.Error("Expected progress reporter to be initialized")
 	}
 
-	if crawler.progress.IsRateLimited() {
+	if session.progress.IsRateLimited() {
 		t.Error("Expected rate limiting to be disabled")
 	}
 }
@@ -407,12 +414,16 @@ func TestConcurrentCrawler_WithRateLimit(t *testing.T) {
 		t.Fatalf("Failed to create concurrent crawler: %v", err)
 	}
 
+	// Each crawl gets its own progress reporter
+	session := crawler.newSession(context.Background())
+	defer session.cancel()
+
 	// Check that progress reporter is initialized with rate limiting
-	if crawler.progress == nil {
+	if session.progress == nil {
 		t.Error("Expected progress reporter to be initialized")
 	}
 
-	if !crawler.progress.IsRateLimited() {
+	if !session.progress.IsRateLimited() {
 		t.Error("Expected rate limiting to be enabled")
 	}
 }
@@ -434,7 +445,164 @@ func TestConcurrentCrawler_WithoutProgress(t *testing.T) {
 	}
 
 	// Check that progress reporter is not initialized
-	if crawler.progress != nil {
+	session := crawler.newSession(context.Background())
+	defer session.cancel()
+	if session.progress != nil {
 		t.Error("Expected progress reporter to not be initialized")
 	}
 }
+
+func TestConcurrentCrawler_URLFilter(t *testing.T) {
+	server := createNestedMockServer(t)
+	defer server.Close()
+
+	urlFilter, err := filter.New(nil, []string{"/level1/page2"})
+	if err != nil {
+		t.Fatalf("filter.New() failed: %v", err)
+	}
+
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:   -1,
+		SameDomain: true,
+		Workers:    2,
+		URLFilter:  urlFilter,
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	for _, result := range results {
+		if strings.HasSuffix(result.URL, "/level1/page2") || strings.HasSuffix(result.URL, "/level2/page2") {
+			t.Errorf("Excluded branch should not be crawled: %s", result.URL)
+		}
+	}
+
+	if stats.SkippedURLs == 0 {
+		t.Error("Expected filtered URLs to be counted as skipped")
+	}
+}
+
+func TestConcurrentCrawler_URLFilterAddedDuringCrawl(t *testing.T) {
+	urlFilter, err := filter.New(nil, nil)
+	if err != nil {
+		t.Fatalf("filter.New() failed: %v", err)
+	}
+
+	var calendarFetched atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><a href="/trap">Trap</a><a href="/calendar/2024">Calendar</a></body></html>`)
+		case "/trap":
+			// The trap is noticed after /calendar/2024 was already queued
+			urlFilter.AddExclude("/calendar/*")
+			fmt.Fprint(w, `<html><body></body></html>`)
+		default:
+			calendarFetched.Store(true)
+			fmt.Fprint(w, `<html><body></body></html>`)
+		}
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 1, SameDomain: true, Workers: 1, URLFilter: urlFilter})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	if calendarFetched.Load() {
+		t.Error("queued URL matching the new exclude pattern was fetched")
+	}
+	if len(results) != 2 {
+		t.Errorf("got %d results, want 2", len(results))
+	}
+	if stats.SkippedURLs != 1 {
+		t.Errorf("SkippedURLs = %d, want 1", stats.SkippedURLs)
+	}
+}
+
+func TestConcurrentCrawler_SyntheticSiteWithErrors(t *testing.T) {
+	// Pages 4, 8 and 12 fail; they are leaves, so every page is still reached
+	server := testsite.Site{Pages: 13, Fanout: 3, ErrorEvery: 4}.Start()
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 10})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	if len(results) != 13 {
+		t.Errorf("got %d results, want 13", len(results))
+	}
+	if stats.CrawledURLs != 10 || stats.FailedURLs != 3 {
+		t.Errorf("crawled %d, failed %d; want 10 and 3", stats.CrawledURLs, stats.FailedURLs)
+	}
+}
+
+func TestConcurrentCrawler_RecordsParent(t *testing.T) {
+	server := createNestedMockServer(t)
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:   2,
+		SameDomain: true,
+		Workers:    2,
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	parents := make(map[string]string)
+	for _, result := range results {
+		parents[strings.TrimPrefix(result.URL, server.URL)] = strings.TrimPrefix(result.Parent, server.URL)
+	}
+	if parents["/level2/page1"] != "/level1/page1" {
+		t.Errorf("expected /level2/page1 to be found on /level1/page1, got %q", parents["/level2/page1"])
+	}
+}
+
+func TestConcurrentCrawler_PageTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		w.Write([]byte("<html><body>slow</body></html>"))
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:    0,
+		SameDomain:  true,
+		Workers:     1,
+		PageTimeout: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Error == nil {
+		t.Errorf("expected the slow page to exceed the page timeout: %+v", results)
+	}
+}
@@ -0,0 +1,101 @@
+package crawler
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// A deterministic crawl (Config.Deterministic) fetches pages one at a time
+// in the order they were queued, so two runs against the same site crawl the
+// same URLs in the same order and produce identical results:
+//
+//   - one worker fed by a dispatcher, which never drops jobs when the queue
+//     is full and keeps the order of jobs queued from several places
+//   - the links of each page are queued sorted, so markup reordering does not
+//     change the crawl order
+//   - throttled URLs are queued again instead of being retried after a timer
+//   - the circuit breaker, whose cool-off depends on the clock, is disabled
+//   - results carry no fetch times or durations
+
+// dispatcher queues the jobs of a deterministic crawl in order
+type dispatcher struct {
+	mu      sync.Mutex
+	pending []CrawlJob
+	ready   chan struct{} // Signalled when jobs are queued
+}
+
+func newDispatcher() *dispatcher {
+	return &dispatcher{ready: make(chan struct{}, 1)}
+}
+
+// queue appends jobs, which the caller has counted as active
+func (d *dispatcher) queue(jobs ...CrawlJob) {
+	d.mu.Lock()
+	d.pending = append(d.pending, jobs...)
+	d.mu.Unlock()
+
+	select {
+	case d.ready <- struct{}{}:
+	default:
+	}
+}
+
+// next removes and returns the first queued job
+func (d *dispatcher) next() (CrawlJob, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.pending) == 0 {
+		return CrawlJob{}, false
+	}
+	job := d.pending[0]
+	d.pending = d.pending[1:]
+	return job, true
+}
+
+// dispatch sends the queued jobs to the worker one at a time until the
+// session is done
+func (s *crawlSession) dispatch() {
+	for {
+		job, ok := s.dispatcher.next()
+		if !ok {
+			select {
+			case <-s.dispatcher.ready:
+				continue
+			case <-s.ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case s.jobs <- job:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// queueOrder returns the links of a page in the order they are queued:
+// sorted in a deterministic crawl, as found otherwise
+func (s *crawlSession) queueOrder(links []string) []string {
+	if !s.deterministic {
+		return links
+	}
+	return slices.Sorted(slices.Values(links))
+}
+
+// withoutTimings clears the times and durations of result, which differ
+// between runs
+func withoutTimings(result CrawlResult) CrawlResult {
+	result.FetchTime = time.Time{}
+	result.ResponseTime = 0
+	if len(result.Attempts) > 0 {
+		attempts := slices.Clone(result.Attempts)
+		for i := range attempts {
+			attempts[i].Duration = 0
+		}
+		result.Attempts = attempts
+	}
+	return result
+}
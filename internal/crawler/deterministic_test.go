@@ -0,0 +1,62 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConcurrentCrawler_Deterministic(t *testing.T) {
+	// The home page links to 50 pages in reverse order, more than the job
+	// queue holds; each of them links back home and to /leaf/N
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		var links strings.Builder
+		switch {
+		case r.URL.Path == "/":
+			for i := 50; i > 0; i-- {
+				fmt.Fprintf(&links, `<a href="/page/%02d">page</a>`, i)
+			}
+		case strings.HasPrefix(r.URL.Path, "/page/"):
+			fmt.Fprintf(&links, `<a href="/leaf/%s">leaf</a><a href="/">home</a>`, strings.TrimPrefix(r.URL.Path, "/page/"))
+		}
+		fmt.Fprintf(w, "<html><body>%s</body></html>", links.String())
+	}))
+	defer server.Close()
+
+	crawl := func() []CrawlResult {
+		cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, Workers: 10, Deterministic: true})
+		if err != nil {
+			t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+		}
+		results, stats, err := cc.CrawlConcurrent(server.URL)
+		if err != nil {
+			t.Fatalf("CrawlConcurrent() failed: %v", err)
+		}
+		if stats.CrawledURLs != 101 {
+			t.Errorf("crawled %d URLs, want 101", stats.CrawledURLs)
+		}
+		return results
+	}
+
+	first := crawl()
+	if !reflect.DeepEqual(first, crawl()) {
+		t.Error("two deterministic crawls returned different results")
+	}
+
+	// Pages are crawled breadth-first, with the links of each page sorted
+	var order []string
+	for _, result := range first {
+		order = append(order, strings.TrimPrefix(result.URL, server.URL))
+		if !result.FetchTime.IsZero() || result.ResponseTime != 0 {
+			t.Errorf("%s: result has timings", result.URL)
+		}
+	}
+	want := []string{"/", "/page/01", "/page/02"}
+	if !reflect.DeepEqual(order[:3], want) || order[50] != "/page/50" || order[51] != "/leaf/01" || order[100] != "/leaf/50" {
+		t.Errorf("crawl order %v", order)
+	}
+}
@@ -0,0 +1,90 @@
+package crawler
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+// DeviceComparison holds the desktop and mobile versions of a page
+type DeviceComparison struct {
+	DesktopStatus   int      // Status code served to the desktop user agent
+	MobileStatus    int      // Status code served to the mobile user agent (0 if the fetch failed)
+	DesktopFinalURL string   // Desktop redirect target (empty if not redirected)
+	MobileFinalURL  string   // Mobile redirect target (empty if not redirected)
+	MissingOnMobile []string // Desktop links the mobile version lacks
+	ExtraOnMobile   []string // Mobile links the desktop version lacks
+	Error           error    // Error fetching the mobile version, if any
+}
+
+// Differs reports whether the mobile version differs in status, redirect target or links
+func (d DeviceComparison) Differs() bool {
+	return d.Error != nil ||
+		d.DesktopStatus != d.MobileStatus ||
+		d.DesktopFinalURL != d.MobileFinalURL ||
+		len(d.MissingOnMobile) > 0 ||
+		len(d.ExtraOnMobile) > 0
+}
+
+// compareDevices fetches the page again with the mobile user agent, the same
+// way (static or rendered) as the desktop response, and records the differences
+func (cc *ConcurrentCrawler) compareDevices(ctx context.Context, result *CrawlResult, response client.UnifiedResponse) {
+	_, rendered := response.(*client.JSResponse)
+
+	comparison := &DeviceComparison{
+		DesktopStatus:   result.StatusCode,
+		DesktopFinalURL: result.FinalURL,
+	}
+	result.Devices = comparison
+
+	mobile, err := cc.client.FetchMobile(ctx, cc.rewrites.FetchURL(result.URL), cc.mobileUA, rendered)
+	if err != nil {
+		cc.logger.Warn("Mobile fetch failed", "url", result.URL, "error", err)
+		comparison.Error = err
+		return
+	}
+
+	comparison.MobileStatus = mobile.StatusCode()
+	mobileResult := CrawlResult{URL: result.URL}
+	recordRedirect(&mobileResult, cc.stripSessionIDs(cc.rewrites.OriginalURL(mobile.FinalURL())))
+	comparison.MobileFinalURL = mobileResult.FinalURL
+
+	// Links are only compared when both versions were served successfully
+	if result.Error != nil || comparison.MobileStatus < 200 || comparison.MobileStatus >= 400 {
+		return
+	}
+
+	mobileLinks, err := cc.extractLinks(result.URL, mobile.String())
+	if err != nil {
+		cc.logger.Warn("Mobile link extraction failed", "url", result.URL, "error", err)
+		comparison.Error = err
+		return
+	}
+	comparison.MissingOnMobile = linkDifference(result.Links, mobileLinks)
+	comparison.ExtraOnMobile = linkDifference(mobileLinks, result.Links)
+
+	cc.logger.Debug("Compared desktop and mobile versions", "url", result.URL,
+		"mobile_status", comparison.MobileStatus,
+		"missing_on_mobile", len(comparison.MissingOnMobile),
+		"extra_on_mobile", len(comparison.ExtraOnMobile))
+}
+
+// linkDifference returns the sorted links in a that are not in b
+func linkDifference(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, link := range b {
+		inB[link] = true
+	}
+
+	seen := make(map[string]bool, len(a))
+	var diff []string
+	for _, link := range a {
+		if !inB[link] && !seen[link] {
+			seen[link] = true
+			diff = append(diff, link)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
@@ -0,0 +1,83 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeviceComparisonDiffers(t *testing.T) {
+	same := DeviceComparison{DesktopStatus: 200, MobileStatus: 200}
+	if same.Differs() {
+		t.Error("identical versions should not differ")
+	}
+
+	for _, comparison := range []DeviceComparison{
+		{DesktopStatus: 200, MobileStatus: 404},
+		{DesktopStatus: 200, MobileStatus: 200, MobileFinalURL: "https://m.example.com/"},
+		{DesktopStatus: 200, MobileStatus: 200, MissingOnMobile: []string{"https://example.com/a"}},
+		{DesktopStatus: 200, MobileStatus: 200, ExtraOnMobile: []string{"https://example.com/app"}},
+	} {
+		if !comparison.Differs() {
+			t.Errorf("expected %+v to differ", comparison)
+		}
+	}
+}
+
+func TestLinkDifference(t *testing.T) {
+	diff := linkDifference([]string{"c", "a", "b", "a"}, []string{"b"})
+	if !reflect.DeepEqual(diff, []string{"a", "c"}) {
+		t.Errorf("linkDifference() = %v, want [a c]", diff)
+	}
+}
+
+func TestConcurrentCrawler_DualUA(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mobile := strings.Contains(r.Header.Get("User-Agent"), "Mobile")
+		w.Header().Set("Content-Type", "text/html")
+		switch {
+		case r.URL.Path == "/" && mobile:
+			fmt.Fprint(w, `<html><body><a href="/same">same</a><a href="/app">app</a></body></html>`)
+		case r.URL.Path == "/":
+			fmt.Fprint(w, `<html><body><a href="/same">same</a><a href="/desktop-only">desktop</a></body></html>`)
+		case r.URL.Path == "/desktop-only" && mobile:
+			http.NotFound(w, r)
+		default:
+			fmt.Fprint(w, `<html><body>page</body></html>`)
+		}
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 1, SameDomain: true, Workers: 2, DualUA: true})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	devices := make(map[string]*DeviceComparison)
+	for _, result := range results {
+		if result.Devices == nil {
+			t.Fatalf("expected a device comparison for %s", result.URL)
+		}
+		devices[strings.TrimPrefix(result.URL, server.URL)] = result.Devices
+	}
+
+	root := devices["/"]
+	if !reflect.DeepEqual(root.MissingOnMobile, []string{server.URL + "/desktop-only"}) ||
+		!reflect.DeepEqual(root.ExtraOnMobile, []string{server.URL + "/app"}) {
+		t.Errorf("unexpected link differences: %+v", root)
+	}
+	if devices["/desktop-only"].MobileStatus != http.StatusNotFound || !devices["/desktop-only"].Differs() {
+		t.Errorf("expected /desktop-only to be missing on mobile: %+v", devices["/desktop-only"])
+	}
+	if devices["/same"].Differs() {
+		t.Errorf("expected /same to match: %+v", devices["/same"])
+	}
+}
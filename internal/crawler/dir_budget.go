@@ -0,0 +1,63 @@
+package crawler
+
+import (
+	neturl "net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+// dirBudget limits how many URLs are crawled under each path directory
+type dirBudget struct {
+	mu     sync.Mutex
+	limit  int
+	counts map[string]int
+}
+
+// newDirBudget creates a budget of limit URLs per directory.
+// A limit below 1 disables the budget.
+func newDirBudget(limit int) *dirBudget {
+	return &dirBudget{
+		limit:  limit,
+		counts: make(map[string]int),
+	}
+}
+
+// Allow reports whether rawURL fits in its directory's budget and, if so, counts it
+func (b *dirBudget) Allow(rawURL string) bool {
+	if b == nil || b.limit < 1 {
+		return true
+	}
+
+	dir := urlDirectory(rawURL)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.counts[dir] >= b.limit {
+		return false
+	}
+	b.counts[dir]++
+	return true
+}
+
+// urlDirectory returns the host and directory of a URL, e.g.
+// "example.com/products/" for https://example.com/products/item-1
+func urlDirectory(rawURL string) string {
+	parsed, err := neturl.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	dir := parsed.Path
+	if dir == "" {
+		dir = "/"
+	}
+	if !strings.HasSuffix(dir, "/") {
+		dir = path.Dir(dir)
+		if !strings.HasSuffix(dir, "/") {
+			dir += "/"
+		}
+	}
+	return parsed.Host + dir
+}
@@ -0,0 +1,88 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestURLDirectory(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"https://example.com/products/item-1", "example.com/products/"},
+		{"https://example.com/products/", "example.com/products/"},
+		{"https://example.com/products/shoes/item?id=1", "example.com/products/shoes/"},
+		{"https://example.com/about", "example.com/"},
+		{"https://example.com", "example.com/"},
+	}
+
+	for _, tt := range tests {
+		if got := urlDirectory(tt.input); got != tt.expected {
+			t.Errorf("urlDirectory(%q) = %q; want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestDirBudget(t *testing.T) {
+	budget := newDirBudget(2)
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if budget.Allow(fmt.Sprintf("https://example.com/products/%d", i)) {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("expected 2 URLs allowed under /products/, got %d", allowed)
+	}
+	if !budget.Allow("https://example.com/blog/post") {
+		t.Error("other directories should have their own budget")
+	}
+
+	var disabled *dirBudget
+	if !disabled.Allow("https://example.com/a") || !newDirBudget(0).Allow("https://example.com/a") {
+		t.Error("disabled budget should allow everything")
+	}
+}
+
+func TestConcurrentCrawler_MaxPerDir(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path != "/" {
+			fmt.Fprint(w, "<html><body>item</body></html>")
+			return
+		}
+		var links strings.Builder
+		for i := 0; i < 8; i++ {
+			fmt.Fprintf(&links, `<a href="/products/%d">p</a><a href="/blog/%d">b</a>`, i, i)
+		}
+		fmt.Fprintf(w, "<html><body>%s</body></html>", links.String())
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 10, MaxPerDir: 3})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, result := range results {
+		counts[urlDirectory(result.URL)]++
+	}
+	for dir, count := range counts {
+		if count > 3 {
+			t.Errorf("directory %s crawled %d times, want at most 3", dir, count)
+		}
+	}
+	if stats.DirLimitSkipped != 10 {
+		t.Errorf("expected 10 URLs skipped by the directory budget, got %d", stats.DirLimitSkipped)
+	}
+}
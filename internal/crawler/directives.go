@@ -0,0 +1,55 @@
+package crawler
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+// DirectiveCount is how many pages use a robots directive
+type DirectiveCount struct {
+	Directive string // e.g. "noarchive" or "max-snippet:50"
+	Pages     int
+}
+
+// recordRobotsDirectives stores the directives of the X-Robots-Tag header
+// and, for HTML pages, of the robots meta tags (only with ExtractRobots)
+func (c *Crawler) recordRobotsDirectives(result *CrawlResult, meta responseMeta, body string) {
+	if !c.robotsMeta {
+		return
+	}
+
+	directives := parser.ParseRobotsDirectives(meta.robotsTag)
+	if kind := ContentKind(meta.contentType); kind == ContentHTML || kind == ContentUnknown {
+		for _, directive := range parser.ExtractRobotsDirectives(body) {
+			if !slices.Contains(directives, directive) {
+				directives = append(directives, directive)
+			}
+		}
+	}
+	result.Robots = directives
+}
+
+// SummarizeRobotsDirectives counts the pages of results using each robots
+// directive, the most common first
+func SummarizeRobotsDirectives(results []CrawlResult) []DirectiveCount {
+	pages := make(map[string]int)
+	for _, result := range results {
+		for _, directive := range result.Robots {
+			pages[directive]++
+		}
+	}
+
+	counts := make([]DirectiveCount, 0, len(pages))
+	for directive, count := range pages {
+		counts = append(counts, DirectiveCount{Directive: directive, Pages: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Pages != counts[j].Pages {
+			return counts[i].Pages > counts[j].Pages
+		}
+		return counts[i].Directive < counts[j].Directive
+	})
+	return counts
+}
@@ -0,0 +1,58 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConcurrentCrawler_RobotsDirectives(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("X-Robots-Tag", "noarchive")
+			fmt.Fprint(w, `<html><head><meta name="robots" content="noimageindex, max-snippet: 50, noarchive"></head>
+<body><a href="/doc.pdf">PDF</a><a href="/plain">Plain</a></body></html>`)
+		case "/doc.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Header().Set("X-Robots-Tag", "noindex")
+			fmt.Fprint(w, `%PDF-1.4 <meta name="robots" content="nofollow">`)
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, `<html><body>page</body></html>`)
+		}
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 1, SameDomain: true, Workers: 2, ExtractRobots: true})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	results, _, err := cc.CrawlConcurrent(server.URL + "/")
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	directives := make(map[string][]string)
+	for _, result := range results {
+		directives[strings.TrimPrefix(result.URL, server.URL)] = result.Robots
+	}
+	want := map[string][]string{
+		"/":        {"noarchive", "noimageindex", "max-snippet:50"},
+		"/doc.pdf": {"noindex"},
+		"/plain":   nil,
+	}
+	if !reflect.DeepEqual(directives, want) {
+		t.Errorf("directives = %v, want %v", directives, want)
+	}
+
+	counts := SummarizeRobotsDirectives(results)
+	wantCounts := []DirectiveCount{{"max-snippet:50", 1}, {"noarchive", 1}, {"noimageindex", 1}, {"noindex", 1}}
+	if !reflect.DeepEqual(counts, wantCounts) {
+		t.Errorf("SummarizeRobotsDirectives() = %v, want %v", counts, wantCounts)
+	}
+}
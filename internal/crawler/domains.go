@@ -0,0 +1,98 @@
+package crawler
+
+import (
+	neturl "net/url"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ExternalDomain is a third-party domain a page references
+type ExternalDomain struct {
+	Domain string   // Host name, e.g. "cdn.jsdelivr.net"
+	Kinds  []string // Kinds of references to the domain, sorted, e.g. "script"
+}
+
+// DomainUse is a third-party domain and how many pages reference it
+type DomainUse struct {
+	Domain string
+	Kinds  []string // Kinds of references on any page, sorted
+	Pages  int
+}
+
+// recordExternalDomains stores the third-party domains the links, scripts,
+// stylesheets, images and frames of an HTML page point to (only with
+// ExtractDomains)
+func (c *Crawler) recordExternalDomains(result *CrawlResult, contentType, body string) {
+	if !c.extractDomains {
+		return
+	}
+	if kind := ContentKind(contentType); kind != ContentHTML && kind != ContentUnknown {
+		return
+	}
+
+	refs, err := c.parser.ExtractReferences(result.URL, body)
+	if err != nil {
+		c.logger.Warn("Failed to read references", "url", result.URL, "error", err)
+		return
+	}
+	page, err := neturl.Parse(result.URL)
+	if err != nil {
+		return
+	}
+
+	kinds := make(map[string][]string)
+	for _, ref := range refs {
+		target, err := neturl.Parse(ref.URL)
+		if err != nil || target.Hostname() == "" || sameSite(page.Hostname(), target.Hostname()) {
+			continue
+		}
+		domain := strings.ToLower(target.Hostname())
+		if !slices.Contains(kinds[domain], ref.Kind) {
+			kinds[domain] = append(kinds[domain], ref.Kind)
+		}
+	}
+
+	result.ExternalDomains = nil
+	for domain, domainKinds := range kinds {
+		sort.Strings(domainKinds)
+		result.ExternalDomains = append(result.ExternalDomains, ExternalDomain{Domain: domain, Kinds: domainKinds})
+	}
+	sort.Slice(result.ExternalDomains, func(i, j int) bool {
+		return result.ExternalDomains[i].Domain < result.ExternalDomains[j].Domain
+	})
+}
+
+// SummarizeExternalDomains returns the third-party domains the pages of
+// results reference, the most common first
+func SummarizeExternalDomains(results []CrawlResult) []DomainUse {
+	uses := make(map[string]*DomainUse)
+	for _, result := range results {
+		for _, external := range result.ExternalDomains {
+			use, ok := uses[external.Domain]
+			if !ok {
+				use = &DomainUse{Domain: external.Domain}
+				uses[external.Domain] = use
+			}
+			for _, kind := range external.Kinds {
+				if !slices.Contains(use.Kinds, kind) {
+					use.Kinds = append(use.Kinds, kind)
+				}
+			}
+			use.Pages++
+		}
+	}
+
+	summaries := make([]DomainUse, 0, len(uses))
+	for _, use := range uses {
+		sort.Strings(use.Kinds)
+		summaries = append(summaries, *use)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Pages != summaries[j].Pages {
+			return summaries[i].Pages > summaries[j].Pages
+		}
+		return summaries[i].Domain < summaries[j].Domain
+	})
+	return summaries
+}
@@ -0,0 +1,58 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestConcurrentCrawler_ExternalDomains(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><script src="https://cdn.jsdelivr.net/app.js"></script>
+<link rel="stylesheet" href="https://CDN.jsdelivr.net/style.css"></head>
+<body><a href="https://twitter.com/example">Twitter</a><a href="/local">Local</a>
+<img src="/logo.png"></body></html>`)
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 0, SameDomain: true, Workers: 1, ExtractDomains: true})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	want := []ExternalDomain{
+		{Domain: "cdn.jsdelivr.net", Kinds: []string{"script", "stylesheet"}},
+		{Domain: "twitter.com", Kinds: []string{"link"}},
+	}
+	if len(results) != 1 || !reflect.DeepEqual(results[0].ExternalDomains, want) {
+		t.Errorf("expected external domains %+v, got %+v", want, results)
+	}
+}
+
+func TestSummarizeExternalDomains(t *testing.T) {
+	results := []CrawlResult{
+		{URL: "https://example.com/", ExternalDomains: []ExternalDomain{
+			{Domain: "fonts.googleapis.com", Kinds: []string{"stylesheet"}},
+			{Domain: "www.youtube.com", Kinds: []string{"frame"}},
+		}},
+		{URL: "https://example.com/a", ExternalDomains: []ExternalDomain{
+			{Domain: "www.youtube.com", Kinds: []string{"link"}},
+		}},
+		{URL: "https://example.com/b"},
+	}
+
+	want := []DomainUse{
+		{Domain: "www.youtube.com", Kinds: []string{"frame", "link"}, Pages: 2},
+		{Domain: "fonts.googleapis.com", Kinds: []string{"stylesheet"}, Pages: 1},
+	}
+	if got := SummarizeExternalDomains(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeExternalDomains() = %+v, want %+v", got, want)
+	}
+}
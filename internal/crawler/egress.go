@@ -0,0 +1,60 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+// egressPool hands out the egress proxies of a crawl (Config.Egress). Pages
+// rotate across regions, and across the proxies of a region, so each region
+// gets an equal share of the pages whatever its number of proxies.
+type egressPool struct {
+	regions [][]client.Egress // Proxies grouped by region, in configuration order
+	next    atomic.Uint64     // Pages routed so far
+}
+
+// newEgressPool builds the pool of the proxies of region, or of every region
+// if region is empty (nil without egress proxies)
+func newEgressPool(egresses []client.Egress, region string) (*egressPool, error) {
+	if len(egresses) == 0 {
+		if region != "" {
+			return nil, fmt.Errorf("region %q selected without egress proxies", region)
+		}
+		return nil, nil
+	}
+
+	pool := &egressPool{}
+	var names []string
+	for _, egress := range egresses {
+		if region != "" && egress.Region != region {
+			continue
+		}
+		i := slices.Index(names, egress.Region)
+		if i < 0 {
+			names = append(names, egress.Region)
+			pool.regions = append(pool.regions, nil)
+			i = len(names) - 1
+		}
+		pool.regions[i] = append(pool.regions[i], egress)
+	}
+	if len(pool.regions) == 0 {
+		return nil, fmt.Errorf("no egress proxy for region %q", region)
+	}
+	return pool, nil
+}
+
+// route makes the requests of ctx go through the next egress and returns
+// the region it is in
+func (p *egressPool) route(ctx context.Context) (context.Context, string) {
+	if p == nil {
+		return ctx, ""
+	}
+	n := int(p.next.Add(1) - 1)
+	proxies := p.regions[n%len(p.regions)]
+	egress := proxies[n/len(p.regions)%len(proxies)]
+	return client.WithEgress(ctx, egress), egress.Region
+}
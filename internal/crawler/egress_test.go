@@ -0,0 +1,118 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+func TestEgressPool(t *testing.T) {
+	egresses, err := client.ParseEgress([]string{
+		"eu=http://eu-1.example.com:8080",
+		"eu=http://eu-2.example.com:8080",
+		"us=http://us-1.example.com:8080",
+	})
+	if err != nil {
+		t.Fatalf("ParseEgress: %v", err)
+	}
+
+	pool, err := newEgressPool(egresses, "")
+	if err != nil {
+		t.Fatalf("newEgressPool: %v", err)
+	}
+	var regions []string
+	for i := 0; i < 4; i++ {
+		_, region := pool.route(context.Background())
+		regions = append(regions, region)
+	}
+	if fmt.Sprint(regions) != "[eu us eu us]" {
+		t.Errorf("expected pages to rotate across regions, got %v", regions)
+	}
+
+	pool, err = newEgressPool(egresses, "us")
+	if err != nil {
+		t.Fatalf("newEgressPool(us): %v", err)
+	}
+	if _, region := pool.route(context.Background()); region != "us" {
+		t.Errorf("expected region us, got %s", region)
+	}
+
+	if _, err := newEgressPool(egresses, "apac"); err == nil {
+		t.Error("expected error for a region without proxies")
+	}
+	if _, err := newEgressPool(nil, "eu"); err == nil {
+		t.Error("expected error for a region without egress proxies")
+	}
+
+	var disabled *egressPool
+	if ctx, region := disabled.route(context.Background()); region != "" || ctx == nil {
+		t.Error("a nil pool should not route requests")
+	}
+}
+
+func TestConcurrentCrawler_Egress(t *testing.T) {
+	// Each proxy serves the site itself and records the pages it fetched
+	var mu sync.Mutex
+	served := make(map[string]string) // Path -> region
+	proxy := func(region string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			served[r.URL.Path] = region
+			mu.Unlock()
+			w.Header().Set("Content-Type", "text/html")
+			if r.URL.Path == "/" {
+				fmt.Fprint(w, `<html><body><a href="/a">A</a><a href="/b">B</a><a href="/c">C</a></body></html>`)
+				return
+			}
+			fmt.Fprint(w, "<html><body>page</body></html>")
+		}))
+	}
+	eu, us := proxy("eu"), proxy("us")
+	defer eu.Close()
+	defer us.Close()
+
+	egresses, err := client.ParseEgress([]string{"eu=" + eu.URL, "us=" + us.URL})
+	if err != nil {
+		t.Fatalf("ParseEgress: %v", err)
+	}
+
+	// The site is only reachable through the proxies
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:   -1,
+		SameDomain: true,
+		Workers:    2,
+		Egress:     egresses,
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, _, err := cc.CrawlConcurrent("http://geo.invalid/")
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+
+	regions := make(map[string]int)
+	for _, result := range results {
+		if result.Error != nil {
+			t.Errorf("unexpected error for %s: %v", result.URL, result.Error)
+			continue
+		}
+		path := result.URL[len("http://geo.invalid"):]
+		if result.Egress != served[path] {
+			t.Errorf("%s: reported egress %q, fetched through %q", result.URL, result.Egress, served[path])
+		}
+		regions[result.Egress]++
+	}
+	if regions["eu"] != 2 || regions["us"] != 2 {
+		t.Errorf("expected pages split evenly across regions, got %v", regions)
+	}
+}
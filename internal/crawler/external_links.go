@@ -0,0 +1,26 @@
+package crawler
+
+import "github.com/aoshimash/urlmap/internal/url"
+
+// recordExternalLinks stores the links of an HTML page to other sites (only
+// with ExtractExternalLinks), which link extraction leaves out of Links
+func (c *Crawler) recordExternalLinks(result *CrawlResult, contentType, body string) {
+	if !c.externalLinks {
+		return
+	}
+	if kind := ContentKind(contentType); kind != ContentHTML && kind != ContentUnknown {
+		return
+	}
+
+	links, err := c.parser.ExtractLinks(result.URL, body)
+	if err != nil {
+		c.logger.Warn("Failed to read external links", "url", result.URL, "error", err)
+		return
+	}
+	result.ExternalLinks = nil
+	for _, link := range links {
+		if isSame, err := url.IsSameDomain(result.URL, link); err == nil && !isSame {
+			result.ExternalLinks = append(result.ExternalLinks, link)
+		}
+	}
+}
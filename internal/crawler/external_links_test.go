@@ -0,0 +1,35 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestConcurrentCrawler_ExternalLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><a href="https://twitter.com/example">Twitter</a><a href="/local">Local</a>
+<script src="https://cdn.jsdelivr.net/app.js"></script></body></html>`)
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 0, SameDomain: true, Workers: 1, ExtractExternalLinks: true})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	want := []string{"https://twitter.com/example"}
+	if len(results) != 1 || !reflect.DeepEqual(results[0].ExternalLinks, want) {
+		t.Errorf("expected external links %v, got %+v", want, results)
+	}
+	if !reflect.DeepEqual(results[0].Links, []string{server.URL + "/local"}) {
+		t.Errorf("expected links [%s/local], got %v", server.URL, results[0].Links)
+	}
+}
@@ -0,0 +1,84 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+var (
+	// ErrDisallowed is returned by a Gate for URLs robots.txt disallows
+	ErrDisallowed = errors.New("disallowed by robots.txt")
+	// ErrCircuitOpen is returned by a Gate for hosts the circuit breaker skips
+	ErrCircuitOpen = errors.New("circuit open for host")
+)
+
+// Gate sends a request to rawURL with send once the crawl's limits allow it,
+// for requests made outside the crawl queue such as those of
+// Config.PlanResume. It returns ErrDisallowed or ErrCircuitOpen without
+// sending the request if the crawl would skip the URL; an error of send
+// counts as a connection failure of the host.
+type Gate func(rawURL string, send func() error) error
+
+// gate holds back a request like processJob holds back fetching a page: the
+// rate limits, the host's back-off, robots.txt and its Crawl-delay as the
+// politeness profile requires, and the circuit breaker
+func (s *crawlSession) gate(rawURL string, send func() error) error {
+	if s.progress != nil {
+		s.progress.WaitForRateLimit()
+	}
+
+	var crawlDelay time.Duration
+	if s.robotsChecker != nil {
+		if allowed, err := s.robotsChecker.IsAllowed(rawURL); err == nil && !allowed {
+			return ErrDisallowed
+		}
+		if delay, err := s.robotsChecker.GetCrawlDelay(rawURL); err == nil && delay > 0 {
+			crawlDelay = delay
+		}
+	}
+
+	host, _ := url.ExtractDomain(rawURL)
+	if err := s.throttle.Wait(s.ctx, host); err != nil {
+		return err
+	}
+	if s.progress != nil {
+		if err := s.progress.WaitForHostRateLimit(s.ctx, host); err != nil {
+			return err
+		}
+	}
+	if !s.breaker.Allow(host) {
+		return ErrCircuitOpen
+	}
+
+	release, err := s.waitPolitely(host, crawlDelay)
+	if err != nil {
+		return err
+	}
+	err = send()
+	release()
+
+	if err != nil && !errors.Is(err, context.Canceled) {
+		if s.breaker.RecordFailure(host) {
+			s.logger.Warn("Too many consecutive failures, skipping host",
+				"host", host, "cool_off", s.breaker.coolOff, "error", err)
+		}
+	} else if err == nil {
+		s.breaker.RecordSuccess(host)
+	}
+	return err
+}
+
+// planResume adds the pages Config.PlanResume decides not to fetch again to
+// the results the crawl resumes from
+func (s *crawlSession) planResume() {
+	if s.planner == nil || s.resumeFrom != nil {
+		return
+	}
+	if planned := s.planner(s.ctx, s.gate); len(planned) > 0 {
+		s.resume = slices.Concat(planned, s.resume)
+	}
+}
@@ -0,0 +1,81 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConcurrentCrawler_PlanResume(t *testing.T) {
+	var seedFetches atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+		case "/":
+			seedFetches.Add(1)
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, `<html><body><a href="/a">A</a></body></html>`)
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, `<html><body>leaf</body></html>`)
+		}
+	}))
+	defer server.Close()
+
+	var gateErrors []error
+	sent := 0
+	send := func() error {
+		sent++
+		return nil
+	}
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:         -1,
+		SameDomain:       true,
+		Workers:          1,
+		RespectRobots:    true,
+		BreakerThreshold: 2,
+		BreakerCoolOff:   time.Minute,
+		PlanResume: func(ctx context.Context, gate Gate) []CrawlResult {
+			// Requests are skipped like the crawl would skip the URL
+			gateErrors = append(gateErrors, gate(server.URL+"/private", send))
+			refused := func() error { return errors.New("connection refused") }
+			for range 3 {
+				gateErrors = append(gateErrors, gate("http://localhost:1/page", refused))
+			}
+			gateErrors = append(gateErrors, gate(server.URL+"/", send))
+			return []CrawlResult{{URL: server.URL + "/", Links: []string{server.URL + "/a"}}}
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	if !errors.Is(gateErrors[0], ErrDisallowed) {
+		t.Errorf("gate() for a disallowed URL = %v, want ErrDisallowed", gateErrors[0])
+	}
+	if !errors.Is(gateErrors[3], ErrCircuitOpen) {
+		t.Errorf("gate() after repeated failures = %v, want ErrCircuitOpen", gateErrors[3])
+	}
+	if gateErrors[4] != nil || sent != 1 {
+		t.Errorf("gate() for an allowed URL = %v with %d requests sent, want 1", gateErrors[4], sent)
+	}
+
+	// The planned page is resumed instead of fetched
+	if len(results) != 1 || results[0].URL != server.URL+"/a" {
+		t.Errorf("crawled %+v, want only %s/a", results, server.URL)
+	}
+	if seedFetches.Load() != 0 {
+		t.Error("the planned page was fetched again")
+	}
+}
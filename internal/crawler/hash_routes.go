@@ -0,0 +1,12 @@
+package crawler
+
+import "github.com/aoshimash/urlmap/internal/url"
+
+// normalizeURL normalizes rawURL, keeping its hash route when the crawler
+// crawls hash routes
+func (c *Crawler) normalizeURL(rawURL string) (string, error) {
+	if c.hashRoutes {
+		return url.NormalizeHashRouteURL(rawURL)
+	}
+	return url.NormalizeURL(rawURL)
+}
@@ -0,0 +1,88 @@
+package crawler
+
+import (
+	neturl "net/url"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// HintOrigin is a third-party origin named in resource hints
+type HintOrigin struct {
+	Origin string   // Scheme and host, e.g. "https://fonts.gstatic.com"
+	Rels   []string // Hint relations used for the origin, sorted
+	Pages  int      // Pages with a hint for the origin
+}
+
+// recordHints stores the resource hints of an HTML page (only with ExtractHints)
+func (c *Crawler) recordHints(result *CrawlResult, contentType, body string) {
+	if !c.extractHints {
+		return
+	}
+	if kind := ContentKind(contentType); kind != ContentHTML && kind != ContentUnknown {
+		return
+	}
+
+	hints, err := c.parser.ExtractResourceHints(result.URL, body)
+	if err != nil {
+		c.logger.Warn("Failed to read resource hints", "url", result.URL, "error", err)
+		return
+	}
+	result.Hints = hints
+}
+
+// SummarizeHints returns the third-party origins the pages of results give
+// resource hints for, the most common first. An origin is third-party when
+// its host is neither the page's host nor shares its domain, so
+// cdn.example.com is first-party for www.example.com.
+func SummarizeHints(results []CrawlResult) []HintOrigin {
+	origins := make(map[string]*HintOrigin)
+	for _, result := range results {
+		page, err := neturl.Parse(result.URL)
+		if err != nil {
+			continue
+		}
+		counted := make(map[string]bool)
+		for _, hint := range result.Hints {
+			target, err := neturl.Parse(hint.URL)
+			if err != nil || sameSite(page.Hostname(), target.Hostname()) {
+				continue
+			}
+
+			origin := target.Scheme + "://" + target.Host
+			summary, ok := origins[origin]
+			if !ok {
+				summary = &HintOrigin{Origin: origin}
+				origins[origin] = summary
+			}
+			if !slices.Contains(summary.Rels, hint.Rel) {
+				summary.Rels = append(summary.Rels, hint.Rel)
+			}
+			if !counted[origin] {
+				counted[origin] = true
+				summary.Pages++
+			}
+		}
+	}
+
+	summaries := make([]HintOrigin, 0, len(origins))
+	for _, summary := range origins {
+		sort.Strings(summary.Rels)
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Pages != summaries[j].Pages {
+			return summaries[i].Pages > summaries[j].Pages
+		}
+		return summaries[i].Origin < summaries[j].Origin
+	})
+	return summaries
+}
+
+// sameSite reports whether host belongs to the site served from pageHost:
+// the same host, or the same domain once a leading "www." is dropped
+func sameSite(pageHost, host string) bool {
+	site := strings.TrimPrefix(strings.ToLower(pageHost), "www.")
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	return host == site || strings.HasSuffix(host, "."+site) || strings.HasSuffix(site, "."+host)
+}
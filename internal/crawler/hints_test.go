@@ -0,0 +1,65 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+func TestSummarizeHints(t *testing.T) {
+	results := []CrawlResult{
+		{URL: "https://www.example.com/", Hints: []parser.ResourceHint{
+			{Rel: "preconnect", URL: "https://fonts.gstatic.com/"},
+			{Rel: "preload", URL: "https://fonts.gstatic.com/font.woff2"},
+			{Rel: "preload", URL: "https://cdn.example.com/app.js"},
+			{Rel: "prefetch", URL: "https://www.example.com/next"},
+		}},
+		{URL: "https://www.example.com/about", Hints: []parser.ResourceHint{
+			{Rel: "dns-prefetch", URL: "https://www.googletagmanager.com/"},
+			{Rel: "preconnect", URL: "https://fonts.gstatic.com/"},
+		}},
+		{URL: "https://www.example.com/plain"},
+	}
+
+	want := []HintOrigin{
+		{Origin: "https://fonts.gstatic.com", Rels: []string{"preconnect", "preload"}, Pages: 2},
+		{Origin: "https://www.googletagmanager.com", Rels: []string{"dns-prefetch"}, Pages: 1},
+	}
+	if got := SummarizeHints(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeHints() = %+v, want %+v", got, want)
+	}
+}
+
+func TestConcurrentCrawler_Hints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><link rel="preconnect" href="https://fonts.gstatic.com">
+<link rel="stylesheet" href="/style.css"></head><body>page</body></html>`)
+	}))
+	defer server.Close()
+
+	crawl := func(extract bool) []CrawlResult {
+		cc, err := NewConcurrentCrawler(&Config{MaxDepth: 0, SameDomain: true, Workers: 1, ExtractHints: extract})
+		if err != nil {
+			t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+		}
+		results, _, err := cc.CrawlConcurrent(server.URL)
+		if err != nil {
+			t.Fatalf("CrawlConcurrent() failed: %v", err)
+		}
+		return results
+	}
+
+	if results := crawl(false); len(results) != 1 || results[0].Hints != nil {
+		t.Errorf("expected no hints without ExtractHints, got %+v", results)
+	}
+
+	want := []parser.ResourceHint{{Rel: "preconnect", URL: "https://fonts.gstatic.com"}}
+	if results := crawl(true); len(results) != 1 || !reflect.DeepEqual(results[0].Hints, want) {
+		t.Errorf("expected hints %+v, got %+v", want, results)
+	}
+}
@@ -0,0 +1,47 @@
+package crawler
+
+import (
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// Historical URLs (Config.HistoricalURLs) come from web archives. They are
+// queued once the live crawl ran dry, so only the ones the live site does
+// not link to anymore are fetched; those that still resolve are tagged
+// Historical and those that do not are dropped from the results.
+
+// flushHistorical queues the historical URLs that were not crawled once no
+// other work is left. It returns false if there was nothing to queue.
+func (s *crawlSession) flushHistorical() bool {
+	s.historicalMu.Lock()
+	urls := s.historical
+	s.historical = nil
+	s.historicalMu.Unlock()
+
+	if len(urls) == 0 || s.ctx.Err() != nil {
+		return false
+	}
+
+	var jobs []CrawlJob
+	for _, rawURL := range urls {
+		normalized, err := url.NormalizeURL(rawURL)
+		if err != nil {
+			continue
+		}
+		normalized = s.stripSessionIDs(normalized)
+		if s.admitLink(normalized) {
+			jobs = append(jobs, CrawlJob{URL: normalized, Depth: 0, Historical: true})
+		}
+	}
+	if len(jobs) == 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	s.stats.TotalURLs += len(jobs)
+	s.stats.Historical += len(jobs)
+	s.mu.Unlock()
+
+	s.logger.Info("Checking historical URLs not linked from the site", "count", len(jobs))
+	s.sendJobs(jobs)
+	return true
+}
@@ -0,0 +1,58 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConcurrentCrawler_HistoricalURLs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><a href="/linked">Linked</a></body></html>`)
+		case "/linked", "/orphan":
+			fmt.Fprint(w, "<html><body>page</body></html>")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:       3,
+		SameDomain:     true,
+		Workers:        2,
+		HistoricalURLs: []string{server.URL + "/linked", server.URL + "/orphan", server.URL + "/gone", "https://other.example.com/"},
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL + "/")
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	historical := make(map[string]bool)
+	for _, result := range results {
+		historical[result.URL] = result.Historical
+	}
+	expected := map[string]bool{
+		server.URL + "/":       false,
+		server.URL + "/linked": false,
+		server.URL + "/orphan": true,
+	}
+	if fmt.Sprint(historical) != fmt.Sprint(expected) {
+		t.Errorf("expected results %v, got %v", expected, historical)
+	}
+
+	if stats.Historical != 2 || stats.HistoricalGone != 1 {
+		t.Errorf("expected 2 historical URLs with 1 gone, got %d and %d", stats.Historical, stats.HistoricalGone)
+	}
+	if stats.FailedURLs != 0 {
+		t.Errorf("historical URLs that are gone should not count as failures, got %d", stats.FailedURLs)
+	}
+}
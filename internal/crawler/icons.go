@@ -0,0 +1,75 @@
+package crawler
+
+import (
+	neturl "net/url"
+	"slices"
+	"sort"
+
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+// OriginIcons are the icons and manifests the pages of an origin link to
+type OriginIcons struct {
+	Origin string        // Scheme and host of the pages, e.g. "https://example.com"
+	Icons  []parser.Icon // Icons and manifests, in the order first found
+}
+
+// recordIcons stores the icons and manifest of an HTML page (only with ExtractIcons)
+func (c *Crawler) recordIcons(result *CrawlResult, contentType, body string) {
+	if !c.extractIcons {
+		return
+	}
+	if kind := ContentKind(contentType); kind != ContentHTML && kind != ContentUnknown {
+		return
+	}
+
+	icons, err := c.parser.ExtractIcons(result.URL, body)
+	if err != nil {
+		c.logger.Warn("Failed to read icons", "url", result.URL, "error", err)
+		return
+	}
+	result.Icons = icons
+}
+
+// CollectIcons groups the icons of the successfully crawled pages of results
+// by origin, sorted by origin. An origin whose pages link no favicon gets
+// /favicon.ico, which browsers request instead.
+func CollectIcons(results []CrawlResult) []OriginIcons {
+	origins := make(map[string]*OriginIcons)
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		page, err := neturl.Parse(result.URL)
+		if err != nil || page.Host == "" {
+			continue
+		}
+
+		origin := page.Scheme + "://" + page.Host
+		summary, ok := origins[origin]
+		if !ok {
+			summary = &OriginIcons{Origin: origin}
+			origins[origin] = summary
+		}
+		for _, icon := range result.Icons {
+			if !slices.Contains(summary.Icons, icon) {
+				summary.Icons = append(summary.Icons, icon)
+			}
+		}
+	}
+
+	summaries := make([]OriginIcons, 0, len(origins))
+	for _, summary := range origins {
+		hasFavicon := slices.ContainsFunc(summary.Icons, func(icon parser.Icon) bool {
+			return icon.Rel == parser.RelIcon
+		})
+		if !hasFavicon {
+			summary.Icons = append([]parser.Icon{{Rel: parser.RelIcon, URL: summary.Origin + "/favicon.ico"}}, summary.Icons...)
+		}
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Origin < summaries[j].Origin
+	})
+	return summaries
+}
@@ -0,0 +1,68 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+func TestCollectIcons(t *testing.T) {
+	results := []CrawlResult{
+		{URL: "https://example.com/", Icons: []parser.Icon{
+			{Rel: parser.RelIcon, URL: "https://example.com/favicon.svg"},
+			{Rel: parser.RelManifest, URL: "https://example.com/site.webmanifest"},
+		}},
+		{URL: "https://example.com/about", Icons: []parser.Icon{
+			{Rel: parser.RelIcon, URL: "https://example.com/favicon.svg"},
+		}},
+		{URL: "https://blog.example.com/", Icons: []parser.Icon{
+			{Rel: parser.RelAppleTouchIcon, URL: "https://blog.example.com/apple.png", Sizes: "180x180"},
+		}},
+		{URL: "https://down.example.com/", Error: errors.New("HTTP error: 500")},
+	}
+
+	want := []OriginIcons{
+		{Origin: "https://blog.example.com", Icons: []parser.Icon{
+			{Rel: parser.RelIcon, URL: "https://blog.example.com/favicon.ico"},
+			{Rel: parser.RelAppleTouchIcon, URL: "https://blog.example.com/apple.png", Sizes: "180x180"},
+		}},
+		{Origin: "https://example.com", Icons: []parser.Icon{
+			{Rel: parser.RelIcon, URL: "https://example.com/favicon.svg"},
+			{Rel: parser.RelManifest, URL: "https://example.com/site.webmanifest"},
+		}},
+	}
+	if got := CollectIcons(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectIcons() = %+v, want %+v", got, want)
+	}
+}
+
+func TestConcurrentCrawler_Icons(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><link rel="icon" sizes="32x32" href="/favicon-32.png">
+<link rel="manifest" href="/site.webmanifest"></head><body>page</body></html>`)
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 0, SameDomain: true, Workers: 1, ExtractIcons: true})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	want := []parser.Icon{
+		{Rel: parser.RelIcon, URL: server.URL + "/favicon-32.png", Sizes: "32x32"},
+		{Rel: parser.RelManifest, URL: server.URL + "/site.webmanifest"},
+	}
+	if len(results) != 1 || !reflect.DeepEqual(results[0].Icons, want) {
+		t.Errorf("expected icons %+v, got %+v", want, results)
+	}
+}
@@ -0,0 +1,78 @@
+package crawler
+
+import (
+	"sort"
+
+	"github.com/aoshimash/urlmap/internal/language"
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+// LanguageCount is how many pages are in a language
+type LanguageCount struct {
+	Language string
+	Pages    int
+	Detected int // Pages whose language was guessed from their text
+}
+
+// LanguageSummary counts the crawled HTML pages per language
+type LanguageSummary struct {
+	Languages   []LanguageCount // The most common first
+	MissingLang []string        // Pages without a lang attribute, in the order of results
+}
+
+// recordLanguage stores the language of an HTML page: its lang attribute or,
+// without one, the language its text is written in (only with DetectLanguage)
+func (c *Crawler) recordLanguage(result *CrawlResult, contentType, body string) {
+	if !c.detectLanguage {
+		return
+	}
+	if kind := ContentKind(contentType); kind != ContentHTML && kind != ContentUnknown {
+		return
+	}
+
+	lang, text := parser.ExtractLang(body)
+	if lang != "" {
+		result.Language = lang
+		return
+	}
+	result.Language = language.Detect(text)
+	result.LanguageDetected = true
+}
+
+// SummarizeLanguages counts the pages of results per language. Pages whose
+// language could not be detected count as "unknown".
+func SummarizeLanguages(results []CrawlResult) LanguageSummary {
+	var summary LanguageSummary
+	counts := make(map[string]*LanguageCount)
+	for _, result := range results {
+		if result.Language == "" && !result.LanguageDetected {
+			continue // Not an HTML page, or failed
+		}
+
+		lang := result.Language
+		if lang == "" {
+			lang = "unknown"
+		}
+		count, ok := counts[lang]
+		if !ok {
+			count = &LanguageCount{Language: lang}
+			counts[lang] = count
+		}
+		count.Pages++
+		if result.LanguageDetected {
+			count.Detected++
+			summary.MissingLang = append(summary.MissingLang, result.URL)
+		}
+	}
+
+	for _, count := range counts {
+		summary.Languages = append(summary.Languages, *count)
+	}
+	sort.Slice(summary.Languages, func(i, j int) bool {
+		if summary.Languages[i].Pages != summary.Languages[j].Pages {
+			return summary.Languages[i].Pages > summary.Languages[j].Pages
+		}
+		return summary.Languages[i].Language < summary.Languages[j].Language
+	})
+	return summary
+}
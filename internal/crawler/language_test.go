@@ -0,0 +1,69 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConcurrentCrawler_DetectLanguage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html lang="en-US"><body><a href="/de">Deutsch</a><a href="/short">Short</a></body></html>`)
+		case "/de":
+			fmt.Fprint(w, `<html><body><p>Die schnelle braune Katze springt über den faulen Hund und läuft in den Wald, um etwas zu essen.</p></body></html>`)
+		default:
+			fmt.Fprint(w, `<html><body>Hi</body></html>`)
+		}
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 1, SameDomain: true, Workers: 2, DetectLanguage: true})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	results, _, err := cc.CrawlConcurrent(server.URL + "/")
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	languages := make(map[string]string)
+	for _, result := range results {
+		lang := result.Language
+		if result.LanguageDetected {
+			lang += " (detected)"
+		}
+		languages[strings.TrimPrefix(result.URL, server.URL)] = lang
+	}
+	want := map[string]string{"/": "en-us", "/de": "de (detected)", "/short": " (detected)"}
+	if !reflect.DeepEqual(languages, want) {
+		t.Errorf("languages = %v, want %v", languages, want)
+	}
+}
+
+func TestSummarizeLanguages(t *testing.T) {
+	results := []CrawlResult{
+		{URL: "https://example.com/", Language: "en"},
+		{URL: "https://example.com/a", Language: "en", LanguageDetected: true},
+		{URL: "https://example.com/ja/", Language: "ja"},
+		{URL: "https://example.com/empty", LanguageDetected: true},
+		{URL: "https://example.com/feed.xml"},
+	}
+
+	want := LanguageSummary{
+		Languages: []LanguageCount{
+			{Language: "en", Pages: 2, Detected: 1},
+			{Language: "ja", Pages: 1},
+			{Language: "unknown", Pages: 1, Detected: 1},
+		},
+		MissingLang: []string{"https://example.com/a", "https://example.com/empty"},
+	}
+	if got := SummarizeLanguages(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeLanguages() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package crawler
+
+import (
+	neturl "net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// localeSegment matches path segments that look like locale codes,
+// e.g. "en", "ja", "en-us", "pt_BR" or "zh-Hant"
+var localeSegment = regexp.MustCompile(`^[a-zA-Z]{2}([-_]([a-zA-Z]{2}|[a-zA-Z]{4}))?$`)
+
+// localeScope limits crawling to URLs under the allowed locale prefixes.
+// URLs without a locale segment (shared pages) are always allowed.
+type localeScope struct {
+	allowed []string
+}
+
+// newLocaleScope creates a scope for the given locales, or nil if none are given
+func newLocaleScope(locales []string) *localeScope {
+	var allowed []string
+	for _, locale := range locales {
+		if locale = normalizeLocale(locale); locale != "" {
+			allowed = append(allowed, locale)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	return &localeScope{allowed: allowed}
+}
+
+// Allow reports whether rawURL is outside any locale prefix or under an allowed one.
+// An allowed language also matches its regional variants ("en" matches "/en-gb/").
+func (s *localeScope) Allow(rawURL string) bool {
+	if s == nil {
+		return true
+	}
+
+	locale, ok := pathLocale(rawURL)
+	if !ok {
+		return true
+	}
+
+	for _, allowed := range s.allowed {
+		if locale == allowed || strings.HasPrefix(locale, allowed+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// pathLocale returns the normalized locale of the URL's first path segment
+func pathLocale(rawURL string) (string, bool) {
+	parsed, err := neturl.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+
+	segment, _, _ := strings.Cut(strings.TrimPrefix(parsed.Path, "/"), "/")
+	if !localeSegment.MatchString(segment) {
+		return "", false
+	}
+	return normalizeLocale(segment), true
+}
+
+// normalizeLocale lowercases a locale and uses "-" as separator
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// LocalesFromAcceptLanguage returns the languages listed in an Accept-Language
+// header, skipping wildcards and languages with q=0
+func LocalesFromAcceptLanguage(header string) []string {
+	var locales []string
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				continue
+			}
+		}
+
+		locales = append(locales, normalizeLocale(tag))
+	}
+	return locales
+}
@@ -0,0 +1,88 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLocaleScopeAllow(t *testing.T) {
+	scope := newLocaleScope([]string{"en", "ja"})
+
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{"https://example.com/en/docs", true},
+		{"https://example.com/en-GB/docs", true},
+		{"https://example.com/ja/", true},
+		{"https://example.com/fr/docs", false},
+		{"https://example.com/pt_BR/docs", false},
+		{"https://example.com/zh-Hant/", false},
+		{"https://example.com/about", true},
+		{"https://example.com/", true},
+	}
+
+	for _, tt := range tests {
+		if got := scope.Allow(tt.url); got != tt.expected {
+			t.Errorf("Allow(%q) = %v; want %v", tt.url, got, tt.expected)
+		}
+	}
+
+	if newLocaleScope(nil) != nil || !newLocaleScope(nil).Allow("https://example.com/fr/") {
+		t.Error("empty scope should allow every locale")
+	}
+}
+
+func TestLocalesFromAcceptLanguage(t *testing.T) {
+	got := LocalesFromAcceptLanguage("en-US,en;q=0.9, ja;q=0.8, fr;q=0, *;q=0.1")
+	expected := []string{"en-us", "en", "ja"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("LocalesFromAcceptLanguage() = %v; want %v", got, expected)
+	}
+
+	if got := LocalesFromAcceptLanguage(""); len(got) != 0 {
+		t.Errorf("expected no locales for empty header, got %v", got)
+	}
+}
+
+func TestConcurrentCrawler_LangPrefixes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path != "/" {
+			fmt.Fprint(w, "<html><body>page</body></html>")
+			return
+		}
+		var links strings.Builder
+		for _, locale := range []string{"en", "ja", "fr", "de"} {
+			fmt.Fprintf(&links, `<a href="/%s/">%s</a>`, locale, locale)
+		}
+		fmt.Fprintf(w, "<html><body>%s<a href=\"/about\">about</a></body></html>", links.String())
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 2, LangPrefixes: []string{"en", "ja"}})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	for _, result := range results {
+		if strings.Contains(result.URL, "/fr/") || strings.Contains(result.URL, "/de/") {
+			t.Errorf("other locale should not be crawled: %s", result.URL)
+		}
+	}
+	if stats.LocaleSkipped != 2 || len(stats.SkippedAlternates) != 2 {
+		t.Errorf("expected 2 skipped alternates, got %d: %v", stats.LocaleSkipped, stats.SkippedAlternates)
+	}
+	if len(results) != 4 {
+		t.Errorf("expected root, /en/, /ja/ and /about to be crawled, got %d results", len(results))
+	}
+}
@@ -0,0 +1,53 @@
+package crawler
+
+import "github.com/aoshimash/urlmap/internal/client"
+
+// responseMeta is everything a crawl result may keep of a response. The record
+// functions take it instead of the response, so results hold the body's size
+// and hash but never the body, headers beyond these or cookies; the body
+// itself is only read for link extraction and then dropped. The only content
+// kept is the start of HTTP error bodies, when asked for.
+type responseMeta struct {
+	statusCode   int
+	finalURL     string
+	contentType  string
+	etag         string
+	lastModified string
+	server       string
+	size         int
+	contentHash  string
+	errorBody    string // Start of the body of an HTTP error response
+	robotsTag    string // X-Robots-Tag header
+
+	botProtection string // Bot protection that answered with a challenge, if any
+}
+
+// newResponseMeta reduces response to the metadata kept in results, keeping
+// up to errorBodySize bytes of the body of HTTP error responses
+func newResponseMeta(response client.UnifiedResponse, errorBodySize int) responseMeta {
+	body := response.String()
+	meta := responseMeta{
+		statusCode:   response.StatusCode(),
+		finalURL:     response.FinalURL(),
+		contentType:  response.Header("Content-Type"),
+		etag:         response.Header("ETag"),
+		lastModified: response.Header("Last-Modified"),
+		server:       response.Header("Server"),
+		robotsTag:    response.Header("X-Robots-Tag"),
+		size:         len(body),
+		contentHash:  contentHash(body),
+	}
+	if meta.statusCode >= 400 {
+		meta.errorBody = client.BodySnippet(body, errorBodySize)
+		meta.botProtection = detectBotProtection(meta.statusCode, response.Header, body)
+	}
+	return meta
+}
+
+// recordErrorResponse stores what tells apart the causes of an HTTP error,
+// such as a WAF block page, a rate limit or a genuine server error
+func recordErrorResponse(result *CrawlResult, meta responseMeta) {
+	result.Server = meta.server
+	result.ErrorBody = meta.errorBody
+	result.BotProtection = meta.botProtection
+}
@@ -0,0 +1,160 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestCrawlResult_HoldsNoContent guards the guarantee behind --no-store-content:
+// results may only hold metadata, never response bodies, headers or cookies
+func TestCrawlResult_HoldsNoContent(t *testing.T) {
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	forbidden := map[reflect.Type]bool{
+		reflect.TypeOf([]byte(nil)):      true,
+		reflect.TypeOf(http.Header(nil)): true,
+		reflect.TypeOf(http.Cookie{}):    true,
+		reflect.TypeOf(http.Request{}):   true,
+		reflect.TypeOf(http.Response{}):  true,
+		reflect.TypeOf(responseMeta{}):   true,
+	}
+
+	seen := make(map[reflect.Type]bool)
+	var check func(path string, typ reflect.Type)
+	check = func(path string, typ reflect.Type) {
+		if seen[typ] {
+			return
+		}
+		seen[typ] = true
+
+		if forbidden[typ] {
+			t.Errorf("%s has type %s, which can carry response content", path, typ)
+			return
+		}
+		switch typ.Kind() {
+		case reflect.Interface:
+			if typ != errorType {
+				t.Errorf("%s is interface %s, which could hold a response", path, typ)
+			}
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			check(path, typ.Elem())
+		case reflect.Map:
+			check(path, typ.Key())
+			check(path, typ.Elem())
+		case reflect.Struct:
+			for i := 0; i < typ.NumField(); i++ {
+				field := typ.Field(i)
+				check(path+"."+field.Name, field.Type)
+			}
+		}
+	}
+	check("CrawlResult", reflect.TypeOf(CrawlResult{}))
+}
+
+func TestNewResponseMeta(t *testing.T) {
+	body := "<html><body>hello</body></html>"
+	meta := newResponseMeta(&stubResponse{
+		body:   body,
+		status: http.StatusOK,
+		header: http.Header{"Content-Type": {"text/html"}, "Etag": {`"v1"`}, "Set-Cookie": {"id=1"}},
+	}, 512)
+
+	want := responseMeta{
+		statusCode:  http.StatusOK,
+		finalURL:    "https://example.com/final",
+		contentType: "text/html",
+		etag:        `"v1"`,
+		size:        len(body),
+		contentHash: contentHash(body),
+	}
+	if meta != want {
+		t.Errorf("newResponseMeta() = %+v, want %+v", meta, want)
+	}
+
+	// Only error bodies are kept, and only when asked for
+	errorResponse := &stubResponse{body: "<h1>Access denied</h1>", status: http.StatusForbidden, header: http.Header{"Server": {"cloudflare"}}}
+	if meta := newResponseMeta(errorResponse, 512); meta.errorBody != "<h1>Access denied</h1>" || meta.server != "cloudflare" {
+		t.Errorf("newResponseMeta() kept error body %q, server %q", meta.errorBody, meta.server)
+	}
+	if meta := newResponseMeta(errorResponse, 0); meta.errorBody != "" {
+		t.Errorf("newResponseMeta() kept error body %q without errorBodySize", meta.errorBody)
+	}
+}
+
+// stubResponse is a client.UnifiedResponse with fixed content
+type stubResponse struct {
+	body   string
+	status int
+	header http.Header
+}
+
+func (r *stubResponse) String() string            { return r.body }
+func (r *stubResponse) StatusCode() int           { return r.status }
+func (r *stubResponse) Header(name string) string { return r.header.Get(name) }
+func (r *stubResponse) FinalURL() string          { return "https://example.com/final" }
+
+func TestConcurrentCrawler_ExtractMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><title> Home </title></head><body></body></html>`)
+	}))
+	defer server.Close()
+
+	for _, extract := range []bool{false, true} {
+		cc, err := NewConcurrentCrawler(&Config{MaxDepth: 0, Workers: 1, ExtractMetadata: extract})
+		if err != nil {
+			t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+		}
+		results, _, err := cc.CrawlConcurrent(server.URL)
+		if err != nil {
+			t.Fatalf("CrawlConcurrent() failed: %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("got %d results, want 1", len(results))
+		}
+
+		want := ""
+		if extract {
+			want = "Home"
+		}
+		if results[0].Title != want {
+			t.Errorf("ExtractMetadata=%v: Title = %q, want %q", extract, results[0].Title, want)
+		}
+	}
+}
+
+func TestConcurrentCrawler_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "cloudflare")
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `<html><body><a href="/blocked">blocked</a></body></html>`)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "<html>\n<title>Attention Required!</title>\n</html>")
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, Workers: 1, ErrorBodySize: 512})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	for _, result := range results {
+		if result.Error == nil {
+			if result.Server != "" || result.ErrorBody != "" {
+				t.Errorf("%s: successful page kept server %q, body %q", result.URL, result.Server, result.ErrorBody)
+			}
+			continue
+		}
+		if result.Server != "cloudflare" || result.ErrorBody != "<html> <title>Attention Required!</title> </html>" {
+			t.Errorf("%s: server %q, error body %q", result.URL, result.Server, result.ErrorBody)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package crawler
+
+import (
+	"sort"
+
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+// OGImageUse is an og:image and the pages declaring it
+type OGImageUse struct {
+	URL   string
+	Pages []string // In the order of results
+}
+
+// recordOGImage stores the og:image of an HTML page (only with ExtractOGImage)
+func (c *Crawler) recordOGImage(result *CrawlResult, contentType, body string) {
+	if !c.ogImage {
+		return
+	}
+	if kind := ContentKind(contentType); kind != ContentHTML && kind != ContentUnknown {
+		return
+	}
+	result.OGImage = parser.ExtractOGImage(result.URL, body)
+}
+
+// CollectOGImages returns the og:images of the pages of results with the
+// pages declaring each, sorted by image URL
+func CollectOGImages(results []CrawlResult) []OGImageUse {
+	pages := make(map[string][]string)
+	for _, result := range results {
+		if result.OGImage != "" {
+			pages[result.OGImage] = append(pages[result.OGImage], result.URL)
+		}
+	}
+
+	uses := make([]OGImageUse, 0, len(pages))
+	for image, declaring := range pages {
+		uses = append(uses, OGImageUse{URL: image, Pages: declaring})
+	}
+	sort.Slice(uses, func(i, j int) bool {
+		return uses[i].URL < uses[j].URL
+	})
+	return uses
+}
@@ -0,0 +1,46 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestCollectOGImages(t *testing.T) {
+	results := []CrawlResult{
+		{URL: "https://example.com/b", OGImage: "https://example.com/card.png"},
+		{URL: "https://example.com/a", OGImage: "https://cdn.example.com/a.png"},
+		{URL: "https://example.com/c"},
+		{URL: "https://example.com/d", OGImage: "https://example.com/card.png"},
+	}
+
+	want := []OGImageUse{
+		{URL: "https://cdn.example.com/a.png", Pages: []string{"https://example.com/a"}},
+		{URL: "https://example.com/card.png", Pages: []string{"https://example.com/b", "https://example.com/d"}},
+	}
+	if got := CollectOGImages(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectOGImages() = %+v, want %+v", got, want)
+	}
+}
+
+func TestConcurrentCrawler_OGImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><meta property="og:image" content="/card.png"></head><body>page</body></html>`)
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 0, SameDomain: true, Workers: 1, ExtractOGImage: true})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+	if len(results) != 1 || results[0].OGImage != server.URL+"/card.png" {
+		t.Errorf("expected og:image %s, got %+v", server.URL+"/card.png", results)
+	}
+}
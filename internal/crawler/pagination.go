@@ -0,0 +1,30 @@
+package crawler
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// recordNextPage adds the next page of a paginated page to the links of
+// result, unless the page links to it already or its number is over
+// MaxPagination
+func (c *Crawler) recordNextPage(result *CrawlResult) {
+	if c.maxPagination <= 0 {
+		return
+	}
+
+	next, page, ok := url.NextPage(result.URL)
+	if !ok || page > c.maxPagination || slices.Contains(result.Links, next) {
+		return
+	}
+	result.Links = append(result.Links, next)
+	result.nextPage = next
+}
+
+// missingPage reports whether the server says a page does not exist, which
+// for a guessed next page means its pagination ended
+func missingPage(result CrawlResult) bool {
+	return result.StatusCode == http.StatusNotFound || result.StatusCode == http.StatusGone
+}
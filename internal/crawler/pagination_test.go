@@ -0,0 +1,105 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// newPaginatedSite serves /blog?page=1 to /blog?page=3 without next links,
+// and /news/page/1/ linking to the missing /news/page/2/
+func newPaginatedSite(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch {
+		case r.URL.Path == "/":
+			fmt.Fprint(w, `<html><body><a href="/blog?page=1">blog</a><a href="/news/page/1/">news</a></body></html>`)
+		case r.URL.Path == "/blog" && slices.Contains([]string{"1", "2", "3"}, r.URL.Query().Get("page")):
+			fmt.Fprint(w, `<html><body><div id="pager"></div></body></html>`)
+		case r.URL.Path == "/news/page/1":
+			fmt.Fprint(w, `<html><body><a href="/news/page/2/">next</a></body></html>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// crawledPaths returns the sorted paths of results, with a "!" suffix for failed ones
+func crawledPaths(serverURL string, results []CrawlResult) []string {
+	var paths []string
+	for _, result := range results {
+		path := strings.TrimPrefix(result.URL, serverURL)
+		if result.Error != nil {
+			path += "!"
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestConcurrentCrawler_Pagination(t *testing.T) {
+	server := newPaginatedSite(t)
+
+	crawl := func(maxPagination int) ([]string, *CrawlStats) {
+		cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 2, MaxPagination: maxPagination})
+		if err != nil {
+			t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+		}
+		results, stats, err := cc.CrawlConcurrent(server.URL)
+		if err != nil {
+			t.Fatalf("CrawlConcurrent() failed: %v", err)
+		}
+		return crawledPaths(server.URL, results), stats
+	}
+
+	// A linked missing page is still a failure
+	want := []string{"/", "/blog?page=1", "/news/page/1", "/news/page/2!"}
+	if got, _ := crawl(0); !reflect.DeepEqual(got, want) {
+		t.Errorf("crawled %v, want %v", got, want)
+	}
+
+	// Guessing stops at the missing page 4, which is skipped
+	want = []string{"/", "/blog?page=1", "/blog?page=2", "/blog?page=3", "/news/page/1", "/news/page/2!"}
+	got, stats := crawl(10)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("with MaxPagination 10 crawled %v, want %v", got, want)
+	}
+	if stats.SkippedURLs != 1 || stats.FailedURLs != 1 {
+		t.Errorf("skipped %d, failed %d URLs; want 1, 1", stats.SkippedURLs, stats.FailedURLs)
+	}
+
+	// Pages over the cap are not guessed
+	want = []string{"/", "/blog?page=1", "/blog?page=2", "/news/page/1", "/news/page/2!"}
+	if got, _ := crawl(2); !reflect.DeepEqual(got, want) {
+		t.Errorf("with MaxPagination 2 crawled %v, want %v", got, want)
+	}
+}
+
+func TestCrawler_Pagination(t *testing.T) {
+	server := newPaginatedSite(t)
+
+	c, err := New(&Config{MaxDepth: -1, SameDomain: true, MaxPagination: 10})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	results, stats, err := c.CrawlRecursive(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlRecursive() failed: %v", err)
+	}
+
+	want := []string{"/", "/blog?page=1", "/blog?page=2", "/blog?page=3", "/news/page/1", "/news/page/2!"}
+	if got := crawledPaths(server.URL, results); !reflect.DeepEqual(got, want) {
+		t.Errorf("crawled %v, want %v", got, want)
+	}
+	if stats.SkippedURLs != 1 {
+		t.Errorf("SkippedURLs = %d, want 1", stats.SkippedURLs)
+	}
+}
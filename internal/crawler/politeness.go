@@ -0,0 +1,131 @@
+package crawler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Politeness profiles, the supported values of Config.Politeness
+const (
+	// PolitenessStrict sends one request at a time to each host and keeps
+	// the robots.txt Crawl-delay between them, whatever the rate limit
+	PolitenessStrict = "strict"
+	// PolitenessNormal makes each worker wait the Crawl-delay before its
+	// request, and reschedules throttled URLs DefaultMaxThrottleRetries times
+	PolitenessNormal = "normal"
+	// PolitenessAggressive lets a rate limit replace the Crawl-delay and
+	// reschedules throttled URLs more often
+	PolitenessAggressive = "aggressive"
+)
+
+// PolitenessProfiles are the supported values of Config.Politeness
+var PolitenessProfiles = []string{PolitenessStrict, PolitenessNormal, PolitenessAggressive}
+
+// politenessProfile describes how a profile combines Crawl-delay, per-host
+// concurrency and rescheduling of throttled URLs
+type politenessProfile struct {
+	hostConcurrency int  // Requests in flight per host (0 = as many as workers)
+	spaceRequests   bool // Crawl-delay separates all requests to a host, not each worker's
+	rateLimitWins   bool // A rate limit replaces the Crawl-delay
+	throttleRetries int  // Times a throttled URL is rescheduled
+}
+
+var politenessProfiles = map[string]politenessProfile{
+	PolitenessStrict:     {hostConcurrency: 1, spaceRequests: true, throttleRetries: 1},
+	PolitenessNormal:     {throttleRetries: DefaultMaxThrottleRetries},
+	PolitenessAggressive: {rateLimitWins: true, throttleRetries: 2 * DefaultMaxThrottleRetries},
+}
+
+// hostSlots limits the requests in flight per host and spaces them by the
+// host's Crawl-delay
+type hostSlots struct {
+	mu    sync.Mutex
+	limit int
+	slots map[string]chan struct{}
+	last  map[string]time.Time
+}
+
+// newHostSlots creates slots allowing limit requests in flight per host
+func newHostSlots(limit int) *hostSlots {
+	return &hostSlots{
+		limit: limit,
+		slots: make(map[string]chan struct{}),
+		last:  make(map[string]time.Time),
+	}
+}
+
+// Acquire blocks until a request to host may start, at least delay after the
+// previous one started, or ctx is done. Release must be called once the
+// request is done.
+func (h *hostSlots) Acquire(ctx context.Context, host string, delay time.Duration) error {
+	h.mu.Lock()
+	slot, ok := h.slots[host]
+	if !ok {
+		slot = make(chan struct{}, h.limit)
+		h.slots[host] = slot
+	}
+	h.mu.Unlock()
+
+	select {
+	case slot <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	h.mu.Lock()
+	wait := time.Until(h.last[host].Add(delay))
+	h.last[host] = time.Now().Add(max(wait, 0))
+	h.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		h.Release(host)
+		return ctx.Err()
+	}
+}
+
+// Release frees the slot taken by Acquire
+func (h *hostSlots) Release(host string) {
+	h.mu.Lock()
+	slot := h.slots[host]
+	h.mu.Unlock()
+	<-slot
+}
+
+// waitPolitely holds back a request to host as the politeness profile
+// requires, given the host's robots.txt Crawl-delay. The returned func must
+// be called once the request is done.
+func (s *crawlSession) waitPolitely(host string, crawlDelay time.Duration) (func(), error) {
+	if s.politeness.rateLimitWins && s.progressConfig != nil && (s.progressConfig.RateLimit > 0 || s.progressConfig.RateLimitPerHost > 0) {
+		crawlDelay = 0
+	}
+
+	if s.hostSlots != nil {
+		if err := s.hostSlots.Acquire(s.ctx, host, crawlDelay); err != nil {
+			return nil, err
+		}
+		return func() { s.hostSlots.Release(host) }, nil
+	}
+
+	if crawlDelay > 0 {
+		s.logger.Debug("Applying robots.txt crawl delay", "host", host, "delay", crawlDelay)
+		timer := time.NewTimer(crawlDelay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+	}
+	return func() {}, nil
+}
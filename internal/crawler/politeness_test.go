@@ -0,0 +1,126 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHostSlots(t *testing.T) {
+	slots := newHostSlots(1)
+	ctx := context.Background()
+
+	// Requests to a host are spaced by the delay
+	start := time.Now()
+	if err := slots.Acquire(ctx, "example.com", 50*time.Millisecond); err != nil {
+		t.Fatalf("Acquire() error: %v", err)
+	}
+	slots.Release("example.com")
+	if err := slots.Acquire(ctx, "example.com", 50*time.Millisecond); err != nil {
+		t.Fatalf("Acquire() error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("second Acquire() returned after %v, expected at least 50ms", elapsed)
+	}
+
+	// Other hosts are not held back
+	if err := slots.Acquire(ctx, "other.example.com", 0); err != nil {
+		t.Fatalf("Acquire() error: %v", err)
+	}
+	slots.Release("other.example.com")
+
+	// A host whose slot is taken waits until cancelled
+	cancelled, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := slots.Acquire(cancelled, "example.com", 0); err == nil {
+		t.Error("expected error while the host's slot is taken")
+	}
+	slots.Release("example.com")
+}
+
+func TestNewConcurrentCrawler_Politeness(t *testing.T) {
+	tests := []struct {
+		politeness  string
+		maxThrottle int
+		hostSlots   bool
+		robots      bool
+	}{
+		{"", DefaultMaxThrottleRetries, false, false},
+		{PolitenessNormal, DefaultMaxThrottleRetries, false, false},
+		{PolitenessStrict, 1, true, true},
+		{PolitenessAggressive, 2 * DefaultMaxThrottleRetries, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.politeness, func(t *testing.T) {
+			cc, err := NewConcurrentCrawler(&Config{Workers: 2, Politeness: tt.politeness})
+			if err != nil {
+				t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+			}
+			if cc.maxThrottle != tt.maxThrottle {
+				t.Errorf("maxThrottle = %d, want %d", cc.maxThrottle, tt.maxThrottle)
+			}
+			if (cc.hostSlots != nil) != tt.hostSlots {
+				t.Errorf("hostSlots set = %v, want %v", cc.hostSlots != nil, tt.hostSlots)
+			}
+			if (cc.robotsChecker != nil) != tt.robots {
+				t.Errorf("robotsChecker set = %v, want %v", cc.robotsChecker != nil, tt.robots)
+			}
+		})
+	}
+
+	// An explicit retry limit wins over the profile
+	cc, err := NewConcurrentCrawler(&Config{Politeness: PolitenessStrict, MaxThrottleRetries: 5})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	if cc.maxThrottle != 5 {
+		t.Errorf("maxThrottle = %d, want 5", cc.maxThrottle)
+	}
+
+	if _, err := NewConcurrentCrawler(&Config{Politeness: "rude"}); err == nil {
+		t.Error("expected error for unknown politeness profile")
+	}
+}
+
+func TestConcurrentCrawler_StrictPoliteness(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if n := inFlight.Add(1); n > maxInFlight.Load() {
+			maxInFlight.Store(n)
+		}
+		defer inFlight.Add(-1)
+		time.Sleep(10 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path == "/" {
+			for i := range 6 {
+				fmt.Fprintf(w, `<a href="/page%d">page</a>`, i)
+			}
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 1, SameDomain: true, Workers: 4, Politeness: PolitenessStrict})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	if len(results) != 7 {
+		t.Errorf("expected 7 results, got %d", len(results))
+	}
+	if maxInFlight.Load() != 1 {
+		t.Errorf("expected one request in flight per host, got up to %d", maxInFlight.Load())
+	}
+}
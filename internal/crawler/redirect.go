@@ -0,0 +1,22 @@
+package crawler
+
+import "github.com/aoshimash/urlmap/internal/url"
+
+// recordRedirect stores the URL a page was served from if redirects led
+// somewhere other than the crawled URL. The hash route of a crawled URL is
+// not part of the document it is served from.
+func recordRedirect(result *CrawlResult, finalURL string) {
+	if finalURL == "" {
+		return
+	}
+	if normalized, err := url.NormalizeURL(finalURL); err == nil {
+		finalURL = normalized
+	}
+	page := result.URL
+	if normalized, err := url.NormalizeURL(page); err == nil {
+		page = normalized
+	}
+	if finalURL != result.URL && finalURL != page {
+		result.FinalURL = finalURL
+	}
+}
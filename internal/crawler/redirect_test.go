@@ -0,0 +1,78 @@
+package crawler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecordRedirect(t *testing.T) {
+	tests := []struct {
+		finalURL string
+		expected string
+	}{
+		{"", ""},
+		{"https://example.com/docs", ""},
+		{"https://example.com/docs/", ""},
+		{"https://example.com/docs#intro", ""},
+		{"https://example.com/guide", "https://example.com/guide"},
+	}
+
+	for _, tt := range tests {
+		result := CrawlResult{URL: "https://example.com/docs"}
+		recordRedirect(&result, tt.finalURL)
+		if result.FinalURL != tt.expected {
+			t.Errorf("recordRedirect(%q) FinalURL = %q; want %q", tt.finalURL, result.FinalURL, tt.expected)
+		}
+	}
+}
+
+func TestRecordRedirect_HashRoute(t *testing.T) {
+	result := CrawlResult{URL: "https://example.com/#/about"}
+	recordRedirect(&result, "https://example.com/#/about")
+	if result.FinalURL != "" {
+		t.Errorf("FinalURL = %q, want none for the page of a hash route", result.FinalURL)
+	}
+
+	recordRedirect(&result, "https://example.com/login")
+	if result.FinalURL != "https://example.com/login" {
+		t.Errorf("FinalURL = %q, want https://example.com/login", result.FinalURL)
+	}
+}
+
+func TestConcurrentCrawler_RecordsRedirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`<html><body><a href="/old">old</a></body></html>`))
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		default:
+			w.Write([]byte("<html><body>new</body></html>"))
+		}
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 1, SameDomain: true, Workers: 1})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	for _, result := range results {
+		switch result.URL {
+		case server.URL + "/old":
+			if result.FinalURL != server.URL+"/new" {
+				t.Errorf("expected /old to record its redirect to /new, got %q", result.FinalURL)
+			}
+		default:
+			if result.FinalURL != "" {
+				t.Errorf("unexpected redirect recorded for %s: %q", result.URL, result.FinalURL)
+			}
+		}
+	}
+}
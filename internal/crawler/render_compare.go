@@ -0,0 +1,89 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+// RenderComparison holds the link counts of the static and the
+// JavaScript-rendered version of a page
+type RenderComparison struct {
+	StaticLinks   int // Links found in the HTML served over HTTP
+	RenderedLinks int // Links found after JavaScript rendering
+}
+
+// Difference returns how many more links rendering found
+func (r RenderComparison) Difference() int {
+	return r.RenderedLinks - r.StaticLinks
+}
+
+// NeedsRendering reports whether rendering found links the static HTML lacks
+func (r RenderComparison) NeedsRendering() bool {
+	return r.Difference() > 0
+}
+
+// compareRendering fetches the other version of the page (rendered if the
+// response was static, static if it was rendered) and records both link counts
+func (cc *ConcurrentCrawler) compareRendering(ctx context.Context, result *CrawlResult, response client.UnifiedResponse) {
+	_, rendered := response.(*client.JSResponse)
+
+	other, err := cc.fetchOtherVersion(ctx, result.URL, rendered)
+	if err != nil {
+		cc.logger.Warn("Render comparison failed", "url", result.URL, "error", err)
+		return
+	}
+
+	otherLinks, err := cc.extractLinks(result.URL, other)
+	if err != nil {
+		cc.logger.Warn("Render comparison failed", "url", result.URL, "error", err)
+		return
+	}
+
+	comparison := &RenderComparison{StaticLinks: len(result.Links), RenderedLinks: len(otherLinks)}
+	if rendered {
+		comparison.StaticLinks, comparison.RenderedLinks = len(otherLinks), len(result.Links)
+	}
+	result.Render = comparison
+
+	cc.logger.Debug("Compared static and rendered links", "url", result.URL,
+		"static_links", comparison.StaticLinks, "rendered_links", comparison.RenderedLinks)
+}
+
+// fetchOtherVersion fetches the page over HTTP if rendered is set, otherwise
+// renders it in the browser
+func (cc *ConcurrentCrawler) fetchOtherVersion(ctx context.Context, pageURL string, rendered bool) (string, error) {
+	pageURL = cc.rewrites.FetchURL(pageURL)
+	if rendered {
+		response, err := cc.client.FetchHTTP(ctx, pageURL)
+		if err != nil {
+			return "", fmt.Errorf("static fetch failed: %w", err)
+		}
+		return response.String(), nil
+	}
+
+	response, err := cc.client.FetchJS(ctx, pageURL)
+	if err != nil {
+		return "", fmt.Errorf("rendering failed: %w", err)
+	}
+	return response.String(), nil
+}
+
+// extractLinks extracts links from htmlContent, honouring the same-domain setting
+func (c *Crawler) extractLinks(pageURL, htmlContent string) ([]string, error) {
+	var links []string
+	var err error
+	if c.sameDomain && c.rewrites == nil {
+		links, err = c.parser.ExtractSameDomainLinks(pageURL, htmlContent)
+	} else {
+		links, err = c.parser.ExtractLinks(pageURL, htmlContent)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract links: %w", err)
+	}
+	if c.rewrites != nil {
+		links = c.restoreLinks(pageURL, links)
+	}
+	return c.stripLinkSessionIDs(links), nil
+}
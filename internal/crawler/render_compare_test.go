@@ -0,0 +1,48 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+func TestRenderComparison(t *testing.T) {
+	comparison := RenderComparison{StaticLinks: 2, RenderedLinks: 5}
+	if comparison.Difference() != 3 || !comparison.NeedsRendering() {
+		t.Errorf("unexpected comparison: difference=%d needs=%v", comparison.Difference(), comparison.NeedsRendering())
+	}
+	if (RenderComparison{StaticLinks: 4, RenderedLinks: 4}).NeedsRendering() {
+		t.Error("equal link counts should not need rendering")
+	}
+}
+
+func TestCompareRendering_RenderedResponse(t *testing.T) {
+	// The static HTML only has one link; the rendered page has three
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><a href="/a">a</a><div id="app"></div></body></html>`)
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 0, SameDomain: true, Workers: 1})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	result := CrawlResult{
+		URL:   server.URL + "/",
+		Links: []string{server.URL + "/a", server.URL + "/b", server.URL + "/c"},
+	}
+	cc.compareRendering(context.Background(), &result, &client.JSResponse{Status: 200})
+
+	if result.Render == nil {
+		t.Fatal("expected render comparison to be recorded")
+	}
+	if result.Render.StaticLinks != 1 || result.Render.RenderedLinks != 3 {
+		t.Errorf("unexpected comparison: %+v", *result.Render)
+	}
+}
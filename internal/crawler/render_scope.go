@@ -0,0 +1,40 @@
+package crawler
+
+import (
+	"github.com/aoshimash/urlmap/internal/filter"
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// renderChoice is how the render filter wants a page fetched
+type renderChoice int
+
+const (
+	renderDefault renderChoice = iota // As the JavaScript configuration decides
+	renderAlways                      // Rendered with JavaScript
+	renderNever                       // Fetched with plain HTTP
+)
+
+// renderOnly reports whether the render filter selects the pages to render,
+// rather than only excluding some
+func renderOnly(f *filter.Filter) bool {
+	return f != nil && len(f.Includes()) > 0
+}
+
+// renderMode decides how targetURL is fetched. Pages matching an exclude
+// pattern of the render filter are never rendered. With include patterns,
+// matching pages are always rendered and all others never are. Hash routes
+// the crawler follows are always rendered, unless the filter rules them out.
+func (c *Crawler) renderMode(targetURL string) renderChoice {
+	switch {
+	case !c.renderFilter.IsEmpty() && !c.renderFilter.Allow(targetURL):
+		return renderNever
+	case c.hashRoutes && url.IsHashRoute(targetURL):
+		return renderAlways
+	case c.renderFilter.IsEmpty():
+		return renderDefault
+	case renderOnly(c.renderFilter):
+		return renderAlways
+	default:
+		return renderDefault
+	}
+}
@@ -0,0 +1,66 @@
+package crawler
+
+import (
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/filter"
+)
+
+func TestRenderMode(t *testing.T) {
+	tests := []struct {
+		name  string
+		only  []string
+		never []string
+		url   string
+		want  renderChoice
+	}{
+		{"no filter", nil, nil, "https://example.com/app/", renderDefault},
+		{"never path", nil, []string{"/docs/*"}, "https://example.com/docs/intro", renderNever},
+		{"outside never paths", nil, []string{"/docs/*"}, "https://example.com/app/", renderDefault},
+		{"only path", []string{"/app/*"}, nil, "https://example.com/app/settings", renderAlways},
+		{"outside only paths", []string{"/app/*"}, nil, "https://example.com/blog/", renderNever},
+		{"never wins over only", []string{"/app/*"}, []string{"/app/help/*"}, "https://example.com/app/help/faq", renderNever},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var renderFilter *filter.Filter
+			if tt.only != nil || tt.never != nil {
+				var err error
+				renderFilter, err = filter.New(tt.only, tt.never)
+				if err != nil {
+					t.Fatalf("filter.New() failed: %v", err)
+				}
+			}
+			c := &Crawler{renderFilter: renderFilter}
+			if got := c.renderMode(tt.url); got != tt.want {
+				t.Errorf("renderMode(%q) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMode_HashRoutes(t *testing.T) {
+	renderFilter, err := filter.New(nil, []string{"/admin*"})
+	if err != nil {
+		t.Fatalf("filter.New() failed: %v", err)
+	}
+	c := &Crawler{hashRoutes: true, renderFilter: renderFilter}
+
+	tests := map[string]renderChoice{
+		"https://example.com/#/about": renderAlways,
+		"https://example.com/about":   renderDefault,
+		"https://example.com/#top":    renderDefault,
+		"https://example.com/admin#/": renderNever,
+	}
+	for targetURL, want := range tests {
+		if got := c.renderMode(targetURL); got != want {
+			t.Errorf("renderMode(%q) = %v, want %v", targetURL, got, want)
+		}
+	}
+
+	c.hashRoutes = false
+	if got := c.renderMode("https://example.com/#/about"); got != renderDefault {
+		t.Errorf("renderMode() without hash routes = %v, want %v", got, renderDefault)
+	}
+}
@@ -0,0 +1,26 @@
+package crawler
+
+import (
+	"errors"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+// recordRendering keeps what the browser reported about a rendered page:
+// whether it is partial, and the console errors and failed requests of the
+// page, also when its rendering failed with err (only with
+// JSConfig.Diagnostics). The browser does not go through egress proxies, so
+// rendered pages report no egress region.
+func recordRendering(result *CrawlResult, response client.UnifiedResponse, err error) {
+	if rendered, ok := response.(*client.JSResponse); ok {
+		result.RenderDiagnostics = rendered.Diagnostics
+		result.PartialRender = rendered.Partial
+		result.Egress = ""
+		return
+	}
+	var renderErr *client.RenderError
+	if errors.As(err, &renderErr) {
+		result.RenderDiagnostics = renderErr.Diagnostics
+		result.Egress = ""
+	}
+}
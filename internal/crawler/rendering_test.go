@@ -0,0 +1,53 @@
+package crawler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+func TestRecordRendering(t *testing.T) {
+	diagnostics := &client.RenderDiagnostics{FailedRequests: []string{"GET https://example.com/api: 404"}}
+
+	var rendered CrawlResult
+	recordRendering(&rendered, &client.JSResponse{Diagnostics: diagnostics}, nil)
+	if rendered.RenderDiagnostics != diagnostics {
+		t.Errorf("expected the diagnostics of the rendered page, got %+v", rendered.RenderDiagnostics)
+	}
+	if rendered.PartialRender {
+		t.Error("fully rendered page marked as partial")
+	}
+
+	var partial CrawlResult
+	recordRendering(&partial, &client.JSResponse{Partial: true}, nil)
+	if !partial.PartialRender {
+		t.Error("expected the page to be marked as partially rendered")
+	}
+
+	// A page that failed to render keeps what was collected until then
+	var failed CrawlResult
+	err := fmt.Errorf("failed to fetch: %w", &client.RenderError{Err: fmt.Errorf("timeout"), Diagnostics: diagnostics})
+	recordRendering(&failed, nil, err)
+	if failed.RenderDiagnostics != diagnostics {
+		t.Errorf("expected the diagnostics of the failed render, got %+v", failed.RenderDiagnostics)
+	}
+
+	var static CrawlResult
+	recordRendering(&static, nil, fmt.Errorf("connection refused"))
+	if static.RenderDiagnostics != nil {
+		t.Errorf("expected no diagnostics, got %+v", static.RenderDiagnostics)
+	}
+
+	// The browser does not go through the egress proxy the page was routed to
+	routed := CrawlResult{Egress: "eu"}
+	recordRendering(&routed, &client.JSResponse{}, nil)
+	if routed.Egress != "" {
+		t.Errorf("rendered page reported egress region %q", routed.Egress)
+	}
+	fetched := CrawlResult{Egress: "eu"}
+	recordRendering(&fetched, &client.HTTPResponseWrapper{}, nil)
+	if fetched.Egress != "eu" {
+		t.Errorf("fetched page lost its egress region, got %q", fetched.Egress)
+	}
+}
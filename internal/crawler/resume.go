@@ -0,0 +1,52 @@
+package crawler
+
+import "slices"
+
+// resumeJobs marks the pages of an interrupted run as visited and returns the
+// jobs that continue it: failed pages are retried and links of crawled pages
+// that were not crawled yet are queued, if the classifier accepts them. The
+// seed is queued only if the interrupted run did not reach it.
+func (s *crawlSession) resumeJobs(seed string, completed []CrawlResult) []CrawlJob {
+	var jobs []CrawlJob
+	done := 0
+	for _, result := range completed {
+		if _, loaded := s.visited.LoadOrStore(result.URL, true); loaded {
+			continue
+		}
+		s.sampler.Allow(result.URL)
+		if result.Error != nil {
+			jobs = append(jobs, CrawlJob{URL: result.URL, Depth: result.Depth, Parent: result.Parent, LowConfidence: result.LowConfidence, Tags: result.Tags})
+		} else {
+			done++
+		}
+	}
+
+	if _, loaded := s.visited.LoadOrStore(seed, true); !loaded {
+		s.sampler.Allow(seed)
+		jobs = append(jobs, CrawlJob{URL: seed, Depth: 0})
+	}
+
+	for _, result := range completed {
+		if result.Error != nil {
+			continue
+		}
+		for _, link := range result.Links {
+			if !s.admitLink(link) {
+				continue
+			}
+			crawl, tags := s.classify(link, result.URL, result.Depth+1)
+			if !crawl {
+				continue
+			}
+			jobs = append(jobs, CrawlJob{URL: link, Depth: result.Depth + 1, Parent: result.URL,
+				LowConfidence: slices.Contains(result.ScriptLinks, link), Tags: tags})
+		}
+	}
+
+	s.mu.Lock()
+	s.stats.TotalURLs = done + len(jobs)
+	s.mu.Unlock()
+
+	s.logger.Info("Resuming crawl", "completed", done, "queued", len(jobs))
+	return jobs
+}
@@ -0,0 +1,137 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentCrawler_Resume(t *testing.T) {
+	var mu sync.Mutex
+	fetched := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetched[r.URL.Path]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><a href="/a">A</a><a href="/b">B</a></body></html>`)
+		case "/a":
+			fmt.Fprint(w, `<html><body><a href="/a/1">A1</a></body></html>`)
+		default:
+			fmt.Fprint(w, `<html><body>leaf</body></html>`)
+		}
+	}))
+	defer server.Close()
+
+	// The interrupted run crawled the seed and /a, and failed on /b
+	completed := []CrawlResult{
+		{URL: server.URL + "/", Links: []string{server.URL + "/a", server.URL + "/b"}},
+		{URL: server.URL + "/a", Depth: 1, Parent: server.URL + "/", Links: []string{server.URL + "/a/1"}},
+		{URL: server.URL + "/b", Depth: 1, Parent: server.URL + "/", Error: errors.New("timeout")},
+	}
+
+	var streamed []string
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:   -1,
+		SameDomain: true,
+		Workers:    2,
+		Resume:     completed,
+		OnResult: func(result CrawlResult) {
+			streamed = append(streamed, result.URL)
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	var urls []string
+	for _, result := range results {
+		urls = append(urls, result.URL)
+	}
+	sort.Strings(urls)
+	sort.Strings(streamed)
+	want := []string{server.URL + "/a/1", server.URL + "/b"}
+	if fmt.Sprint(urls) != fmt.Sprint(want) {
+		t.Errorf("crawled %v, want %v", urls, want)
+	}
+	if fmt.Sprint(streamed) != fmt.Sprint(want) {
+		t.Errorf("OnResult got %v, want %v", streamed, want)
+	}
+	if fetched["/"] != 0 || fetched["/a"] != 0 {
+		t.Errorf("completed pages were fetched again: %v", fetched)
+	}
+	if stats.TotalURLs != 4 {
+		t.Errorf("TotalURLs = %d, want 4", stats.TotalURLs)
+	}
+}
+
+func TestConcurrentCrawler_ResumeFinished(t *testing.T) {
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:   -1,
+		SameDomain: true,
+		Workers:    1,
+		Resume:     []CrawlResult{{URL: "https://example.com/"}},
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, _, err := cc.CrawlConcurrent("https://example.com/")
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected nothing to crawl, got %d results", len(results))
+	}
+}
+
+func TestConcurrentCrawler_ResumeClassify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body>leaf</body></html>`)
+	}))
+	defer server.Close()
+
+	// Links of resumed pages go through the classifier like any other link
+	completed := []CrawlResult{
+		{URL: server.URL + "/", Links: []string{server.URL + "/products/1", server.URL + "/admin"}},
+	}
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:   -1,
+		SameDomain: true,
+		Workers:    2,
+		Resume:     completed,
+		Classify: func(link, parent string, depth int) (bool, []string, error) {
+			if strings.HasSuffix(link, "/admin") {
+				return false, nil, nil
+			}
+			return true, []string{"product"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+	if len(results) != 1 || results[0].URL != server.URL+"/products/1" || fmt.Sprint(results[0].Tags) != "[product]" {
+		t.Errorf("expected only the tagged product page, got %+v", results)
+	}
+	if stats.ClassifySkipped != 1 {
+		t.Errorf("expected 1 URL skipped by the classifier, got %d", stats.ClassifySkipped)
+	}
+}
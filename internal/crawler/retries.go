@@ -0,0 +1,83 @@
+package crawler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// RetriedURL is a page that needed more than one request attempt
+type RetriedURL struct {
+	URL      string
+	Attempts []client.Attempt
+	Failed   bool // The page still failed after its last attempt
+}
+
+// HostRetries summarizes the retried pages of a host
+type HostRetries struct {
+	Host       string
+	URLs       int           // Pages that needed more than one attempt
+	Attempts   int           // Attempts made for those pages
+	Failed     int           // Pages that failed after all attempts
+	AvgLatency time.Duration // Average duration of those attempts
+}
+
+// RetrySummary lists the pages that were retried, per host and per URL
+type RetrySummary struct {
+	Hosts []HostRetries // Most retried pages first
+	URLs  []RetriedURL  // Most attempts first
+}
+
+// SummarizeRetries collects the pages of results that needed more than one
+// attempt, so flaky hosts and URLs stand out from plain failures
+func SummarizeRetries(results []CrawlResult) RetrySummary {
+	var summary RetrySummary
+	hosts := make(map[string]*HostRetries)
+	latency := make(map[string]time.Duration)
+
+	for _, result := range results {
+		if len(result.Attempts) < 2 {
+			continue
+		}
+		summary.URLs = append(summary.URLs, RetriedURL{
+			URL:      result.URL,
+			Attempts: result.Attempts,
+			Failed:   result.Error != nil,
+		})
+
+		host, err := url.ExtractDomain(result.URL)
+		if err != nil {
+			host = result.URL
+		}
+		stats, ok := hosts[host]
+		if !ok {
+			stats = &HostRetries{Host: host}
+			hosts[host] = stats
+		}
+		stats.URLs++
+		stats.Attempts += len(result.Attempts)
+		if result.Error != nil {
+			stats.Failed++
+		}
+		for _, attempt := range result.Attempts {
+			latency[host] += attempt.Duration
+		}
+	}
+
+	for host, stats := range hosts {
+		stats.AvgLatency = latency[host] / time.Duration(stats.Attempts)
+		summary.Hosts = append(summary.Hosts, *stats)
+	}
+	sort.Slice(summary.Hosts, func(i, j int) bool {
+		if summary.Hosts[i].URLs != summary.Hosts[j].URLs {
+			return summary.Hosts[i].URLs > summary.Hosts[j].URLs
+		}
+		return summary.Hosts[i].Host < summary.Hosts[j].Host
+	})
+	sort.SliceStable(summary.URLs, func(i, j int) bool {
+		return len(summary.URLs[i].Attempts) > len(summary.URLs[j].Attempts)
+	})
+	return summary
+}
@@ -0,0 +1,70 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+func TestSummarizeRetries(t *testing.T) {
+	results := []CrawlResult{
+		{URL: "https://example.com/ok", Attempts: []client.Attempt{{StatusCode: 200, Duration: time.Millisecond}}},
+		{URL: "https://example.com/flaky", Attempts: []client.Attempt{
+			{StatusCode: 503, Duration: 30 * time.Millisecond},
+			{StatusCode: 200, Duration: 10 * time.Millisecond},
+		}},
+		{URL: "https://example.com/down", Error: errors.New("HTTP error: 502"), Attempts: []client.Attempt{
+			{StatusCode: 502, Duration: 20 * time.Millisecond},
+			{Error: "timeout", Duration: 50 * time.Millisecond},
+			{StatusCode: 502, Duration: 10 * time.Millisecond},
+		}},
+		{URL: "https://api.example.com/v1", Attempts: []client.Attempt{{Error: "reset"}, {StatusCode: 200}}},
+	}
+
+	summary := SummarizeRetries(results)
+
+	if len(summary.URLs) != 3 || summary.URLs[0].URL != "https://example.com/down" || !summary.URLs[0].Failed {
+		t.Fatalf("expected 3 retried URLs with the failed one first, got %+v", summary.URLs)
+	}
+	want := HostRetries{Host: "example.com", URLs: 2, Attempts: 5, Failed: 1, AvgLatency: 24 * time.Millisecond}
+	if len(summary.Hosts) != 2 || summary.Hosts[0] != want {
+		t.Errorf("Hosts = %+v, want %+v first", summary.Hosts, want)
+	}
+}
+
+func TestConcurrentCrawler_RecordsAttemptsAcrossReschedules(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html><body>ok</body></html>")
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 0, SameDomain: true, Workers: 1})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	attempts := results[0].Attempts
+	if len(attempts) != 2 || attempts[0].StatusCode != 429 || attempts[1].StatusCode != 200 {
+		t.Errorf("expected a throttled attempt followed by a 200, got %+v", attempts)
+	}
+}
@@ -0,0 +1,97 @@
+package crawler
+
+import (
+	"fmt"
+	neturl "net/url"
+	"strings"
+
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// hostRewrites maps the hosts of discovered URLs to the hosts they are
+// fetched from. Results keep the original URLs.
+type hostRewrites struct {
+	fetch   map[string]string // Original host -> fetched host
+	restore map[string]string // Fetched host -> original host
+}
+
+// ParseHostRewrites parses "from=to" host mappings, e.g. "example.com=staging.example.com"
+func ParseHostRewrites(specs []string) (map[string]string, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	rewrites := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		from, to, ok := strings.Cut(spec, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" || strings.Contains(from, "/") || strings.Contains(to, "/") {
+			return nil, fmt.Errorf("invalid host rewrite %q (expected FROM=TO, e.g. example.com=staging.example.com)", spec)
+		}
+		rewrites[strings.ToLower(from)] = strings.ToLower(to)
+	}
+	return rewrites, nil
+}
+
+// newHostRewrites builds the rewrite tables (nil if rewrites is empty)
+func newHostRewrites(rewrites map[string]string) *hostRewrites {
+	if len(rewrites) == 0 {
+		return nil
+	}
+
+	h := &hostRewrites{
+		fetch:   make(map[string]string, len(rewrites)),
+		restore: make(map[string]string, len(rewrites)),
+	}
+	for from, to := range rewrites {
+		h.fetch[from] = to
+		h.restore[to] = from
+	}
+	return h
+}
+
+// FetchURL returns the URL to fetch for the discovered URL rawURL
+func (h *hostRewrites) FetchURL(rawURL string) string {
+	if h == nil {
+		return rawURL
+	}
+	return replaceHost(rawURL, h.fetch)
+}
+
+// OriginalURL maps a URL on a rewritten host back to the original host
+func (h *hostRewrites) OriginalURL(rawURL string) string {
+	if h == nil {
+		return rawURL
+	}
+	return replaceHost(rawURL, h.restore)
+}
+
+// replaceHost swaps the host of rawURL if it appears in hosts
+func replaceHost(rawURL string, hosts map[string]string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	host, ok := hosts[strings.ToLower(u.Host)]
+	if !ok {
+		return rawURL
+	}
+	u.Host = host
+	return u.String()
+}
+
+// restoreLinks maps links on rewritten hosts back to the original hosts and,
+// for same-domain crawls, drops links to other domains
+func (c *Crawler) restoreLinks(pageURL string, links []string) []string {
+	restored := make([]string, 0, len(links))
+	for _, link := range links {
+		link = c.rewrites.OriginalURL(link)
+		if c.sameDomain {
+			if isSame, err := url.IsSameDomain(pageURL, link); err != nil || !isSame {
+				continue
+			}
+		}
+		restored = append(restored, link)
+	}
+	return restored
+}
@@ -0,0 +1,96 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseHostRewrites(t *testing.T) {
+	rewrites, err := ParseHostRewrites([]string{"Example.com=staging.example.com", "cdn.example.com = cdn-staging.example.com"})
+	if err != nil {
+		t.Fatalf("ParseHostRewrites() error: %v", err)
+	}
+	if rewrites["example.com"] != "staging.example.com" || rewrites["cdn.example.com"] != "cdn-staging.example.com" {
+		t.Errorf("unexpected rewrites: %v", rewrites)
+	}
+
+	for _, spec := range []string{"example.com", "=staging.example.com", "example.com=", "https://example.com=staging.example.com"} {
+		if _, err := ParseHostRewrites([]string{spec}); err == nil {
+			t.Errorf("ParseHostRewrites(%q) should fail", spec)
+		}
+	}
+
+	if rewrites, err := ParseHostRewrites(nil); err != nil || rewrites != nil {
+		t.Errorf("expected no rewrites, got %v, %v", rewrites, err)
+	}
+}
+
+func TestHostRewrites(t *testing.T) {
+	h := newHostRewrites(map[string]string{"example.com": "staging.example.com"})
+
+	if got := h.FetchURL("https://example.com/a?b=c"); got != "https://staging.example.com/a?b=c" {
+		t.Errorf("FetchURL() = %s", got)
+	}
+	if got := h.FetchURL("https://other.com/a"); got != "https://other.com/a" {
+		t.Errorf("FetchURL() should leave other hosts alone, got %s", got)
+	}
+	if got := h.OriginalURL("https://staging.example.com/a"); got != "https://example.com/a" {
+		t.Errorf("OriginalURL() = %s", got)
+	}
+
+	var disabled *hostRewrites
+	if disabled.FetchURL("https://example.com/") != "https://example.com/" || newHostRewrites(nil) != nil {
+		t.Error("nil rewrites should not change URLs")
+	}
+}
+
+func TestConcurrentCrawler_HostRewrites(t *testing.T) {
+	var staging *httptest.Server
+	staging = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path == "/" {
+			// One relative link and one absolute link to the staging host itself
+			fmt.Fprintf(w, `<html><body><a href="/about">About</a><a href="%s/contact">Contact</a></body></html>`, staging.URL)
+			return
+		}
+		fmt.Fprint(w, "<html><body>page</body></html>")
+	}))
+	defer staging.Close()
+
+	// The production host is never contacted
+	production := "http://production.invalid:8080"
+	stagingHost := strings.TrimPrefix(staging.URL, "http://")
+
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:     -1,
+		SameDomain:   true,
+		Workers:      2,
+		HostRewrites: map[string]string{"production.invalid:8080": stagingHost},
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, _, err := cc.CrawlConcurrent(production + "/")
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	var urls []string
+	for _, result := range results {
+		if result.Error != nil {
+			t.Errorf("unexpected error for %s: %v", result.URL, result.Error)
+		}
+		urls = append(urls, result.URL)
+	}
+	sort.Strings(urls)
+
+	expected := []string{production + "/", production + "/about", production + "/contact"}
+	if strings.Join(urls, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected original URLs %v, got %v", expected, urls)
+	}
+}
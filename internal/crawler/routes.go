@@ -0,0 +1,22 @@
+package crawler
+
+import "github.com/aoshimash/urlmap/internal/client"
+
+// recordDeclaredRoutes adds the routes declared by the client-side router of
+// a rendered page (with client.JSConfig.EnumerateRoutes) to the links of
+// result, so pages no link points to are crawled too
+func (c *Crawler) recordDeclaredRoutes(result *CrawlResult, response client.UnifiedResponse) {
+	rendered, ok := response.(*client.JSResponse)
+	if !ok || len(rendered.Routes) == 0 {
+		return
+	}
+
+	added, err := c.addRenderedLinks(result, rendered.Routes)
+	if err != nil {
+		c.logger.Warn("Failed to read router routes", "url", result.URL, "error", err)
+		return
+	}
+	if added > 0 {
+		c.logger.Debug("Added routes declared by the router", "url", result.URL, "link_count", added)
+	}
+}
@@ -0,0 +1,35 @@
+package crawler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+func TestRecordDeclaredRoutes(t *testing.T) {
+	cc, err := NewConcurrentCrawler(&Config{SameDomain: true})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	result := CrawlResult{URL: "https://example.com/", Links: []string{"https://example.com/about"}}
+	cc.recordDeclaredRoutes(&result, &client.JSResponse{Routes: []string{
+		"https://example.com/about", // Also a link of the page
+		"https://example.com/pricing/",
+		"https://example.com/#/settings", // A hash route is the page itself without --js-hash-routes
+		"https://other.example.org/login",
+	}})
+
+	want := []string{"https://example.com/about", "https://example.com/pricing", "https://example.com/"}
+	if !reflect.DeepEqual(result.Links, want) {
+		t.Errorf("Links = %v, want %v", result.Links, want)
+	}
+
+	// Pages fetched over HTTP have no router
+	static := CrawlResult{URL: "https://example.com/"}
+	cc.recordDeclaredRoutes(&static, nil)
+	if len(static.Links) != 0 {
+		t.Errorf("expected no links, got %v", static.Links)
+	}
+}
@@ -0,0 +1,81 @@
+package crawler
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// TemplateCount is how many URLs of a path template were found and crawled
+type TemplateCount struct {
+	Template   string // Path template, e.g. "example.com/products/{id}"
+	Discovered int    // Distinct URLs found for the template (the estimated total)
+	Sampled    int    // URLs of the template that were crawled
+}
+
+// templateSampler crawls only a few representative URLs per path template
+// while counting every URL found for it
+type templateSampler struct {
+	mu     sync.Mutex
+	limit  int
+	counts map[string]*TemplateCount
+}
+
+// newTemplateSampler creates a sampler that crawls limit URLs per template.
+// A limit below 1 disables sampling.
+func newTemplateSampler(limit int) *templateSampler {
+	if limit < 1 {
+		return nil
+	}
+	return &templateSampler{
+		limit:  limit,
+		counts: make(map[string]*TemplateCount),
+	}
+}
+
+// Allow counts rawURL towards its template and reports whether it should be crawled
+func (s *templateSampler) Allow(rawURL string) bool {
+	if s == nil {
+		return true
+	}
+
+	template := url.PathTemplate(rawURL)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count, ok := s.counts[template]
+	if !ok {
+		count = &TemplateCount{Template: template}
+		s.counts[template] = count
+	}
+	count.Discovered++
+	if count.Sampled >= s.limit {
+		return false
+	}
+	count.Sampled++
+	return true
+}
+
+// Counts returns the per-template counts, most discovered first
+func (s *templateSampler) Counts() []TemplateCount {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make([]TemplateCount, 0, len(s.counts))
+	for _, count := range s.counts {
+		counts = append(counts, *count)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Discovered != counts[j].Discovered {
+			return counts[i].Discovered > counts[j].Discovered
+		}
+		return counts[i].Template < counts[j].Template
+	})
+	return counts
+}
@@ -0,0 +1,75 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTemplateSampler(t *testing.T) {
+	sampler := newTemplateSampler(2)
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if sampler.Allow(fmt.Sprintf("https://example.com/products/%d", i)) {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("expected 2 URLs allowed for /products/{id}, got %d", allowed)
+	}
+	if !sampler.Allow("https://example.com/about") {
+		t.Error("other templates should have their own sample")
+	}
+
+	counts := sampler.Counts()
+	if len(counts) != 2 {
+		t.Fatalf("expected 2 templates, got %+v", counts)
+	}
+	want := TemplateCount{Template: "example.com/products/{id}", Discovered: 5, Sampled: 2}
+	if counts[0] != want {
+		t.Errorf("counts[0] = %+v; want %+v", counts[0], want)
+	}
+
+	var disabled *templateSampler
+	if !disabled.Allow("https://example.com/a") || newTemplateSampler(0) != nil || disabled.Counts() != nil {
+		t.Error("disabled sampler should allow everything and report nothing")
+	}
+}
+
+func TestConcurrentCrawler_SamplePerPattern(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path != "/" {
+			fmt.Fprint(w, "<html><body>item</body></html>")
+			return
+		}
+		var links strings.Builder
+		for i := 0; i < 8; i++ {
+			fmt.Fprintf(&links, `<a href="/products/%d">p</a>`, i)
+		}
+		fmt.Fprintf(w, "<html><body>%s<a href=\"/about\">a</a></body></html>", links.String())
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 10, SamplePerPattern: 3})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	if len(results) != 5 {
+		t.Errorf("expected the seed, /about and 3 products crawled, got %d results", len(results))
+	}
+	if stats.SampleSkipped != 5 {
+		t.Errorf("expected 5 URLs skipped by sampling, got %d", stats.SampleSkipped)
+	}
+	if len(stats.TemplateCounts) == 0 || stats.TemplateCounts[0].Discovered != 8 || stats.TemplateCounts[0].Sampled != 3 {
+		t.Errorf("expected /products/{id} with 8 discovered and 3 sampled first, got %+v", stats.TemplateCounts)
+	}
+}
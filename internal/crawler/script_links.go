@@ -0,0 +1,32 @@
+package crawler
+
+import "slices"
+
+// recordScriptLinks adds the URLs that inline scripts of an HTML page navigate
+// to, and that are not links of the page already, to the links of result
+// (only with ExtractScriptURLs)
+func (c *Crawler) recordScriptLinks(result *CrawlResult, contentType, body string) {
+	if !c.scriptLinks {
+		return
+	}
+	if kind := ContentKind(contentType); kind != ContentHTML && kind != ContentUnknown {
+		return
+	}
+
+	links, err := c.parser.ExtractScriptLinks(result.URL, body)
+	if err != nil {
+		c.logger.Warn("Failed to read script links", "url", result.URL, "error", err)
+		return
+	}
+
+	for _, link := range c.scopeLinks(result.URL, links) {
+		if slices.Contains(result.Links, link) {
+			continue
+		}
+		result.Links = append(result.Links, link)
+		result.ScriptLinks = append(result.ScriptLinks, link)
+	}
+	if len(result.ScriptLinks) > 0 {
+		c.logger.Debug("Extracted script links", "url", result.URL, "link_count", len(result.ScriptLinks))
+	}
+}
@@ -0,0 +1,86 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newScriptSite serves a page linking to /linked, and navigating to /linked
+// and /scripted from an inline script
+func newScriptSite(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `<html><body><a href="/linked">linked</a>
+<script>if (done) { window.location.href = "/scripted"; } else { router.push("/linked"); }</script></body></html>`)
+			return
+		}
+		fmt.Fprint(w, `<html><body>leaf</body></html>`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// lowConfidencePaths maps the crawled paths to whether they are LowConfidence
+func lowConfidencePaths(serverURL string, results []CrawlResult) map[string]bool {
+	paths := make(map[string]bool)
+	for _, result := range results {
+		paths[strings.TrimPrefix(result.URL, serverURL)] = result.LowConfidence
+	}
+	return paths
+}
+
+func TestConcurrentCrawler_ScriptLinks(t *testing.T) {
+	server := newScriptSite(t)
+
+	crawl := func(extract bool) []CrawlResult {
+		cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 2, ExtractScriptURLs: extract})
+		if err != nil {
+			t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+		}
+		results, _, err := cc.CrawlConcurrent(server.URL)
+		if err != nil {
+			t.Fatalf("CrawlConcurrent() failed: %v", err)
+		}
+		return results
+	}
+
+	want := map[string]bool{"/": false, "/linked": false}
+	if got := lowConfidencePaths(server.URL, crawl(false)); !reflect.DeepEqual(got, want) {
+		t.Errorf("crawled %v, want %v", got, want)
+	}
+
+	// Only the URL missing from the page's links is low confidence
+	results := crawl(true)
+	want = map[string]bool{"/": false, "/linked": false, "/scripted": true}
+	if got := lowConfidencePaths(server.URL, results); !reflect.DeepEqual(got, want) {
+		t.Errorf("with ExtractScriptURLs crawled %v, want %v", got, want)
+	}
+	for _, result := range results {
+		if result.Depth == 0 && !reflect.DeepEqual(result.ScriptLinks, []string{server.URL + "/scripted"}) {
+			t.Errorf("ScriptLinks = %v, want only /scripted", result.ScriptLinks)
+		}
+	}
+}
+
+func TestCrawler_ScriptLinks(t *testing.T) {
+	server := newScriptSite(t)
+
+	c, err := New(&Config{MaxDepth: -1, SameDomain: true, ExtractScriptURLs: true})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	results, _, err := c.CrawlRecursive(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlRecursive() failed: %v", err)
+	}
+
+	want := map[string]bool{"/": false, "/linked": false, "/scripted": true}
+	if got := lowConfidencePaths(server.URL, results); !reflect.DeepEqual(got, want) {
+		t.Errorf("crawled %v, want %v", got, want)
+	}
+}
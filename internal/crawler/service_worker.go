@@ -0,0 +1,69 @@
+package crawler
+
+import (
+	neturl "net/url"
+	"sort"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/parser"
+)
+
+// ServiceWorkerUse is a service worker script and the pages registering it
+type ServiceWorkerUse struct {
+	Origin string   // Scheme and host of the pages, e.g. "https://example.com"
+	Script string   // URL of the service worker script
+	Pages  []string // In the order of results
+}
+
+// recordServiceWorker stores the service worker an HTML page registers (only
+// with ExtractServiceWorkers): the one the browser reports for rendered pages,
+// otherwise the one registered in an inline script
+func (c *Crawler) recordServiceWorker(result *CrawlResult, contentType string, response client.UnifiedResponse) {
+	if !c.serviceWorkers {
+		return
+	}
+	if kind := ContentKind(contentType); kind != ContentHTML && kind != ContentUnknown {
+		return
+	}
+
+	if rendered, ok := response.(*client.JSResponse); ok && rendered.ServiceWorker != "" {
+		result.ServiceWorker = rendered.ServiceWorker
+		return
+	}
+	result.ServiceWorker = parser.ExtractServiceWorker(result.URL, response.String())
+}
+
+// CollectServiceWorkers returns the service workers the pages of results
+// register with the pages registering each, sorted by origin and script
+func CollectServiceWorkers(results []CrawlResult) []ServiceWorkerUse {
+	uses := make(map[[2]string]*ServiceWorkerUse)
+	for _, result := range results {
+		if result.ServiceWorker == "" {
+			continue
+		}
+		page, err := neturl.Parse(result.URL)
+		if err != nil || page.Host == "" {
+			continue
+		}
+
+		key := [2]string{page.Scheme + "://" + page.Host, result.ServiceWorker}
+		use, ok := uses[key]
+		if !ok {
+			use = &ServiceWorkerUse{Origin: key[0], Script: key[1]}
+			uses[key] = use
+		}
+		use.Pages = append(use.Pages, result.URL)
+	}
+
+	collected := make([]ServiceWorkerUse, 0, len(uses))
+	for _, use := range uses {
+		collected = append(collected, *use)
+	}
+	sort.Slice(collected, func(i, j int) bool {
+		if collected[i].Origin != collected[j].Origin {
+			return collected[i].Origin < collected[j].Origin
+		}
+		return collected[i].Script < collected[j].Script
+	})
+	return collected
+}
@@ -0,0 +1,62 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+func TestCollectServiceWorkers(t *testing.T) {
+	results := []CrawlResult{
+		{URL: "https://shop.example.com/", ServiceWorker: "https://shop.example.com/sw.js"},
+		{URL: "https://example.com/b", ServiceWorker: "https://example.com/sw.js"},
+		{URL: "https://example.com/c"},
+		{URL: "https://example.com/a", ServiceWorker: "https://example.com/sw.js"},
+	}
+
+	want := []ServiceWorkerUse{
+		{Origin: "https://example.com", Script: "https://example.com/sw.js", Pages: []string{"https://example.com/b", "https://example.com/a"}},
+		{Origin: "https://shop.example.com", Script: "https://shop.example.com/sw.js", Pages: []string{"https://shop.example.com/"}},
+	}
+	if got := CollectServiceWorkers(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectServiceWorkers() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRecordServiceWorker_Rendered(t *testing.T) {
+	c := &Crawler{serviceWorkers: true}
+
+	// The browser also sees registrations in external scripts
+	result := CrawlResult{URL: "https://example.com/"}
+	c.recordServiceWorker(&result, "text/html", &client.JSResponse{
+		Content:       `<script src="/main.js"></script>`,
+		ServiceWorker: "https://example.com/sw.js",
+	})
+	if result.ServiceWorker != "https://example.com/sw.js" {
+		t.Errorf("ServiceWorker = %q, want https://example.com/sw.js", result.ServiceWorker)
+	}
+}
+
+func TestConcurrentCrawler_ServiceWorker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body>page<script>navigator.serviceWorker.register('/sw.js')</script></body></html>`)
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 0, SameDomain: true, Workers: 1, ExtractServiceWorkers: true})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ServiceWorker != server.URL+"/sw.js" {
+		t.Errorf("expected service worker %s, got %+v", server.URL+"/sw.js", results)
+	}
+}
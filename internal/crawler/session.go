@@ -0,0 +1,31 @@
+package crawler
+
+import "github.com/aoshimash/urlmap/internal/url"
+
+// stripSessionIDs removes session-ID parameters from rawURL unless the crawler
+// keeps them
+func (c *Crawler) stripSessionIDs(rawURL string) string {
+	if c.keepSessionIDs {
+		return rawURL
+	}
+	return url.StripSessionIDs(rawURL)
+}
+
+// stripLinkSessionIDs removes session-ID parameters from links, dropping links
+// that become duplicates
+func (c *Crawler) stripLinkSessionIDs(links []string) []string {
+	if c.keepSessionIDs {
+		return links
+	}
+
+	seen := make(map[string]bool, len(links))
+	stripped := links[:0]
+	for _, link := range links {
+		link = url.StripSessionIDs(link)
+		if !seen[link] {
+			seen[link] = true
+			stripped = append(stripped, link)
+		}
+	}
+	return stripped
+}
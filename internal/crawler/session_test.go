@@ -0,0 +1,52 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+)
+
+func TestConcurrentCrawler_StripsSessionIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path == "/" {
+			fmt.Fprint(w, `<html><body>
+				<a href="/list?PHPSESSID=1&page=2">One</a>
+				<a href="/list?PHPSESSID=2&page=2">Two</a>
+				<a href="/cart;jsessionid=ABC">Cart</a>
+			</body></html>`)
+		}
+	}))
+	defer server.Close()
+
+	crawl := func(keep bool) []string {
+		cc, err := NewConcurrentCrawler(&Config{MaxDepth: 1, SameDomain: true, Workers: 1, KeepSessionIDs: keep})
+		if err != nil {
+			t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+		}
+		results, _, err := cc.CrawlConcurrent(server.URL + "/?sid=seed")
+		if err != nil {
+			t.Fatalf("CrawlConcurrent() failed: %v", err)
+		}
+		var urls []string
+		for _, result := range results {
+			urls = append(urls, result.URL)
+		}
+		sort.Strings(urls)
+		return urls
+	}
+
+	got := crawl(false)
+	want := []string{server.URL + "/", server.URL + "/cart", server.URL + "/list?page=2"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("crawled %v, want %v", got, want)
+	}
+
+	got = crawl(true)
+	want = []string{server.URL + "/?sid=seed", server.URL + "/cart;jsessionid=ABC", server.URL + "/list?PHPSESSID=1&page=2", server.URL + "/list?PHPSESSID=2&page=2"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("with KeepSessionIDs crawled %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package crawler
+
+import (
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+// recordShadowLinks adds the links inside open shadow roots of a rendered
+// page, which its HTML does not include, to the links of result
+func (c *Crawler) recordShadowLinks(result *CrawlResult, response client.UnifiedResponse) {
+	rendered, ok := response.(*client.JSResponse)
+	if !ok || len(rendered.ShadowLinks) == 0 {
+		return
+	}
+
+	added, err := c.addRenderedLinks(result, rendered.ShadowLinks)
+	if err != nil {
+		c.logger.Warn("Failed to read shadow DOM links", "url", result.URL, "error", err)
+		return
+	}
+	if added > 0 {
+		c.logger.Debug("Extracted shadow DOM links", "url", result.URL, "link_count", added)
+	}
+}
+
+// addRenderedLinks adds the URLs a browser found on a rendered page, and that
+// are not links of the page already, to the links of result. They are
+// extracted like the page's own links, so the same filters apply. It returns
+// how many links were added.
+func (c *Crawler) addRenderedLinks(result *CrawlResult, found []string) (int, error) {
+	var anchors strings.Builder
+	for _, link := range found {
+		fmt.Fprintf(&anchors, `<a href="%s"></a>`, html.EscapeString(link))
+	}
+	links, err := c.extractLinks(result.URL, anchors.String())
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	for _, link := range links {
+		if slices.Contains(result.Links, link) {
+			continue
+		}
+		result.Links = append(result.Links, link)
+		added++
+	}
+	return added, nil
+}
@@ -0,0 +1,35 @@
+package crawler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aoshimash/urlmap/internal/client"
+)
+
+func TestRecordShadowLinks(t *testing.T) {
+	cc, err := NewConcurrentCrawler(&Config{SameDomain: true})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	result := CrawlResult{URL: "https://example.com/", Links: []string{"https://example.com/about"}}
+	cc.recordShadowLinks(&result, &client.JSResponse{ShadowLinks: []string{
+		"https://example.com/about",            // Also a link of the page
+		"https://example.com/docs?tab=\"api\"", // Quotes survive the round trip through HTML
+		"https://other.example.org/",           // Out of scope
+		"mailto:team@example.com",
+	}})
+
+	want := []string{"https://example.com/about", `https://example.com/docs?tab="api"`}
+	if !reflect.DeepEqual(result.Links, want) {
+		t.Errorf("Links = %v, want %v", result.Links, want)
+	}
+
+	// Pages fetched over HTTP have no shadow roots
+	static := CrawlResult{URL: "https://example.com/"}
+	cc.recordShadowLinks(&static, nil)
+	if len(static.Links) != 0 {
+		t.Errorf("expected no links, got %v", static.Links)
+	}
+}
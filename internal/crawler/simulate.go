@@ -0,0 +1,61 @@
+package crawler
+
+// simulateDepth is the deepest level fetched by Simulate; links found on
+// those pages form the frontier but are not fetched
+const simulateDepth = 1
+
+// PlannedURL is a URL in predicted crawl order
+type PlannedURL struct {
+	URL     string `json:"url"`
+	Depth   int    `json:"depth"`
+	Fetched bool   `json:"fetched"` // Fetched during the simulation (false for frontier URLs)
+}
+
+// Simulation predicts which URLs a crawl limited to MaxPages would cover
+type Simulation struct {
+	MaxPages  int          `json:"max_pages"`
+	Covered   []PlannedURL `json:"covered"`   // URLs crawled within the budget, in crawl order
+	Uncovered []PlannedURL `json:"uncovered"` // Known URLs the budget would not reach
+	Unused    int          `json:"unused"`    // Budget left for pages beyond the frontier
+}
+
+// Simulate predicts which URLs a crawl of startURL limited to maxPages would
+// cover. It fetches the start URL and the pages it links to, then orders them
+// and the links found on them (the frontier) as the breadth-first crawl
+// would, applying the crawler's scope, filters, budgets and known-URL
+// deferral. Frontier URLs are not fetched.
+func (c *Crawler) Simulate(startURL string, maxPages int) (*Simulation, error) {
+	if c.maxDepth < 0 || c.maxDepth > simulateDepth {
+		c.maxDepth = simulateDepth
+	}
+
+	results, stats, err := c.CrawlRecursive(startURL)
+	if err != nil {
+		return nil, err
+	}
+	return SimulateBudget(results, stats.Frontier, c.maxDepth+1, maxPages), nil
+}
+
+// SimulateBudget splits the crawl order of results followed by the frontier
+// URLs (found at frontierDepth) into the URLs covered and not covered by a
+// budget of maxPages. A budget below 1 covers everything.
+func SimulateBudget(results []CrawlResult, frontier []string, frontierDepth, maxPages int) *Simulation {
+	order := make([]PlannedURL, 0, len(results)+len(frontier))
+	for _, result := range results {
+		order = append(order, PlannedURL{URL: result.URL, Depth: result.Depth, Fetched: true})
+	}
+	for _, link := range frontier {
+		order = append(order, PlannedURL{URL: link, Depth: frontierDepth})
+	}
+
+	simulation := &Simulation{MaxPages: maxPages, Covered: order}
+	if maxPages < 1 {
+		return simulation
+	}
+	if len(order) > maxPages {
+		simulation.Covered, simulation.Uncovered = order[:maxPages], order[maxPages:]
+	} else {
+		simulation.Unused = maxPages - len(order)
+	}
+	return simulation
+}
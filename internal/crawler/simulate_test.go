@@ -0,0 +1,80 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSimulateBudget(t *testing.T) {
+	results := []CrawlResult{
+		{URL: "https://example.com/"},
+		{URL: "https://example.com/a", Depth: 1},
+	}
+	frontier := []string{"https://example.com/a/1", "https://example.com/a/2"}
+
+	simulation := SimulateBudget(results, frontier, 2, 3)
+	if len(simulation.Covered) != 3 || len(simulation.Uncovered) != 1 || simulation.Unused != 0 {
+		t.Fatalf("unexpected split: %+v", simulation)
+	}
+	if want := (PlannedURL{URL: "https://example.com/a/1", Depth: 2}); simulation.Covered[2] != want {
+		t.Errorf("Covered[2] = %+v, want %+v", simulation.Covered[2], want)
+	}
+	if simulation.Uncovered[0].URL != "https://example.com/a/2" {
+		t.Errorf("Uncovered = %+v", simulation.Uncovered)
+	}
+
+	simulation = SimulateBudget(results, frontier, 2, 10)
+	if len(simulation.Covered) != 4 || simulation.Unused != 6 {
+		t.Errorf("expected everything covered with 6 pages unused, got %+v", simulation)
+	}
+
+	simulation = SimulateBudget(results, frontier, 2, 0)
+	if len(simulation.Covered) != 4 || len(simulation.Uncovered) != 0 {
+		t.Errorf("expected no budget to cover everything, got %+v", simulation)
+	}
+}
+
+func TestCrawler_Simulate(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><a href="/a">a</a><a href="/b">b</a></body></html>`)
+		case "/a":
+			fmt.Fprint(w, `<html><body><a href="/a/1">1</a><a href="/a/2">2</a></body></html>`)
+		default:
+			fmt.Fprint(w, `<html><body><a href="/deeper">d</a></body></html>`)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(&Config{MaxDepth: -1, SameDomain: true})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	simulation, err := c.Simulate(server.URL, 4)
+	if err != nil {
+		t.Fatalf("Simulate() failed: %v", err)
+	}
+
+	if requests.Load() != 3 {
+		t.Errorf("expected only the seed and depth 1 pages fetched, got %d requests", requests.Load())
+	}
+	var covered []string
+	for _, planned := range simulation.Covered {
+		covered = append(covered, planned.URL)
+	}
+	want := []string{server.URL + "/", server.URL + "/a", server.URL + "/b", server.URL + "/a/1"}
+	if fmt.Sprint(covered) != fmt.Sprint(want) {
+		t.Errorf("Covered = %v, want %v", covered, want)
+	}
+	if len(simulation.Uncovered) != 2 || simulation.Uncovered[0].URL != server.URL+"/a/2" {
+		t.Errorf("Uncovered = %+v, want /a/2 and /deeper", simulation.Uncovered)
+	}
+}
@@ -0,0 +1,64 @@
+package crawler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxThrottleRetries is how often a throttled URL is rescheduled
+	DefaultMaxThrottleRetries = 3
+	// defaultThrottleDelay is used when a 429 response has no usable Retry-After
+	defaultThrottleDelay = 5 * time.Second
+	// maxThrottleDelay caps the delay requested by a server
+	maxThrottleDelay = 5 * time.Minute
+)
+
+// hostThrottle tracks hosts that asked the crawler to back off
+type hostThrottle struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+}
+
+// newHostThrottle creates an empty host throttle
+func newHostThrottle() *hostThrottle {
+	return &hostThrottle{until: make(map[string]time.Time)}
+}
+
+// Delay holds back requests to host for at least d
+func (t *hostThrottle) Delay(host string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if until.After(t.until[host]) {
+		t.until[host] = until
+	}
+}
+
+// Wait blocks until host may be requested again or ctx is done
+func (t *hostThrottle) Wait(ctx context.Context, host string) error {
+	t.mu.Lock()
+	until, ok := t.until[host]
+	t.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	delay := time.Until(until)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,105 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHostThrottle(t *testing.T) {
+	throttle := newHostThrottle()
+
+	// Unknown hosts are not delayed
+	start := time.Now()
+	if err := throttle.Wait(context.Background(), "example.com"); err != nil {
+		t.Fatalf("Wait() error: %v", err)
+	}
+
+	throttle.Delay("example.com", 50*time.Millisecond)
+	if err := throttle.Wait(context.Background(), "example.com"); err != nil {
+		t.Fatalf("Wait() error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Wait() returned after %v, expected at least 50ms", elapsed)
+	}
+
+	// Cancellation interrupts the wait
+	throttle.Delay("slow.example.com", time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := throttle.Wait(ctx, "slow.example.com"); err == nil {
+		t.Error("expected error for cancelled wait")
+	}
+}
+
+// newThrottlingServer returns a server whose /limited page answers 429 for the
+// first throttledRequests requests
+func newThrottlingServer(throttledRequests int32) (*httptest.Server, *atomic.Int32) {
+	var hits atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><a href="/limited">limited</a></body></html>`)
+	})
+	mux.HandleFunc("/limited", func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= throttledRequests {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body>ok</body></html>`)
+	})
+	return httptest.NewServer(mux), &hits
+}
+
+func TestConcurrentCrawler_RetryAfter(t *testing.T) {
+	server, hits := newThrottlingServer(2)
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 2})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	if stats.FailedURLs != 0 {
+		t.Errorf("throttled URL should be rescheduled, not failed: %+v", results)
+	}
+	if stats.ThrottleEvents != 2 {
+		t.Errorf("expected 2 throttle events, got %d", stats.ThrottleEvents)
+	}
+	if hits.Load() != 3 {
+		t.Errorf("expected 3 requests to /limited, got %d", hits.Load())
+	}
+}
+
+func TestConcurrentCrawler_RetryAfterExhausted(t *testing.T) {
+	server, _ := newThrottlingServer(100)
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: -1, SameDomain: true, Workers: 2, MaxThrottleRetries: 1})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	_, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+
+	if stats.FailedURLs != 1 {
+		t.Errorf("expected the URL to fail after its retries, got %d failures", stats.FailedURLs)
+	}
+	if stats.ThrottleEvents != 2 {
+		t.Errorf("expected 2 throttle events, got %d", stats.ThrottleEvents)
+	}
+}
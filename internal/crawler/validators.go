@@ -0,0 +1,20 @@
+package crawler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// recordValidators stores the cache validators and content hash of a
+// response, used to detect changed pages between crawls
+func recordValidators(result *CrawlResult, meta responseMeta) {
+	result.ETag = meta.etag
+	result.LastModified = meta.lastModified
+	result.ContentHash = meta.contentHash
+}
+
+// contentHash returns the hex-encoded SHA-256 of body
+func contentHash(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,40 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConcurrentCrawler_RecordsValidators(t *testing.T) {
+	const body = "<html><body>hello</body></html>"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	cc, err := NewConcurrentCrawler(&Config{MaxDepth: 0, SameDomain: true, Workers: 1})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, _, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	result := results[0]
+	if result.ETag != `"v1"` || result.LastModified != "Mon, 01 Jan 2024 00:00:00 GMT" {
+		t.Errorf("validators not recorded: etag=%q last-modified=%q", result.ETag, result.LastModified)
+	}
+	if result.ContentHash != contentHash(body) {
+		t.Errorf("ContentHash = %q; want %q", result.ContentHash, contentHash(body))
+	}
+}
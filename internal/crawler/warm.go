@@ -0,0 +1,81 @@
+package crawler
+
+// knownURLs is the set of URLs crawled by a previous run. Known URLs are
+// still crawled, but only after all newly discovered URLs.
+type knownURLs map[string]struct{}
+
+// newKnownURLs builds the known URL set (nil if urls is empty)
+func newKnownURLs(urls []string) knownURLs {
+	if len(urls) == 0 {
+		return nil
+	}
+	known := make(knownURLs, len(urls))
+	for _, u := range urls {
+		known[u] = struct{}{}
+	}
+	return known
+}
+
+// Contains reports whether u was crawled by the previous run
+func (k knownURLs) Contains(u string) bool {
+	_, ok := k[u]
+	return ok
+}
+
+// deferJob holds back a job for a known URL until the queue runs dry
+func (s *crawlSession) deferJob(job CrawlJob) {
+	s.deferredMu.Lock()
+	s.deferred = append(s.deferred, job)
+	s.deferredMu.Unlock()
+
+	s.mu.Lock()
+	s.stats.KnownDeferred++
+	s.mu.Unlock()
+}
+
+// flushDeferred queues the deferred jobs once no other work is left.
+// It returns false if there was nothing to flush.
+func (s *crawlSession) flushDeferred() bool {
+	s.deferredMu.Lock()
+	jobs := s.deferred
+	s.deferred = nil
+	s.deferredMu.Unlock()
+
+	if len(jobs) == 0 || s.ctx.Err() != nil {
+		return false
+	}
+
+	s.logger.Debug("Crawling URLs known from the previous run", "count", len(jobs))
+	s.sendJobs(jobs)
+	return true
+}
+
+// sendJobs queues jobs without dropping any when the queue is full
+func (s *crawlSession) sendJobs(jobs []CrawlJob) {
+	// Count the jobs as active before sending so the jobs channel stays open;
+	// send from a goroutine since there may be more jobs than buffer space
+	s.activeJobsMu.Lock()
+	s.activeJobs += len(jobs)
+	s.activeJobsMu.Unlock()
+	for _, job := range jobs {
+		s.trackPending(job)
+	}
+
+	if s.dispatcher != nil {
+		s.dispatcher.queue(jobs...)
+		return
+	}
+
+	go func() {
+		for i, job := range jobs {
+			select {
+			case s.jobs <- job:
+			case <-s.ctx.Done():
+				for range jobs[i:] {
+					s.checkAndCloseJobsChannel()
+				}
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,84 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newWarmTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path != "/" {
+			fmt.Fprint(w, "<html><body>page</body></html>")
+			return
+		}
+		fmt.Fprint(w, `<html><body><a href="/old1">1</a><a href="/new1">2</a><a href="/old2">3</a><a href="/new2">4</a></body></html>`)
+	}))
+}
+
+// assertKnownLast checks that the known URLs were crawled after the new ones
+func assertKnownLast(t *testing.T, results []CrawlResult, known map[string]bool) {
+	t.Helper()
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(results))
+	}
+	seenKnown := false
+	for _, result := range results[1:] {
+		if known[result.URL] {
+			seenKnown = true
+		} else if seenKnown {
+			t.Errorf("new URL %s crawled after a known URL", result.URL)
+		}
+	}
+}
+
+func TestConcurrentCrawler_KnownURLsDeferred(t *testing.T) {
+	server := newWarmTestServer()
+	defer server.Close()
+
+	known := map[string]bool{server.URL + "/old1": true, server.URL + "/old2": true}
+	cc, err := NewConcurrentCrawler(&Config{
+		MaxDepth:   1,
+		SameDomain: true,
+		Workers:    1,
+		KnownURLs:  []string{server.URL + "/old1", server.URL + "/old2"},
+	})
+	if err != nil {
+		t.Fatalf("NewConcurrentCrawler() failed: %v", err)
+	}
+
+	results, stats, err := cc.CrawlConcurrent(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlConcurrent() failed: %v", err)
+	}
+	assertKnownLast(t, results, known)
+	if stats.KnownDeferred != 2 {
+		t.Errorf("expected 2 deferred URLs, got %d", stats.KnownDeferred)
+	}
+}
+
+func TestCrawlRecursive_KnownURLsDeferred(t *testing.T) {
+	server := newWarmTestServer()
+	defer server.Close()
+
+	known := map[string]bool{server.URL + "/old1": true, server.URL + "/old2": true}
+	c, err := New(&Config{
+		MaxDepth:   1,
+		SameDomain: true,
+		KnownURLs:  []string{server.URL + "/old1", server.URL + "/old2"},
+	})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	results, stats, err := c.CrawlRecursive(server.URL)
+	if err != nil {
+		t.Fatalf("CrawlRecursive() failed: %v", err)
+	}
+	assertKnownLast(t, results, known)
+	if stats.KnownDeferred != 2 {
+		t.Errorf("expected 2 deferred URLs, got %d", stats.KnownDeferred)
+	}
+}
@@ -0,0 +1,110 @@
+package detector
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// デフォルトの検出パラメータ
+const (
+	DefaultThreshold          = 0.5
+	DefaultStructureThreshold = 0.3
+	DefaultFrameworkWeight    = 0.4
+	DefaultStructureWeight    = 0.3
+	DefaultLowLinkWeight      = 0.2
+	DefaultDynamicWeight      = 0.1
+	DefaultSignatureWeight    = 0.4
+	DefaultLowLinkCount       = 10
+)
+
+// Config SPA検出の閾値・重み・カスタムシグネチャ（0 = デフォルト値）
+type Config struct {
+	// Threshold SPAと判定する信頼度
+	Threshold float64 `json:"threshold,omitempty"`
+	// StructureThreshold SPA構造が見つかった場合に適用する低い閾値
+	StructureThreshold float64 `json:"structure_threshold,omitempty"`
+
+	// 各指標の重み
+	FrameworkWeight float64 `json:"framework_weight,omitempty"`
+	StructureWeight float64 `json:"structure_weight,omitempty"`
+	LowLinkWeight   float64 `json:"low_link_weight,omitempty"`
+	DynamicWeight   float64 `json:"dynamic_weight,omitempty"`
+
+	// LowLinkCount これ未満のリンク数を「少ない」とみなす
+	LowLinkCount int `json:"low_link_count,omitempty"`
+
+	// Signatures ユーザー定義のフレームワークシグネチャ
+	Signatures []Signature `json:"signatures,omitempty"`
+}
+
+// Signature 正規表現またはCSSセレクタによるカスタムシグネチャ
+type Signature struct {
+	Name     string  `json:"name"`
+	Pattern  string  `json:"pattern,omitempty"`  // HTMLに対する正規表現
+	Selector string  `json:"selector,omitempty"` // CSSセレクタ
+	Weight   float64 `json:"weight,omitempty"`   // 一致時に加算する信頼度（0 = DefaultSignatureWeight）
+
+	re *regexp.Regexp
+}
+
+// DefaultConfig デフォルトの検出設定を返す
+func DefaultConfig() *Config {
+	return &Config{
+		Threshold:          DefaultThreshold,
+		StructureThreshold: DefaultStructureThreshold,
+		FrameworkWeight:    DefaultFrameworkWeight,
+		StructureWeight:    DefaultStructureWeight,
+		LowLinkWeight:      DefaultLowLinkWeight,
+		DynamicWeight:      DefaultDynamicWeight,
+		LowLinkCount:       DefaultLowLinkCount,
+	}
+}
+
+// withDefaults 未設定の値をデフォルトで補完し、シグネチャを検証したコピーを返す
+func (c *Config) withDefaults() (*Config, error) {
+	cfg := DefaultConfig()
+	if c == nil {
+		return cfg, nil
+	}
+
+	if c.Threshold < 0 || c.Threshold > 1 {
+		return nil, fmt.Errorf("detector threshold must be between 0 and 1, got %g", c.Threshold)
+	}
+
+	setFloat := func(dst *float64, v float64) {
+		if v > 0 {
+			*dst = v
+		}
+	}
+	setFloat(&cfg.Threshold, c.Threshold)
+	setFloat(&cfg.StructureThreshold, c.StructureThreshold)
+	setFloat(&cfg.FrameworkWeight, c.FrameworkWeight)
+	setFloat(&cfg.StructureWeight, c.StructureWeight)
+	setFloat(&cfg.LowLinkWeight, c.LowLinkWeight)
+	setFloat(&cfg.DynamicWeight, c.DynamicWeight)
+	if c.LowLinkCount > 0 {
+		cfg.LowLinkCount = c.LowLinkCount
+	}
+
+	for _, sig := range c.Signatures {
+		if sig.Name == "" {
+			return nil, fmt.Errorf("detector signature must have a name")
+		}
+		if (sig.Pattern == "") == (sig.Selector == "") {
+			return nil, fmt.Errorf("detector signature %s: set exactly one of pattern or selector", sig.Name)
+		}
+		if sig.Pattern != "" {
+			re, err := regexp.Compile(sig.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("detector signature %s: invalid pattern: %w", sig.Name, err)
+			}
+			sig.re = re
+		}
+		if sig.Weight <= 0 {
+			sig.Weight = DefaultSignatureWeight
+		}
+		cfg.Signatures = append(cfg.Signatures, sig)
+	}
+
+	return cfg, nil
+}
@@ -2,6 +2,10 @@ package detector
 
 import (
 	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -360,3 +364,100 @@ func TestDetectionCache(t *testing.T) {
 		t.Errorf("Expected cache size: 1, got: %d", size)
 	}
 }
+
+func TestSPADetector_CustomSignatures(t *testing.T) {
+	detector, err := NewSPADetectorWithConfig(slog.Default(), &Config{
+		Signatures: []Signature{
+			{Name: "qwik", Pattern: `q:container="paused"`, Weight: 0.6},
+			{Name: "htmx", Selector: "[hx-get]"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewSPADetectorWithConfig() error: %v", err)
+	}
+
+	html := `<html q:container="paused"><body><h1>Shop</h1><p>Products</p><a href="/a">A</a></body></html>`
+	result, err := detector.DetectSPA("https://qwik.example.com/", html)
+	if err != nil {
+		t.Fatalf("DetectSPA() error: %v", err)
+	}
+	if !result.IsSPA || !slices.Contains(result.Indicators, "signature:qwik") {
+		t.Errorf("expected qwik signature to trigger detection, got %+v", result)
+	}
+
+	html = `<html><body><h1>Docs</h1><p>Text</p><button hx-get="/more">More</button></body></html>`
+	result, err = detector.DetectSPA("https://htmx.example.com/", html)
+	if err != nil {
+		t.Fatalf("DetectSPA() error: %v", err)
+	}
+	if result.IsSPA || !slices.Contains(result.Indicators, "signature:htmx") {
+		t.Errorf("htmx signature should match but stay below the threshold, got %+v", result)
+	}
+}
+
+func TestSPADetector_Threshold(t *testing.T) {
+	// Framework, low link count and dynamic content score 0.7, which a stricter threshold rejects
+	html := `<html><body><h1>Title</h1><p>Text</p><script>window.angular = {}</script></body></html>`
+
+	lenient := NewSPADetector(slog.Default())
+	strict, err := NewSPADetectorWithConfig(slog.Default(), &Config{Threshold: 0.8})
+	if err != nil {
+		t.Fatalf("NewSPADetectorWithConfig() error: %v", err)
+	}
+
+	lenientResult, _ := lenient.DetectSPA("https://a.example.com/", html)
+	strictResult, _ := strict.DetectSPA("https://b.example.com/", html)
+	if lenientResult.Confidence != strictResult.Confidence {
+		t.Fatalf("threshold should not change confidence: %.2f vs %.2f", lenientResult.Confidence, strictResult.Confidence)
+	}
+	if !lenientResult.IsSPA || strictResult.IsSPA {
+		t.Errorf("expected only the default threshold to accept confidence %.2f", lenientResult.Confidence)
+	}
+}
+
+func TestNewSPADetectorWithConfig_Invalid(t *testing.T) {
+	invalid := []*Config{
+		{Threshold: 1.5},
+		{Signatures: []Signature{{Name: "both", Pattern: "x", Selector: "#x"}}},
+		{Signatures: []Signature{{Name: "bad", Pattern: "("}}},
+		{Signatures: []Signature{{Pattern: "x"}}},
+	}
+	for _, config := range invalid {
+		if _, err := NewSPADetectorWithConfig(slog.Default(), config); err == nil {
+			t.Errorf("expected error for config %+v", config)
+		}
+	}
+}
+
+// TestSPADetector_Corpus runs the detector against saved pages whose file
+// names state the expected outcome (spa-*.html or static-*.html)
+func TestSPADetector_Corpus(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "corpus", "*.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no corpus files found")
+	}
+
+	for _, file := range files {
+		name := filepath.Base(file)
+		t.Run(name, func(t *testing.T) {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := NewSPADetector(slog.Default()).DetectSPA("https://"+name+"/", string(content))
+			if err != nil {
+				t.Fatalf("DetectSPA failed: %v", err)
+			}
+
+			expectSPA := strings.HasPrefix(name, "spa-")
+			if result.IsSPA != expectSPA {
+				t.Errorf("expected SPA=%v, got %v (confidence: %.2f, indicators: %v)",
+					expectSPA, result.IsSPA, result.Confidence, result.Indicators)
+			}
+		})
+	}
+}
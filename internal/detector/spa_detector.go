@@ -16,6 +16,7 @@ import (
 type SPADetector struct {
 	logger *slog.Logger
 	cache  *DetectionCache
+	config *Config
 }
 
 // DetectionResult SPA検出の結果を表す構造体
@@ -32,9 +33,27 @@ func NewSPADetector(logger *slog.Logger) *SPADetector {
 	return &SPADetector{
 		logger: logger,
 		cache:  NewDetectionCache(1 * time.Hour), // 1時間のTTL
+		config: DefaultConfig(),
 	}
 }
 
+// NewSPADetectorWithConfig 閾値・重み・カスタムシグネチャを指定してSPA検出器を作成
+func NewSPADetectorWithConfig(logger *slog.Logger, config *Config) (*SPADetector, error) {
+	cfg, err := config.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	d := NewSPADetector(logger)
+	d.config = cfg
+	return d, nil
+}
+
+// Threshold SPAと判定する信頼度の閾値を返す
+func (d *SPADetector) Threshold() float64 {
+	return d.config.Threshold
+}
+
 // DetectSPA URLとHTMLコンテンツからSPAかどうかを検出
 func (d *SPADetector) DetectSPA(url, htmlContent string) (*DetectionResult, error) {
 	// キャッシュから結果を取得
@@ -51,34 +70,51 @@ func (d *SPADetector) DetectSPA(url, htmlContent string) (*DetectionResult, erro
 		Timestamp:  time.Now(),
 	}
 
+	cfg := d.config
+	framework := d.detectFramework(htmlContent)
+	structure := d.detectSPAStructure(htmlContent)
+
 	// 1. Framework検出
-	if d.detectFramework(htmlContent) {
-		result.IsSPA = true
-		result.Confidence += 0.4
+	if framework {
+		result.Confidence += cfg.FrameworkWeight
 		result.Indicators = append(result.Indicators, "framework_detected")
 	}
 
 	// 2. DOM構造分析
-	if d.detectSPAStructure(htmlContent) {
-		result.IsSPA = true
-		result.Confidence += 0.3
+	if structure {
+		result.Confidence += cfg.StructureWeight
 		result.Indicators = append(result.Indicators, "spa_structure")
 	}
 
 	// 3. リンク数分析（フレームワークが検出された場合のみ）
-	if d.detectFramework(htmlContent) && d.detectLowLinkCount(htmlContent) {
-		result.Confidence += 0.2
+	if framework && d.detectLowLinkCount(htmlContent) {
+		result.Confidence += cfg.LowLinkWeight
 		result.Indicators = append(result.Indicators, "low_link_count")
 	}
 
 	// 4. 動的コンテンツ検出（フレームワークが検出された場合のみ）
-	if d.detectFramework(htmlContent) && d.detectDynamicContent(htmlContent) {
-		result.Confidence += 0.1
+	if framework && d.detectDynamicContent(htmlContent) {
+		result.Confidence += cfg.DynamicWeight
 		result.Indicators = append(result.Indicators, "dynamic_content")
 	}
 
+	// 5. カスタムシグネチャ
+	for _, sig := range d.matchSignatures(htmlContent) {
+		result.Confidence += sig.Weight
+		result.Indicators = append(result.Indicators, "signature:"+sig.Name)
+	}
+	result.Confidence = math.Min(result.Confidence, 1.0)
+
 	// 閾値判定（フレームワーク検出またはSPA構造 + 高い信頼度）
-	result.IsSPA = result.Confidence >= 0.5 || (d.detectSPAStructure(htmlContent) && result.Confidence >= 0.3)
+	result.IsSPA = result.Confidence >= cfg.Threshold || (structure && result.Confidence >= cfg.StructureThreshold)
+
+	d.logger.Debug("SPA detection result for domain",
+		"domain", d.extractDomain(url),
+		"is_spa", result.IsSPA,
+		"confidence", result.Confidence,
+		"threshold", cfg.Threshold,
+		"indicators", result.Indicators,
+	)
 
 	// 結果をキャッシュに保存
 	d.cache.Set(d.extractDomain(url), result)
@@ -196,8 +232,8 @@ func (d *SPADetector) detectLowLinkCount(htmlContent string) bool {
 
 	linkCount := doc.Find("a[href]").Length()
 
-	// リンク数が10個未満の場合はSPAの可能性
-	if linkCount < 10 {
+	// リンク数が閾値未満の場合はSPAの可能性
+	if linkCount < d.config.LowLinkCount {
 		d.logger.Debug("Low link count detected", "count", linkCount)
 		return true
 	}
@@ -205,6 +241,37 @@ func (d *SPADetector) detectLowLinkCount(htmlContent string) bool {
 	return false
 }
 
+// matchSignatures 一致したカスタムシグネチャを返す
+func (d *SPADetector) matchSignatures(htmlContent string) []Signature {
+	if len(d.config.Signatures) == 0 {
+		return nil
+	}
+
+	var doc *goquery.Document
+	var matched []Signature
+	for _, sig := range d.config.Signatures {
+		if sig.re != nil {
+			if sig.re.MatchString(htmlContent) {
+				matched = append(matched, sig)
+			}
+			continue
+		}
+
+		if doc == nil {
+			var err error
+			doc, err = goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+			if err != nil {
+				d.logger.Warn("Failed to parse HTML for signature matching", "error", err)
+				return matched
+			}
+		}
+		if doc.Find(sig.Selector).Length() > 0 {
+			matched = append(matched, sig)
+		}
+	}
+	return matched
+}
+
 // detectDynamicContent 動的コンテンツの検出
 func (d *SPADetector) detectDynamicContent(htmlContent string) bool {
 	// JavaScriptの存在確認
@@ -0,0 +1,251 @@
+package filter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// regexPrefix marks a pattern as a regular expression instead of a glob
+const regexPrefix = "re:"
+
+// Pattern is a compiled URL pattern.
+//
+// Patterns starting with "/" are matched against the URL path (and query),
+// any other pattern is matched against the full URL. Globs support "*"
+// (any sequence of characters, including "/") and "?" (a single character);
+// a trailing "/*" also matches the directory itself ("/docs/*" matches "/docs").
+// Patterns prefixed with "re:" are unanchored regular expressions.
+type Pattern struct {
+	raw      string
+	re       *regexp.Regexp
+	pathOnly bool
+}
+
+// Compile compiles a single pattern
+func Compile(pattern string) (*Pattern, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, fmt.Errorf("pattern cannot be empty")
+	}
+
+	if expr, ok := strings.CutPrefix(pattern, regexPrefix); ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex pattern %q: %w", pattern, err)
+		}
+		return &Pattern{raw: pattern, re: re}, nil
+	}
+
+	re, err := regexp.Compile(globToRegex(pattern))
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+	}
+
+	return &Pattern{
+		raw:      pattern,
+		re:       re,
+		pathOnly: strings.HasPrefix(pattern, "/"),
+	}, nil
+}
+
+// globToRegex converts a glob pattern into an anchored regular expression
+func globToRegex(glob string) string {
+	dirGlob := strings.HasSuffix(glob, "/*")
+	if dirGlob {
+		glob = strings.TrimSuffix(glob, "/*")
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if dirGlob {
+		b.WriteString("(/.*)?")
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// String returns the pattern as given by the user
+func (p *Pattern) String() string {
+	return p.raw
+}
+
+// Match reports whether the URL matches the pattern
+func (p *Pattern) Match(rawURL string) bool {
+	if !p.pathOnly {
+		return p.re.MatchString(rawURL)
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	path := parsed.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if p.re.MatchString(path) {
+		return true
+	}
+	if parsed.RawQuery != "" {
+		return p.re.MatchString(path + "?" + parsed.RawQuery)
+	}
+	return false
+}
+
+// Filter decides whether URLs are in scope based on include and exclude patterns.
+// A URL is allowed when it matches at least one include pattern (or no include
+// patterns are configured) and matches no exclude pattern. Filter is safe for
+// concurrent use.
+type Filter struct {
+	mu      sync.RWMutex
+	include []*Pattern
+	exclude []*Pattern
+}
+
+// New creates a filter from include and exclude pattern lists
+func New(include, exclude []string) (*Filter, error) {
+	f := &Filter{}
+	for _, p := range include {
+		if err := f.AddInclude(p); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range exclude {
+		if err := f.AddExclude(p); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// AddInclude adds an include pattern
+func (f *Filter) AddInclude(pattern string) error {
+	compiled, err := Compile(pattern)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.include = append(f.include, compiled)
+	f.mu.Unlock()
+	return nil
+}
+
+// AddExclude adds an exclude pattern
+func (f *Filter) AddExclude(pattern string) error {
+	compiled, err := Compile(pattern)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.exclude = append(f.exclude, compiled)
+	f.mu.Unlock()
+	return nil
+}
+
+// Allow reports whether the URL passes the filter
+func (f *Filter) Allow(rawURL string) bool {
+	if f == nil {
+		return true
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for _, p := range f.exclude {
+		if p.Match(rawURL) {
+			return false
+		}
+	}
+
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, p := range f.include {
+		if p.Match(rawURL) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty returns true if the filter has no patterns
+func (f *Filter) IsEmpty() bool {
+	if f == nil {
+		return true
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.include) == 0 && len(f.exclude) == 0
+}
+
+// Includes returns the include patterns as strings
+func (f *Filter) Includes() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return patternStrings(f.include)
+}
+
+// Excludes returns the exclude patterns as strings
+func (f *Filter) Excludes() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return patternStrings(f.exclude)
+}
+
+func patternStrings(patterns []*Pattern) []string {
+	result := make([]string, len(patterns))
+	for i, p := range patterns {
+		result[i] = p.raw
+	}
+	return result
+}
+
+// LoadPatterns reads one pattern per line from the file at path, skipping
+// blank lines and '#' comments
+func LoadPatterns(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pattern file: %w", err)
+	}
+	defer file.Close()
+
+	return ReadPatterns(file)
+}
+
+// ReadPatterns reads one pattern per line, skipping blank lines and '#' comments.
+// Each pattern is validated so errors point at the offending line.
+func ReadPatterns(r io.Reader) ([]string, error) {
+	var patterns []string
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, err := Compile(line); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read patterns: %w", err)
+	}
+	return patterns, nil
+}
@@ -0,0 +1,180 @@
+package filter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		wantErr bool
+	}{
+		{name: "Path glob", pattern: "/docs/*"},
+		{name: "Full URL glob", pattern: "https://example.com/*"},
+		{name: "Regex", pattern: `re:/page/\d+$`},
+		{name: "Empty", pattern: "  ", wantErr: true},
+		{name: "Invalid regex", pattern: "re:([", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Compile(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Compile(%q) error = %v, wantErr %v", tt.pattern, err, tt.wantErr)
+			}
+			if err == nil && p.String() != tt.pattern {
+				t.Errorf("String() = %q, want %q", p.String(), tt.pattern)
+			}
+		})
+	}
+}
+
+func TestPatternMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		url     string
+		want    bool
+	}{
+		{"Path glob matches nested path", "/docs/*", "https://example.com/docs/a/b", true},
+		{"Path glob does not match sibling", "/docs/*", "https://example.com/blog/a", false},
+		{"Directory glob matches directory itself", "/docs/*", "https://example.com/docs", true},
+		{"Directory glob does not match prefix sibling", "/docs/*", "https://example.com/docsite", false},
+		{"Path glob is anchored", "/docs/*", "https://example.com/en/docs/a", false},
+		{"Question mark", "/p?ge", "https://example.com/page", true},
+		{"Path glob matches query", "/search?q=*", "https://example.com/search?q=go", true},
+		{"Full URL glob", "https://example.com/*", "https://example.com/x", true},
+		{"Full URL glob other host", "https://example.com/*", "https://other.com/x", false},
+		{"Host glob", "*://cdn.example.com/*", "https://cdn.example.com/x.js", true},
+		{"Regex unanchored", `re:/page/\d+`, "https://example.com/blog/page/3", true},
+		{"Regex no match", `re:/page/\d+$`, "https://example.com/page/x", false},
+		{"Meta characters are literal in globs", "/a.b", "https://example.com/aXb", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Compile(tt.pattern)
+			if err != nil {
+				t.Fatalf("Compile() error: %v", err)
+			}
+			if got := p.Match(tt.url); got != tt.want {
+				t.Errorf("Match(%q) with %q = %v, want %v", tt.url, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterAllow(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		url     string
+		want    bool
+	}{
+		{"No patterns", nil, nil, "https://example.com/a", true},
+		{"Included", []string{"/docs/*"}, nil, "https://example.com/docs/x", true},
+		{"Not included", []string{"/docs/*"}, nil, "https://example.com/blog/x", false},
+		{"Excluded", nil, []string{"/calendar/*"}, "https://example.com/calendar/2024", false},
+		{"Exclude wins over include", []string{"/docs/*"}, []string{"/docs/old/*"}, "https://example.com/docs/old/x", false},
+		{"Multiple includes", []string{"/docs/*", "/api/*"}, nil, "https://example.com/api/v1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := New(tt.include, tt.exclude)
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+			if got := f.Allow(tt.url); got != tt.want {
+				t.Errorf("Allow(%q) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterNilAndEmpty(t *testing.T) {
+	var f *Filter
+	if !f.Allow("https://example.com/") {
+		t.Error("nil filter should allow everything")
+	}
+	if !f.IsEmpty() {
+		t.Error("nil filter should be empty")
+	}
+
+	f, err := New(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.IsEmpty() {
+		t.Error("new filter without patterns should be empty")
+	}
+
+	if err := f.AddExclude("/x/*"); err != nil {
+		t.Fatal(err)
+	}
+	if f.IsEmpty() {
+		t.Error("filter with exclude pattern should not be empty")
+	}
+	if got := f.Excludes(); len(got) != 1 || got[0] != "/x/*" {
+		t.Errorf("Excludes() = %v", got)
+	}
+	if got := f.Includes(); len(got) != 0 {
+		t.Errorf("Includes() = %v", got)
+	}
+}
+
+func TestNewInvalidPattern(t *testing.T) {
+	if _, err := New([]string{"re:(["}, nil); err == nil {
+		t.Error("expected error for invalid include pattern")
+	}
+	if _, err := New(nil, []string{""}); err == nil {
+		t.Error("expected error for empty exclude pattern")
+	}
+}
+
+func TestReadPatterns(t *testing.T) {
+	input := `
+# curated scope
+/docs/*
+  re:/page/\d+$
+
+https://example.com/blog/*
+`
+	patterns, err := ReadPatterns(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadPatterns() error: %v", err)
+	}
+	want := []string{"/docs/*", `re:/page/\d+$`, "https://example.com/blog/*"}
+	if strings.Join(patterns, " ") != strings.Join(want, " ") {
+		t.Errorf("ReadPatterns() = %v, want %v", patterns, want)
+	}
+
+	_, err = ReadPatterns(strings.NewReader("/docs/*\nre:([\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected error for line 2, got %v", err)
+	}
+}
+
+func TestLoadPatterns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deny.txt")
+	if err := os.WriteFile(path, []byte("/admin/*\n# comment\n/tmp/*\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	patterns, err := LoadPatterns(path)
+	if err != nil {
+		t.Fatalf("LoadPatterns() error: %v", err)
+	}
+	if len(patterns) != 2 {
+		t.Errorf("expected 2 patterns, got %v", patterns)
+	}
+
+	if _, err := LoadPatterns(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -0,0 +1,280 @@
+package incremental
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/parser"
+	"github.com/aoshimash/urlmap/internal/state"
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// maxSitemaps caps how many sitemaps of a sitemap index are read
+const maxSitemaps = 50
+
+// Config holds configuration for planning an incremental crawl
+type Config struct {
+	Client  *client.Client // HTTP client used for sitemaps and conditional requests
+	Workers int            // Number of concurrent conditional requests
+	Sample  float64        // Fraction of unchanged pages crawled again to verify them
+	Seed    uint64         // Random seed of Sample, for reproducible samples (0 = random)
+	Logger  *slog.Logger   // Logger instance
+
+	// Gate sends each request with send once the crawl's rate limits and
+	// politeness allow it, as crawler.Gate does (nil = send at once)
+	Gate func(rawURL string, send func() error) error
+}
+
+// Plan is the outcome of checking the pages of the previous crawl
+type Plan struct {
+	Unchanged []state.PageState // Pages that are not crawled again, sorted by URL
+	Changed   []string          // Pages that changed or could not be checked, sorted by URL
+	Sampled   []string          // Unchanged pages crawled again to verify them, sorted by URL
+}
+
+// Planner decides which pages of a previous crawl have to be crawled again
+type Planner struct {
+	client  *client.Client
+	workers int
+	sample  float64
+	seed    uint64
+	logger  *slog.Logger
+	gate    func(rawURL string, send func() error) error
+}
+
+// New creates a new planner
+func New(config *Config) *Planner {
+	if config == nil {
+		config = &Config{}
+	}
+
+	httpClient := config.Client
+	if httpClient == nil {
+		httpClient = client.NewDefaultClient()
+	}
+
+	workers := config.Workers
+	if workers <= 0 {
+		workers = 10
+	}
+
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Planner{
+		client:  httpClient,
+		workers: workers,
+		sample:  config.Sample,
+		seed:    config.Seed,
+		logger:  logger,
+		gate:    config.Gate,
+	}
+}
+
+// Plan checks the pages of previous on the domain of seed. A page is
+// unchanged if the sitemap of the site gives a lastmod no later than the
+// previous fetch, or, without a lastmod, if a conditional request with its
+// stored ETag or Last-Modified answers 304 Not Modified. Other pages, and a
+// sample of the unchanged ones, are left to be crawled again.
+func (p *Planner) Plan(ctx context.Context, seed string, previous *state.State) *Plan {
+	var pages []state.PageState
+	for _, page := range previous.Pages {
+		if same, err := url.IsSameDomain(seed, page.URL); err == nil && same {
+			pages = append(pages, page)
+		}
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		return pages[i].URL < pages[j].URL
+	})
+
+	lastMods := p.sitemapLastMods(ctx, seed)
+	unchanged := make([]bool, len(pages))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				unchanged[index] = p.unchanged(ctx, pages[index], lastMods)
+			}
+		}()
+	}
+	for i := range pages {
+		if ctx.Err() != nil {
+			break // Unchecked pages count as changed
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	plan := &Plan{}
+	for i, page := range pages {
+		if unchanged[i] {
+			plan.Unchanged = append(plan.Unchanged, page)
+		} else {
+			plan.Changed = append(plan.Changed, page.URL)
+		}
+	}
+	p.takeSample(plan)
+	return plan
+}
+
+// unchanged reports whether page is known not to have changed since it was fetched
+func (p *Planner) unchanged(ctx context.Context, page state.PageState, lastMods map[string]time.Time) bool {
+	if lastMod, ok := lastMods[page.URL]; ok {
+		// A date without a time covers the whole day
+		if lastMod.Equal(lastMod.Truncate(24 * time.Hour)) {
+			lastMod = lastMod.Add(24 * time.Hour)
+		}
+		return !lastMod.After(page.FetchTime)
+	}
+	if page.ETag == "" && page.LastModified == "" {
+		return false
+	}
+
+	headers := make(map[string]string)
+	if page.ETag != "" {
+		headers["If-None-Match"] = page.ETag
+	}
+	if page.LastModified != "" {
+		headers["If-Modified-Since"] = page.LastModified
+	}
+	var response *resty.Response
+	err := p.send(page.URL, func() (err error) {
+		response, err = p.client.GetClient().R().SetContext(ctx).SetHeaders(headers).Head(page.URL)
+		return err
+	})
+	if err != nil {
+		p.logger.Debug("Conditional request failed, crawling the page again", "url", page.URL, "error", err)
+		return false
+	}
+
+	switch {
+	case response.StatusCode() == http.StatusNotModified:
+		return true
+	case !response.IsSuccess():
+		return false
+	case page.ETag != "":
+		// Some servers ignore conditional headers on HEAD requests
+		return response.Header().Get("ETag") == page.ETag
+	default:
+		return response.Header().Get("Last-Modified") == page.LastModified
+	}
+}
+
+// sitemapLastMods returns the lastmod dates the sitemap of the site of seed
+// gives, following sitemap indexes. The plan falls back to conditional
+// requests if the site has no sitemap.
+func (p *Planner) sitemapLastMods(ctx context.Context, seed string) map[string]time.Time {
+	lastMods := make(map[string]time.Time)
+
+	root, err := sitemapURL(seed)
+	if err != nil {
+		return lastMods
+	}
+	queue := []string{root}
+	seen := map[string]bool{root: true}
+	for read := 0; len(queue) > 0 && read < maxSitemaps; read++ {
+		sitemap := queue[0]
+		queue = queue[1:]
+
+		entries, children, err := p.readSitemap(ctx, sitemap)
+		if err != nil {
+			p.logger.Debug("Failed to read sitemap", "url", sitemap, "error", err)
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.LastMod.IsZero() {
+				lastMods[entry.URL] = entry.LastMod
+			}
+		}
+		for _, child := range children {
+			if same, err := url.IsSameDomain(seed, child); err == nil && same && !seen[child] {
+				seen[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	p.logger.Debug("Read sitemap lastmod dates", "seed", seed, "pages", len(lastMods))
+	return lastMods
+}
+
+// readSitemap fetches and parses one sitemap
+func (p *Planner) readSitemap(ctx context.Context, sitemap string) ([]parser.SitemapEntry, []string, error) {
+	var response *resty.Response
+	err := p.send(sitemap, func() (err error) {
+		response, err = p.client.Get(ctx, sitemap)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if !response.IsSuccess() {
+		return nil, nil, fmt.Errorf("HTTP error: %d", response.StatusCode())
+	}
+	return parser.ParseSitemap(sitemap, response.String())
+}
+
+// send sends a request to rawURL through the gate
+func (p *Planner) send(rawURL string, request func() error) error {
+	if p.gate == nil {
+		return request()
+	}
+	return p.gate(rawURL, request)
+}
+
+// sitemapURL returns the conventional sitemap location of the site of seed
+func sitemapURL(seed string) (string, error) {
+	parsed, err := neturl.Parse(seed)
+	if err != nil {
+		return "", err
+	}
+	return (&neturl.URL{Scheme: parsed.Scheme, Host: parsed.Host, Path: "/sitemap.xml"}).String(), nil
+}
+
+// takeSample moves a random sample of the unchanged pages to Sampled, so
+// their changes are caught even when the sitemap or validators are stale
+func (p *Planner) takeSample(plan *Plan) {
+	count := int(math.Ceil(p.sample * float64(len(plan.Unchanged))))
+	if count <= 0 {
+		return
+	}
+
+	seed := p.seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	random := rand.New(rand.NewPCG(seed, seed))
+
+	sampled := make(map[int]bool, count)
+	for _, index := range random.Perm(len(plan.Unchanged))[:count] {
+		sampled[index] = true
+	}
+
+	var unchanged []state.PageState
+	for i, page := range plan.Unchanged {
+		if sampled[i] {
+			plan.Sampled = append(plan.Sampled, page.URL)
+		} else {
+			unchanged = append(unchanged, page)
+		}
+	}
+	plan.Unchanged = unchanged
+}
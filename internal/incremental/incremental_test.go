@@ -0,0 +1,141 @@
+package incremental
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/state"
+)
+
+func newTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<sitemapindex><sitemap><loc>/sitemap-pages.xml</loc></sitemap></sitemapindex>`)
+	})
+	mux.HandleFunc("/sitemap-pages.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<urlset>
+  <url><loc>/old</loc><lastmod>2024-01-01</lastmod></url>
+  <url><loc>/updated</loc><lastmod>2024-06-02T12:00:00Z</lastmod></url>
+  <url><loc>/same-day</loc><lastmod>2024-06-01</lastmod></url>
+</urlset>`)
+	})
+	validated := func(etag string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+			}
+		}
+	}
+	mux.HandleFunc("/etag", validated(`"v1"`))
+	mux.HandleFunc("/etag-changed", validated(`"v2"`))
+	return httptest.NewServer(mux)
+}
+
+func newTestClient() *client.Client {
+	config := client.DefaultConfig()
+	config.RetryCount = 0
+	config.Timeout = 5 * time.Second
+	return client.NewClient(config)
+}
+
+func TestPlan(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	fetched := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	previous := state.New()
+	for _, page := range []state.PageState{
+		{URL: server.URL + "/old"},
+		{URL: server.URL + "/updated"},
+		{URL: server.URL + "/same-day"},
+		{URL: server.URL + "/etag", ETag: `"v1"`},
+		{URL: server.URL + "/etag-changed", ETag: `"v1"`},
+		{URL: server.URL + "/no-validators"},
+		{URL: "https://other.example/"},
+	} {
+		page.FetchTime = fetched
+		previous.Add(page)
+	}
+
+	plan := New(&Config{Client: newTestClient(), Workers: 2}).Plan(context.Background(), server.URL+"/", previous)
+
+	var unchanged []string
+	for _, page := range plan.Unchanged {
+		unchanged = append(unchanged, page.URL)
+	}
+	if want := []string{server.URL + "/etag", server.URL + "/old"}; !reflect.DeepEqual(unchanged, want) {
+		t.Errorf("Unchanged = %v, want %v", unchanged, want)
+	}
+	wantChanged := []string{server.URL + "/etag-changed", server.URL + "/no-validators", server.URL + "/same-day", server.URL + "/updated"}
+	if !reflect.DeepEqual(plan.Changed, wantChanged) {
+		t.Errorf("Changed = %v, want %v", plan.Changed, wantChanged)
+	}
+	if len(plan.Sampled) != 0 {
+		t.Errorf("Sampled = %v, want none", plan.Sampled)
+	}
+}
+
+func TestPlan_Sample(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	previous := state.New()
+	for i := 0; i < 10; i++ {
+		previous.Add(state.PageState{URL: fmt.Sprintf("%s/etag?page=%d", server.URL, i), ETag: `"v1"`})
+	}
+
+	config := &Config{Client: newTestClient(), Sample: 0.25, Seed: 7}
+	plan := New(config).Plan(context.Background(), server.URL+"/", previous)
+	if len(plan.Sampled) != 3 || len(plan.Unchanged) != 7 {
+		t.Fatalf("got %d sampled and %d unchanged, want 3 and 7", len(plan.Sampled), len(plan.Unchanged))
+	}
+
+	// The same seed takes the same sample
+	again := New(config).Plan(context.Background(), server.URL+"/", previous)
+	if !reflect.DeepEqual(plan.Sampled, again.Sampled) {
+		t.Errorf("Sampled = %v, then %v with the same seed", plan.Sampled, again.Sampled)
+	}
+}
+
+func TestPlan_Gate(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	previous := state.New()
+	previous.Add(state.PageState{URL: server.URL + "/etag", ETag: `"v1"`})
+	previous.Add(state.PageState{URL: server.URL + "/etag-changed", ETag: `"v1"`})
+
+	// Every request goes through the gate, which may refuse to send it
+	var mu sync.Mutex
+	var gated []string
+	gate := func(rawURL string, send func() error) error {
+		mu.Lock()
+		gated = append(gated, rawURL)
+		mu.Unlock()
+		if rawURL == server.URL+"/etag" {
+			return errors.New("circuit open")
+		}
+		return send()
+	}
+	plan := New(&Config{Client: newTestClient(), Workers: 1, Gate: gate}).Plan(context.Background(), server.URL+"/", previous)
+
+	sort.Strings(gated)
+	want := []string{server.URL + "/etag", server.URL + "/etag-changed", server.URL + "/sitemap-pages.xml", server.URL + "/sitemap.xml"}
+	if !reflect.DeepEqual(gated, want) {
+		t.Errorf("gated %v, want %v", gated, want)
+	}
+	// A page the gate did not let through could not be checked
+	if len(plan.Unchanged) != 0 || len(plan.Changed) != 2 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+}
@@ -0,0 +1,107 @@
+// Package language guesses the language of a text from its script and, for
+// Latin-script text, from its most common character trigrams.
+package language
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MinLetters is how many letters a text needs for Detect to guess its language
+const MinLetters = 40
+
+// scripts map writing systems used by a single language, or a family whose
+// most common language is assumed, to that language
+var scripts = []struct {
+	table *unicode.RangeTable
+	lang  string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+}
+
+// profiles are the most frequent trigrams of Latin-script languages, with
+// spaces marking word boundaries
+var profiles = map[string][]string{
+	"en": {" th", "the", "he ", "and", " an", "nd ", " of", "of ", " to", "to ", "ing", "ng ", " in", "in ", "is ", " is", "ed ", "ion", "tio", "at ", "on ", "er ", "hat", "for", " fo"},
+	"de": {"en ", "er ", " de", "der", "ie ", "die", " di", "ch ", "sch", "ein", "ich", "nd ", "und", " un", "che", "den", " ei", "te ", "gen", "ung", "cht", " zu", "ine", "ver", "ist"},
+	"fr": {"es ", " de", "de ", "le ", " le", "ent", "nt ", "la ", " la", "ion", "les", "re ", "tio", "on ", "que", " qu", "ue ", "des", " et", "et ", " pa", "ur ", "est", " po", "our"},
+	"es": {" de", "de ", "os ", "la ", " la", "el ", " el", "es ", " qu", "que", "ue ", "en ", "as ", "ent", " co", "ión", "ció", " en", "del", "ad ", "los", " lo", "ado", "nte", "por"},
+	"it": {" di", "di ", "to ", "la ", " la", "re ", "che", " ch", "he ", "ell", "lla", " co", "del", "one", "ion", "ent", "zio", "ato", " il", "il ", "no ", "per", " pe", "are", "gli"},
+	"pt": {" de", "de ", "os ", "ão ", "que", " qu", "ue ", "do ", " do", "da ", " da", "ent", " co", "em ", "ção", "açã", "es ", "nte", "as ", "ado", "com", " pa", "par", "não", "uma"},
+	"nl": {"en ", " de", "de ", "an ", "van", " va", "het", " he", "et ", "er ", "een", " ee", "ij ", "aar", "oor", "den", "nde", " ge", "ing", "ver", "ten", "cht", "ijn", "sch", " in"},
+}
+
+// Detect returns the ISO 639-1 code of the language text is most likely
+// written in, or "" if the text is too short or matches no known language
+func Detect(text string) string {
+	var letters, latin int
+	counts := make(map[string]int)
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+			continue
+		}
+		for _, script := range scripts {
+			if unicode.Is(script.table, r) {
+				counts[script.lang]++
+				break
+			}
+		}
+	}
+	if letters < MinLetters {
+		return ""
+	}
+
+	// Kana mark Japanese even in text mostly written in Han characters
+	if counts["ja"] > 0 && counts["ja"]+counts["zh"] > letters/2 {
+		return "ja"
+	}
+	best, bestCount := "", 0
+	for _, script := range scripts {
+		if count := counts[script.lang]; count > bestCount {
+			best, bestCount = script.lang, count
+		}
+	}
+	if bestCount > latin {
+		return best
+	}
+	return detectLatin(text)
+}
+
+// detectLatin scores text against the trigram profiles
+func detectLatin(text string) string {
+	normalized := " " + strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}), " ") + " "
+
+	runes := []rune(normalized)
+	trigrams := make(map[string]int)
+	for i := 0; i+3 <= len(runes); i++ {
+		trigrams[string(runes[i:i+3])]++
+	}
+
+	best, bestScore := "", 0
+	for _, lang := range []string{"en", "de", "fr", "es", "it", "pt", "nl"} {
+		score := 0
+		for _, trigram := range profiles[lang] {
+			score += trigrams[trigram]
+		}
+		if score > bestScore {
+			best, bestScore = lang, score
+		}
+	}
+	return best
+}
@@ -0,0 +1,33 @@
+package language
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", "The quick brown fox jumps over the lazy dog and runs into the forest to find something to eat.", "en"},
+		{"german", "Die schnelle braune Katze springt über den faulen Hund und läuft in den Wald, um etwas zu essen.", "de"},
+		{"french", "Le renard brun rapide saute par-dessus le chien paresseux et court dans la forêt pour trouver de quoi manger.", "fr"},
+		{"spanish", "El zorro marrón rápido salta sobre el perro perezoso y corre hacia el bosque para encontrar algo que comer.", "es"},
+		{"italian", "La volpe marrone veloce salta sopra il cane pigro e corre nella foresta per trovare qualcosa da mangiare.", "it"},
+		{"portuguese", "A raposa marrom rápida pula sobre o cão preguiçoso e corre para a floresta para encontrar algo que comer, não é?", "pt"},
+		{"dutch", "De snelle bruine vos springt over de luie hond en rent het bos in om iets te eten te vinden voor een van de jongen.", "nl"},
+		{"japanese", "素早い茶色の狐は怠け者の犬を飛び越えて、何か食べるものを探しに森の中へ走っていきました。", "ja"},
+		{"chinese", "敏捷的棕色狐狸跳过了懒惰的狗，然后跑进森林里去寻找一些可以吃的东西，它已经一整天没有吃饭了，非常饿。", "zh"},
+		{"korean", "빠른 갈색 여우가 게으른 개를 뛰어넘어 먹을 것을 찾으러 숲 속으로 달려갔습니다 그리고 돌아왔습니다.", "ko"},
+		{"russian", "Быстрая коричневая лиса перепрыгивает через ленивую собаку и бежит в лес, чтобы найти что-нибудь поесть.", "ru"},
+		{"too short", "Hello world", ""},
+		{"no letters", "1234567890 !!! ??? 1234567890 !!! ??? 1234567890 !!! ???", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.text); got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BrokenLink is a linked URL that returned a 4xx/5xx status or could not be
+// fetched, with the pages linking to it
+type BrokenLink struct {
+	URL        string   `json:"url" xml:"url"`
+	StatusCode int      `json:"status_code" xml:"status_code"` // 0 if the request failed
+	Error      string   `json:"error" xml:"error"`
+	External   bool     `json:"external,omitempty" xml:"external,omitempty"` // Not crawled, fetched with --external
+	Referrers  []string `json:"referrers" xml:"referrers>referrer"`
+}
+
+// BrokenLinkOutput represents the complete broken link report
+type BrokenLinkOutput struct {
+	XMLName   xml.Name     `json:"-" xml:"broken_links"`
+	Links     []BrokenLink `json:"links" xml:"links>link"`
+	Timestamp time.Time    `json:"timestamp" xml:"timestamp"`
+	Checked   int          `json:"checked" xml:"checked"`
+	Broken    int          `json:"broken" xml:"broken"`
+}
+
+// WriteBrokenLinks writes the broken links found among checked URLs to w in
+// the specified format (text, json, csv or xml)
+func WriteBrokenLinks(w io.Writer, links []BrokenLink, checked int, config *OutputConfig) error {
+	if config == nil {
+		config = &OutputConfig{Format: FormatText}
+	}
+
+	switch config.Format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newBrokenLinkOutput(links, checked))
+	case FormatCSV:
+		return writeBrokenLinksCSV(w, links)
+	case FormatXML:
+		xmlData, err := xml.MarshalIndent(newBrokenLinkOutput(links, checked), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal XML: %w", err)
+		}
+		if _, err := fmt.Fprint(w, xml.Header); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(xmlData))
+		return err
+	case FormatText:
+		fallthrough
+	default:
+		return writeBrokenLinksText(w, links)
+	}
+}
+
+// newBrokenLinkOutput wraps links with summary information
+func newBrokenLinkOutput(links []BrokenLink, checked int) BrokenLinkOutput {
+	if links == nil {
+		links = []BrokenLink{}
+	}
+	return BrokenLinkOutput{
+		Links:     links,
+		Timestamp: now(),
+		Checked:   checked,
+		Broken:    len(links),
+	}
+}
+
+// writeBrokenLinksText writes each broken link with its status, followed by
+// the pages linking to it
+func writeBrokenLinksText(w io.Writer, links []BrokenLink) error {
+	var b strings.Builder
+	for _, link := range links {
+		status := strconv.Itoa(link.StatusCode)
+		if link.StatusCode == 0 {
+			status = "ERR"
+		}
+		fmt.Fprintf(&b, "%s %s", status, link.URL)
+		if link.External {
+			b.WriteString(" (external)")
+		}
+		if link.StatusCode == 0 {
+			fmt.Fprintf(&b, " (%s)", link.Error)
+		}
+		b.WriteString("\n")
+		for _, referrer := range link.Referrers {
+			fmt.Fprintf(&b, "  linked from %s\n", referrer)
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write broken links: %w", err)
+	}
+	return nil
+}
+
+// writeBrokenLinksCSV writes one row per broken link and referring page, so
+// each row is a link to fix
+func writeBrokenLinksCSV(w io.Writer, links []BrokenLink) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"url", "status_code", "error", "external", "referrer"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, link := range links {
+		referrers := link.Referrers
+		if len(referrers) == 0 {
+			referrers = []string{""}
+		}
+		for _, referrer := range referrers {
+			record := []string{link.URL, strconv.Itoa(link.StatusCode), link.Error, strconv.FormatBool(link.External), referrer}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write CSV record: %w", err)
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
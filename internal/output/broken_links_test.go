@@ -0,0 +1,58 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+var testBrokenLinks = []BrokenLink{
+	{URL: "https://example.com/missing", StatusCode: 404, Error: "HTTP error: 404", Referrers: []string{"https://example.com/", "https://example.com/b"}},
+	{URL: "https://down.example.net/", Error: "connection refused", External: true, Referrers: []string{"https://example.com/"}},
+}
+
+func TestWriteBrokenLinksText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBrokenLinks(&buf, testBrokenLinks, 5, nil); err != nil {
+		t.Fatalf("WriteBrokenLinks() error: %v", err)
+	}
+
+	want := "404 https://example.com/missing\n" +
+		"  linked from https://example.com/\n" +
+		"  linked from https://example.com/b\n" +
+		"ERR https://down.example.net/ (external) (connection refused)\n" +
+		"  linked from https://example.com/\n"
+	if buf.String() != want {
+		t.Errorf("WriteBrokenLinks() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteBrokenLinksJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBrokenLinks(&buf, nil, 5, &OutputConfig{Format: FormatJSON}); err != nil {
+		t.Fatalf("WriteBrokenLinks() error: %v", err)
+	}
+
+	var decoded BrokenLinkOutput
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded.Checked != 5 || decoded.Broken != 0 || decoded.Links == nil {
+		t.Errorf("unexpected report: %+v", decoded)
+	}
+}
+
+func TestWriteBrokenLinksCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBrokenLinks(&buf, testBrokenLinks, 5, &OutputConfig{Format: FormatCSV}); err != nil {
+		t.Fatalf("WriteBrokenLinks() error: %v", err)
+	}
+
+	want := "url,status_code,error,external,referrer\n" +
+		"https://example.com/missing,404,HTTP error: 404,false,https://example.com/\n" +
+		"https://example.com/missing,404,HTTP error: 404,false,https://example.com/b\n" +
+		"https://down.example.net/,0,connection refused,true,https://example.com/\n"
+	if buf.String() != want {
+		t.Errorf("WriteBrokenLinks() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,96 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ChangeResult represents a page that differs from the previous crawl
+type ChangeResult struct {
+	URL    string `json:"url" xml:"url"`
+	Kind   string `json:"kind" xml:"kind"`
+	Reason string `json:"reason,omitempty" xml:"reason,omitempty"`
+}
+
+// ChangeOutput represents the complete change report
+type ChangeOutput struct {
+	XMLName   xml.Name       `json:"-" xml:"changes"`
+	Changes   []ChangeResult `json:"changes" xml:"change"`
+	Timestamp time.Time      `json:"timestamp" xml:"timestamp"`
+	Total     int            `json:"total" xml:"total"`
+}
+
+// WriteChangeReport writes the changed pages report to w in the specified format
+func WriteChangeReport(w io.Writer, changes []ChangeResult, config *OutputConfig) error {
+	if config == nil {
+		config = &OutputConfig{Format: FormatText}
+	}
+
+	switch config.Format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newChangeOutput(changes))
+	case FormatCSV:
+		return writeChangeCSV(w, changes)
+	case FormatXML:
+		xmlData, err := xml.MarshalIndent(newChangeOutput(changes), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal XML: %w", err)
+		}
+		if _, err := fmt.Fprint(w, xml.Header); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(xmlData))
+		return err
+	case FormatText:
+		fallthrough
+	default:
+		return writeChangeText(w, changes)
+	}
+}
+
+// newChangeOutput wraps changes with summary information
+func newChangeOutput(changes []ChangeResult) ChangeOutput {
+	return ChangeOutput{
+		Changes:   changes,
+		Timestamp: now(),
+		Total:     len(changes),
+	}
+}
+
+// writeChangeText writes one line per page: kind, URL and the validator that changed
+func writeChangeText(w io.Writer, changes []ChangeResult) error {
+	for _, change := range changes {
+		line := fmt.Sprintf("%-8s %s", change.Kind, change.URL)
+		if change.Reason != "" {
+			line += " (" + change.Reason + ")"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("failed to write change line: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeChangeCSV writes the change report as CSV
+func writeChangeCSV(w io.Writer, changes []ChangeResult) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"url", "kind", "reason"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, change := range changes {
+		if err := writer.Write([]string{change.URL, change.Kind, change.Reason}); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var testChanges = []ChangeResult{
+	{URL: "https://example.com/a", Kind: "added"},
+	{URL: "https://example.com/b", Kind: "changed", Reason: "etag"},
+	{URL: "https://example.com/c", Kind: "removed"},
+}
+
+func TestWriteChangeReportText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteChangeReport(&buf, testChanges, nil); err != nil {
+		t.Fatalf("WriteChangeReport() error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d: %s", len(lines), buf.String())
+	}
+	if lines[1] != "changed  https://example.com/b (etag)" {
+		t.Errorf("unexpected changed line: %q", lines[1])
+	}
+}
+
+func TestWriteChangeReportJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteChangeReport(&buf, testChanges, &OutputConfig{Format: FormatJSON}); err != nil {
+		t.Fatalf("WriteChangeReport() error: %v", err)
+	}
+
+	var decoded ChangeOutput
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded.Total != 3 || decoded.Changes[1].Reason != "etag" {
+		t.Errorf("unexpected report: %+v", decoded)
+	}
+}
+
+func TestWriteChangeReportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteChangeReport(&buf, testChanges, &OutputConfig{Format: FormatCSV}); err != nil {
+		t.Fatalf("WriteChangeReport() error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 4 || records[0][0] != "url" || records[3][1] != "removed" {
+		t.Errorf("unexpected CSV records: %v", records)
+	}
+}
@@ -0,0 +1,137 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Comparison kinds as reported by CompareResult.Kind
+const (
+	CompareOnlyBase       = "only-base"
+	CompareOnlyTarget     = "only-target"
+	CompareStatusMismatch = "status-mismatch"
+)
+
+// CompareResult represents a path that differs between two environments
+type CompareResult struct {
+	Path         string `json:"path" xml:"path"`
+	Kind         string `json:"kind" xml:"kind"`
+	BaseURL      string `json:"base_url,omitempty" xml:"base_url,omitempty"`
+	TargetURL    string `json:"target_url,omitempty" xml:"target_url,omitempty"`
+	BaseStatus   int    `json:"base_status,omitempty" xml:"base_status,omitempty"`
+	TargetStatus int    `json:"target_status,omitempty" xml:"target_status,omitempty"`
+}
+
+// CompareOutput represents the complete comparison report
+type CompareOutput struct {
+	XMLName        xml.Name        `json:"-" xml:"comparison"`
+	Base           string          `json:"base" xml:"base"`
+	Target         string          `json:"target" xml:"target"`
+	Results        []CompareResult `json:"results" xml:"results>result"`
+	Timestamp      time.Time       `json:"timestamp" xml:"timestamp"`
+	OnlyBase       int             `json:"only_base" xml:"only_base"`
+	OnlyTarget     int             `json:"only_target" xml:"only_target"`
+	StatusMismatch int             `json:"status_mismatch" xml:"status_mismatch"`
+}
+
+// OutputCompareResults outputs comparison results to stdout in the specified format
+func OutputCompareResults(base, target string, results []CompareResult, config *OutputConfig) error {
+	return WriteCompareResults(os.Stdout, base, target, results, config)
+}
+
+// WriteCompareResults writes comparison results to w in the specified format
+func WriteCompareResults(w io.Writer, base, target string, results []CompareResult, config *OutputConfig) error {
+	if config == nil {
+		config = &OutputConfig{Format: FormatText}
+	}
+
+	switch config.Format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newCompareOutput(base, target, results))
+	case FormatCSV:
+		return writeCompareCSV(w, results)
+	case FormatXML:
+		xmlData, err := xml.MarshalIndent(newCompareOutput(base, target, results), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal XML: %w", err)
+		}
+		if _, err := fmt.Fprint(w, xml.Header); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(xmlData))
+		return err
+	case FormatText:
+		fallthrough
+	default:
+		return writeCompareText(w, results)
+	}
+}
+
+// newCompareOutput wraps results with summary counts
+func newCompareOutput(base, target string, results []CompareResult) CompareOutput {
+	out := CompareOutput{
+		Base:      base,
+		Target:    target,
+		Results:   results,
+		Timestamp: now(),
+	}
+	for _, result := range results {
+		switch result.Kind {
+		case CompareOnlyBase:
+			out.OnlyBase++
+		case CompareOnlyTarget:
+			out.OnlyTarget++
+		case CompareStatusMismatch:
+			out.StatusMismatch++
+		}
+	}
+	return out
+}
+
+// writeCompareText writes one line per path: kind, path and the status codes on mismatch
+func writeCompareText(w io.Writer, results []CompareResult) error {
+	for _, result := range results {
+		line := fmt.Sprintf("%-15s %s", result.Kind, result.Path)
+		if result.Kind == CompareStatusMismatch {
+			line += fmt.Sprintf(" (%d -> %d)", result.BaseStatus, result.TargetStatus)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("failed to write comparison line: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeCompareCSV writes comparison results as CSV
+func writeCompareCSV(w io.Writer, results []CompareResult) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"path", "kind", "base_url", "target_url", "base_status", "target_status"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, result := range results {
+		record := []string{
+			result.Path,
+			result.Kind,
+			result.BaseURL,
+			result.TargetURL,
+			strconv.Itoa(result.BaseStatus),
+			strconv.Itoa(result.TargetStatus),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
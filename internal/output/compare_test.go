@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var testCompareResults = []CompareResult{
+	{Path: "/about", Kind: CompareStatusMismatch, BaseURL: "https://staging.example.com/about", TargetURL: "https://example.com/about", BaseStatus: 200, TargetStatus: 404},
+	{Path: "/beta", Kind: CompareOnlyBase, BaseURL: "https://staging.example.com/beta", BaseStatus: 200},
+	{Path: "/legacy", Kind: CompareOnlyTarget, TargetURL: "https://example.com/legacy", TargetStatus: 200},
+}
+
+func TestWriteCompareResultsText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCompareResults(&buf, "https://staging.example.com", "https://example.com", testCompareResults, nil); err != nil {
+		t.Fatalf("WriteCompareResults() error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d: %s", len(lines), buf.String())
+	}
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "status-mismatch /about (200 -> 404)" {
+		t.Errorf("unexpected first line: %q", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != "only-base /beta" {
+		t.Errorf("unexpected second line: %q", lines[1])
+	}
+}
+
+func TestWriteCompareResultsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCompareResults(&buf, "https://staging.example.com", "https://example.com", testCompareResults, &OutputConfig{Format: FormatJSON}); err != nil {
+		t.Fatalf("WriteCompareResults() error: %v", err)
+	}
+
+	var decoded CompareOutput
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded.Base != "https://staging.example.com" || decoded.Target != "https://example.com" {
+		t.Errorf("unexpected environments: base=%s target=%s", decoded.Base, decoded.Target)
+	}
+	if decoded.OnlyBase != 1 || decoded.OnlyTarget != 1 || decoded.StatusMismatch != 1 {
+		t.Errorf("unexpected counts: %+v", decoded)
+	}
+}
+
+func TestWriteCompareResultsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCompareResults(&buf, "", "", testCompareResults, &OutputConfig{Format: FormatCSV}); err != nil {
+		t.Fatalf("WriteCompareResults() error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "path,kind,base_url,target_url,base_status,target_status\n") {
+		t.Errorf("unexpected CSV header: %s", buf.String())
+	}
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ContentCount is the number of responses with a content kind or in a size range
+type ContentCount struct {
+	Label string
+	Count int
+}
+
+// ContentReport holds the content type and size distribution of a crawl
+type ContentReport struct {
+	Responses   int
+	Kinds       []ContentCount // Responses per content kind (html, json, ...)
+	Sizes       []ContentCount // Responses per size range
+	TotalBytes  int64
+	LargestURL  string
+	LargestSize int
+}
+
+// WriteContentReport writes the content distribution report as text
+func WriteContentReport(w io.Writer, report ContentReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Content types (%d responses, %d bytes):\n", report.Responses, report.TotalBytes)
+	for _, kind := range report.Kinds {
+		fmt.Fprintf(&b, "  %-12s %6d\n", kind.Label, kind.Count)
+	}
+
+	fmt.Fprintln(&b, "Response sizes:")
+	for _, size := range report.Sizes {
+		fmt.Fprintf(&b, "  %-12s %6d\n", size.Label, size.Count)
+	}
+
+	if report.LargestURL != "" {
+		fmt.Fprintf(&b, "Largest response: %s (%d bytes)\n", report.LargestURL, report.LargestSize)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write content report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteContentReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteContentReport(&buf, ContentReport{
+		Responses:   3,
+		Kinds:       []ContentCount{{Label: "html", Count: 2}, {Label: "json", Count: 1}},
+		Sizes:       []ContentCount{{Label: "< 10 KB", Count: 2}, {Label: ">= 1 MB", Count: 1}},
+		TotalBytes:  2 << 20,
+		LargestURL:  "https://example.com/api/dump",
+		LargestSize: 2<<20 - 100,
+	})
+	if err != nil {
+		t.Fatalf("WriteContentReport() failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"Content types (3 responses, 2097152 bytes):\n",
+		"  html              2\n",
+		"  json              1\n",
+		"Response sizes:\n  < 10 KB           2\n",
+		"Largest response: https://example.com/api/dump (2097052 bytes)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CycleReport holds link graph structures that point to architecture problems
+type CycleReport struct {
+	RedirectLoops    [][]string // Redirect chains that lead back to themselves
+	SelfLinkOnly     []string   // Pages whose only internal links point to themselves
+	IsolatedClusters [][]string // Groups of pages linking to each other that the homepage cannot reach
+}
+
+// WriteCycleReport writes the cycle report as text
+func WriteCycleReport(w io.Writer, report CycleReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Redirect loops: %d\n", len(report.RedirectLoops))
+	for _, loop := range report.RedirectLoops {
+		fmt.Fprintf(&b, "  %s -> %s\n", strings.Join(loop, " -> "), loop[0])
+	}
+
+	fmt.Fprintf(&b, "Pages linking only to themselves: %d\n", len(report.SelfLinkOnly))
+	for _, url := range report.SelfLinkOnly {
+		fmt.Fprintf(&b, "  %s\n", url)
+	}
+
+	fmt.Fprintf(&b, "Clusters unreachable from the homepage: %d\n", len(report.IsolatedClusters))
+	for i, cluster := range report.IsolatedClusters {
+		fmt.Fprintf(&b, "  Cluster %d (%d pages):\n", i+1, len(cluster))
+		for _, url := range cluster {
+			fmt.Fprintf(&b, "    %s\n", url)
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write cycle report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCycleReport(t *testing.T) {
+	report := CycleReport{
+		RedirectLoops:    [][]string{{"https://example.com/a", "https://example.com/b"}},
+		IsolatedClusters: [][]string{{"https://example.com/x", "https://example.com/y"}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCycleReport(&buf, report); err != nil {
+		t.Fatalf("WriteCycleReport() error: %v", err)
+	}
+
+	expected := "Redirect loops: 1\n" +
+		"  https://example.com/a -> https://example.com/b -> https://example.com/a\n" +
+		"Pages linking only to themselves: 0\n" +
+		"Clusters unreachable from the homepage: 1\n" +
+		"  Cluster 1 (2 pages):\n" +
+		"    https://example.com/x\n" +
+		"    https://example.com/y\n"
+	if buf.String() != expected {
+		t.Errorf("WriteCycleReport() =\n%s\nwant\n%s", buf.String(), expected)
+	}
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SinglePathResult is a page that only one other page links to
+type SinglePathResult struct {
+	URL  string
+	From string
+}
+
+// DeadEndReport holds pages that hinder navigation through a site
+type DeadEndReport struct {
+	NoOutlinks  []string           // Pages without links to other crawled pages
+	SinglePaths []SinglePathResult // Pages reachable through a single inbound link only
+}
+
+// WriteDeadEndReport writes the dead-end report as text
+func WriteDeadEndReport(w io.Writer, report DeadEndReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Dead-end pages (no outgoing internal links): %d\n", len(report.NoOutlinks))
+	for _, url := range report.NoOutlinks {
+		fmt.Fprintf(&b, "  %s\n", url)
+	}
+
+	fmt.Fprintf(&b, "Pages reachable via one link only: %d\n", len(report.SinglePaths))
+	for _, page := range report.SinglePaths {
+		fmt.Fprintf(&b, "  %s (from %s)\n", page.URL, page.From)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write dead-end report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteDeadEndReport(t *testing.T) {
+	report := DeadEndReport{
+		NoOutlinks:  []string{"https://example.com/terms"},
+		SinglePaths: []SinglePathResult{{URL: "https://example.com/a/deep", From: "https://example.com/a"}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDeadEndReport(&buf, report); err != nil {
+		t.Fatalf("WriteDeadEndReport() error: %v", err)
+	}
+
+	expected := "Dead-end pages (no outgoing internal links): 1\n" +
+		"  https://example.com/terms\n" +
+		"Pages reachable via one link only: 1\n" +
+		"  https://example.com/a/deep (from https://example.com/a)\n"
+	if buf.String() != expected {
+		t.Errorf("WriteDeadEndReport() =\n%s\nwant\n%s", buf.String(), expected)
+	}
+}
@@ -0,0 +1,134 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeviceResult describes how the mobile version of a page differs from the desktop one
+type DeviceResult struct {
+	URL             string   `json:"url" xml:"url"`
+	DesktopStatus   int      `json:"desktop_status" xml:"desktop_status"`
+	MobileStatus    int      `json:"mobile_status" xml:"mobile_status"`
+	DesktopFinalURL string   `json:"desktop_final_url,omitempty" xml:"desktop_final_url,omitempty"`
+	MobileFinalURL  string   `json:"mobile_final_url,omitempty" xml:"mobile_final_url,omitempty"`
+	MissingOnMobile []string `json:"missing_on_mobile,omitempty" xml:"missing_on_mobile>url,omitempty"`
+	ExtraOnMobile   []string `json:"extra_on_mobile,omitempty" xml:"extra_on_mobile>url,omitempty"`
+	Error           string   `json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// DeviceOutput represents the complete desktop vs mobile report
+type DeviceOutput struct {
+	XMLName   xml.Name       `json:"-" xml:"device_comparison"`
+	Results   []DeviceResult `json:"results" xml:"results>result"`
+	Timestamp time.Time      `json:"timestamp" xml:"timestamp"`
+	Compared  int            `json:"compared" xml:"compared"`
+	Differing int            `json:"differing" xml:"differing"`
+}
+
+// OutputDeviceResults outputs the desktop vs mobile report to stdout in the specified format
+func OutputDeviceResults(results []DeviceResult, compared int, config *OutputConfig) error {
+	return WriteDeviceResults(os.Stdout, results, compared, config)
+}
+
+// WriteDeviceResults writes the pages that differ between desktop and mobile
+// to w in the specified format. compared is the number of pages fetched both ways.
+func WriteDeviceResults(w io.Writer, results []DeviceResult, compared int, config *OutputConfig) error {
+	if config == nil {
+		config = &OutputConfig{Format: FormatText}
+	}
+
+	output := DeviceOutput{
+		Results:   results,
+		Timestamp: now(),
+		Compared:  compared,
+		Differing: len(results),
+	}
+
+	switch config.Format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	case FormatCSV:
+		return writeDeviceCSV(w, results)
+	case FormatXML:
+		xmlData, err := xml.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal XML: %w", err)
+		}
+		if _, err := fmt.Fprint(w, xml.Header); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(xmlData))
+		return err
+	case FormatText:
+		fallthrough
+	default:
+		return writeDeviceText(w, results)
+	}
+}
+
+// writeDeviceText writes one line per page: desktop and mobile status, links
+// missing and extra on mobile and the URL, followed by differing redirects and errors
+func writeDeviceText(w io.Writer, results []DeviceResult) error {
+	for _, result := range results {
+		line := fmt.Sprintf("%3d %3d -%d +%d %s", result.DesktopStatus, result.MobileStatus,
+			len(result.MissingOnMobile), len(result.ExtraOnMobile), result.URL)
+		if result.DesktopFinalURL != result.MobileFinalURL {
+			line += fmt.Sprintf(" (desktop -> %s, mobile -> %s)", finalURLOrSelf(result.DesktopFinalURL), finalURLOrSelf(result.MobileFinalURL))
+		}
+		if result.Error != "" {
+			line += " (" + result.Error + ")"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("failed to write device line: %w", err)
+		}
+	}
+	return nil
+}
+
+// finalURLOrSelf describes a redirect target, or its absence
+func finalURLOrSelf(finalURL string) string {
+	if finalURL == "" {
+		return "(no redirect)"
+	}
+	return finalURL
+}
+
+// writeDeviceCSV writes the desktop vs mobile report as CSV.
+// Link lists are space separated.
+func writeDeviceCSV(w io.Writer, results []DeviceResult) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"url", "desktop_status", "mobile_status", "desktop_final_url", "mobile_final_url", "missing_on_mobile", "extra_on_mobile", "error"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, result := range results {
+		record := []string{
+			result.URL,
+			strconv.Itoa(result.DesktopStatus),
+			strconv.Itoa(result.MobileStatus),
+			result.DesktopFinalURL,
+			result.MobileFinalURL,
+			strings.Join(result.MissingOnMobile, " "),
+			strings.Join(result.ExtraOnMobile, " "),
+			result.Error,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+var testDeviceResults = []DeviceResult{
+	{
+		URL:             "https://example.com/",
+		DesktopStatus:   200,
+		MobileStatus:    200,
+		MissingOnMobile: []string{"https://example.com/compare", "https://example.com/specs"},
+		ExtraOnMobile:   []string{"https://example.com/app"},
+	},
+	{
+		URL:            "https://example.com/shop",
+		DesktopStatus:  200,
+		MobileStatus:   200,
+		MobileFinalURL: "https://m.example.com/shop",
+	},
+}
+
+func TestWriteDeviceResultsText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDeviceResults(&buf, testDeviceResults, 5, nil); err != nil {
+		t.Fatalf("WriteDeviceResults() error: %v", err)
+	}
+
+	expected := "200 200 -2 +1 https://example.com/\n" +
+		"200 200 -0 +0 https://example.com/shop (desktop -> (no redirect), mobile -> https://m.example.com/shop)\n"
+	if buf.String() != expected {
+		t.Errorf("WriteDeviceResults() =\n%s\nwant\n%s", buf.String(), expected)
+	}
+}
+
+func TestWriteDeviceResultsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDeviceResults(&buf, testDeviceResults, 5, &OutputConfig{Format: FormatJSON}); err != nil {
+		t.Fatalf("WriteDeviceResults() error: %v", err)
+	}
+
+	var decoded DeviceOutput
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded.Compared != 5 || decoded.Differing != 2 || len(decoded.Results[0].MissingOnMobile) != 2 {
+		t.Errorf("unexpected output: %+v", decoded)
+	}
+}
+
+func TestWriteDeviceResultsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDeviceResults(&buf, testDeviceResults, 5, &OutputConfig{Format: FormatCSV}); err != nil {
+		t.Fatalf("WriteDeviceResults() error: %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if lines[0] != "url,desktop_status,mobile_status,desktop_final_url,mobile_final_url,missing_on_mobile,extra_on_mobile,error" {
+		t.Errorf("unexpected CSV header: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], ",https://example.com/compare https://example.com/specs,") {
+		t.Errorf("expected space-separated link list: %s", lines[1])
+	}
+}
+
+func TestWriteDeviceResultsXML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDeviceResults(&buf, testDeviceResults, 5, &OutputConfig{Format: FormatXML}); err != nil {
+		t.Fatalf("WriteDeviceResults() error: %v", err)
+	}
+
+	var decoded DeviceOutput
+	if err := xml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if len(decoded.Results) != 2 || len(decoded.Results[0].ExtraOnMobile) != 1 {
+		t.Errorf("unexpected output: %+v", decoded)
+	}
+}
@@ -0,0 +1,34 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DirectiveCountResult is how many pages use a robots directive
+type DirectiveCountResult struct {
+	Directive string
+	Pages     int
+}
+
+// RobotsReport summarizes the robots directives of the crawled pages
+type RobotsReport struct {
+	Pages      int // Pages with at least one directive
+	Directives []DirectiveCountResult
+}
+
+// WriteRobotsReport writes the robots directives report as text
+func WriteRobotsReport(w io.Writer, report RobotsReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Pages with robots directives: %d\n", report.Pages)
+	for _, count := range report.Directives {
+		fmt.Fprintf(&b, "  %s: %d pages\n", count.Directive, count.Pages)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write robots directives report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteRobotsReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteRobotsReport(&buf, RobotsReport{Pages: 5, Directives: []DirectiveCountResult{
+		{Directive: "noarchive", Pages: 5},
+		{Directive: "max-snippet:50", Pages: 2},
+	}})
+	if err != nil {
+		t.Fatalf("WriteRobotsReport() failed: %v", err)
+	}
+
+	want := "Pages with robots directives: 5\n" +
+		"  noarchive: 5 pages\n" +
+		"  max-snippet:50: 2 pages\n"
+	if buf.String() != want {
+		t.Errorf("report = %q, want %q", buf.String(), want)
+	}
+}
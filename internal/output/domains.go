@@ -0,0 +1,38 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DomainUseResult is a third-party domain and how many pages reference it
+type DomainUseResult struct {
+	Domain string
+	Kinds  []string
+	Pages  int
+}
+
+// DomainReport holds the third-party domains the crawled pages reference
+type DomainReport struct {
+	Domains []DomainUseResult
+}
+
+// WriteDomainReport writes the third-party domains report as text
+func WriteDomainReport(w io.Writer, report DomainReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Third-party domains: %d\n", len(report.Domains))
+	for _, domain := range report.Domains {
+		pages := "pages"
+		if domain.Pages == 1 {
+			pages = "page"
+		}
+		fmt.Fprintf(&b, "  %s (%s) on %d %s\n", domain.Domain, strings.Join(domain.Kinds, ", "), domain.Pages, pages)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write third-party domains report: %w", err)
+	}
+	return nil
+}
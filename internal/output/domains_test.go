@@ -0,0 +1,24 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteDomainReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteDomainReport(&buf, DomainReport{Domains: []DomainUseResult{
+		{Domain: "www.googletagmanager.com", Kinds: []string{"script"}, Pages: 40},
+		{Domain: "twitter.com", Kinds: []string{"frame", "link"}, Pages: 1},
+	}})
+	if err != nil {
+		t.Fatalf("WriteDomainReport() failed: %v", err)
+	}
+
+	want := "Third-party domains: 2\n" +
+		"  www.googletagmanager.com (script) on 40 pages\n" +
+		"  twitter.com (frame, link) on 1 page\n"
+	if buf.String() != want {
+		t.Errorf("report = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,91 @@
+package output
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenResults exercise deduplication, ordering, time zones and escaping
+func goldenResults() []URLResult {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	fetched := time.Date(2024, 5, 1, 21, 30, 0, 0, tokyo)
+	return []URLResult{
+		{URL: "https://example.com/docs/install", Timestamp: fetched.Add(3 * time.Second), Depth: 2, Parent: "https://example.com/docs", Hash: "c3"},
+		{URL: "https://example.com/", Timestamp: fetched, Title: "Home", Hash: "a1", Links: []string{"https://example.com/docs", "https://example.com/search?q=a&b=<c>", "https://example.org/"}},
+		{URL: "https://example.com/search?q=a&b=<c>", Timestamp: fetched.Add(2 * time.Second), Depth: 1, Parent: "https://example.com/", Title: `Search "a" & <b>`},
+		{URL: "https://example.com/docs", Timestamp: fetched.Add(time.Second), Depth: 1, Parent: "https://example.com/", Title: "Docs [v2]", Hash: "b2", Links: []string{"https://example.com/", "https://example.com/docs", "https://example.com/docs/install", "https://example.com/docs/install"}},
+		{URL: "https://example.com/docs/install", Timestamp: fetched.Add(4 * time.Second), Depth: 3, Parent: "https://example.com/search?q=a&b=<c>"},
+	}
+}
+
+// goldenProvenance describes a run in a time zone other than UTC
+func goldenProvenance() *Provenance {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	return &Provenance{
+		Version:   "v1.2.3",
+		Commit:    "abc1234",
+		Seeds:     []string{"https://example.com/"},
+		StartTime: time.Date(2024, 5, 1, 21, 29, 0, 0, tokyo),
+		EndTime:   time.Date(2024, 5, 1, 21, 31, 0, 0, tokyo),
+		Config:    []Setting{{Name: "depth", Value: "3"}, {Name: "include", Value: "[/docs/*]"}},
+	}
+}
+
+func TestWriteResults_Golden(t *testing.T) {
+	originalNow := now
+	t.Cleanup(func() { now = originalNow })
+	now = func() time.Time { return time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC) }
+
+	tests := map[string]*OutputConfig{
+		"csv-depth-hash":  {Format: FormatCSV, ShowDepth: true, ShowHash: true},
+		"text-depth-hash": {Format: FormatText, ShowDepth: true, IndentDepth: true, ShowHash: true},
+		"text-limit":      {Format: FormatText, Limit: 2},
+	}
+	for _, format := range Formats {
+		tests[string(format)] = &OutputConfig{Format: format}
+	}
+	for _, format := range []OutputFormat{FormatJSON, FormatXML} {
+		tests[string(format)+"-provenance"] = &OutputConfig{Format: format, Provenance: goldenProvenance()}
+	}
+
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteResults(&buf, goldenResults(), config); err != nil {
+				t.Fatalf("WriteResults() error: %v", err)
+			}
+			checkGolden(t, name, buf.Bytes())
+		})
+	}
+}
+
+// checkGolden compares got with testdata/golden/<name>.golden, or rewrites
+// the file when the test runs with -update
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".golden")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run go test -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run go test -update if the change is intended):\n got:\n%s\nwant:\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FormatDOT writes the link graph of the site in the Graphviz DOT language
+const FormatDOT OutputFormat = "dot"
+
+// FormatGraphML writes the link graph of the site as GraphML, e.g. for Gephi
+const FormatGraphML OutputFormat = "graphml"
+
+// graphMLNamespace is the XML namespace of GraphML
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+// dotString escapes characters that would end a quoted DOT string
+var dotString = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// linkGraph is the link graph of URL results: every result is a node, and
+// each link from one result to another is an edge
+type linkGraph struct {
+	nodes []URLResult
+	edges [][2]int // Indexes of the linking and the linked node
+}
+
+// newLinkGraph builds the graph of urlResults. Links to URLs that are not
+// among the results (external pages, or pages dropped by --limit or
+// --sample) are left out, as are self-links and repeated links.
+func newLinkGraph(urlResults []URLResult) linkGraph {
+	index := make(map[string]int, len(urlResults))
+	for i, result := range urlResults {
+		index[result.URL] = i
+	}
+
+	graph := linkGraph{nodes: urlResults}
+	seen := make(map[[2]int]bool)
+	for i, result := range urlResults {
+		for _, link := range result.Links {
+			j, ok := index[link]
+			if !ok || i == j || seen[[2]int{i, j}] {
+				continue
+			}
+			seen[[2]int{i, j}] = true
+			graph.edges = append(graph.edges, [2]int{i, j})
+		}
+	}
+	sort.Slice(graph.edges, func(a, b int) bool {
+		if graph.edges[a][0] != graph.edges[b][0] {
+			return graph.edges[a][0] < graph.edges[b][0]
+		}
+		return graph.edges[a][1] < graph.edges[b][1]
+	})
+	return graph
+}
+
+// writeDOT writes URL results as a directed DOT graph. Nodes are named after
+// their URL and labelled with the page title, if known.
+func writeDOT(w io.Writer, urlResults []URLResult) error {
+	graph := newLinkGraph(urlResults)
+
+	var b strings.Builder
+	b.WriteString("digraph urlmap {\n")
+	for _, node := range graph.nodes {
+		fmt.Fprintf(&b, "  \"%s\" [depth=%d", dotString.Replace(node.URL), node.Depth)
+		if node.Title != "" {
+			fmt.Fprintf(&b, ", label=\"%s\"", dotString.Replace(node.Title))
+		}
+		b.WriteString("];\n")
+	}
+	for _, edge := range graph.edges {
+		fmt.Fprintf(&b, "  \"%s\" -> \"%s\";\n", dotString.Replace(graph.nodes[edge[0]].URL), dotString.Replace(graph.nodes[edge[1]].URL))
+	}
+	b.WriteString("}\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write DOT: %w", err)
+	}
+	return nil
+}
+
+// graphMLDocument is the root element of a GraphML file
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+// graphMLKey declares a node attribute
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+// graphMLGraph holds the nodes and edges of the graph
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+// graphMLNode is a URL and its attributes
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+// graphMLData is the value of a node attribute
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// graphMLEdge is a link between two nodes
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+// writeGraphML writes URL results as a directed GraphML graph. Node IDs are
+// n0, n1, ... in the order of the results, as URLs are not valid IDs; the
+// URL, title and depth are node attributes.
+func writeGraphML(w io.Writer, urlResults []URLResult) error {
+	graph := newLinkGraph(urlResults)
+
+	document := graphMLDocument{
+		Xmlns: graphMLNamespace,
+		Keys: []graphMLKey{
+			{ID: "url", For: "node", Name: "url", Type: "string"},
+			{ID: "title", For: "node", Name: "title", Type: "string"},
+			{ID: "depth", For: "node", Name: "depth", Type: "int"},
+		},
+		Graph: graphMLGraph{ID: "urlmap", EdgeDefault: "directed"},
+	}
+	for i, node := range graph.nodes {
+		data := []graphMLData{{Key: "url", Value: node.URL}}
+		if node.Title != "" {
+			data = append(data, graphMLData{Key: "title", Value: node.Title})
+		}
+		data = append(data, graphMLData{Key: "depth", Value: strconv.Itoa(node.Depth)})
+		document.Graph.Nodes = append(document.Graph.Nodes, graphMLNode{ID: graphMLNodeID(i), Data: data})
+	}
+	for _, edge := range graph.edges {
+		document.Graph.Edges = append(document.Graph.Edges, graphMLEdge{Source: graphMLNodeID(edge[0]), Target: graphMLNodeID(edge[1])})
+	}
+
+	xmlData, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal GraphML: %w", err)
+	}
+
+	if _, err := fmt.Fprint(w, xml.Header); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(xmlData))
+	return err
+}
+
+// graphMLNodeID returns the ID of the i-th node
+func graphMLNodeID(i int) string {
+	return "n" + strconv.Itoa(i)
+}
@@ -0,0 +1,29 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteResultsDOTLimit(t *testing.T) {
+	results := []URLResult{
+		{URL: "https://example.com/", Links: []string{"https://example.com/a", "https://example.com/b"}},
+		{URL: "https://example.com/a", Depth: 1, Links: []string{"https://example.com/b"}},
+		{URL: "https://example.com/b", Depth: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, results, &OutputConfig{Format: FormatDOT, Limit: 2}); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+
+	// Links to the page dropped by the limit are left out
+	want := "digraph urlmap {\n" +
+		"  \"https://example.com/\" [depth=0];\n" +
+		"  \"https://example.com/a\" [depth=1];\n" +
+		"  \"https://example.com/\" -> \"https://example.com/a\";\n" +
+		"}\n"
+	if buf.String() != want {
+		t.Errorf("WriteResults() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,38 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// HintOriginResult is a third-party origin named in resource hints
+type HintOriginResult struct {
+	Origin string
+	Rels   []string
+	Pages  int
+}
+
+// HintReport holds the third-party origins of the crawl's resource hints
+type HintReport struct {
+	Origins []HintOriginResult
+}
+
+// WriteHintReport writes the resource hints report as text
+func WriteHintReport(w io.Writer, report HintReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Third-party origins in resource hints: %d\n", len(report.Origins))
+	for _, origin := range report.Origins {
+		pages := "pages"
+		if origin.Pages == 1 {
+			pages = "page"
+		}
+		fmt.Fprintf(&b, "  %s (%s) on %d %s\n", origin.Origin, strings.Join(origin.Rels, ", "), origin.Pages, pages)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write resource hints report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteHintReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteHintReport(&buf, HintReport{Origins: []HintOriginResult{
+		{Origin: "https://fonts.gstatic.com", Rels: []string{"preconnect", "preload"}, Pages: 12},
+		{Origin: "https://www.googletagmanager.com", Rels: []string{"dns-prefetch"}, Pages: 1},
+	}})
+	if err != nil {
+		t.Fatalf("WriteHintReport() failed: %v", err)
+	}
+
+	want := "Third-party origins in resource hints: 2\n" +
+		"  https://fonts.gstatic.com (preconnect, preload) on 12 pages\n" +
+		"  https://www.googletagmanager.com (dns-prefetch) on 1 page\n"
+	if buf.String() != want {
+		t.Errorf("report = %q, want %q", buf.String(), want)
+	}
+}
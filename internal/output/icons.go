@@ -0,0 +1,67 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// IconResult is the outcome of fetching an icon or manifest
+type IconResult struct {
+	Rel        string
+	URL        string
+	Sizes      string
+	StatusCode int    // 0 if the request failed
+	Error      string // Failed request, error status or invalid manifest
+}
+
+// OriginIconResult holds the icons and manifests of an origin
+type OriginIconResult struct {
+	Origin string
+	Icons  []IconResult
+}
+
+// IconReport holds the icons and manifests of each crawled origin
+type IconReport struct {
+	Origins []OriginIconResult
+}
+
+// WriteIconReport writes the icons report as text
+func WriteIconReport(w io.Writer, report IconReport) error {
+	var b strings.Builder
+
+	broken := 0
+	for _, origin := range report.Origins {
+		for _, icon := range origin.Icons {
+			if icon.Error != "" {
+				broken++
+			}
+		}
+	}
+	fmt.Fprintf(&b, "Icons and manifests: %d origins, %d broken\n", len(report.Origins), broken)
+
+	for _, origin := range report.Origins {
+		fmt.Fprintf(&b, "%s\n", origin.Origin)
+		manifest := false
+		for _, icon := range origin.Icons {
+			label := icon.Rel
+			if icon.Sizes != "" {
+				label += " " + icon.Sizes
+			}
+			outcome := fmt.Sprintf("%d", icon.StatusCode)
+			if icon.Error != "" {
+				outcome = "error: " + icon.Error
+			}
+			fmt.Fprintf(&b, "  %s %s: %s\n", label, icon.URL, outcome)
+			manifest = manifest || icon.Rel == "manifest"
+		}
+		if !manifest {
+			fmt.Fprintf(&b, "  no web app manifest\n")
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write icons report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteIconReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteIconReport(&buf, IconReport{Origins: []OriginIconResult{
+		{Origin: "https://example.com", Icons: []IconResult{
+			{Rel: "icon", URL: "https://example.com/favicon.ico", StatusCode: 200},
+			{Rel: "apple-touch-icon", URL: "https://example.com/apple.png", Sizes: "180x180", StatusCode: 404, Error: "HTTP error: 404"},
+			{Rel: "manifest", URL: "https://example.com/site.webmanifest", StatusCode: 200},
+		}},
+		{Origin: "https://shop.example.com", Icons: []IconResult{
+			{Rel: "icon", URL: "https://shop.example.com/favicon.ico", Error: "connection refused"},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("WriteIconReport() failed: %v", err)
+	}
+
+	want := "Icons and manifests: 2 origins, 2 broken\n" +
+		"https://example.com\n" +
+		"  icon https://example.com/favicon.ico: 200\n" +
+		"  apple-touch-icon 180x180 https://example.com/apple.png: error: HTTP error: 404\n" +
+		"  manifest https://example.com/site.webmanifest: 200\n" +
+		"https://shop.example.com\n" +
+		"  icon https://shop.example.com/favicon.ico: error: connection refused\n" +
+		"  no web app manifest\n"
+	if buf.String() != want {
+		t.Errorf("report = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadIndexExport reads the URLs of a search engine export: a CSV file from
+// Google Search Console or Bing Webmaster Tools, whose URL column may be
+// anywhere, or one URL per line. The first http(s) URL of each row is used;
+// header rows and '#' comments are skipped.
+func ReadIndexExport(r io.Reader) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var urls []string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read index export: %w", err)
+		}
+		for _, field := range record {
+			field = strings.TrimSpace(field)
+			if strings.HasPrefix(field, "http://") || strings.HasPrefix(field, "https://") {
+				urls = append(urls, field)
+				break
+			}
+		}
+	}
+	return urls, nil
+}
+
+// IndexReport compares the crawl with URLs exported from a search engine
+type IndexReport struct {
+	Indexed    int      // URLs in the export within the crawl's scope
+	NotLinked  []string // Indexed URLs the crawl did not reach
+	NotIndexed []string // Crawled pages missing from the export
+}
+
+// WriteIndexReport writes the index comparison as text
+func WriteIndexReport(w io.Writer, report IndexReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Indexed URLs in scope: %d\n", report.Indexed)
+
+	fmt.Fprintf(&b, "Indexed but not linked (orphan pages): %d\n", len(report.NotLinked))
+	for _, url := range report.NotLinked {
+		fmt.Fprintf(&b, "  %s\n", url)
+	}
+
+	fmt.Fprintf(&b, "Linked but not indexed: %d\n", len(report.NotIndexed))
+	for _, url := range report.NotIndexed {
+		fmt.Fprintf(&b, "  %s\n", url)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write index report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteIndexReport(t *testing.T) {
+	report := IndexReport{
+		Indexed:    3,
+		NotLinked:  []string{"https://example.com/orphan"},
+		NotIndexed: []string{"https://example.com/new"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteIndexReport(&buf, report); err != nil {
+		t.Fatalf("WriteIndexReport() error: %v", err)
+	}
+
+	expected := "Indexed URLs in scope: 3\n" +
+		"Indexed but not linked (orphan pages): 1\n" +
+		"  https://example.com/orphan\n" +
+		"Linked but not indexed: 1\n" +
+		"  https://example.com/new\n"
+	if buf.String() != expected {
+		t.Errorf("WriteIndexReport() =\n%s\nwant\n%s", buf.String(), expected)
+	}
+}
+
+func TestReadIndexExport(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		expect string
+	}{
+		{
+			name:   "search console pages",
+			input:  "Top pages,Clicks,Impressions,CTR,Position\nhttps://example.com/,10,100,10%,1.5\n\"https://example.com/a,b\",1,20,5%,8\n",
+			expect: "https://example.com/ https://example.com/a,b",
+		},
+		{
+			name:   "URL in a later column",
+			input:  "Last crawled,URL\n2024-05-01,https://example.com/orphan\n",
+			expect: "https://example.com/orphan",
+		},
+		{
+			name:   "URL list",
+			input:  "# exported from Bing\nhttps://example.com/\n\n  https://example.com/b\n",
+			expect: "https://example.com/ https://example.com/b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urls, err := ReadIndexExport(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ReadIndexExport() error: %v", err)
+			}
+			if got := strings.Join(urls, " "); got != tt.expect {
+				t.Errorf("ReadIndexExport() = %q, want %q", got, tt.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LanguageCountResult is how many pages are in a language
+type LanguageCountResult struct {
+	Language string
+	Pages    int
+	Detected int // Pages whose language was detected from their text
+}
+
+// LanguageReport counts the crawled pages per language
+type LanguageReport struct {
+	Languages   []LanguageCountResult
+	MissingLang []string // Pages without a lang attribute
+}
+
+// WriteLanguageReport writes the languages report as text
+func WriteLanguageReport(w io.Writer, report LanguageReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Languages: %d\n", len(report.Languages))
+	for _, count := range report.Languages {
+		fmt.Fprintf(&b, "  %s: %d pages", count.Language, count.Pages)
+		if count.Detected > 0 {
+			fmt.Fprintf(&b, " (%d detected from text)", count.Detected)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "Pages without a lang attribute: %d\n", len(report.MissingLang))
+	for _, url := range report.MissingLang {
+		fmt.Fprintf(&b, "  %s\n", url)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write languages report: %w", err)
+	}
+	return nil
+}
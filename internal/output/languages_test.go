@@ -0,0 +1,29 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteLanguageReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteLanguageReport(&buf, LanguageReport{
+		Languages: []LanguageCountResult{
+			{Language: "en", Pages: 12, Detected: 1},
+			{Language: "ja", Pages: 4},
+		},
+		MissingLang: []string{"https://example.com/legacy"},
+	})
+	if err != nil {
+		t.Fatalf("WriteLanguageReport() failed: %v", err)
+	}
+
+	want := "Languages: 2\n" +
+		"  en: 12 pages (1 detected from text)\n" +
+		"  ja: 4 pages\n" +
+		"Pages without a lang attribute: 1\n" +
+		"  https://example.com/legacy\n"
+	if buf.String() != want {
+		t.Errorf("report = %q, want %q", buf.String(), want)
+	}
+}
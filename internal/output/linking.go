@@ -0,0 +1,45 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LinkCount is the number of internal links to or from a page
+type LinkCount struct {
+	URL   string
+	Count int
+}
+
+// LinkingReport holds the most linked-to and most linking pages of a crawl
+type LinkingReport struct {
+	MostLinked  []LinkCount // Pages by number of pages linking to them
+	MostLinking []LinkCount // Pages by number of pages they link to
+}
+
+// WriteLinkingReport writes the internal linking report as text
+func WriteLinkingReport(w io.Writer, report LinkingReport) error {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "Most linked-to pages (inbound internal links):")
+	writeLinkCounts(&b, report.MostLinked)
+	fmt.Fprintln(&b, "Pages with the most outbound internal links:")
+	writeLinkCounts(&b, report.MostLinking)
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write linking report: %w", err)
+	}
+	return nil
+}
+
+// writeLinkCounts writes one line per page: link count and URL
+func writeLinkCounts(b *strings.Builder, counts []LinkCount) {
+	if len(counts) == 0 {
+		fmt.Fprintln(b, "  (none)")
+		return
+	}
+	for _, count := range counts {
+		fmt.Fprintf(b, "  %5d %s\n", count.Count, count.URL)
+	}
+}
@@ -0,0 +1,29 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteLinkingReport(t *testing.T) {
+	report := LinkingReport{
+		MostLinked: []LinkCount{
+			{URL: "https://example.com/", Count: 12},
+			{URL: "https://example.com/pricing", Count: 3},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteLinkingReport(&buf, report); err != nil {
+		t.Fatalf("WriteLinkingReport() error: %v", err)
+	}
+
+	expected := "Most linked-to pages (inbound internal links):\n" +
+		"     12 https://example.com/\n" +
+		"      3 https://example.com/pricing\n" +
+		"Pages with the most outbound internal links:\n" +
+		"  (none)\n"
+	if buf.String() != expected {
+		t.Errorf("WriteLinkingReport() =\n%s\nwant\n%s", buf.String(), expected)
+	}
+}
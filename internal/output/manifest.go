@@ -0,0 +1,89 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ManifestFile is the name of the manifest in a working directory
+const ManifestFile = "manifest.json"
+
+// Manifest describes the files a run wrote to its working directory
+// (--workdir), so the artifacts of a run can be found and audited without
+// knowing the flags it ran with
+type Manifest struct {
+	Provenance *Provenance `json:"provenance,omitempty"`
+	Invocation *Invocation `json:"invocation,omitempty"`
+	ExitCode   int         `json:"exit_code"`
+	Error      string      `json:"error,omitempty"`
+	Files      []Artifact  `json:"files"`
+}
+
+// Invocation is how a run was started, so it can be repeated
+type Invocation struct {
+	Args      []string `json:"args"`       // Command line arguments after the program name, as given
+	Dir       string   `json:"dir"`        // Working directory relative paths in Args refer to
+	GoVersion string   `json:"go_version"` // Go release urlmap was built with
+	OS        string   `json:"os"`
+	Arch      string   `json:"arch"`
+}
+
+// Artifact is a file of the working directory
+type Artifact struct {
+	Path  string `json:"path"` // Relative to the working directory, with forward slashes
+	Bytes int64  `json:"bytes"`
+}
+
+// WriteManifest lists the files in dir in manifest.Files, sorted by path,
+// and writes the manifest to dir/manifest.json
+func WriteManifest(dir string, manifest Manifest) error {
+	manifest.Files = []Artifact{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == ManifestFile {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, Artifact{Path: filepath.ToSlash(rel), Bytes: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list working directory: %w", err)
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Path < manifest.Files[j].Path
+	})
+	manifest.Provenance = canonicalProvenance(manifest.Provenance)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// ReadManifest reads the manifest of the working directory dir
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", filepath.Join(dir, ManifestFile), err)
+	}
+	return &manifest, nil
+}
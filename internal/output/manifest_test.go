@@ -0,0 +1,54 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWriteManifest(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "output"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "output", "urls.txt"), []byte("https://example.com/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "urlmap.log"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := Manifest{
+		Provenance: &Provenance{Version: "v1.2.3", Seeds: []string{"https://example.com/"}},
+		Invocation: &Invocation{Args: []string{"--workdir", dir, "https://example.com/"}, Dir: "/home/user"},
+		ExitCode:   3,
+		Error:      "1 of 2 URLs failed",
+	}
+	if err := WriteManifest(dir, manifest); err != nil {
+		t.Fatalf("WriteManifest() error: %v", err)
+	}
+	// Writing it again does not list the manifest itself
+	if err := WriteManifest(dir, manifest); err != nil {
+		t.Fatalf("WriteManifest() error: %v", err)
+	}
+
+	got, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatalf("ReadManifest() error: %v", err)
+	}
+	want := []Artifact{{Path: "output/urls.txt", Bytes: 21}, {Path: "urlmap.log", Bytes: 0}}
+	if !reflect.DeepEqual(got.Files, want) {
+		t.Errorf("Files = %v, want %v", got.Files, want)
+	}
+	if got.ExitCode != 3 || got.Error != "1 of 2 URLs failed" || got.Provenance.Version != "v1.2.3" {
+		t.Errorf("manifest = %+v", got)
+	}
+	if !reflect.DeepEqual(got.Invocation, manifest.Invocation) {
+		t.Errorf("Invocation = %+v, want %+v", got.Invocation, manifest.Invocation)
+	}
+
+	if _, err := ReadManifest(t.TempDir()); err == nil {
+		t.Error("ReadManifest() of a directory without manifest should fail")
+	}
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FormatMarkdown writes the URLs as nested Markdown lists following the
+// page each URL was first found on
+const FormatMarkdown OutputFormat = "markdown"
+
+// markdownText escapes characters that would end or restyle link text, or start inline HTML
+var markdownText = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, "<", `\<`, "*", `\*`, "_", `\_`, "`", "\\`")
+
+// markdownURL escapes characters that would end a link destination
+var markdownURL = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E")
+
+// writeMarkdown writes URL results as a tree of nested lists. Each URL is
+// listed under its parent; URLs whose parent is not among the results (the
+// seed, or pages dropped by --limit or --sample) start a top-level item.
+// Pages with a title are written as links named after it, and URLs only
+// found in inline scripts are marked low confidence.
+func writeMarkdown(w io.Writer, urlResults []URLResult) error {
+	index := make(map[string]bool, len(urlResults))
+	for _, result := range urlResults {
+		index[result.URL] = true
+	}
+
+	var roots []URLResult
+	children := make(map[string][]URLResult)
+	for _, result := range urlResults {
+		if result.Parent == "" || result.Parent == result.URL || !index[result.Parent] {
+			roots = append(roots, result)
+			continue
+		}
+		children[result.Parent] = append(children[result.Parent], result)
+	}
+
+	var b strings.Builder
+	written := make(map[string]bool, len(urlResults))
+	var writeItem func(result URLResult, level int)
+	writeItem = func(result URLResult, level int) {
+		if written[result.URL] {
+			return
+		}
+		written[result.URL] = true
+
+		b.WriteString(strings.Repeat("  ", level))
+		if result.Title != "" {
+			fmt.Fprintf(&b, "- [%s](%s)", markdownText.Replace(result.Title), markdownURL.Replace(result.URL))
+		} else {
+			fmt.Fprintf(&b, "- <%s>", markdownURL.Replace(result.URL))
+		}
+		if result.LowConfidence {
+			b.WriteString(" (low confidence)")
+		}
+		if result.Historical {
+			b.WriteString(" (historical)")
+		}
+		if result.BotProtection != "" {
+			b.WriteString(" " + blockedTag(result))
+		}
+		b.WriteString("\n")
+		for _, child := range children[result.URL] {
+			writeItem(child, level+1)
+		}
+	}
+	for _, root := range roots {
+		writeItem(root, 0)
+	}
+
+	// Pages on a parent cycle are not reachable from any root
+	for _, result := range urlResults {
+		writeItem(result, 0)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write Markdown: %w", err)
+	}
+	return nil
+}
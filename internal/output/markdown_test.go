@@ -0,0 +1,50 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteResultsMarkdown(t *testing.T) {
+	results := []URLResult{
+		{URL: "https://example.com/", Title: "Home"},
+		{URL: "https://example.com/docs", Parent: "https://example.com/", Title: "Docs [v2]"},
+		{URL: "https://example.com/docs/install", Parent: "https://example.com/docs"},
+		{URL: "https://example.com/about", Parent: "https://example.com/", Title: "About_us"},
+		{URL: "https://example.com/orphan", Parent: "https://example.com/missing"},
+		{URL: "https://example.com/a(b)", Parent: "https://example.com/"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, results, &OutputConfig{Format: FormatMarkdown}); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+
+	want := `- [Home](https://example.com/)
+  - <https://example.com/a%28b%29>
+  - [About\_us](https://example.com/about)
+  - [Docs \[v2\]](https://example.com/docs)
+    - <https://example.com/docs/install>
+- <https://example.com/orphan>
+`
+	if got := buf.String(); got != want {
+		t.Errorf("markdown:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteResultsMarkdown_ParentCycle(t *testing.T) {
+	results := []URLResult{
+		{URL: "https://example.com/a", Parent: "https://example.com/b"},
+		{URL: "https://example.com/b", Parent: "https://example.com/a"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, results, &OutputConfig{Format: FormatMarkdown}); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+
+	want := "- <https://example.com/a>\n  - <https://example.com/b>\n"
+	if got := buf.String(); got != want {
+		t.Errorf("markdown:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,195 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ResultSet holds the results of one stored crawl, a JSON document
+// (--output-format json) or an ND-JSON file (--ndjson), as ND-JSON results,
+// with the provenance of the runs that wrote it
+type ResultSet struct {
+	Results    []NDJSONResult
+	Provenance []Provenance
+}
+
+// ReadResultSet reads a JSON document or an ND-JSON file. JSON documents keep
+// no status codes or links, so their results only have what the document holds.
+func ReadResultSet(r io.Reader) (*ResultSet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+
+	// A JSON document is a single object holding a "urls" array
+	var document struct {
+		URLs       *[]URLResult `json:"urls"`
+		Provenance *Provenance  `json:"provenance"`
+	}
+	if json.Unmarshal(data, &document) == nil && document.URLs != nil {
+		set := &ResultSet{}
+		for _, result := range *document.URLs {
+			set.Results = append(set.Results, ndjsonFromURLResult(result))
+		}
+		if document.Provenance != nil {
+			set.Provenance = append(set.Provenance, *document.Provenance)
+		}
+		return set, nil
+	}
+
+	results, err := ReadNDJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	set := &ResultSet{Results: results}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var record ndjsonProvenance
+		if json.Unmarshal(scanner.Bytes(), &record) == nil && record.Provenance != nil {
+			set.Provenance = append(set.Provenance, *record.Provenance)
+		}
+	}
+	return set, nil
+}
+
+// ndjsonFromURLResult converts a result of a JSON document
+func ndjsonFromURLResult(result URLResult) NDJSONResult {
+	return NDJSONResult{
+		URL:           result.URL,
+		Depth:         result.Depth,
+		Parent:        result.Parent,
+		Hash:          result.Hash,
+		Title:         result.Title,
+		Timestamp:     result.Timestamp,
+		LowConfidence: result.LowConfidence,
+		BotProtection: result.BotProtection,
+		Historical:    result.Historical,
+		Tags:          result.Tags,
+		Egress:        result.Egress,
+
+		Language:         result.Language,
+		LanguageDetected: result.LanguageDetected,
+		Robots:           result.Robots,
+
+		RenderDiagnostics: result.RenderDiagnostics,
+		PartialRender:     result.PartialRender,
+	}
+}
+
+// URLResult converts the result for the other output formats
+func (r NDJSONResult) URLResult() URLResult {
+	return URLResult{
+		URL:           r.URL,
+		Timestamp:     r.Timestamp,
+		Depth:         r.Depth,
+		Hash:          r.Hash,
+		Title:         r.Title,
+		Parent:        r.Parent,
+		LowConfidence: r.LowConfidence,
+		BotProtection: r.BotProtection,
+		Historical:    r.Historical,
+		Tags:          r.Tags,
+		Egress:        r.Egress,
+
+		Language:         r.Language,
+		LanguageDetected: r.LanguageDetected,
+		Robots:           r.Robots,
+
+		RenderDiagnostics: r.RenderDiagnostics,
+		PartialRender:     r.PartialRender,
+	}
+}
+
+// MergeStats describes a merge of result sets
+type MergeStats struct {
+	Sets       int // Result sets merged
+	Results    int // Results read from all sets
+	Duplicates int // Results replaced by a later result for the same URL
+	URLs       int // URLs in the merged results
+	Failed     int // Merged URLs whose latest result is an error
+}
+
+// MergeResultSets returns the union of the results of sets, sorted by URL.
+// When several sets hold the same URL the result fetched last wins, so a page
+// that failed in one run and was crawled in another keeps its latest status;
+// on equal times the result of the later set wins.
+func MergeResultSets(sets []*ResultSet) ([]NDJSONResult, MergeStats) {
+	stats := MergeStats{Sets: len(sets)}
+	latest := make(map[string]NDJSONResult)
+	for _, set := range sets {
+		for _, result := range set.Results {
+			stats.Results++
+			if current, ok := latest[result.URL]; ok {
+				stats.Duplicates++
+				if result.Timestamp.Before(current.Timestamp) {
+					continue
+				}
+			}
+			latest[result.URL] = result
+		}
+	}
+
+	merged := make([]NDJSONResult, 0, len(latest))
+	for _, result := range latest {
+		merged = append(merged, result)
+		if result.Error != "" {
+			stats.Failed++
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].URL < merged[j].URL })
+	stats.URLs = len(merged)
+	return merged, stats
+}
+
+// MergeProvenance returns the seeds of every run recorded in sets and the
+// time span they crawled in; the caller fills in the merging build and options
+func MergeProvenance(sets []*ResultSet) Provenance {
+	var merged Provenance
+	for _, set := range sets {
+		for _, run := range set.Provenance {
+			for _, seed := range run.Seeds {
+				if !slices.Contains(merged.Seeds, seed) {
+					merged.Seeds = append(merged.Seeds, seed)
+				}
+			}
+			merged.StartTime = earliest(merged.StartTime, run.StartTime)
+			if run.EndTime.After(merged.EndTime) {
+				merged.EndTime = run.EndTime
+			}
+		}
+	}
+	sort.Strings(merged.Seeds)
+	return merged
+}
+
+// earliest returns the earlier of two times, ignoring zero times
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+// WriteNDJSON writes results to w as an ND-JSON file, starting with a
+// provenance line if provenance is not nil
+func WriteNDJSON(w io.Writer, results []NDJSONResult, provenance *Provenance) error {
+	encoder := json.NewEncoder(w)
+	if provenance != nil {
+		if err := encoder.Encode(ndjsonProvenance{Provenance: canonicalProvenance(provenance)}); err != nil {
+			return fmt.Errorf("failed to write ND-JSON provenance: %w", err)
+		}
+	}
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("failed to write ND-JSON result: %w", err)
+		}
+	}
+	return nil
+}
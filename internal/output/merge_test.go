@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadResultSet(t *testing.T) {
+	var document bytes.Buffer
+	if err := WriteResults(&document, goldenResults(), &OutputConfig{Format: FormatJSON, Provenance: goldenProvenance()}); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	set, err := ReadResultSet(&document)
+	if err != nil {
+		t.Fatalf("ReadResultSet() on JSON error: %v", err)
+	}
+	if len(set.Results) != 4 || set.Results[0].URL != "https://example.com/" || set.Results[0].Title != "Home" {
+		t.Errorf("unexpected JSON results: %+v", set.Results)
+	}
+	if len(set.Provenance) != 1 || set.Provenance[0].Version != "v1.2.3" {
+		t.Errorf("unexpected JSON provenance: %+v", set.Provenance)
+	}
+
+	ndjson := `{"provenance":{"version":"v1","seeds":["https://example.com/blog/"],"start_time":"2024-05-01T12:00:00Z","config":[]}}
+{"url":"https://example.com/blog/","depth":0,"status":200,"timestamp":"2024-05-01T12:00:01Z"}
+{"url":"https://example.com/blog/a","depth":1,"error":"HTTP error: 503","timestamp":"2024-05-01T12:00:02Z"}
+`
+	set, err = ReadResultSet(strings.NewReader(ndjson))
+	if err != nil {
+		t.Fatalf("ReadResultSet() on ND-JSON error: %v", err)
+	}
+	if len(set.Results) != 2 || set.Results[0].StatusCode != 200 || set.Results[1].Error == "" {
+		t.Errorf("unexpected ND-JSON results: %+v", set.Results)
+	}
+	if len(set.Provenance) != 1 || set.Provenance[0].Seeds[0] != "https://example.com/blog/" {
+		t.Errorf("unexpected ND-JSON provenance: %+v", set.Provenance)
+	}
+}
+
+func TestMergeResultSets(t *testing.T) {
+	at := func(minute int) time.Time { return time.Date(2024, 5, 1, 12, minute, 0, 0, time.UTC) }
+	eu := &ResultSet{
+		Results: []NDJSONResult{
+			{URL: "https://example.com/", StatusCode: 200, Timestamp: at(1)},
+			{URL: "https://example.com/shop", Error: "HTTP error: 503", StatusCode: 503, Timestamp: at(5)},
+		},
+		Provenance: []Provenance{{Seeds: []string{"https://example.com/"}, StartTime: at(0), EndTime: at(6)}},
+	}
+	us := &ResultSet{
+		Results: []NDJSONResult{
+			{URL: "https://example.com/", StatusCode: 200, Timestamp: at(0)},
+			{URL: "https://example.com/shop", StatusCode: 200, Timestamp: at(9)},
+			{URL: "https://example.com/about", Error: "timeout", Timestamp: at(2)},
+		},
+		Provenance: []Provenance{{Seeds: []string{"https://example.com/"}, StartTime: at(-1), EndTime: at(10)}},
+	}
+
+	merged, stats := MergeResultSets([]*ResultSet{eu, us})
+
+	var got []string
+	for _, result := range merged {
+		got = append(got, result.URL+" "+result.Timestamp.Format("15:04"))
+	}
+	want := []string{"https://example.com/ 12:01", "https://example.com/about 12:02", "https://example.com/shop 12:09"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("merged %v, want %v", got, want)
+	}
+	if stats != (MergeStats{Sets: 2, Results: 5, Duplicates: 2, URLs: 3, Failed: 1}) {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	provenance := MergeProvenance([]*ResultSet{eu, us})
+	if !reflect.DeepEqual(provenance.Seeds, []string{"https://example.com/"}) || !provenance.StartTime.Equal(at(-1)) || !provenance.EndTime.Equal(at(10)) {
+		t.Errorf("unexpected provenance: %+v", provenance)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	results := []NDJSONResult{{URL: "https://example.com/", StatusCode: 200}}
+	if err := WriteNDJSON(&buf, results, goldenProvenance()); err != nil {
+		t.Fatalf("WriteNDJSON() error: %v", err)
+	}
+
+	set, err := ReadResultSet(&buf)
+	if err != nil {
+		t.Fatalf("ReadResultSet() error: %v", err)
+	}
+	if len(set.Results) != 1 || set.Results[0].StatusCode != 200 || len(set.Provenance) != 1 {
+		t.Errorf("written file read back as %+v", set)
+	}
+}
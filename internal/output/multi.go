@@ -0,0 +1,92 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Formats lists every format WriteResults supports
+var Formats = []OutputFormat{FormatText, FormatJSON, FormatCSV, FormatXML, FormatSitemap, FormatMarkdown, FormatDOT, FormatGraphML}
+
+// ParseFormats parses a comma-separated list of output formats, e.g.
+// "json,csv,sitemap". Duplicates are dropped.
+func ParseFormats(list string) ([]OutputFormat, error) {
+	var formats []OutputFormat
+	seen := make(map[OutputFormat]bool)
+	for _, name := range strings.Split(list, ",") {
+		format := OutputFormat(strings.ToLower(strings.TrimSpace(name)))
+		if !format.valid() {
+			return nil, fmt.Errorf("unsupported output format: %s (supported: %s)", name, formatList())
+		}
+		if !seen[format] {
+			seen[format] = true
+			formats = append(formats, format)
+		}
+	}
+	return formats, nil
+}
+
+// FileName returns the name of the file the format is written to by WriteResultsToDir
+func (f OutputFormat) FileName() string {
+	switch f {
+	case FormatText:
+		return "urls.txt"
+	case FormatSitemap:
+		return "sitemap.xml"
+	case FormatMarkdown:
+		return "urls.md"
+	default:
+		return "urls." + string(f)
+	}
+}
+
+// valid reports whether f is one of Formats
+func (f OutputFormat) valid() bool {
+	for _, format := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// formatList returns the supported formats for error messages
+func formatList() string {
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = string(format)
+	}
+	return strings.Join(names, ", ")
+}
+
+// WriteResultsToDir writes results to dir once per format, creating dir if
+// needed. Deduplication, sampling and the limit are applied once, so every
+// file holds the same URLs; config.Format is ignored.
+func WriteResultsToDir(dir string, results []URLResult, formats []OutputFormat, config *OutputConfig) error {
+	if config == nil {
+		config = &OutputConfig{}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	selected := selectResults(results, config)
+	for _, format := range formats {
+		path := filepath.Join(dir, format.FileName())
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		err = writeFormat(file, format, selected, config)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
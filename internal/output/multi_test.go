@@ -0,0 +1,64 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFormats(t *testing.T) {
+	formats, err := ParseFormats("json, CSV,sitemap,json")
+	if err != nil {
+		t.Fatalf("ParseFormats() error: %v", err)
+	}
+	want := []OutputFormat{FormatJSON, FormatCSV, FormatSitemap}
+	if !reflect.DeepEqual(formats, want) {
+		t.Errorf("ParseFormats() = %v, want %v", formats, want)
+	}
+
+	if _, err := ParseFormats("json,yaml"); err == nil || !strings.Contains(err.Error(), "yaml") {
+		t.Errorf("expected an error naming yaml, got %v", err)
+	}
+	if _, err := ParseFormats(""); err == nil {
+		t.Error("expected an error for an empty list")
+	}
+}
+
+func TestWriteResultsToDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	results := make([]URLResult, 50)
+	for i := range results {
+		results[i] = URLResult{URL: "https://example.com/" + strings.Repeat("a", i+1)}
+	}
+	config := &OutputConfig{Sample: 0.5}
+
+	formats := []OutputFormat{FormatText, FormatJSON, FormatCSV, FormatXML, FormatSitemap}
+	if err := WriteResultsToDir(dir, results, formats, config); err != nil {
+		t.Fatalf("WriteResultsToDir() error: %v", err)
+	}
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return string(data)
+	}
+
+	// Every format holds the same sample
+	text := read("urls.txt")
+	urls := strings.Fields(text)
+	for _, name := range []string{"urls.json", "urls.csv", "urls.xml", "sitemap.xml"} {
+		content := read(name)
+		for _, u := range urls {
+			if !strings.Contains(content, u+"\"") && !strings.Contains(content, u+",") && !strings.Contains(content, u+"<") {
+				t.Errorf("%s is missing %s", name, u)
+			}
+		}
+		if n := strings.Count(content, "https://example.com/"); n != len(urls) {
+			t.Errorf("%s holds %d URLs, urls.txt holds %d", name, n, len(urls))
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// NDJSONResult is one line of an ND-JSON result file. It keeps the links of
+// each page so an interrupted crawl can be resumed from the file.
+type NDJSONResult struct {
+	URL        string    `json:"url"`
+	Depth      int       `json:"depth"`
+	Parent     string    `json:"parent,omitempty"`
+	StatusCode int       `json:"status,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Server     string    `json:"server,omitempty"`     // Server header of an HTTP error response
+	ErrorBody  string    `json:"error_body,omitempty"` // Start of the body of an HTTP error response
+	ErrorKind  string    `json:"error_kind,omitempty"` // "dns", "tcp" or "tls" for connection failures
+	Hash       string    `json:"hash,omitempty"`
+	Title      string    `json:"title,omitempty"`
+	Links      []string  `json:"links,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+
+	// Links of the page found only in inline scripts, and whether the page
+	// itself was found that way (--extract-script-urls)
+	ScriptLinks   []string `json:"script_links,omitempty"`
+	LowConfidence bool     `json:"low_confidence,omitempty"`
+
+	// Bot protection that answered with a challenge instead of the page
+	BotProtection string `json:"bot_protection,omitempty"`
+
+	// Whether the page was found in a web archive rather than on the site
+	Historical bool `json:"historical,omitempty"`
+
+	// Tags assigned by the URL classifier
+	Tags []string `json:"tags,omitempty"`
+
+	// Region of the egress proxy the page was fetched through
+	Egress string `json:"egress,omitempty"`
+
+	// Language of the page, and whether it was detected from the text
+	// because the page has no lang attribute
+	Language         string `json:"language,omitempty"`
+	LanguageDetected bool   `json:"language_detected,omitempty"`
+
+	// Directives of the robots meta tags and X-Robots-Tag header
+	Robots []string `json:"robots,omitempty"`
+
+	// Third-party domains the page references (--report-domains)
+	ExternalDomains []ExternalDomain `json:"external_domains,omitempty"`
+
+	// Console errors and failed requests of the rendered page (--js-diagnostics)
+	RenderDiagnostics *RenderDiagnostics `json:"render_diagnostics,omitempty"`
+
+	// Whether rendering timed out and the page is what had loaded by then
+	PartialRender bool `json:"partial_render,omitempty"`
+}
+
+// ExternalDomain is a third-party domain a page references and the kinds of
+// references to it, e.g. "script"
+type ExternalDomain struct {
+	Domain string   `json:"domain"`
+	Kinds  []string `json:"kinds"`
+}
+
+// NDJSONWriter appends results to a file as they arrive, one JSON object per
+// line. It is safe for concurrent use.
+type NDJSONWriter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenNDJSON opens path for appending, creating it if needed. A line left
+// incomplete by an interrupted run is removed first, so the file stays
+// readable by ReadNDJSON.
+func OpenNDJSON(path string) (*NDJSONWriter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ND-JSON file: %w", err)
+	}
+
+	if err := trimPartialLine(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to prepare ND-JSON file: %w", err)
+	}
+
+	return &NDJSONWriter{file: file}, nil
+}
+
+// trimPartialLine truncates file after its last newline
+func trimPartialLine(file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	const chunkSize = 4096
+	chunk := make([]byte, chunkSize)
+	end := info.Size()
+	for end > 0 {
+		start := max(end-chunkSize, 0)
+		n, err := file.ReadAt(chunk[:end-start], start)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if i := bytes.LastIndexByte(chunk[:n], '\n'); i >= 0 {
+			end = start + int64(i) + 1
+			break
+		}
+		end = start
+	}
+
+	if end == info.Size() {
+		return nil
+	}
+	return file.Truncate(end)
+}
+
+// Write appends result as a single line
+func (w *NDJSONWriter) Write(result NDJSONResult) error {
+	line, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+	line = append(line, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write ND-JSON result: %w", err)
+	}
+	return nil
+}
+
+// ndjsonProvenance is the line recording the provenance of a run. Each run
+// writing to a file appends one, so a resumed crawl lists every run.
+type ndjsonProvenance struct {
+	Provenance *Provenance `json:"provenance"`
+}
+
+// WriteProvenance appends a line describing the run writing the results
+// that follow
+func (w *NDJSONWriter) WriteProvenance(provenance Provenance) error {
+	line, err := json.Marshal(ndjsonProvenance{Provenance: canonicalProvenance(&provenance)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal provenance: %w", err)
+	}
+	line = append(line, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write ND-JSON provenance: %w", err)
+	}
+	return nil
+}
+
+// Close closes the file
+func (w *NDJSONWriter) Close() error {
+	return w.file.Close()
+}
+
+// ReadNDJSON reads the results of an ND-JSON file in the order their URLs
+// first appear. A later line for the same URL (e.g. a retry of a failed page)
+// replaces the earlier one; provenance lines and a malformed final line, left
+// by an interrupted write, are ignored.
+func ReadNDJSON(r io.Reader) ([]NDJSONResult, error) {
+	var results []NDJSONResult
+	index := make(map[string]int)
+	var pending error
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if pending != nil {
+			return nil, pending
+		}
+
+		var result NDJSONResult
+		err := json.Unmarshal(line, &result)
+		if err == nil && result.URL == "" && isProvenance(line) {
+			continue
+		}
+		if err != nil || result.URL == "" {
+			pending = fmt.Errorf("invalid ND-JSON result on line %d", lineNo)
+			continue
+		}
+
+		if i, ok := index[result.URL]; ok {
+			results[i] = result
+			continue
+		}
+		index[result.URL] = len(results)
+		results = append(results, result)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ND-JSON results: %w", err)
+	}
+	return results, nil
+}
+
+// isProvenance reports whether line is a provenance line
+func isProvenance(line []byte) bool {
+	var record ndjsonProvenance
+	return json.Unmarshal(line, &record) == nil && record.Provenance != nil
+}
+
+// LoadNDJSON reads the results of an ND-JSON file; a missing file holds none
+func LoadNDJSON(path string) ([]NDJSONResult, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ND-JSON file: %w", err)
+	}
+	defer file.Close()
+	return ReadNDJSON(file)
+}
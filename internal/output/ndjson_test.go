@@ -0,0 +1,110 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNDJSONWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.ndjson")
+
+	writer, err := OpenNDJSON(path)
+	if err != nil {
+		t.Fatalf("OpenNDJSON() error: %v", err)
+	}
+	if err := writer.WriteProvenance(*goldenProvenance()); err != nil {
+		t.Fatalf("WriteProvenance() error: %v", err)
+	}
+	var wg sync.WaitGroup
+	for _, u := range []string{"https://example.com/", "https://example.com/a", "https://example.com/b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := writer.Write(NDJSONResult{URL: u, StatusCode: 200}); err != nil {
+				t.Errorf("Write() error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	// Simulate a run interrupted in the middle of a line, then append again
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	file.WriteString(`{"url":"https://example.com/tr`)
+	file.Close()
+
+	writer, err = OpenNDJSON(path)
+	if err != nil {
+		t.Fatalf("OpenNDJSON() on existing file error: %v", err)
+	}
+	if err := writer.Write(NDJSONResult{URL: "https://example.com/c", Error: "timeout"}); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	writer.Close()
+
+	results, err := LoadNDJSON(path)
+	if err != nil {
+		t.Fatalf("LoadNDJSON() error: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d: %+v", len(results), results)
+	}
+	if last := results[3]; last.URL != "https://example.com/c" || last.Error != "timeout" {
+		t.Errorf("unexpected last result: %+v", last)
+	}
+}
+
+func TestReadNDJSON(t *testing.T) {
+	input := `{"provenance":{"version":"v1.2.3","seeds":["https://example.com/"],"start_time":"2024-05-01T12:29:00Z","config":[]}}
+{"url":"https://example.com/","depth":0,"status":200,"links":["https://example.com/a"]}
+{"url":"https://example.com/a","depth":1,"error":"timeout"}
+
+{"url":"https://example.com/a","depth":1,"status":200}
+{"url":"https://example.com/b","dep`
+
+	results, err := ReadNDJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadNDJSON() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d: %+v", len(results), results)
+	}
+	if results[0].URL != "https://example.com/" || len(results[0].Links) != 1 {
+		t.Errorf("unexpected first result: %+v", results[0])
+	}
+	if results[1].URL != "https://example.com/a" || results[1].Error != "" || results[1].StatusCode != 200 {
+		t.Errorf("retry did not replace the failed result: %+v", results[1])
+	}
+}
+
+func TestLoadNDJSONMissingFile(t *testing.T) {
+	results, err := LoadNDJSON(filepath.Join(t.TempDir(), "missing.ndjson"))
+	if err != nil || results != nil {
+		t.Errorf("LoadNDJSON() = %v, %v; want nil, nil", results, err)
+	}
+}
+
+func TestOpenNDJSONTrimsLongPartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.ndjson")
+	complete := `{"url":"https://example.com/"}` + "\n"
+	partial := `{"url":"https://example.com/` + strings.Repeat("x", 10000)
+	if err := os.WriteFile(path, []byte(complete+partial), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	writer, err := OpenNDJSON(path)
+	if err != nil {
+		t.Fatalf("OpenNDJSON() error: %v", err)
+	}
+	writer.Close()
+
+	data, _ := os.ReadFile(path)
+	if string(data) != complete {
+		t.Errorf("partial line not removed, file holds %d bytes", len(data))
+	}
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// OGImageResult is the outcome of checking an og:image
+type OGImageResult struct {
+	URL         string
+	Pages       []string // Pages declaring the image
+	StatusCode  int      // 0 if the request failed
+	ContentType string
+	Width       int    // 0 if unknown
+	Height      int    // 0 if unknown
+	Problem     string // Why the image is broken, "" if it is fine
+}
+
+// OGImageReport holds the checked og:images of the crawl
+type OGImageReport struct {
+	Images []OGImageResult
+}
+
+// WriteOGImageReport writes the Open Graph images report as text, listing
+// the broken images with the pages declaring them
+func WriteOGImageReport(w io.Writer, report OGImageReport) error {
+	var b strings.Builder
+
+	pages, broken := 0, 0
+	for _, image := range report.Images {
+		pages += len(image.Pages)
+		if image.Problem != "" {
+			broken++
+		}
+	}
+	fmt.Fprintf(&b, "Open Graph images: %d on %d pages, %d broken\n", len(report.Images), pages, broken)
+
+	for _, image := range report.Images {
+		if image.Problem == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s: %s (%d pages)\n", image.URL, image.Problem, len(image.Pages))
+		for _, page := range image.Pages {
+			fmt.Fprintf(&b, "    %s\n", page)
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write Open Graph images report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteOGImageReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteOGImageReport(&buf, OGImageReport{Images: []OGImageResult{
+		{URL: "https://example.com/card.png", Pages: []string{"https://example.com/"}, StatusCode: 200, ContentType: "image/png", Width: 1200, Height: 630},
+		{URL: "https://example.com/gone.png", Pages: []string{"https://example.com/a", "https://example.com/b"}, StatusCode: 404, Problem: "HTTP error: 404"},
+	}})
+	if err != nil {
+		t.Fatalf("WriteOGImageReport() failed: %v", err)
+	}
+
+	want := "Open Graph images: 2 on 3 pages, 1 broken\n" +
+		"  https://example.com/gone.png: HTTP error: 404 (2 pages)\n" +
+		"    https://example.com/a\n" +
+		"    https://example.com/b\n"
+	if buf.String() != want {
+		t.Errorf("report = %q, want %q", buf.String(), want)
+	}
+}
@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -22,7 +26,17 @@ const (
 
 // OutputConfig holds configuration for output formatting
 type OutputConfig struct {
-	Format OutputFormat
+	Format      OutputFormat
+	ShowDepth   bool    // Prefix text output with the crawl depth (adds a depth column to CSV)
+	IndentDepth bool    // Indent text output by crawl depth
+	Limit       int     // Maximum number of URLs to output (0 = no limit)
+	Sample      float64 // Fraction of URLs to output at random (0 = all)
+	ShowHash    bool    // Append the content hash to text output (adds a hash column to CSV)
+	Confidence  bool    // Mark low-confidence URLs in text output (adds a low_confidence column to CSV)
+	Seed        uint64  // Random seed of Sample, for reproducible samples (0 = random)
+
+	// Provenance is embedded in JSON and XML documents (optional)
+	Provenance *Provenance
 }
 
 // URLResult represents a single URL result with metadata
@@ -30,6 +44,48 @@ type URLResult struct {
 	URL       string    `json:"url" xml:"url"`
 	Timestamp time.Time `json:"timestamp" xml:"timestamp"`
 	Depth     int       `json:"depth,omitempty" xml:"depth,omitempty"`
+	Hash      string    `json:"hash,omitempty" xml:"hash,omitempty"`   // Hex-encoded content hash (--hash)
+	Title     string    `json:"title,omitempty" xml:"title,omitempty"` // Page title (--extract-metadata)
+	Parent    string    `json:"-" xml:"-"`                             // Page the URL was first found on (markdown only)
+	Links     []string  `json:"-" xml:"-"`                             // Links found on the page (dot and graphml only)
+
+	// LowConfidence marks URLs only found in inline scripts (--extract-script-urls)
+	LowConfidence bool `json:"low_confidence,omitempty" xml:"low_confidence,omitempty"`
+
+	// BotProtection names the bot protection that blocked the URL, e.g. "cloudflare"
+	BotProtection string `json:"bot_protection,omitempty" xml:"bot_protection,omitempty"`
+
+	// Historical marks URLs found in web archives but not linked from the site (--seed-wayback)
+	Historical bool `json:"historical,omitempty" xml:"historical,omitempty"`
+
+	// Tags are the tags the --classify-cmd classifier assigned to the URL
+	Tags []string `json:"tags,omitempty" xml:"tag,omitempty"`
+
+	// Egress is the region of the egress proxy the URL was fetched through (--egress)
+	Egress string `json:"egress,omitempty" xml:"egress,omitempty"`
+
+	// Language is the page's lang attribute, or the language detected from its
+	// text when LanguageDetected is set (--extract-metadata)
+	Language         string `json:"language,omitempty" xml:"language,omitempty"`
+	LanguageDetected bool   `json:"language_detected,omitempty" xml:"language_detected,omitempty"`
+
+	// Robots are the directives of the page's robots meta tags and X-Robots-Tag
+	// header, e.g. "noarchive" (--extract-metadata)
+	Robots []string `json:"robots,omitempty" xml:"robots,omitempty"`
+
+	// RenderDiagnostics holds the console errors and failed requests of the
+	// rendered page (--js-diagnostics)
+	RenderDiagnostics *RenderDiagnostics `json:"render_diagnostics,omitempty" xml:"render_diagnostics,omitempty"`
+
+	// PartialRender marks pages whose rendering timed out, so their links
+	// come from what had loaded by then (--js-partial-on-timeout)
+	PartialRender bool `json:"partial_render,omitempty" xml:"partial_render,omitempty"`
+}
+
+// RenderDiagnostics is what went wrong in the browser while a page was rendered
+type RenderDiagnostics struct {
+	ConsoleErrors  []string `json:"console_errors,omitempty" xml:"console_error,omitempty"`
+	FailedRequests []string `json:"failed_requests,omitempty" xml:"failed_request,omitempty"`
 }
 
 // CrawlOutput represents the complete crawl output
@@ -37,6 +93,9 @@ type CrawlOutput struct {
 	URLs      []URLResult `json:"urls" xml:"urls>url"`
 	Timestamp time.Time   `json:"timestamp" xml:"timestamp"`
 	Total     int         `json:"total" xml:"total"`
+
+	// Provenance describes the run that produced the document, if known
+	Provenance *Provenance `json:"provenance,omitempty" xml:"provenance,omitempty"`
 }
 
 // OutputURLs outputs URLs in plain text format to stdout
@@ -128,13 +187,112 @@ func OutputURLsWithFormat(urls []string, config *OutputConfig) error {
 	}
 }
 
-// outputJSON outputs URLs in JSON format
-func outputJSON(urls []string) error {
+// OutputResultsWithFormat outputs URL results with metadata to stdout in the specified format
+func OutputResultsWithFormat(results []URLResult, config *OutputConfig) error {
+	return WriteResults(os.Stdout, results, config)
+}
+
+// WriteResults writes URL results to w in the specified format.
+// Results are deduplicated by URL (keeping the smallest depth) and sorted alphabetically.
+func WriteResults(w io.Writer, results []URLResult, config *OutputConfig) error {
+	if config == nil {
+		config = &OutputConfig{Format: FormatText}
+	}
+
+	return writeFormat(w, config.Format, selectResults(results, config), config)
+}
+
+// selectResults deduplicates and canonicalizes results and applies the sample
+// rate and limit of config
+func selectResults(results []URLResult, config *OutputConfig) []URLResult {
+	return limitResults(sampleResults(canonicalResults(GetUniqueResults(results)), config.Sample, config.Seed), config.Limit)
+}
+
+// writeFormat writes already selected results to w in the given format
+func writeFormat(w io.Writer, format OutputFormat, results []URLResult, config *OutputConfig) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, results, config.Provenance)
+	case FormatCSV:
+		return writeCSV(w, results, config.ShowDepth, config.ShowHash, config.Confidence)
+	case FormatXML:
+		return writeXML(w, results, config.Provenance)
+	case FormatSitemap:
+		return writeSitemap(w, results)
+	case FormatMarkdown:
+		return writeMarkdown(w, results)
+	case FormatDOT:
+		return writeDOT(w, results)
+	case FormatGraphML:
+		return writeGraphML(w, results)
+	case FormatText:
+		fallthrough
+	default:
+		return writeText(w, results, config)
+	}
+}
+
+// GetUniqueResults returns results deduplicated by URL and sorted alphabetically.
+// When a URL occurs more than once the entry with the smallest depth is kept.
+func GetUniqueResults(results []URLResult) []URLResult {
+	index := make(map[string]int, len(results))
+	unique := make([]URLResult, 0, len(results))
+
+	for _, result := range results {
+		if i, exists := index[result.URL]; exists {
+			if result.Depth < unique[i].Depth {
+				unique[i] = result
+			}
+			continue
+		}
+		index[result.URL] = len(unique)
+		unique = append(unique, result)
+	}
+
+	sort.Slice(unique, func(i, j int) bool {
+		return unique[i].URL < unique[j].URL
+	})
+
+	return unique
+}
+
+// sampleResults keeps each result with the given probability, drawing the
+// same sample for the same seed unless it is 0.
+// A rate of 0 or at least 1 keeps all results.
+func sampleResults(results []URLResult, rate float64, seed uint64) []URLResult {
+	if rate <= 0 || rate >= 1 {
+		return results
+	}
+
+	random := rand.Float64
+	if seed != 0 {
+		random = rand.New(rand.NewPCG(seed, seed)).Float64
+	}
+
+	sampled := make([]URLResult, 0, int(float64(len(results))*rate)+1)
+	for _, result := range results {
+		if random() < rate {
+			sampled = append(sampled, result)
+		}
+	}
+	return sampled
+}
+
+// limitResults truncates results to at most limit entries (0 = no limit)
+func limitResults(results []URLResult, limit int) []URLResult {
+	if limit <= 0 || len(results) <= limit {
+		return results
+	}
+	return results[:limit]
+}
+
+// urlsToResults converts plain URLs into results sharing one timestamp
+func urlsToResults(urls []string) []URLResult {
 	uniqueURLs := removeDuplicates(urls)
 	sort.Strings(uniqueURLs)
 
 	urlResults := make([]URLResult, len(uniqueURLs))
-	timestamp := time.Now()
+	timestamp := now()
 
 	for i, url := range uniqueURLs {
 		urlResults[i] = URLResult{
@@ -143,61 +301,120 @@ func outputJSON(urls []string) error {
 		}
 	}
 
+	return urlResults
+}
+
+// writeText writes one URL per line, optionally annotated with its depth,
+// hash and confidence
+func writeText(w io.Writer, results []URLResult, config *OutputConfig) error {
+	for _, result := range results {
+		line := result.URL
+		if config.ShowDepth {
+			line = fmt.Sprintf("[%d] %s", result.Depth, line)
+		}
+		if config.IndentDepth {
+			line = strings.Repeat("  ", result.Depth) + line
+		}
+		if config.ShowHash && result.Hash != "" {
+			line += " " + result.Hash
+		}
+		if config.Confidence && result.LowConfidence {
+			line += " (low confidence)"
+		}
+		if result.Historical {
+			line += " (historical)"
+		}
+		if result.BotProtection != "" {
+			line += " " + blockedTag(result)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("failed to write URL: %w", err)
+		}
+	}
+	return nil
+}
+
+// blockedTag marks a URL blocked by bot protection in text output
+func blockedTag(result URLResult) string {
+	return fmt.Sprintf("(blocked-by-bot-protection: %s)", result.BotProtection)
+}
+
+// outputJSON outputs URLs in JSON format
+func outputJSON(urls []string) error {
+	return writeJSON(os.Stdout, urlsToResults(urls), nil)
+}
+
+// writeJSON writes URL results in JSON format
+func writeJSON(w io.Writer, urlResults []URLResult, provenance *Provenance) error {
 	output := CrawlOutput{
-		URLs:      urlResults,
-		Timestamp: timestamp,
-		Total:     len(urlResults),
+		URLs:       urlResults,
+		Timestamp:  now(),
+		Total:      len(urlResults),
+		Provenance: canonicalProvenance(provenance),
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(output)
 }
 
 // outputCSV outputs URLs in CSV format
 func outputCSV(urls []string) error {
-	uniqueURLs := removeDuplicates(urls)
-	sort.Strings(uniqueURLs)
+	return writeCSV(os.Stdout, urlsToResults(urls), false, false, false)
+}
 
-	writer := csv.NewWriter(os.Stdout)
-	defer writer.Flush()
+// writeCSV writes URL results in CSV format
+func writeCSV(w io.Writer, urlResults []URLResult, withDepth, withHash, withConfidence bool) error {
+	writer := csv.NewWriter(w)
 
 	// Write header
-	if err := writer.Write([]string{"url", "timestamp"}); err != nil {
+	header := []string{"url", "timestamp"}
+	if withDepth {
+		header = append(header, "depth")
+	}
+	if withHash {
+		header = append(header, "hash")
+	}
+	if withConfidence {
+		header = append(header, "low_confidence")
+	}
+	if err := writer.Write(header); err != nil {
 		return fmt.Errorf("failed to write CSV header: %w", err)
 	}
 
-	timestamp := time.Now().Format(time.RFC3339)
-
 	// Write data
-	for _, url := range uniqueURLs {
-		if err := writer.Write([]string{url, timestamp}); err != nil {
+	for _, result := range urlResults {
+		record := []string{result.URL, result.Timestamp.Format(time.RFC3339)}
+		if withDepth {
+			record = append(record, strconv.Itoa(result.Depth))
+		}
+		if withHash {
+			record = append(record, result.Hash)
+		}
+		if withConfidence {
+			record = append(record, strconv.FormatBool(result.LowConfidence))
+		}
+		if err := writer.Write(record); err != nil {
 			return fmt.Errorf("failed to write CSV record: %w", err)
 		}
 	}
 
-	return nil
+	writer.Flush()
+	return writer.Error()
 }
 
 // outputXML outputs URLs in XML format
 func outputXML(urls []string) error {
-	uniqueURLs := removeDuplicates(urls)
-	sort.Strings(uniqueURLs)
-
-	urlResults := make([]URLResult, len(uniqueURLs))
-	timestamp := time.Now()
-
-	for i, url := range uniqueURLs {
-		urlResults[i] = URLResult{
-			URL:       url,
-			Timestamp: timestamp,
-		}
-	}
+	return writeXML(os.Stdout, urlsToResults(urls), nil)
+}
 
+// writeXML writes URL results in XML format
+func writeXML(w io.Writer, urlResults []URLResult, provenance *Provenance) error {
 	output := CrawlOutput{
-		URLs:      urlResults,
-		Timestamp: timestamp,
-		Total:     len(urlResults),
+		URLs:       urlResults,
+		Timestamp:  now(),
+		Total:      len(urlResults),
+		Provenance: canonicalProvenance(provenance),
 	}
 
 	xmlData, err := xml.MarshalIndent(output, "", "  ")
@@ -205,7 +422,9 @@ func outputXML(urls []string) error {
 		return fmt.Errorf("failed to marshal XML: %w", err)
 	}
 
-	fmt.Print(xml.Header)
-	fmt.Println(string(xmlData))
-	return nil
+	if _, err := fmt.Fprint(w, xml.Header); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(xmlData))
+	return err
 }
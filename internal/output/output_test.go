@@ -1,6 +1,8 @@
 package output
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"reflect"
 	"strings"
@@ -299,3 +301,264 @@ func TestOutputXML(t *testing.T) {
 		t.Errorf("outputXML() returned error: %v", err)
 	}
 }
+
+func TestGetUniqueResults(t *testing.T) {
+	results := []URLResult{
+		{URL: "https://example.com/b", Depth: 2},
+		{URL: "https://example.com/a", Depth: 1},
+		{URL: "https://example.com/b", Depth: 1},
+	}
+
+	unique := GetUniqueResults(results)
+	if len(unique) != 2 {
+		t.Fatalf("expected 2 unique results, got %d", len(unique))
+	}
+	if unique[0].URL != "https://example.com/a" {
+		t.Errorf("results should be sorted, got %s first", unique[0].URL)
+	}
+	if unique[1].Depth != 1 {
+		t.Errorf("duplicate should keep smallest depth, got %d", unique[1].Depth)
+	}
+}
+
+func TestWriteResultsDepth(t *testing.T) {
+	results := []URLResult{
+		{URL: "https://example.com/", Depth: 0},
+		{URL: "https://example.com/a", Depth: 1},
+		{URL: "https://example.com/a/b", Depth: 2},
+	}
+
+	tests := []struct {
+		name     string
+		config   *OutputConfig
+		expected string
+	}{
+		{
+			name:     "plain",
+			config:   &OutputConfig{Format: FormatText},
+			expected: "https://example.com/\nhttps://example.com/a\nhttps://example.com/a/b\n",
+		},
+		{
+			name:     "show depth",
+			config:   &OutputConfig{Format: FormatText, ShowDepth: true},
+			expected: "[0] https://example.com/\n[1] https://example.com/a\n[2] https://example.com/a/b\n",
+		},
+		{
+			name:     "show depth with indent",
+			config:   &OutputConfig{Format: FormatText, ShowDepth: true, IndentDepth: true},
+			expected: "[0] https://example.com/\n  [1] https://example.com/a\n    [2] https://example.com/a/b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			if err := WriteResults(&buf, results, tt.config); err != nil {
+				t.Fatalf("WriteResults() error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("WriteResults() = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteResultsCSVDepthColumn(t *testing.T) {
+	results := []URLResult{{URL: "https://example.com/a", Depth: 1}}
+
+	var buf strings.Builder
+	if err := WriteResults(&buf, results, &OutputConfig{Format: FormatCSV, ShowDepth: true}); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "url,timestamp,depth" {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ",1") {
+		t.Errorf("expected depth column, got: %s", lines[1])
+	}
+}
+
+func TestWriteResultsLimitAndSample(t *testing.T) {
+	results := make([]URLResult, 1000)
+	for i := range results {
+		results[i] = URLResult{URL: fmt.Sprintf("https://example.com/%04d", i)}
+	}
+
+	countLines := func(config *OutputConfig) int {
+		var buf strings.Builder
+		if err := WriteResults(&buf, results, config); err != nil {
+			t.Fatalf("WriteResults() error: %v", err)
+		}
+		return strings.Count(buf.String(), "\n")
+	}
+
+	if n := countLines(&OutputConfig{Format: FormatText, Limit: 10}); n != 10 {
+		t.Errorf("limit 10: got %d URLs", n)
+	}
+	if n := countLines(&OutputConfig{Format: FormatText, Sample: 1}); n != 1000 {
+		t.Errorf("sample 1: got %d URLs", n)
+	}
+	if n := countLines(&OutputConfig{Format: FormatText, Sample: 0.1}); n < 50 || n > 150 {
+		t.Errorf("sample 0.1: got %d URLs, want roughly 100", n)
+	}
+	if n := countLines(&OutputConfig{Format: FormatText, Sample: 0.5, Limit: 20}); n != 20 {
+		t.Errorf("sample 0.5 with limit 20: got %d URLs", n)
+	}
+}
+
+func TestWriteResultsSeededSample(t *testing.T) {
+	results := make([]URLResult, 100)
+	for i := range results {
+		results[i] = URLResult{URL: fmt.Sprintf("https://example.com/%03d", i)}
+	}
+
+	sample := func(seed uint64) string {
+		var buf strings.Builder
+		if err := WriteResults(&buf, results, &OutputConfig{Format: FormatText, Sample: 0.3, Seed: seed}); err != nil {
+			t.Fatalf("WriteResults() error: %v", err)
+		}
+		return buf.String()
+	}
+
+	if sample(1) != sample(1) {
+		t.Error("the same seed drew different samples")
+	}
+	if sample(1) == sample(2) {
+		t.Error("different seeds drew the same sample")
+	}
+}
+
+func TestWriteResultsWithHash(t *testing.T) {
+	results := []URLResult{
+		{URL: "https://example.com/", Hash: "aaa"},
+		{URL: "https://example.com/missing"},
+	}
+	config := &OutputConfig{Format: FormatCSV, ShowHash: true}
+
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, results, config); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "url,timestamp,hash" || !strings.HasSuffix(lines[1], ",aaa") || !strings.HasSuffix(lines[2], ",") {
+		t.Errorf("unexpected CSV output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	config.Format = FormatJSON
+	if err := WriteResults(&buf, results, config); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"hash": "aaa"`) || strings.Count(buf.String(), `"hash"`) != 1 {
+		t.Errorf("unexpected JSON output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	config.Format = FormatText
+	if err := WriteResults(&buf, results, config); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	if buf.String() != "https://example.com/ aaa\nhttps://example.com/missing\n" {
+		t.Errorf("unexpected text output:\n%s", buf.String())
+	}
+}
+
+func TestWriteResultsLowConfidence(t *testing.T) {
+	results := []URLResult{
+		{URL: "https://example.com/"},
+		{URL: "https://example.com/scripted", Parent: "https://example.com/", LowConfidence: true},
+	}
+	config := &OutputConfig{Format: FormatCSV, Confidence: true}
+
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, results, config); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "url,timestamp,low_confidence" || !strings.HasSuffix(lines[1], ",false") || !strings.HasSuffix(lines[2], ",true") {
+		t.Errorf("unexpected CSV output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	config.Format = FormatJSON
+	if err := WriteResults(&buf, results, config); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	if strings.Count(buf.String(), `"low_confidence": true`) != 1 {
+		t.Errorf("unexpected JSON output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	config.Format = FormatText
+	if err := WriteResults(&buf, results, config); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	if buf.String() != "https://example.com/\nhttps://example.com/scripted (low confidence)\n" {
+		t.Errorf("unexpected text output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	config.Format = FormatMarkdown
+	if err := WriteResults(&buf, results, config); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	if buf.String() != "- <https://example.com/>\n  - <https://example.com/scripted> (low confidence)\n" {
+		t.Errorf("unexpected markdown output:\n%s", buf.String())
+	}
+}
+
+func TestWriteResultsBotProtection(t *testing.T) {
+	results := []URLResult{
+		{URL: "https://example.com/"},
+		{URL: "https://example.com/shop", Parent: "https://example.com/", BotProtection: "cloudflare"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, results, &OutputConfig{Format: FormatText}); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	if buf.String() != "https://example.com/\nhttps://example.com/shop (blocked-by-bot-protection: cloudflare)\n" {
+		t.Errorf("unexpected text output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteResults(&buf, results, &OutputConfig{Format: FormatJSON}); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	if strings.Count(buf.String(), `"bot_protection": "cloudflare"`) != 1 {
+		t.Errorf("unexpected JSON output:\n%s", buf.String())
+	}
+}
+
+func TestWriteResultsHistorical(t *testing.T) {
+	results := []URLResult{
+		{URL: "https://example.com/"},
+		{URL: "https://example.com/old-page", Historical: true},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, results, &OutputConfig{Format: FormatText}); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	if buf.String() != "https://example.com/\nhttps://example.com/old-page (historical)\n" {
+		t.Errorf("unexpected text output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteResults(&buf, results, &OutputConfig{Format: FormatMarkdown}); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "- <https://example.com/old-page> (historical)\n") {
+		t.Errorf("unexpected Markdown output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteResults(&buf, results, &OutputConfig{Format: FormatJSON}); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+	if strings.Count(buf.String(), `"historical": true`) != 1 {
+		t.Errorf("unexpected JSON output:\n%s", buf.String())
+	}
+}
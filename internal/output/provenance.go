@@ -0,0 +1,33 @@
+package output
+
+import "time"
+
+// Provenance describes the run that produced an output document: which
+// urlmap build crawled which seeds, when, and with which options. JSON and
+// XML documents and ND-JSON files carry it, so result files can still be
+// audited long after the run.
+type Provenance struct {
+	Version   string    `json:"version" xml:"version"`
+	Commit    string    `json:"commit" xml:"commit"`
+	Seeds     []string  `json:"seeds" xml:"seeds>seed"`
+	StartTime time.Time `json:"start_time" xml:"start_time"`
+	EndTime   time.Time `json:"end_time,omitzero" xml:"end_time"` // Not known yet when an ND-JSON file is opened
+	Config    []Setting `json:"config" xml:"config>option"`
+}
+
+// Setting is the effective value of one option of the run
+type Setting struct {
+	Name  string `json:"name" xml:"name,attr"`
+	Value string `json:"value" xml:",chardata"`
+}
+
+// canonicalProvenance returns provenance with its times in UTC, or nil
+func canonicalProvenance(provenance *Provenance) *Provenance {
+	if provenance == nil {
+		return nil
+	}
+	canonical := *provenance
+	canonical.StartTime = canonical.StartTime.UTC()
+	canonical.EndTime = canonical.EndTime.UTC()
+	return &canonical
+}
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadResultURLs reads the URLs from a previous run's output, either JSON
+// (--output-format json) or text (one URL per line, depth prefixes and hashes allowed)
+func ReadResultURLs(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var crawlOutput CrawlOutput
+		if err := json.Unmarshal(trimmed, &crawlOutput); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON results: %w", err)
+		}
+		urls := make([]string, 0, len(crawlOutput.URLs))
+		for _, result := range crawlOutput.URLs {
+			urls = append(urls, result.URL)
+		}
+		return urls, nil
+	}
+
+	var urls []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// Strip the "[depth] " prefix written by --show-depth
+		if strings.HasPrefix(line, "[") {
+			if end := strings.Index(line, "] "); end > 0 {
+				line = line[end+2:]
+			}
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// Drop a trailing content hash written by --hash
+		urls = append(urls, strings.Fields(line)[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+	return urls, nil
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadResultURLs(t *testing.T) {
+	results := []URLResult{
+		{URL: "https://example.com/", Depth: 0, Hash: "abc"},
+		{URL: "https://example.com/a", Depth: 1, Hash: "def"},
+	}
+	expected := []string{"https://example.com/", "https://example.com/a"}
+
+	var jsonOut bytes.Buffer
+	if err := WriteResults(&jsonOut, results, &OutputConfig{Format: FormatJSON}); err != nil {
+		t.Fatal(err)
+	}
+	var textOut bytes.Buffer
+	if err := WriteResults(&textOut, results, &OutputConfig{Format: FormatText, ShowDepth: true, IndentDepth: true, ShowHash: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, input := range map[string]string{"json": jsonOut.String(), "text": textOut.String()} {
+		urls, err := ReadResultURLs(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("%s: ReadResultURLs() error: %v", name, err)
+		}
+		if !reflect.DeepEqual(urls, expected) {
+			t.Errorf("%s: ReadResultURLs() = %v; want %v", name, urls, expected)
+		}
+	}
+
+	if _, err := ReadResultURLs(strings.NewReader("{broken")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// RenderResult compares the links of a page with and without JavaScript rendering
+type RenderResult struct {
+	URL           string `json:"url" xml:"url"`
+	StaticLinks   int    `json:"static_links" xml:"static_links"`
+	RenderedLinks int    `json:"rendered_links" xml:"rendered_links"`
+	Difference    int    `json:"difference" xml:"difference"`
+}
+
+// RenderOutput represents the complete render comparison report
+type RenderOutput struct {
+	XMLName        xml.Name       `json:"-" xml:"render_comparison"`
+	Results        []RenderResult `json:"results" xml:"results>result"`
+	Timestamp      time.Time      `json:"timestamp" xml:"timestamp"`
+	Total          int            `json:"total" xml:"total"`
+	NeedsRendering int            `json:"needs_rendering" xml:"needs_rendering"`
+}
+
+// OutputRenderResults outputs the render comparison to stdout in the specified format
+func OutputRenderResults(results []RenderResult, config *OutputConfig) error {
+	return WriteRenderResults(os.Stdout, results, config)
+}
+
+// WriteRenderResults writes the render comparison to w in the specified format
+func WriteRenderResults(w io.Writer, results []RenderResult, config *OutputConfig) error {
+	if config == nil {
+		config = &OutputConfig{Format: FormatText}
+	}
+
+	switch config.Format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newRenderOutput(results))
+	case FormatCSV:
+		return writeRenderCSV(w, results)
+	case FormatXML:
+		xmlData, err := xml.MarshalIndent(newRenderOutput(results), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal XML: %w", err)
+		}
+		if _, err := fmt.Fprint(w, xml.Header); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(xmlData))
+		return err
+	case FormatText:
+		fallthrough
+	default:
+		return writeRenderText(w, results)
+	}
+}
+
+// newRenderOutput wraps results with summary information
+func newRenderOutput(results []RenderResult) RenderOutput {
+	return RenderOutput{
+		Results:        results,
+		Timestamp:      now(),
+		Total:          len(results),
+		NeedsRendering: CountNeedsRendering(results),
+	}
+}
+
+// CountNeedsRendering returns how many pages gained links through rendering
+func CountNeedsRendering(results []RenderResult) int {
+	count := 0
+	for _, result := range results {
+		if result.Difference > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// writeRenderText writes one line per page: static and rendered link counts,
+// their difference and the URL
+func writeRenderText(w io.Writer, results []RenderResult) error {
+	for _, result := range results {
+		line := fmt.Sprintf("%5d %5d %+5d %s", result.StaticLinks, result.RenderedLinks, result.Difference, result.URL)
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("failed to write render line: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeRenderCSV writes the render comparison as CSV
+func writeRenderCSV(w io.Writer, results []RenderResult) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"url", "static_links", "rendered_links", "difference"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, result := range results {
+		record := []string{
+			result.URL,
+			strconv.Itoa(result.StaticLinks),
+			strconv.Itoa(result.RenderedLinks),
+			strconv.Itoa(result.Difference),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var testRenderResults = []RenderResult{
+	{URL: "https://example.com/", StaticLinks: 3, RenderedLinks: 12, Difference: 9},
+	{URL: "https://example.com/about", StaticLinks: 4, RenderedLinks: 4, Difference: 0},
+}
+
+func TestWriteRenderResultsText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteRenderResults(&buf, testRenderResults, nil); err != nil {
+		t.Fatalf("WriteRenderResults() error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(lines), buf.String())
+	}
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "3 12 +9 https://example.com/" {
+		t.Errorf("unexpected first line: %q", lines[0])
+	}
+}
+
+func TestWriteRenderResultsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteRenderResults(&buf, testRenderResults, &OutputConfig{Format: FormatJSON}); err != nil {
+		t.Fatalf("WriteRenderResults() error: %v", err)
+	}
+
+	var decoded RenderOutput
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded.Total != 2 || decoded.NeedsRendering != 1 {
+		t.Errorf("unexpected totals: total=%d needs_rendering=%d", decoded.Total, decoded.NeedsRendering)
+	}
+}
+
+func TestWriteRenderResultsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteRenderResults(&buf, testRenderResults, &OutputConfig{Format: FormatCSV}); err != nil {
+		t.Fatalf("WriteRenderResults() error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "url,static_links,rendered_links,difference\n") {
+		t.Errorf("unexpected CSV header: %s", buf.String())
+	}
+}
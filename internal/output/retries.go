@@ -0,0 +1,68 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// RetryAttempt is the outcome of one request attempt
+type RetryAttempt struct {
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+}
+
+// RetriedURLResult is a page that needed more than one attempt
+type RetriedURLResult struct {
+	URL      string
+	Failed   bool
+	Attempts []RetryAttempt
+}
+
+// HostRetryResult summarizes the retried pages of a host
+type HostRetryResult struct {
+	Host       string
+	URLs       int
+	Attempts   int
+	Failed     int
+	AvgLatency time.Duration
+}
+
+// RetryReport holds the retried pages per host and per URL
+type RetryReport struct {
+	Hosts []HostRetryResult
+	URLs  []RetriedURLResult
+}
+
+// WriteRetryReport writes the retries report as text
+func WriteRetryReport(w io.Writer, report RetryReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Retried URLs: %d\n", len(report.URLs))
+	for _, host := range report.Hosts {
+		fmt.Fprintf(&b, "  %s: %d URLs, %d attempts, %d still failed, avg %s per attempt\n",
+			host.Host, host.URLs, host.Attempts, host.Failed, host.AvgLatency.Round(time.Millisecond))
+	}
+
+	for _, retried := range report.URLs {
+		outcome := "recovered"
+		if retried.Failed {
+			outcome = "failed"
+		}
+		fmt.Fprintf(&b, "%s (%s)\n", retried.URL, outcome)
+		for i, attempt := range retried.Attempts {
+			result := fmt.Sprintf("%d", attempt.StatusCode)
+			if attempt.Error != "" {
+				result = "error: " + attempt.Error
+			}
+			fmt.Fprintf(&b, "  #%d %s in %s\n", i+1, result, attempt.Duration.Round(time.Millisecond))
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write retries report: %w", err)
+	}
+	return nil
+}
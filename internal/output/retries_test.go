@@ -0,0 +1,39 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteRetryReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteRetryReport(&buf, RetryReport{
+		Hosts: []HostRetryResult{{Host: "example.com", URLs: 1, Attempts: 2, Failed: 1, AvgLatency: 1500 * time.Millisecond}},
+		URLs: []RetriedURLResult{{
+			URL:    "https://example.com/flaky",
+			Failed: true,
+			Attempts: []RetryAttempt{
+				{StatusCode: 503, Duration: 120 * time.Millisecond},
+				{Error: "context deadline exceeded", Duration: 3 * time.Second},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("WriteRetryReport() failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"Retried URLs: 1\n",
+		"  example.com: 1 URLs, 2 attempts, 1 still failed, avg 1.5s per attempt\n",
+		"https://example.com/flaky (failed)\n",
+		"  #1 503 in 120ms\n",
+		"  #2 error: context deadline exceeded in 3s\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
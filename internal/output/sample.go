@@ -0,0 +1,33 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TemplateCountResult is the crawled sample and estimated size of a path template
+type TemplateCountResult struct {
+	Template   string
+	Discovered int
+	Sampled    int
+}
+
+// WriteSampleReport writes the estimated URL counts per path template as text
+func WriteSampleReport(w io.Writer, counts []TemplateCountResult) error {
+	var b strings.Builder
+
+	total := 0
+	for _, count := range counts {
+		total += count.Discovered
+	}
+	fmt.Fprintf(&b, "Estimated URLs per template: %d across %d templates\n", total, len(counts))
+	for _, count := range counts {
+		fmt.Fprintf(&b, "  %6d  %s (crawled %d)\n", count.Discovered, count.Template, count.Sampled)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write sample report: %w", err)
+	}
+	return nil
+}
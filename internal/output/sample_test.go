@@ -0,0 +1,29 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteSampleReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteSampleReport(&buf, []TemplateCountResult{
+		{Template: "example.com/products/{id}", Discovered: 120, Sampled: 10},
+		{Template: "example.com/", Discovered: 1, Sampled: 1},
+	})
+	if err != nil {
+		t.Fatalf("WriteSampleReport() failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"Estimated URLs per template: 121 across 2 templates\n",
+		"     120  example.com/products/{id} (crawled 10)\n",
+		"       1  example.com/ (crawled 1)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PrecachedURL is a URL a service worker caches when it is installed
+type PrecachedURL struct {
+	URL     string
+	Crawled bool // The crawl found the URL too
+}
+
+// ServiceWorkerResult is a service worker script of an origin and the URLs it
+// precaches, which the site's pages can be used offline with
+type ServiceWorkerResult struct {
+	Origin     string
+	Script     string
+	Pages      int    // Pages registering the worker
+	StatusCode int    // 0 if the request failed
+	Error      string // Failed request or error status
+	Precached  []PrecachedURL
+}
+
+// ServiceWorkerReport holds the service workers of the crawled origins
+type ServiceWorkerReport struct {
+	Origins int // Origins crawled
+	Workers []ServiceWorkerResult
+}
+
+// WriteServiceWorkerReport writes the service workers report as text
+func WriteServiceWorkerReport(w io.Writer, report ServiceWorkerReport) error {
+	var b strings.Builder
+
+	origins := make(map[string]bool)
+	precached, uncrawled := 0, 0
+	for _, worker := range report.Workers {
+		origins[worker.Origin] = true
+		for _, u := range worker.Precached {
+			precached++
+			if !u.Crawled {
+				uncrawled++
+			}
+		}
+	}
+	fmt.Fprintf(&b, "Service workers: %d of %d origins, %d precached URLs (%d not found by the crawl)\n",
+		len(origins), report.Origins, precached, uncrawled)
+
+	for _, worker := range report.Workers {
+		outcome := fmt.Sprintf("%d", worker.StatusCode)
+		if worker.Error != "" {
+			outcome = "error: " + worker.Error
+		}
+		fmt.Fprintf(&b, "%s: %s (%d pages): %s\n", worker.Origin, worker.Script, worker.Pages, outcome)
+		if worker.Error != "" {
+			continue
+		}
+		if len(worker.Precached) == 0 {
+			fmt.Fprintf(&b, "  no precached URLs found\n")
+		}
+		for _, u := range worker.Precached {
+			if u.Crawled {
+				fmt.Fprintf(&b, "  precached %s\n", u.URL)
+			} else {
+				fmt.Fprintf(&b, "  precached %s (not crawled)\n", u.URL)
+			}
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write service workers report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteServiceWorkerReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteServiceWorkerReport(&buf, ServiceWorkerReport{Origins: 3, Workers: []ServiceWorkerResult{
+		{Origin: "https://example.com", Script: "https://example.com/sw.js", Pages: 12, StatusCode: 200, Precached: []PrecachedURL{
+			{URL: "https://example.com/", Crawled: true},
+			{URL: "https://example.com/offline.html"},
+		}},
+		{Origin: "https://shop.example.com", Script: "https://shop.example.com/worker.js", Pages: 1, StatusCode: 200},
+		{Origin: "https://shop.example.com", Script: "https://shop.example.com/old-sw.js", Pages: 2, StatusCode: 404, Error: "HTTP error: 404"},
+	}})
+	if err != nil {
+		t.Fatalf("WriteServiceWorkerReport() failed: %v", err)
+	}
+
+	want := "Service workers: 2 of 3 origins, 2 precached URLs (1 not found by the crawl)\n" +
+		"https://example.com: https://example.com/sw.js (12 pages): 200\n" +
+		"  precached https://example.com/\n" +
+		"  precached https://example.com/offline.html (not crawled)\n" +
+		"https://shop.example.com: https://shop.example.com/worker.js (1 pages): 200\n" +
+		"  no precached URLs found\n" +
+		"https://shop.example.com: https://shop.example.com/old-sw.js (2 pages): error: HTTP error: 404\n"
+	if buf.String() != want {
+		t.Errorf("report = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// FormatSitemap writes the URLs as a sitemaps.org XML sitemap
+const FormatSitemap OutputFormat = "sitemap"
+
+// sitemapNamespace is the XML namespace of the sitemap protocol
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// sitemapURLSet is the root element of a sitemap
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL is a single sitemap entry
+type sitemapURL struct {
+	Loc string `xml:"loc"`
+}
+
+// writeSitemap writes URL results as a sitemap. The protocol allows 50,000
+// URLs per file; larger crawls can be split with --limit or --sample.
+func writeSitemap(w io.Writer, urlResults []URLResult) error {
+	urlSet := sitemapURLSet{Xmlns: sitemapNamespace}
+	for _, result := range urlResults {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{Loc: result.URL})
+	}
+
+	xmlData, err := xml.MarshalIndent(urlSet, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sitemap: %w", err)
+	}
+
+	if _, err := fmt.Fprint(w, xml.Header); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(xmlData))
+	return err
+}
@@ -0,0 +1,37 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteResultsSitemap(t *testing.T) {
+	results := []URLResult{
+		{URL: "https://example.com/b"},
+		{URL: "https://example.com/a?x=1&y=2"},
+		{URL: "https://example.com/b"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteResults(&buf, results, &OutputConfig{Format: FormatSitemap}); err != nil {
+		t.Fatalf("WriteResults() error: %v", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://example.com/a?x=1&amp;y=2</loc>
+  </url>
+  <url>
+    <loc>https://example.com/b</loc>
+  </url>
+</urlset>
+`
+	if got := buf.String(); got != want {
+		t.Errorf("sitemap:\n%s\nwant:\n%s", got, want)
+	}
+	if strings.Count(buf.String(), "<url>") != 2 {
+		t.Errorf("duplicates were not removed")
+	}
+}
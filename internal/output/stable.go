@@ -0,0 +1,34 @@
+package output
+
+import "time"
+
+// Output is parsed by other programs, so everything a writer emits must be
+// reproducible from its input: results are deduplicated and sorted by URL,
+// struct field order fixes the order of JSON and XML fields, and timestamps
+// are written in UTC. Golden files in testdata/golden pin every format; a
+// change to them is a change to the output format.
+
+// now returns the generation time stamped on output documents. Tests replace
+// it to make whole documents reproducible.
+var now = func() time.Time {
+	return time.Now().UTC()
+}
+
+// FreezeTime stamps output documents with t instead of the current time, so
+// repeated runs produce identical documents
+func FreezeTime(t time.Time) {
+	now = func() time.Time {
+		return t.UTC()
+	}
+}
+
+// canonicalResults returns results with their timestamps in UTC, so output
+// does not depend on the time zone of the machine that crawled
+func canonicalResults(results []URLResult) []URLResult {
+	canonical := make([]URLResult, len(results))
+	for i, result := range results {
+		result.Timestamp = result.Timestamp.UTC()
+		canonical[i] = result
+	}
+	return canonical
+}
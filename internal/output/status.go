@@ -0,0 +1,142 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// StatusResult represents the outcome of fetching a single URL
+type StatusResult struct {
+	URL          string `json:"url" xml:"url"`
+	FinalURL     string `json:"final_url,omitempty" xml:"final_url,omitempty"`
+	StatusCode   int    `json:"status_code" xml:"status_code"`
+	ResponseTime int64  `json:"response_time_ms" xml:"response_time_ms"`
+	Error        string `json:"error,omitempty" xml:"error,omitempty"`
+	Server       string `json:"server,omitempty" xml:"server,omitempty"`         // Server header of an HTTP error response
+	ErrorBody    string `json:"error_body,omitempty" xml:"error_body,omitempty"` // Start of the body of an HTTP error response
+	ErrorKind    string `json:"error_kind,omitempty" xml:"error_kind,omitempty"` // "dns", "tcp" or "tls" for connection failures
+}
+
+// Redirected reports whether the request ended on a different URL
+func (r StatusResult) Redirected() bool {
+	return r.FinalURL != "" && r.FinalURL != r.URL
+}
+
+// StatusOutput represents the complete status report
+type StatusOutput struct {
+	XMLName   xml.Name       `json:"-" xml:"status"`
+	Results   []StatusResult `json:"results" xml:"results>result"`
+	Timestamp time.Time      `json:"timestamp" xml:"timestamp"`
+	Total     int            `json:"total" xml:"total"`
+	Failed    int            `json:"failed" xml:"failed"`
+}
+
+// OutputStatusResults outputs status results to stdout in the specified format.
+// Unlike URL output, results keep their input order.
+func OutputStatusResults(results []StatusResult, config *OutputConfig) error {
+	return WriteStatusResults(os.Stdout, results, config)
+}
+
+// WriteStatusResults writes status results to w in the specified format
+func WriteStatusResults(w io.Writer, results []StatusResult, config *OutputConfig) error {
+	if config == nil {
+		config = &OutputConfig{Format: FormatText}
+	}
+
+	switch config.Format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newStatusOutput(results))
+	case FormatCSV:
+		return writeStatusCSV(w, results)
+	case FormatXML:
+		xmlData, err := xml.MarshalIndent(newStatusOutput(results), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal XML: %w", err)
+		}
+		if _, err := fmt.Fprint(w, xml.Header); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(xmlData))
+		return err
+	case FormatText:
+		fallthrough
+	default:
+		return writeStatusText(w, results)
+	}
+}
+
+// newStatusOutput wraps results with summary information
+func newStatusOutput(results []StatusResult) StatusOutput {
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+
+	return StatusOutput{
+		Results:   results,
+		Timestamp: now(),
+		Total:     len(results),
+		Failed:    failed,
+	}
+}
+
+// writeStatusText writes one line per URL: status, time, URL and redirect target
+func writeStatusText(w io.Writer, results []StatusResult) error {
+	for _, result := range results {
+		status := strconv.Itoa(result.StatusCode)
+		if result.StatusCode == 0 {
+			status = "ERR"
+		}
+
+		line := fmt.Sprintf("%s %6dms %s", status, result.ResponseTime, result.URL)
+		if result.Redirected() {
+			line += " -> " + result.FinalURL
+		}
+		switch {
+		case result.ErrorKind != "":
+			line += " (" + result.ErrorKind + ": " + result.Error + ")"
+		case result.Error != "":
+			line += " (" + result.Error + ")"
+		}
+
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("failed to write status line: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeStatusCSV writes status results as CSV
+func writeStatusCSV(w io.Writer, results []StatusResult) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"url", "final_url", "status_code", "response_time_ms", "error"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, result := range results {
+		record := []string{
+			result.URL,
+			result.FinalURL,
+			strconv.Itoa(result.StatusCode),
+			strconv.FormatInt(result.ResponseTime, 10),
+			result.Error,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
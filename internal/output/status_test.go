@@ -0,0 +1,109 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+var testStatusResults = []StatusResult{
+	{URL: "https://example.com/b", FinalURL: "https://example.com/b", StatusCode: 200, ResponseTime: 12},
+	{URL: "https://example.com/old", FinalURL: "https://example.com/new", StatusCode: 200, ResponseTime: 30},
+	{URL: "https://example.com/missing", FinalURL: "https://example.com/missing", StatusCode: 404, ResponseTime: 5, Error: "HTTP error: 404",
+		Server: "nginx", ErrorBody: "<h1>404 Not Found</h1>"},
+	{URL: "https://down.example.com/", ResponseTime: 1, Error: "connection refused", ErrorKind: "tcp"},
+}
+
+func TestWriteStatusResultsText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteStatusResults(&buf, testStatusResults, nil); err != nil {
+		t.Fatalf("WriteStatusResults() error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d: %s", len(lines), buf.String())
+	}
+
+	// Input order is preserved
+	if !strings.HasPrefix(lines[0], "200") || !strings.HasSuffix(lines[0], "https://example.com/b") {
+		t.Errorf("unexpected first line: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "https://example.com/old -> https://example.com/new") {
+		t.Errorf("redirect should be shown, got: %s", lines[1])
+	}
+	if !strings.HasPrefix(lines[3], "ERR") || !strings.Contains(lines[3], "(tcp: connection refused)") {
+		t.Errorf("error line should be marked, got: %s", lines[3])
+	}
+}
+
+func TestWriteStatusResultsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteStatusResults(&buf, testStatusResults, &OutputConfig{Format: FormatJSON}); err != nil {
+		t.Fatalf("WriteStatusResults() error: %v", err)
+	}
+
+	var decoded StatusOutput
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded.Total != 4 || decoded.Failed != 2 {
+		t.Errorf("unexpected totals: total=%d failed=%d", decoded.Total, decoded.Failed)
+	}
+	if decoded.Results[1].FinalURL != "https://example.com/new" {
+		t.Errorf("unexpected final URL: %s", decoded.Results[1].FinalURL)
+	}
+	if decoded.Results[2].Server != "nginx" || decoded.Results[2].ErrorBody != "<h1>404 Not Found</h1>" {
+		t.Errorf("error response details not kept: %+v", decoded.Results[2])
+	}
+	if decoded.Results[3].ErrorKind != "tcp" {
+		t.Errorf("error kind not kept: %+v", decoded.Results[3])
+	}
+	if strings.Count(buf.String(), `"error_body"`) != 1 {
+		t.Errorf("error_body should only be written for error responses:\n%s", buf.String())
+	}
+}
+
+func TestWriteStatusResultsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteStatusResults(&buf, testStatusResults, &OutputConfig{Format: FormatCSV}); err != nil {
+		t.Fatalf("WriteStatusResults() error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "url,final_url,status_code,response_time_ms,error" {
+		t.Errorf("unexpected CSV header: %s", lines[0])
+	}
+	if len(lines) != 5 {
+		t.Errorf("expected header plus 4 records, got %d lines", len(lines))
+	}
+}
+
+func TestWriteStatusResultsXML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteStatusResults(&buf, testStatusResults, &OutputConfig{Format: FormatXML}); err != nil {
+		t.Fatalf("WriteStatusResults() error: %v", err)
+	}
+
+	var decoded StatusOutput
+	if err := xml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if len(decoded.Results) != 4 {
+		t.Errorf("expected 4 results, got %d", len(decoded.Results))
+	}
+}
+
+func TestStatusResultRedirected(t *testing.T) {
+	if testStatusResults[0].Redirected() {
+		t.Error("same final URL should not count as redirect")
+	}
+	if !testStatusResults[1].Redirected() {
+		t.Error("different final URL should count as redirect")
+	}
+	if testStatusResults[3].Redirected() {
+		t.Error("empty final URL should not count as redirect")
+	}
+}
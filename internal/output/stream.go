@@ -0,0 +1,70 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// StreamFormats are the formats a ResultStream can write
+var StreamFormats = []OutputFormat{FormatText, FormatJSON}
+
+// ResultStream writes each result to w as soon as it arrives, one per line:
+// as in text output, or in JSON format as one JSON object per line (JSON
+// lines, laid out like ND-JSON files). URLs already written are skipped and
+// writing stops at config.Limit. It is safe for concurrent use.
+type ResultStream struct {
+	mu      sync.Mutex
+	w       io.Writer
+	config  *OutputConfig
+	written map[string]bool
+}
+
+// NewResultStream starts a stream to w in config.Format. A JSON stream
+// starts with a line recording config.Provenance, if set.
+func NewResultStream(w io.Writer, config *OutputConfig) (*ResultStream, error) {
+	if config == nil {
+		config = &OutputConfig{Format: FormatText}
+	}
+	if config.Format != FormatText && config.Format != FormatJSON {
+		return nil, fmt.Errorf("streaming supports text and json output, got %s", config.Format)
+	}
+
+	if config.Format == FormatJSON && config.Provenance != nil {
+		line, err := json.Marshal(ndjsonProvenance{Provenance: canonicalProvenance(config.Provenance)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal provenance: %w", err)
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return nil, fmt.Errorf("failed to write provenance: %w", err)
+		}
+	}
+
+	return &ResultStream{w: w, config: config, written: make(map[string]bool)}, nil
+}
+
+// Write writes result, unless its URL was already written or the limit is reached
+func (s *ResultStream) Write(result NDJSONResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.written[result.URL] || (s.config.Limit > 0 && len(s.written) >= s.config.Limit) {
+		return nil
+	}
+	s.written[result.URL] = true
+
+	result.Timestamp = result.Timestamp.UTC()
+	if s.config.Format == FormatText {
+		return writeText(s.w, []URLResult{result.URLResult()}, s.config)
+	}
+
+	line, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+	if _, err := s.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+	return nil
+}
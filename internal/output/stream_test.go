@@ -0,0 +1,65 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestResultStream_Text(t *testing.T) {
+	var buf bytes.Buffer
+	stream, err := NewResultStream(&buf, &OutputConfig{Format: FormatText, ShowDepth: true, Limit: 2})
+	if err != nil {
+		t.Fatalf("NewResultStream() error: %v", err)
+	}
+
+	for _, result := range []NDJSONResult{
+		{URL: "https://example.com/"},
+		{URL: "https://example.com/b", Depth: 1},
+		{URL: "https://example.com/b", Depth: 1}, // Already written
+		{URL: "https://example.com/a", Depth: 1}, // Beyond the limit
+	} {
+		if err := stream.Write(result); err != nil {
+			t.Fatalf("Write() error: %v", err)
+		}
+	}
+
+	// URLs are written in crawl order, not sorted
+	want := "[0] https://example.com/\n[1] https://example.com/b\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestResultStream_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	stream, err := NewResultStream(&buf, &OutputConfig{Format: FormatJSON, Provenance: goldenProvenance()})
+	if err != nil {
+		t.Fatalf("NewResultStream() error: %v", err)
+	}
+	for _, u := range []string{"https://example.com/", "https://example.com/a"} {
+		if err := stream.Write(NDJSONResult{URL: u, StatusCode: 200}); err != nil {
+			t.Fatalf("Write() error: %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], `{"provenance":`) {
+		t.Fatalf("expected a provenance line and 2 results, got %q", buf.String())
+	}
+
+	// The stream reads back like an ND-JSON file
+	results, err := ReadNDJSON(&buf)
+	if err != nil {
+		t.Fatalf("ReadNDJSON() error: %v", err)
+	}
+	if len(results) != 2 || results[1].URL != "https://example.com/a" {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+func TestResultStream_UnsupportedFormat(t *testing.T) {
+	if _, err := NewResultStream(&bytes.Buffer{}, &OutputConfig{Format: FormatCSV}); err == nil {
+		t.Error("expected error for csv")
+	}
+}
@@ -0,0 +1,43 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// StructureReport holds the site-structure statistics of a crawl
+type StructureReport struct {
+	Pages        int      // Pages crawled, including failed ones
+	DepthCounts  []int    // Pages per depth level, indexed by depth
+	AvgLinks     float64  // Average links per successfully crawled page
+	MaxBreadth   int      // Most pages found on a single depth level
+	BreadthDepth int      // Depth level with MaxBreadth pages
+	LongestChain []string // Longest discovery path, from the seed down
+}
+
+// WriteStructureReport writes the site-structure statistics as text
+func WriteStructureReport(w io.Writer, report StructureReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Site structure (%d pages):\n", report.Pages)
+	fmt.Fprintln(&b, "  Pages per depth:")
+	for depth, count := range report.DepthCounts {
+		fmt.Fprintf(&b, "    %3d: %d\n", depth, count)
+	}
+	fmt.Fprintf(&b, "  Average links:   %.1f per page\n", report.AvgLinks)
+	fmt.Fprintf(&b, "  Max breadth:     %d pages at depth %d\n", report.MaxBreadth, report.BreadthDepth)
+	fmt.Fprintf(&b, "  Longest chain:   %d pages\n", len(report.LongestChain))
+	for i, url := range report.LongestChain {
+		prefix := "    "
+		if i > 0 {
+			prefix = "    -> "
+		}
+		fmt.Fprintln(&b, prefix+url)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write structure report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteStructureReport(t *testing.T) {
+	report := StructureReport{
+		Pages:        4,
+		DepthCounts:  []int{1, 2, 1},
+		AvgLinks:     2.5,
+		MaxBreadth:   2,
+		BreadthDepth: 1,
+		LongestChain: []string{"https://example.com/", "https://example.com/a", "https://example.com/a/1"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteStructureReport(&buf, report); err != nil {
+		t.Fatalf("WriteStructureReport() error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"Site structure (4 pages):",
+		"      1: 2\n",
+		"Average links:   2.5 per page",
+		"Max breadth:     2 pages at depth 1",
+		"Longest chain:   3 pages",
+		"    https://example.com/\n    -> https://example.com/a\n    -> https://example.com/a/1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TemplateResult is a path template and how many URLs match it
+type TemplateResult struct {
+	Pattern string
+	Count   int
+	Example string
+}
+
+// WriteTemplateReport writes the URL counts per path template as text
+func WriteTemplateReport(w io.Writer, templates []TemplateResult) error {
+	var b strings.Builder
+
+	total := 0
+	for _, template := range templates {
+		total += template.Count
+	}
+	fmt.Fprintf(&b, "URL templates: %d covering %d URLs\n", len(templates), total)
+	for _, template := range templates {
+		fmt.Fprintf(&b, "  %6d  %s (e.g. %s)\n", template.Count, template.Pattern, template.Example)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write template report: %w", err)
+	}
+	return nil
+}
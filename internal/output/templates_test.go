@@ -0,0 +1,29 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteTemplateReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteTemplateReport(&buf, []TemplateResult{
+		{Pattern: "example.com/products/{id}", Count: 3, Example: "https://example.com/products/1"},
+		{Pattern: "example.com/", Count: 1, Example: "https://example.com/"},
+	})
+	if err != nil {
+		t.Fatalf("WriteTemplateReport() failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"URL templates: 2 covering 4 URLs\n",
+		"       3  example.com/products/{id} (e.g. https://example.com/products/1)\n",
+		"       1  example.com/ (e.g. https://example.com/)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// hintRels are the link relations that tell the browser to fetch or connect
+// to something before the page needs it
+var hintRels = []string{"preload", "modulepreload", "prefetch", "preconnect", "dns-prefetch", "prerender"}
+
+// ResourceHint is a <link> resource hint of a page
+type ResourceHint struct {
+	Rel string // Hint relation, e.g. "preconnect"
+	URL string // Absolute URL or origin the hint refers to
+}
+
+// ExtractResourceHints extracts the preload, modulepreload, prefetch,
+// preconnect, dns-prefetch and prerender hints of an HTML page, resolved
+// against baseURL. A link with several hint relations gives one hint each.
+func (le *LinkExtractor) ExtractResourceHints(baseURL, htmlContent string) ([]ResourceHint, error) {
+	if !url.IsValidURL(baseURL) {
+		return nil, fmt.Errorf("invalid base URL: %s", baseURL)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML content: %w", err)
+	}
+
+	var hints []ResourceHint
+	seen := make(map[ResourceHint]bool)
+	doc.Find("link[rel][href]").Each(func(i int, s *goquery.Selection) {
+		href := strings.TrimSpace(s.AttrOr("href", ""))
+		if href == "" {
+			return
+		}
+		resolved, err := url.ResolveURL(baseURL, href)
+		if err != nil || !url.IsValidURL(resolved) {
+			return
+		}
+
+		for _, rel := range strings.Fields(strings.ToLower(s.AttrOr("rel", ""))) {
+			if !slices.Contains(hintRels, rel) {
+				continue
+			}
+			hint := ResourceHint{Rel: rel, URL: resolved}
+			if !seen[hint] {
+				seen[hint] = true
+				hints = append(hints, hint)
+			}
+		}
+	})
+
+	le.logger.Debug("Resource hint extraction completed", "base_url", baseURL, "hint_count", len(hints))
+	return hints, nil
+}
@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkExtractor_ExtractResourceHints(t *testing.T) {
+	le := NewLinkExtractor(nil)
+
+	html := `<html><head>
+<link rel="preconnect" href="https://fonts.gstatic.com" crossorigin>
+<link rel="dns-prefetch preconnect" href="//cdn.example.net">
+<link rel="preload" href="/fonts/inter.woff2" as="font">
+<link rel="ModulePreload" href="/app.js">
+<link rel="prefetch" href="/next-page">
+<link rel="preconnect" href="https://fonts.gstatic.com">
+<link rel="stylesheet" href="/style.css">
+<link rel="preload" href="">
+</head><body></body></html>`
+
+	hints, err := le.ExtractResourceHints("https://example.com/page", html)
+	require.NoError(t, err)
+	assert.Equal(t, []ResourceHint{
+		{Rel: "preconnect", URL: "https://fonts.gstatic.com"},
+		{Rel: "dns-prefetch", URL: "https://cdn.example.net"},
+		{Rel: "preconnect", URL: "https://cdn.example.net"},
+		{Rel: "preload", URL: "https://example.com/fonts/inter.woff2"},
+		{Rel: "modulepreload", URL: "https://example.com/app.js"},
+		{Rel: "prefetch", URL: "https://example.com/next-page"},
+	}, hints)
+
+	_, err = le.ExtractResourceHints("not a url", html)
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// Icon relations, plus RelManifestIcon for icons listed in a manifest
+const (
+	RelIcon           = "icon"             // Favicon, also "shortcut icon"
+	RelAppleTouchIcon = "apple-touch-icon" // Home screen icon on iOS
+	RelMaskIcon       = "mask-icon"        // Pinned tab icon in Safari
+	RelManifest       = "manifest"         // Web app manifest
+	RelManifestIcon   = "manifest-icon"    // Icon listed in a web app manifest
+)
+
+// iconRels are the link relations of icons and manifests; the precomposed
+// Apple icon counts as an apple-touch-icon
+var iconRels = []string{RelIcon, RelAppleTouchIcon, "apple-touch-icon-precomposed", RelMaskIcon, RelManifest}
+
+// Icon is a favicon variant or web app manifest of a page
+type Icon struct {
+	Rel   string // Icon relation, e.g. "apple-touch-icon"
+	URL   string // Absolute URL of the icon or manifest
+	Sizes string // Declared sizes, e.g. "180x180" (optional)
+}
+
+// ExtractIcons extracts the favicons, Apple touch and mask icons and the web
+// app manifest linked from an HTML page, resolved against baseURL
+func (le *LinkExtractor) ExtractIcons(baseURL, htmlContent string) ([]Icon, error) {
+	if !url.IsValidURL(baseURL) {
+		return nil, fmt.Errorf("invalid base URL: %s", baseURL)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML content: %w", err)
+	}
+
+	var icons []Icon
+	doc.Find("link[rel][href]").Each(func(i int, s *goquery.Selection) {
+		href := strings.TrimSpace(s.AttrOr("href", ""))
+		if href == "" {
+			return
+		}
+		resolved, err := url.ResolveURL(baseURL, href)
+		if err != nil || !url.IsValidURL(resolved) {
+			return
+		}
+
+		for _, rel := range strings.Fields(strings.ToLower(s.AttrOr("rel", ""))) {
+			if !slices.Contains(iconRels, rel) {
+				continue
+			}
+			if rel == "apple-touch-icon-precomposed" {
+				rel = RelAppleTouchIcon
+			}
+			icon := Icon{Rel: rel, URL: resolved, Sizes: strings.TrimSpace(s.AttrOr("sizes", ""))}
+			if !slices.Contains(icons, icon) {
+				icons = append(icons, icon)
+			}
+			break
+		}
+	})
+
+	le.logger.Debug("Icon extraction completed", "base_url", baseURL, "icon_count", len(icons))
+	return icons, nil
+}
+
+// ExtractManifestIcons returns the icons listed in a web app manifest,
+// resolved against manifestURL
+func ExtractManifestIcons(manifestURL string, data []byte) ([]Icon, error) {
+	var manifest struct {
+		Icons []struct {
+			Src   string `json:"src"`
+			Sizes string `json:"sizes"`
+		} `json:"icons"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid web app manifest: %w", err)
+	}
+
+	var icons []Icon
+	for _, entry := range manifest.Icons {
+		if strings.TrimSpace(entry.Src) == "" {
+			continue
+		}
+		resolved, err := url.ResolveURL(manifestURL, strings.TrimSpace(entry.Src))
+		if err != nil || !url.IsValidURL(resolved) {
+			continue
+		}
+		icon := Icon{Rel: RelManifestIcon, URL: resolved, Sizes: entry.Sizes}
+		if !slices.Contains(icons, icon) {
+			icons = append(icons, icon)
+		}
+	}
+	return icons, nil
+}
@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkExtractor_ExtractIcons(t *testing.T) {
+	le := NewLinkExtractor(nil)
+
+	html := `<html><head>
+<link rel="shortcut icon" href="/favicon.ico">
+<link rel="icon" type="image/png" sizes="32x32" href="/favicon-32.png">
+<link rel="apple-touch-icon-precomposed" sizes="180x180" href="/apple-touch-icon.png">
+<link rel="mask-icon" href="/safari-pinned-tab.svg" color="#000">
+<link rel="manifest" href="/site.webmanifest">
+<link rel="icon" href="/favicon.ico">
+<link rel="stylesheet" href="/style.css">
+</head><body></body></html>`
+
+	icons, err := le.ExtractIcons("https://example.com/page", html)
+	require.NoError(t, err)
+	assert.Equal(t, []Icon{
+		{Rel: RelIcon, URL: "https://example.com/favicon.ico"},
+		{Rel: RelIcon, URL: "https://example.com/favicon-32.png", Sizes: "32x32"},
+		{Rel: RelAppleTouchIcon, URL: "https://example.com/apple-touch-icon.png", Sizes: "180x180"},
+		{Rel: RelMaskIcon, URL: "https://example.com/safari-pinned-tab.svg"},
+		{Rel: RelManifest, URL: "https://example.com/site.webmanifest"},
+	}, icons)
+
+	_, err = le.ExtractIcons("not a url", html)
+	assert.Error(t, err)
+}
+
+func TestExtractManifestIcons(t *testing.T) {
+	manifest := `{"name":"Example","icons":[
+		{"src":"icons/192.png","sizes":"192x192","type":"image/png"},
+		{"src":"https://cdn.example.com/512.png","sizes":"512x512"},
+		{"src":""}
+	]}`
+
+	icons, err := ExtractManifestIcons("https://example.com/app/site.webmanifest", []byte(manifest))
+	require.NoError(t, err)
+	assert.Equal(t, []Icon{
+		{Rel: RelManifestIcon, URL: "https://example.com/app/icons/192.png", Sizes: "192x192"},
+		{Rel: RelManifestIcon, URL: "https://cdn.example.com/512.png", Sizes: "512x512"},
+	}, icons)
+
+	_, err = ExtractManifestIcons("https://example.com/site.webmanifest", []byte("<html>"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ExtractLang returns the lang attribute of the <html> element of
+// htmlContent, lowercased and with "_" replaced by "-" (e.g. "en-us"), and
+// the visible text of the page with whitespace collapsed
+func ExtractLang(htmlContent string) (lang, text string) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return "", ""
+	}
+
+	lang = strings.ToLower(strings.TrimSpace(doc.Find("html").First().AttrOr("lang", "")))
+	lang = strings.ReplaceAll(lang, "_", "-")
+
+	body := doc.Find("body")
+	body.Find("script, style, noscript, template").Remove()
+	return lang, strings.Join(strings.Fields(body.Text()), " ")
+}
@@ -0,0 +1,20 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractLang(t *testing.T) {
+	lang, text := ExtractLang(`<html lang=" en_US "><head><title>Title</title></head>
+<body><h1>Hello</h1>
+<script>var hidden = "script";</script><style>p { color: red }</style>
+<p>visible   text</p></body></html>`)
+	assert.Equal(t, "en-us", lang)
+	assert.Equal(t, "Hello visible text", text)
+
+	lang, text = ExtractLang(`<html><body><p>no attribute</p></body></html>`)
+	assert.Empty(t, lang)
+	assert.Equal(t, "no attribute", text)
+}
@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// ExtractOGImage returns the og:image URL of an HTML page resolved against
+// baseURL, or "" if the page declares none
+func ExtractOGImage(baseURL, htmlContent string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return ""
+	}
+
+	var image string
+	doc.Find("meta[content]").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		property := strings.ToLower(s.AttrOr("property", s.AttrOr("name", "")))
+		if property != "og:image" && property != "og:image:url" {
+			return true
+		}
+		content := strings.TrimSpace(s.AttrOr("content", ""))
+		if content == "" {
+			return true
+		}
+		resolved, err := url.ResolveURL(baseURL, content)
+		if err != nil || !url.IsValidURL(resolved) {
+			return true
+		}
+		image = resolved
+		return false
+	})
+	return image
+}
@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractOGImage(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"absolute", `<meta property="og:image" content="https://cdn.example.com/card.png">`, "https://cdn.example.com/card.png"},
+		{"relative", `<meta property="og:image" content=" /images/card.jpg ">`, "https://example.com/images/card.jpg"},
+		{"url property", `<meta property="og:image:url" content="/card.png">`, "https://example.com/card.png"},
+		{"name attribute", `<meta name="og:image" content="/card.png">`, "https://example.com/card.png"},
+		{"first non-empty", `<meta property="og:image" content=""><meta property="og:image" content="/a.png"><meta property="og:image" content="/b.png">`, "https://example.com/a.png"},
+		{"other properties", `<meta property="og:title" content="Title"><meta property="og:image:width" content="1200">`, ""},
+		{"none", `<html><body>page</body></html>`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExtractOGImage("https://example.com/blog/post", tt.html))
+		})
+	}
+}
@@ -13,8 +13,9 @@ import (
 
 // LinkExtractor provides functionality to extract and filter links from HTML content
 type LinkExtractor struct {
-	logger *slog.Logger
-	client *client.UnifiedClient
+	logger     *slog.Logger
+	client     *client.UnifiedClient
+	hashRoutes bool // Keep hash-router fragments (#/about) in links
 }
 
 // NewLinkExtractor creates a new LinkExtractor instance
@@ -39,6 +40,31 @@ func NewLinkExtractorWithClient(logger *slog.Logger, unifiedClient *client.Unifi
 	}
 }
 
+// SetHashRoutes makes the extractor keep links to the client-side routes of
+// hash routers, e.g. https://example.com/#/about, as links of their own
+// instead of stripping the fragment
+func (le *LinkExtractor) SetHashRoutes(enabled bool) {
+	le.hashRoutes = enabled
+}
+
+// skip reports whether href is filtered out. Hash routes are not when the
+// extractor keeps them.
+func (le *LinkExtractor) skip(href string) bool {
+	if le.hashRoutes && url.IsHashRoute(href) {
+		return false
+	}
+	return url.ShouldSkipURL(href)
+}
+
+// normalize normalizes absoluteURL, keeping its hash route if the extractor
+// keeps them
+func (le *LinkExtractor) normalize(absoluteURL string) (string, error) {
+	if le.hashRoutes {
+		return url.NormalizeHashRouteURL(absoluteURL)
+	}
+	return url.NormalizeURL(absoluteURL)
+}
+
 // ExtractLinksFromURL fetches content from URL and extracts links using the unified client
 // This method supports both HTTP and JavaScript rendering based on client configuration
 func (le *LinkExtractor) ExtractLinksFromURL(ctx context.Context, targetURL string) ([]string, error) {
@@ -161,7 +187,7 @@ func (le *LinkExtractor) ExtractLinks(baseURL, htmlContent string) ([]string, er
 		}
 
 		// Skip URLs that should be filtered out
-		if url.ShouldSkipURL(href) {
+		if le.skip(href) {
 			le.logger.Debug("Skipping filtered URL", "url", href)
 			return
 		}
@@ -188,7 +214,7 @@ func (le *LinkExtractor) ExtractLinks(baseURL, htmlContent string) ([]string, er
 		}
 
 		// Normalize the URL
-		normalizedURL, err := url.NormalizeURL(absoluteURL)
+		normalizedURL, err := le.normalize(absoluteURL)
 		if err != nil {
 			le.logger.Debug("Failed to normalize URL", "url", absoluteURL, "error", err)
 			return
@@ -281,7 +307,7 @@ func (le *LinkExtractor) ExtractLinksWithStats(baseURL, htmlContent string) ([]s
 		}
 
 		// Skip URLs that should be filtered out
-		if url.ShouldSkipURL(href) {
+		if le.skip(href) {
 			stats.FilteredOut++
 			return
 		}
@@ -307,7 +333,7 @@ func (le *LinkExtractor) ExtractLinksWithStats(baseURL, htmlContent string) ([]s
 		}
 
 		// Normalize the URL
-		normalizedURL, err := url.NormalizeURL(absoluteURL)
+		normalizedURL, err := le.normalize(absoluteURL)
 		if err != nil {
 			stats.NormalizationErrors++
 			return
@@ -337,3 +363,13 @@ func (s *ExtractionStats) String() string {
 	return fmt.Sprintf("ExtractionStats{Total: %d, Valid: %d, Empty: %d, Filtered: %d, Relative: %d, ResolutionErr: %d, Invalid: %d, NormalizationErr: %d}",
 		s.TotalFound, s.Valid, s.EmptyHrefs, s.FilteredOut, s.RelativeURLs, s.ResolutionErrors, s.InvalidURLs, s.NormalizationErrors)
 }
+
+// ExtractTitle returns the text of the first <title> element of htmlContent
+// with whitespace collapsed, or "" if the page has none
+func ExtractTitle(htmlContent string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(doc.Find("title").First().Text()), " ")
+}
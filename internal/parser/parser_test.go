@@ -422,6 +422,29 @@ func TestNewLinkExtractor(t *testing.T) {
 	}
 }
 
+func TestLinkExtractor_HashRoutes(t *testing.T) {
+	html := `<html><body>
+		<a href="#/about">About</a>
+		<a href="#!/users/1/">User</a>
+		<a href="#top">Top</a>
+		<a href="/app#/settings">Settings</a>
+	</body></html>`
+
+	extractor := NewLinkExtractor(nil)
+	links, err := extractor.ExtractLinks(testBaseURL, html)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/app"}, links)
+
+	extractor.SetHashRoutes(true)
+	links, err = extractor.ExtractLinks(testBaseURL, html)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"https://example.com/#/about",
+		"https://example.com/#!/users/1",
+		"https://example.com/app#/settings",
+	}, links)
+}
+
 func TestExtractionStats_String(t *testing.T) {
 	stats := &ExtractionStats{
 		TotalFound:          10,
@@ -631,3 +654,24 @@ func TestLinkExtractor_ExtractLinksFromURLWithFallback_InvalidURL(t *testing.T)
 	assert.Nil(t, links)
 	assert.Contains(t, err.Error(), "invalid target URL")
 }
+
+func TestExtractTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"simple", "<html><head><title>Home</title></head></html>", "Home"},
+		{"whitespace collapsed", "<title>\n  Getting   started\n</title>", "Getting started"},
+		{"first title wins", "<title>One</title><svg><title>Two</title></svg>", "One"},
+		{"entities decoded", "<title>Q&amp;A</title>", "Q&A"},
+		{"no title", "<html><body>text</body></html>", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExtractTitle(tt.html))
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// Kinds of references of a page
+const (
+	RefLink       = "link"       // <a href>
+	RefScript     = "script"     // <script src>
+	RefStylesheet = "stylesheet" // <link rel="stylesheet" href>
+	RefImage      = "image"      // <img src>
+	RefFrame      = "frame"      // <iframe src>
+)
+
+// Reference is a URL an HTML page links to or loads
+type Reference struct {
+	Kind string // Kind of reference, e.g. "script"
+	URL  string // Absolute URL
+}
+
+// referenceSelectors map the elements of each kind of reference to the
+// attribute holding the URL
+var referenceSelectors = []struct {
+	kind, selector, attr string
+}{
+	{RefLink, "a[href]", "href"},
+	{RefScript, "script[src]", "src"},
+	{RefStylesheet, `link[rel~="stylesheet"][href]`, "href"},
+	{RefImage, "img[src]", "src"},
+	{RefFrame, "iframe[src]", "src"},
+}
+
+// ExtractReferences extracts the links, scripts, stylesheets, images and
+// frames of an HTML page, resolved against baseURL. Only http(s) URLs are
+// returned, each kind and URL once.
+func (le *LinkExtractor) ExtractReferences(baseURL, htmlContent string) ([]Reference, error) {
+	if !url.IsValidURL(baseURL) {
+		return nil, fmt.Errorf("invalid base URL: %s", baseURL)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML content: %w", err)
+	}
+
+	var refs []Reference
+	for _, sel := range referenceSelectors {
+		doc.Find(sel.selector).Each(func(i int, s *goquery.Selection) {
+			value := strings.TrimSpace(s.AttrOr(sel.attr, ""))
+			if value == "" {
+				return
+			}
+			resolved, err := url.ResolveURL(baseURL, value)
+			if err != nil || !url.IsValidURL(resolved) {
+				return
+			}
+			ref := Reference{Kind: sel.kind, URL: resolved}
+			if !slices.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+		})
+	}
+
+	le.logger.Debug("Reference extraction completed", "base_url", baseURL, "reference_count", len(refs))
+	return refs, nil
+}
@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkExtractor_ExtractReferences(t *testing.T) {
+	le := NewLinkExtractor(nil)
+
+	html := `<html><head>
+<link rel="stylesheet" href="https://cdn.example.net/style.css">
+<link rel="preload" href="/font.woff2">
+<script src="https://www.googletagmanager.com/gtag/js"></script>
+<script>inline()</script>
+</head><body>
+<a href="/about">About</a>
+<a href="https://twitter.com/example">Twitter</a>
+<a href="mailto:info@example.com">Mail</a>
+<a href="/about">About again</a>
+<img src="//images.example.org/logo.png">
+<img src="data:image/png;base64,AAAA">
+<iframe src="https://www.youtube.com/embed/abc"></iframe>
+</body></html>`
+
+	refs, err := le.ExtractReferences("https://example.com/", html)
+	require.NoError(t, err)
+	assert.Equal(t, []Reference{
+		{Kind: RefLink, URL: "https://example.com/about"},
+		{Kind: RefLink, URL: "https://twitter.com/example"},
+		{Kind: RefScript, URL: "https://www.googletagmanager.com/gtag/js"},
+		{Kind: RefStylesheet, URL: "https://cdn.example.net/style.css"},
+		{Kind: RefImage, URL: "https://images.example.org/logo.png"},
+		{Kind: RefFrame, URL: "https://www.youtube.com/embed/abc"},
+	}, refs)
+
+	_, err = le.ExtractReferences("not a url", html)
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ParseRobotsDirectives splits the value of a robots meta tag or X-Robots-Tag
+// header into its directives, lowercased and with spaces around ":" removed,
+// e.g. "noarchive, max-snippet: 50" gives "noarchive" and "max-snippet:50"
+func ParseRobotsDirectives(value string) []string {
+	var directives []string
+	for _, field := range strings.Split(value, ",") {
+		name, arg, hasArg := strings.Cut(strings.ToLower(strings.TrimSpace(field)), ":")
+		directive := strings.TrimSpace(name)
+		if hasArg {
+			directive += ":" + strings.TrimSpace(arg)
+		}
+		if directive != "" && !slices.Contains(directives, directive) {
+			directives = append(directives, directive)
+		}
+	}
+	return directives
+}
+
+// ExtractRobotsDirectives returns the directives of the robots meta tags of
+// htmlContent, in order and each once
+func ExtractRobotsDirectives(htmlContent string) []string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil
+	}
+
+	var directives []string
+	doc.Find("meta[name][content]").Each(func(i int, s *goquery.Selection) {
+		if !strings.EqualFold(strings.TrimSpace(s.AttrOr("name", "")), "robots") {
+			return
+		}
+		for _, directive := range ParseRobotsDirectives(s.AttrOr("content", "")) {
+			if !slices.Contains(directives, directive) {
+				directives = append(directives, directive)
+			}
+		}
+	})
+	return directives
+}
@@ -0,0 +1,25 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRobotsDirectives(t *testing.T) {
+	assert.Equal(t, []string{"noarchive", "max-snippet:50", "max-image-preview:large"},
+		ParseRobotsDirectives(" NoArchive, max-snippet: 50 ,, max-image-preview:large, noarchive"))
+	assert.Nil(t, ParseRobotsDirectives(""))
+}
+
+func TestExtractRobotsDirectives(t *testing.T) {
+	html := `<html><head>
+<meta name="robots" content="noindex, nofollow">
+<meta name="ROBOTS" content="noimageindex, nofollow">
+<meta name="googlebot" content="nosnippet">
+<meta name="description" content="noarchive">
+</head><body></body></html>`
+
+	assert.Equal(t, []string{"noindex", "nofollow", "noimageindex"}, ExtractRobotsDirectives(html))
+	assert.Nil(t, ExtractRobotsDirectives(`<html><body>page</body></html>`))
+}
@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// scriptURLPatterns match navigations to a string literal in JavaScript. They
+// are deliberately narrow: only plain single- or double-quoted literals are
+// taken, as the whole value, so concatenations and template literals are ignored.
+var scriptURLPatterns = []*regexp.Regexp{
+	// window.location = "...", location.href = '...' ending the statement
+	regexp.MustCompile(`\blocation(?:\.href)?\s*=\s*(?:"([^"\\\s]+)"|'([^'\\\s]+)')[ \t]*(?:[;}\r\n]|$)`),
+	// location.assign("..."), location.replace('...')
+	regexp.MustCompile(`\blocation\.(?:assign|replace)\(\s*(?:"([^"\\\s]+)"|'([^'\\\s]+)')\s*\)`),
+	// router.push("..."), router.replace('...') of client-side routers
+	regexp.MustCompile(`\brouter\.(?:push|replace)\(\s*(?:"([^"\\\s]+)"|'([^'\\\s]+)')\s*[,)]`),
+}
+
+// ExtractScriptLinks extracts the URLs that inline scripts of an HTML page
+// navigate to with window.location or router.push and string literals.
+// Relative URLs are resolved against baseURL. Scripts loaded with src are
+// not fetched, and scripts of other types (JSON, templates) are skipped.
+func (le *LinkExtractor) ExtractScriptLinks(baseURL, htmlContent string) ([]string, error) {
+	if !url.IsValidURL(baseURL) {
+		return nil, fmt.Errorf("invalid base URL: %s", baseURL)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML content: %w", err)
+	}
+
+	links := newLinkSet(baseURL)
+	doc.Find("script:not([src])").Each(func(i int, s *goquery.Selection) {
+		if !isJavaScript(s.AttrOr("type", "")) {
+			return
+		}
+		script := s.Text()
+		for _, pattern := range scriptURLPatterns {
+			for _, match := range pattern.FindAllStringSubmatch(script, -1) {
+				links.add(match[1] + match[2])
+			}
+		}
+	})
+
+	le.logger.Debug("Script link extraction completed", "base_url", baseURL, "valid_count", len(links.urls))
+	return links.urls, nil
+}
+
+// isJavaScript reports whether a script type attribute denotes JavaScript
+func isJavaScript(scriptType string) bool {
+	switch strings.ToLower(strings.TrimSpace(scriptType)) {
+	case "", "module", "text/javascript", "application/javascript":
+		return true
+	}
+	return false
+}
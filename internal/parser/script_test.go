@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkExtractor_ExtractScriptLinks(t *testing.T) {
+	le := NewLinkExtractor(nil)
+
+	tests := []struct {
+		name string
+		html string
+		want []string
+	}{
+		{
+			name: "location assignments",
+			html: `<script>
+if (loggedIn) { window.location.href = "/dashboard"; } else { location = '/login'; }
+document.getElementById("next").onclick = function() { window.location.assign("https://example.com/step/2"); };
+location.replace('/moved');
+</script>`,
+			want: []string{"https://example.com/dashboard", "https://example.com/login", "https://example.com/step/2", "https://example.com/moved"},
+		},
+		{
+			name: "router navigation",
+			html: `<script type="module">router.push("/products"); router.replace('/cart', { scroll: false });</script>`,
+			want: []string{"https://example.com/products", "https://example.com/cart"},
+		},
+		{
+			name: "dynamic and non-navigating values ignored",
+			html: `<script>
+window.location.href = "/user/" + id;
+location.href = ` + "`/items/${id}`" + `;
+if (location.href == "/home") {}
+location.hash = "#top";
+router.push({ path: "/settings" });
+</script>`,
+			want: []string{},
+		},
+		{
+			name: "external and non-JavaScript scripts ignored",
+			html: `<script src="/app.js">location.href = "/src";</script>
+<script type="application/ld+json">{"location": "/json"}</script>
+<script type="text/template">location.href = "/template"</script>`,
+			want: []string{},
+		},
+		{
+			name: "duplicates and skipped schemes",
+			html: `<script>location.href = "/a"; router.push("/a"); location.href = "javascript:void(0)";</script>`,
+			want: []string{"https://example.com/a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links, err := le.ExtractScriptLinks(testBaseURL, tt.html)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, links)
+		})
+	}
+}
+
+func TestLinkExtractor_ExtractScriptLinks_InvalidBaseURL(t *testing.T) {
+	le := NewLinkExtractor(nil)
+
+	_, err := le.ExtractScriptLinks("not a url", `<script>location.href = "/a"</script>`)
+	assert.Error(t, err)
+}
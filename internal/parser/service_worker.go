@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// serviceWorkerRegistration matches navigator.serviceWorker.register() with
+// a string literal script URL
+var serviceWorkerRegistration = regexp.MustCompile(`\bserviceWorker\s*\.\s*register\(\s*(?:"([^"\\\s]+)"|'([^'\\\s]+)'|` + "`([^`$\\\\\\s]+)`" + `)`)
+
+// precachePatterns match the URLs a service worker script caches when it is
+// installed: the entries of a Workbox precache manifest, {url: "...",
+// revision: ...} or as JSON, and the string literals passed to cache.addAll
+var precachePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`["']?\burl["']?\s*:\s*(?:"([^"\\\s]+)"|'([^'\\\s]+)')`),
+	regexp.MustCompile(`\baddAll\(\s*\[([^\]]*)\]`),
+}
+
+// quotedString matches a single- or double-quoted string literal
+var quotedString = regexp.MustCompile(`"([^"\\\s]+)"|'([^'\\\s]+)'`)
+
+// ExtractServiceWorker returns the URL of the service worker script an HTML
+// page registers in an inline script, resolved against baseURL, or "" if it
+// registers none there. Registrations in external scripts are only seen by
+// a browser.
+func ExtractServiceWorker(baseURL, htmlContent string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return ""
+	}
+
+	var script string
+	doc.Find("script:not([src])").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if !isJavaScript(s.AttrOr("type", "")) {
+			return true
+		}
+		match := serviceWorkerRegistration.FindStringSubmatch(s.Text())
+		if match == nil {
+			return true
+		}
+		resolved, err := url.ResolveURL(baseURL, match[1]+match[2]+match[3])
+		if err != nil || !url.IsValidURL(resolved) {
+			return true
+		}
+		script = resolved
+		return false
+	})
+	return script
+}
+
+// ExtractPrecacheURLs returns the URLs a service worker script precaches,
+// resolved against scriptURL: the Workbox precache manifest and the lists
+// given to cache.addAll. URLs built at run time are not found.
+func ExtractPrecacheURLs(scriptURL, script string) []string {
+	links := newLinkSet(scriptURL)
+	for _, match := range precachePatterns[0].FindAllStringSubmatch(script, -1) {
+		links.add(match[1] + match[2])
+	}
+	for _, list := range precachePatterns[1].FindAllStringSubmatch(script, -1) {
+		for _, match := range quotedString.FindAllStringSubmatch(list[1], -1) {
+			links.add(match[1] + match[2])
+		}
+	}
+	return links.urls
+}
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractServiceWorker(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"relative", `<script>navigator.serviceWorker.register('/sw.js')</script>`, "https://example.com/sw.js"},
+		{"with options", `<script>if ('serviceWorker' in navigator) { navigator.serviceWorker.register("service-worker.js", {scope: "/"}); }</script>`, "https://example.com/app/service-worker.js"},
+		{"template literal", "<script>navigator.serviceWorker.register(`/sw.js`)</script>", "https://example.com/sw.js"},
+		{"built at run time", "<script>navigator.serviceWorker.register(`${base}/sw.js`)</script>", ""},
+		{"external script", `<script src="/register-sw.js"></script>`, ""},
+		{"JSON script", `<script type="application/json">{"serviceWorker.register('/sw.js')": 1}</script>`, ""},
+		{"none", `<html><body>page</body></html>`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExtractServiceWorker("https://example.com/app/", tt.html))
+		})
+	}
+}
+
+func TestExtractPrecacheURLs(t *testing.T) {
+	script := `importScripts("workbox-sw.js");
+workbox.precaching.precacheAndRoute([{url:"/index.html",revision:"3f2a"},{url:'offline.html',revision:null},{"revision":"1","url":"/app.js"}]);
+self.addEventListener('install', (event) => {
+  event.waitUntil(caches.open('v1').then((cache) => cache.addAll(['/', "/about", '/index.html'])));
+});`
+
+	assert.Equal(t, []string{
+		"https://example.com/index.html",
+		"https://example.com/offline.html",
+		"https://example.com/app.js",
+		"https://example.com/",
+		"https://example.com/about",
+	}, ExtractPrecacheURLs("https://example.com/sw.js", script))
+
+	assert.Empty(t, ExtractPrecacheURLs("https://example.com/sw.js", `self.addEventListener('fetch', () => {})`))
+}
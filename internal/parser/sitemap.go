@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// SitemapEntry is a page listed in a sitemap
+type SitemapEntry struct {
+	URL     string
+	LastMod time.Time // Zero if the sitemap gives no valid lastmod
+}
+
+// sitemapDocument is a sitemap (<urlset>) or a sitemap index (<sitemapindex>)
+type sitemapDocument struct {
+	URLs []struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// lastModLayouts are the W3C datetime formats sitemaps write lastmod in
+var lastModLayouts = []string{time.RFC3339, "2006-01-02T15:04Z07:00", "2006-01-02T15:04:05", "2006-01-02"}
+
+// ParseSitemap returns the pages a sitemap lists with their lastmod dates,
+// and the sitemaps a sitemap index lists. URLs are resolved against baseURL
+// and normalized like crawled URLs.
+func ParseSitemap(baseURL, content string) ([]SitemapEntry, []string, error) {
+	if !url.IsValidURL(baseURL) {
+		return nil, nil, fmt.Errorf("invalid base URL: %s", baseURL)
+	}
+
+	var document sitemapDocument
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = false
+	if err := decoder.Decode(&document); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse sitemap: %w", err)
+	}
+
+	var entries []SitemapEntry
+	for _, u := range document.URLs {
+		pages := newLinkSet(baseURL)
+		pages.add(u.Loc)
+		if len(pages.urls) == 0 {
+			continue
+		}
+		entries = append(entries, SitemapEntry{URL: pages.urls[0], LastMod: parseLastMod(u.LastMod)})
+	}
+
+	sitemaps := newLinkSet(baseURL)
+	for _, s := range document.Sitemaps {
+		sitemaps.add(s.Loc)
+	}
+	return entries, sitemaps.urls, nil
+}
+
+// parseLastMod parses a lastmod value, returning the zero time if it is invalid
+func parseLastMod(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range lastModLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSitemap(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/</loc><lastmod>2024-05-01T10:00:00+09:00</lastmod></url>
+  <url><loc>/about</loc><lastmod>2024-04-01</lastmod></url>
+  <url><loc>https://example.com/news</loc><lastmod>yesterday</lastmod></url>
+  <url><loc>mailto:info@example.com</loc></url>
+</urlset>`
+
+	entries, sitemaps, err := ParseSitemap("https://example.com/sitemap.xml", content)
+	require.NoError(t, err)
+	assert.Empty(t, sitemaps)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "https://example.com/", entries[0].URL)
+	assert.True(t, entries[0].LastMod.Equal(time.Date(2024, 5, 1, 1, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "https://example.com/about", entries[1].URL)
+	assert.True(t, entries[1].LastMod.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, entries[2].LastMod.IsZero(), "invalid lastmod")
+}
+
+func TestParseSitemap_Index(t *testing.T) {
+	content := `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>https://example.com/sitemap-posts.xml</loc></sitemap>
+  <sitemap><loc>/sitemap-pages.xml</loc></sitemap>
+</sitemapindex>`
+
+	entries, sitemaps, err := ParseSitemap("https://example.com/sitemap.xml", content)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Equal(t, []string{"https://example.com/sitemap-posts.xml", "https://example.com/sitemap-pages.xml"}, sitemaps)
+
+	_, _, err = ParseSitemap("https://example.com/sitemap.xml", "not xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// xmlLinkElements are the XML elements whose text is a URL: <loc> in
+// sitemaps and sitemap indexes, <link> in RSS feeds
+var xmlLinkElements = map[string]bool{"loc": true, "link": true}
+
+// xmlLinkAttributes are the XML attributes holding a URL: href of Atom and
+// xhtml:link elements, url of RSS enclosures and media:content
+var xmlLinkAttributes = map[string]bool{"href": true, "url": true}
+
+// ExtractXMLLinks extracts the URLs of an XML document such as a sitemap,
+// sitemap index, RSS or Atom feed. Relative URLs are resolved against
+// baseURL. On malformed XML the links found before the error are returned
+// with the error.
+func (le *LinkExtractor) ExtractXMLLinks(baseURL, content string) ([]string, error) {
+	if !url.IsValidURL(baseURL) {
+		return nil, fmt.Errorf("invalid base URL: %s", baseURL)
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = false
+
+	links := newLinkSet(baseURL)
+	var inLink bool
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return links.urls, fmt.Errorf("failed to parse XML content: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			for _, attr := range t.Attr {
+				if xmlLinkAttributes[strings.ToLower(attr.Name.Local)] {
+					links.add(attr.Value)
+				}
+			}
+			inLink = xmlLinkElements[strings.ToLower(t.Name.Local)]
+			text.Reset()
+		case xml.CharData:
+			if inLink {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			if inLink {
+				links.add(text.String())
+				inLink = false
+			}
+		}
+	}
+
+	le.logger.Debug("XML link extraction completed", "base_url", baseURL, "valid_count", len(links.urls))
+	return links.urls, nil
+}
+
+// ExtractJSONLinks extracts URL-valued fields of a JSON document: every
+// string that is an absolute http(s) URL, and root-relative paths in fields
+// named like a URL (url, href, link, or ending in _url or Url).
+func (le *LinkExtractor) ExtractJSONLinks(baseURL, content string) ([]string, error) {
+	if !url.IsValidURL(baseURL) {
+		return nil, fmt.Errorf("invalid base URL: %s", baseURL)
+	}
+
+	var document any
+	if err := json.Unmarshal([]byte(content), &document); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON content: %w", err)
+	}
+
+	links := newLinkSet(baseURL)
+	links.addJSON("", document)
+
+	le.logger.Debug("JSON link extraction completed", "base_url", baseURL, "valid_count", len(links.urls))
+	return links.urls, nil
+}
+
+// linkSet collects resolved, normalized links in the order found, without duplicates
+type linkSet struct {
+	baseURL string
+	seen    map[string]bool
+	urls    []string
+}
+
+func newLinkSet(baseURL string) *linkSet {
+	return &linkSet{baseURL: baseURL, seen: make(map[string]bool), urls: []string{}}
+}
+
+// add resolves href against the base URL and records it if it is a valid
+// http(s) URL not seen before
+func (s *linkSet) add(href string) {
+	href = strings.TrimSpace(href)
+	if href == "" || url.ShouldSkipURL(href) {
+		return
+	}
+
+	absoluteURL, err := url.ResolveURL(s.baseURL, href)
+	if err != nil || !url.IsValidURL(absoluteURL) {
+		return
+	}
+	normalizedURL, err := url.NormalizeURL(absoluteURL)
+	if err != nil || s.seen[normalizedURL] {
+		return
+	}
+
+	s.seen[normalizedURL] = true
+	s.urls = append(s.urls, normalizedURL)
+}
+
+// addJSON walks a decoded JSON value, adding the URLs it holds. key is the
+// name of the field holding value; array elements keep their array's name.
+// Object fields are walked in name order so the links are deterministic.
+func (s *linkSet) addJSON(key string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			s.addJSON(k, v[k])
+		}
+	case []any:
+		for _, element := range v {
+			s.addJSON(key, element)
+		}
+	case string:
+		if strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://") ||
+			(isURLField(key) && strings.HasPrefix(v, "/") && !strings.HasPrefix(v, "//")) {
+			s.add(v)
+		}
+	}
+}
+
+// isURLField reports whether a JSON field name suggests a URL value
+func isURLField(key string) bool {
+	switch strings.ToLower(key) {
+	case "url", "href", "link", "uri", "@id":
+		return true
+	}
+	return strings.HasSuffix(key, "_url") || strings.HasSuffix(key, "Url") || strings.HasSuffix(key, "URL")
+}
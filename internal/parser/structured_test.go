@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkExtractor_ExtractXMLLinks(t *testing.T) {
+	le := NewLinkExtractor(nil)
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "sitemap with alternates",
+			content: `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:xhtml="http://www.w3.org/1999/xhtml">
+  <url>
+    <loc> https://example.com/docs/ </loc>
+    <xhtml:link rel="alternate" hreflang="ja" href="https://example.com/ja/docs/"/>
+  </url>
+  <url><loc>/blog</loc></url>
+</urlset>`,
+			want: []string{"https://example.com/docs", "https://example.com/ja/docs", "https://example.com/blog"},
+		},
+		{
+			name:    "sitemap index",
+			content: `<sitemapindex><sitemap><loc>https://example.com/sitemap-1.xml</loc></sitemap></sitemapindex>`,
+			want:    []string{"https://example.com/sitemap-1.xml"},
+		},
+		{
+			name: "rss feed",
+			content: `<rss version="2.0"><channel><link>https://example.com/</link>
+<item><title>Post</title><link>https://example.com/post/1</link>
+<enclosure url="https://example.com/audio/1.mp3" type="audio/mpeg"/></item></channel></rss>`,
+			want: []string{"https://example.com/", "https://example.com/post/1", "https://example.com/audio/1.mp3"},
+		},
+		{
+			name:    "atom feed",
+			content: `<feed xmlns="http://www.w3.org/2005/Atom"><entry><link href="/post/2"/><link rel="alternate" href="/post/2"/></entry></feed>`,
+			want:    []string{"https://example.com/post/2"},
+		},
+		{
+			name:    "no links",
+			content: `<note><to>Tove</to></note>`,
+			want:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links, err := le.ExtractXMLLinks(testBaseURL, tt.content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, links)
+		})
+	}
+}
+
+func TestLinkExtractor_ExtractXMLLinks_Malformed(t *testing.T) {
+	le := NewLinkExtractor(nil)
+
+	// Links before the error are kept
+	links, err := le.ExtractXMLLinks(testBaseURL, `<urlset><url><loc>/a</loc></url><url><loc>/b</loc></url`)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"https://example.com/a", "https://example.com/b"}, links)
+
+	_, err = le.ExtractXMLLinks("not a url", `<urlset/>`)
+	assert.Error(t, err)
+}
+
+func TestLinkExtractor_ExtractJSONLinks(t *testing.T) {
+	le := NewLinkExtractor(nil)
+
+	content := `{
+		"next": "https://example.com/api/items?page=2",
+		"items": [
+			{"id": 1, "url": "/items/1", "title": "/not/a/link"},
+			{"id": 2, "detail_url": "/items/2", "image": "https://cdn.example.com/2.png"},
+			{"id": 3, "href": "//cdn.example.com/3", "link": "mailto:team@example.com"}
+		]
+	}`
+	links, err := le.ExtractJSONLinks(testBaseURL, content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"https://example.com/items/1",
+		"https://example.com/items/2",
+		"https://cdn.example.com/2.png",
+		"https://example.com/api/items?page=2",
+	}, links)
+
+	_, err = le.ExtractJSONLinks(testBaseURL, `{"url": `)
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package progress
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HostRateLimiter limits the rate of requests to each host separately. Each
+// host gets its own token bucket on its first request; tokens are counted
+// from the time elapsed, so idle hosts cost nothing.
+type HostRateLimiter struct {
+	requestsPerSecond float64
+	burst             float64
+	mu                sync.Mutex
+	buckets           map[string]*hostBucket
+}
+
+// hostBucket holds the tokens left for a host when they were last counted.
+// Tokens go below zero for requests waiting their turn.
+type hostBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewHostRateLimiter creates a limiter allowing requestsPerSecond requests to
+// each host, with bursts like those of RateLimiter. It returns nil if
+// requestsPerSecond is not positive; a nil limiter does not limit.
+func NewHostRateLimiter(requestsPerSecond float64) *HostRateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &HostRateLimiter{
+		requestsPerSecond: requestsPerSecond,
+		burst:             float64(int(requestsPerSecond) + 1),
+		buckets:           make(map[string]*hostBucket),
+	}
+}
+
+// Wait blocks until a request to host may start or ctx is done
+func (h *HostRateLimiter) Wait(ctx context.Context, host string) error {
+	if h == nil {
+		return nil
+	}
+
+	wait := h.reserve(host, time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token of host at now and returns how long to wait for it
+func (h *HostRateLimiter) reserve(host string, now time.Time) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	bucket, ok := h.buckets[host]
+	if !ok {
+		bucket = &hostBucket{tokens: h.burst, last: now}
+		h.buckets[host] = bucket
+	}
+
+	bucket.tokens = min(h.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*h.requestsPerSecond)
+	bucket.last = now
+	bucket.tokens--
+
+	if bucket.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-bucket.tokens / h.requestsPerSecond * float64(time.Second))
+}
@@ -0,0 +1,70 @@
+package progress
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHostRateLimiter_Reserve(t *testing.T) {
+	limiter := NewHostRateLimiter(2) // Bursts of 3, then one request every 500ms
+	now := time.Now()
+
+	for i := range 3 {
+		if wait := limiter.reserve("example.com", now); wait != 0 {
+			t.Fatalf("request %d of the burst waited %v", i, wait)
+		}
+	}
+	if wait := limiter.reserve("example.com", now); wait != 500*time.Millisecond {
+		t.Errorf("expected to wait 500ms after the burst, got %v", wait)
+	}
+	if wait := limiter.reserve("example.com", now); wait != time.Second {
+		t.Errorf("expected the next request to queue behind the waiting one, got %v", wait)
+	}
+
+	// Other hosts have their own bucket
+	if wait := limiter.reserve("other.example.com", now); wait != 0 {
+		t.Errorf("request to another host waited %v", wait)
+	}
+
+	// Tokens come back with time
+	if wait := limiter.reserve("example.com", now.Add(5*time.Second)); wait != 0 {
+		t.Errorf("request after a pause waited %v", wait)
+	}
+}
+
+func TestHostRateLimiter_Wait(t *testing.T) {
+	var disabled *HostRateLimiter
+	if NewHostRateLimiter(0) != nil {
+		t.Error("expected no limiter without a rate")
+	}
+	if err := disabled.Wait(context.Background(), "example.com"); err != nil {
+		t.Errorf("disabled limiter returned %v", err)
+	}
+
+	limiter := NewHostRateLimiter(0.5) // Burst of 1, then one request every 2s
+	if err := limiter.Wait(context.Background(), "example.com"); err != nil {
+		t.Fatalf("Wait() error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, "example.com"); err == nil {
+		t.Error("expected an error once the context is done")
+	}
+}
+
+func TestProgressReporter_HostRateLimit(t *testing.T) {
+	pr := NewProgressReporter(&Config{ShowProgress: false, RateLimitPerHost: 100})
+	defer pr.Stop()
+
+	if !pr.IsHostRateLimited() {
+		t.Error("expected per-host rate limiting to be enabled")
+	}
+	if pr.IsRateLimited() {
+		t.Error("expected the global rate limit to stay disabled")
+	}
+	if err := pr.WaitForHostRateLimit(context.Background(), "example.com"); err != nil {
+		t.Errorf("WaitForHostRateLimit() error: %v", err)
+	}
+}
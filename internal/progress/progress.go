@@ -1,14 +1,20 @@
 package progress
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"strings"
 	"sync"
 	"time"
 )
 
+// DefaultPlainInterval is how often progress lines are written in plain mode
+// when no UpdateInterval is configured
+const DefaultPlainInterval = 10 * time.Second
+
 // Stats holds performance and progress statistics
 type Stats struct {
 	StartTime      time.Time     // When crawling started
@@ -32,7 +38,11 @@ type ProgressReporter struct {
 	logger         *slog.Logger
 	updateInterval time.Duration
 	showProgress   bool
+	plain          bool
+	width          int
+	lastLen        int // Length of the line last drawn, to clear its remains
 	rateLimiter    *RateLimiter
+	hostLimiter    *HostRateLimiter
 	done           chan struct{}
 	wg             sync.WaitGroup
 }
@@ -53,6 +63,19 @@ type Config struct {
 	Output         io.Writer     // Where to write progress (default: stderr)
 	Logger         *slog.Logger  // Logger instance
 	RateLimit      float64       // Requests per second (0 = no limit)
+
+	// RateLimitPerHost limits the requests per second to each host, on top
+	// of RateLimit (0 = no limit)
+	RateLimitPerHost float64
+
+	// Plain writes progress as log lines instead of redrawing one line with
+	// "\r", for output that is not a terminal (files, CI logs, consoles
+	// without carriage return support). Plain progress is written every
+	// DefaultPlainInterval unless UpdateInterval is set.
+	Plain bool
+	// Width is the terminal width in columns; longer progress lines are cut
+	// so they do not wrap (0 = unknown)
+	Width int
 }
 
 // DefaultConfig returns a default progress configuration
@@ -82,6 +105,9 @@ func NewProgressReporter(config *Config) *ProgressReporter {
 
 	if config.UpdateInterval <= 0 {
 		config.UpdateInterval = 1 * time.Second
+		if config.Plain {
+			config.UpdateInterval = DefaultPlainInterval
+		}
 	}
 
 	pr := &ProgressReporter{
@@ -93,6 +119,8 @@ func NewProgressReporter(config *Config) *ProgressReporter {
 		logger:         config.Logger,
 		updateInterval: config.UpdateInterval,
 		showProgress:   config.ShowProgress,
+		plain:          config.Plain,
+		width:          config.Width,
 		done:           make(chan struct{}),
 	}
 
@@ -100,6 +128,7 @@ func NewProgressReporter(config *Config) *ProgressReporter {
 	if config.RateLimit > 0 {
 		pr.rateLimiter = NewRateLimiter(config.RateLimit)
 	}
+	pr.hostLimiter = NewHostRateLimiter(config.RateLimitPerHost)
 
 	return pr
 }
@@ -253,6 +282,12 @@ func (pr *ProgressReporter) WaitForRateLimit() {
 	}
 }
 
+// WaitForHostRateLimit waits until a request to host is allowed by the per-host
+// rate limit, if enabled, or ctx is done
+func (pr *ProgressReporter) WaitForHostRateLimit(ctx context.Context, host string) error {
+	return pr.hostLimiter.Wait(ctx, host)
+}
+
 // GetStats returns a copy of current statistics
 func (pr *ProgressReporter) GetStats() Stats {
 	pr.mu.RLock()
@@ -280,7 +315,7 @@ func (pr *ProgressReporter) updateProgress() {
 	var progressMsg string
 	if stats.QueueSize > 0 {
 		// Still crawling
-		progressMsg = fmt.Sprintf("\rCrawling: %d/%d URLs processed (%.1f URLs/sec) [%d workers, %d queued]",
+		progressMsg = fmt.Sprintf("Crawling: %d/%d URLs processed (%.1f URLs/sec) [%d workers, %d queued]",
 			stats.URLsProcessed,
 			stats.URLsDiscovered,
 			stats.ProcessingRate,
@@ -288,13 +323,33 @@ func (pr *ProgressReporter) updateProgress() {
 			stats.QueueSize)
 	} else {
 		// Likely finished or paused
-		progressMsg = fmt.Sprintf("\rProcessed: %d URLs (%.1f URLs/sec, %.1fs elapsed)",
+		progressMsg = fmt.Sprintf("Processed: %d URLs (%.1f URLs/sec, %.1fs elapsed)",
 			stats.URLsProcessed,
 			stats.ProcessingRate,
 			stats.ElapsedTime.Seconds())
 	}
 
-	fmt.Fprint(pr.output, progressMsg)
+	if pr.plain {
+		fmt.Fprintln(pr.output, progressMsg)
+		return
+	}
+	pr.redraw(progressMsg)
+}
+
+// redraw replaces the progress line with msg. The line is cut to the terminal
+// width, since a wrapped line cannot be returned to with "\r", and padded
+// with spaces over the remains of a longer previous line, which terminals
+// such as the Windows console do not clear.
+func (pr *ProgressReporter) redraw(msg string) {
+	if pr.width > 1 && len(msg) > pr.width-1 {
+		msg = msg[:pr.width-1]
+	}
+	padding := ""
+	if len(msg) < pr.lastLen {
+		padding = strings.Repeat(" ", pr.lastLen-len(msg))
+	}
+	fmt.Fprint(pr.output, "\r"+msg+padding)
+	pr.lastLen = len(msg)
 }
 
 // displayFinalStats displays final crawling statistics
@@ -306,7 +361,9 @@ func (pr *ProgressReporter) displayFinalStats() {
 	stats := pr.GetStats()
 
 	// Clear the progress line
-	fmt.Fprint(pr.output, "\r")
+	if !pr.plain && pr.lastLen > 0 {
+		fmt.Fprint(pr.output, "\r"+strings.Repeat(" ", pr.lastLen)+"\r")
+	}
 
 	// Display final summary
 	fmt.Fprintf(pr.output, "Crawling completed in %.2fs:\n", stats.ElapsedTime.Seconds())
@@ -326,6 +383,9 @@ func (pr *ProgressReporter) displayFinalStats() {
 	if pr.rateLimiter != nil && pr.rateLimiter.enabled {
 		fmt.Fprintf(pr.output, "  Rate limit:      %.1f requests/sec\n", pr.rateLimiter.requestsPerSecond)
 	}
+	if pr.hostLimiter != nil {
+		fmt.Fprintf(pr.output, "  Host rate limit: %.1f requests/sec\n", pr.hostLimiter.requestsPerSecond)
+	}
 
 	fmt.Fprintln(pr.output)
 }
@@ -334,3 +394,8 @@ func (pr *ProgressReporter) displayFinalStats() {
 func (pr *ProgressReporter) IsRateLimited() bool {
 	return pr.rateLimiter != nil && pr.rateLimiter.enabled
 }
+
+// IsHostRateLimited returns true if per-host rate limiting is enabled
+func (pr *ProgressReporter) IsHostRateLimited() bool {
+	return pr.hostLimiter != nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -320,3 +321,60 @@ func TestFinalStats(t *testing.T) {
 		}
 	}
 }
+
+func TestProgressDisplay_Plain(t *testing.T) {
+	buf := &bytes.Buffer{}
+	pr := NewProgressReporter(&Config{
+		UpdateInterval: 50 * time.Millisecond,
+		ShowProgress:   true,
+		Output:         buf,
+		Plain:          true,
+	})
+	pr.Start()
+	pr.UpdateStats(5, 10, 0, 0, 2, 3)
+	time.Sleep(120 * time.Millisecond)
+	pr.Stop()
+
+	output := buf.String()
+	if strings.Contains(output, "\r") {
+		t.Errorf("plain output contains carriage returns: %q", output)
+	}
+	if !strings.HasPrefix(output, "Crawling: 5/10 URLs processed") || !strings.Contains(output, "queued]\n") {
+		t.Errorf("plain output is not written as lines: %q", output)
+	}
+}
+
+func TestProgressDisplay_DefaultPlainInterval(t *testing.T) {
+	pr := NewProgressReporter(&Config{Plain: true, Output: &bytes.Buffer{}})
+	if pr.updateInterval != DefaultPlainInterval {
+		t.Errorf("updateInterval = %v, want %v", pr.updateInterval, DefaultPlainInterval)
+	}
+	pr.Stop()
+}
+
+func TestRedraw(t *testing.T) {
+	buf := &bytes.Buffer{}
+	pr := NewProgressReporter(&Config{Output: buf, Width: 11})
+
+	pr.redraw("0123456789abcdef")
+	pr.redraw("short")
+	pr.Stop()
+
+	// Cut to width-1, padded over the longer previous line
+	want := "\r0123456789" + "\rshort     "
+	if got := buf.String(); got != want {
+		t.Errorf("redraw output = %q, want %q", got, want)
+	}
+}
+
+func TestFinalStats_ClearsProgressLine(t *testing.T) {
+	buf := &bytes.Buffer{}
+	pr := NewProgressReporter(&Config{ShowProgress: true, Output: buf})
+	pr.redraw("Processed: 3 URLs")
+	buf.Reset()
+
+	pr.Stop()
+	if !strings.HasPrefix(buf.String(), "\r"+strings.Repeat(" ", 17)+"\rCrawling completed") {
+		t.Errorf("final stats do not clear the progress line: %q", buf.String())
+	}
+}
@@ -0,0 +1,136 @@
+// Package redact masks secrets such as access tokens and session IDs in URLs,
+// headers and log messages before they are written anywhere.
+package redact
+
+import (
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Mask replaces redacted values
+const Mask = "REDACTED"
+
+// DefaultPatterns match the names of common secret query parameters and headers
+var DefaultPatterns = []string{
+	"token", "session", "sid", "secret", "password", "passwd", "auth", "key", "signature", "cookie",
+}
+
+// urlPattern finds URLs embedded in free text such as error messages
+var urlPattern = regexp.MustCompile(`https?://[^\s"'<>]+`)
+
+// Redactor masks query parameters, headers and log attributes whose names
+// contain one of its patterns. A nil Redactor leaves everything unchanged.
+type Redactor struct {
+	patterns []string
+}
+
+// New returns a Redactor for the given case-insensitive name fragments, or nil
+// if there are none
+func New(patterns []string) *Redactor {
+	r := &Redactor{}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern != "" {
+			r.patterns = append(r.patterns, pattern)
+		}
+	}
+	if len(r.patterns) == 0 {
+		return nil
+	}
+	return r
+}
+
+// Matches reports whether name contains one of the patterns
+func (r *Redactor) Matches(name string) bool {
+	if r == nil {
+		return false
+	}
+	name = strings.ToLower(name)
+	for _, pattern := range r.patterns {
+		if strings.Contains(name, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// URL masks the password of rawURL and the values of matching query
+// parameters, keeping the order and encoding of the other parameters
+func (r *Redactor) URL(rawURL string) string {
+	if r == nil {
+		return rawURL
+	}
+
+	if u, err := url.Parse(rawURL); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), Mask)
+			rawURL = u.String()
+		}
+	}
+
+	base, rest, ok := strings.Cut(rawURL, "?")
+	if !ok {
+		return rawURL
+	}
+	query, fragment, hasFragment := strings.Cut(rest, "#")
+
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		name, _, hasValue := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil {
+			name = unescaped
+		}
+		if hasValue && r.Matches(name) {
+			params[i] = param[:strings.Index(param, "=")+1] + Mask
+		}
+	}
+
+	redacted := base + "?" + strings.Join(params, "&")
+	if hasFragment {
+		redacted += "#" + fragment
+	}
+	return redacted
+}
+
+// Header returns value, or Mask if the header name matches
+func (r *Redactor) Header(name, value string) string {
+	if r.Matches(name) {
+		return Mask
+	}
+	return value
+}
+
+// Text masks every URL embedded in s
+func (r *Redactor) Text(s string) string {
+	if r == nil {
+		return s
+	}
+	return urlPattern.ReplaceAllStringFunc(s, r.URL)
+}
+
+// ReplaceAttr is a slog.HandlerOptions.ReplaceAttr function that masks
+// attributes with matching keys and URLs in string and error attributes
+func (r *Redactor) ReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if r == nil {
+		return a
+	}
+
+	if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+		return a
+	}
+	if r.Matches(a.Key) {
+		return slog.String(a.Key, Mask)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, r.Text(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, r.Text(err.Error()))
+		}
+	}
+	return a
+}
@@ -0,0 +1,139 @@
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Archive serves previously archived responses in place of the network.
+// It implements http.RoundTripper, so it can replace a client's transport.
+type Archive struct {
+	dir       string            // Mirror directory, laid out as host/path (directory archives)
+	responses map[string][]byte // Raw HTTP responses by URL (WARC archives)
+}
+
+// Open opens a directory mirror or a WARC file (.warc or .warc.gz).
+//
+// A directory holds one file per URL under its host, as written by
+// "wget --mirror": https://example.com/docs/ is read from
+// example.com/docs/index.html and https://example.com/a.css from
+// example.com/a.css.
+func Open(source string) (*Archive, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay archive: %w", err)
+	}
+	if info.IsDir() {
+		return &Archive{dir: source}, nil
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay archive: %w", err)
+	}
+	defer file.Close()
+
+	responses, err := readWARC(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", source, err)
+	}
+	return &Archive{responses: responses}, nil
+}
+
+// RoundTrip answers req from the archive. URLs that are not archived get a
+// 404 response; the network is never used.
+func (a *Archive) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	if a.responses != nil {
+		raw, ok := a.responses[archiveKey(req.URL.String())]
+		if !ok && req.URL.Path == "" {
+			raw, ok = a.responses[archiveKey(req.URL.String()+"/")]
+		}
+		if !ok {
+			return notArchived(req), nil
+		}
+		return http.ReadResponse(bufio.NewReader(bytes.NewReader(raw)), req)
+	}
+
+	for _, name := range mirrorPaths(req.URL.Host, req.URL.Path, req.URL.RawQuery) {
+		body, err := os.ReadFile(filepath.Join(a.dir, filepath.FromSlash(name)))
+		if err != nil {
+			continue
+		}
+		return fileResponse(req, name, body), nil
+	}
+	return notArchived(req), nil
+}
+
+// mirrorPaths lists the files that may hold a URL in a mirror directory,
+// most specific first
+func mirrorPaths(host, urlPath, query string) []string {
+	cleaned := path.Clean("/" + urlPath)
+	base := host + cleaned
+	if cleaned == "/" {
+		base = host
+	}
+
+	var names []string
+	if strings.HasSuffix(urlPath, "/") || cleaned == "/" {
+		if query != "" {
+			names = append(names, base+"/index.html?"+query)
+		}
+		return append(names, base+"/index.html")
+	}
+	if query != "" {
+		names = append(names, base+"?"+query)
+	}
+	return append(names, base, base+"/index.html", base+".html")
+}
+
+// fileResponse builds a 200 response for a file read from a mirror directory
+func fileResponse(req *http.Request, name string, body []byte) *http.Response {
+	contentType := mime.TypeByExtension(path.Ext(strings.SplitN(name, "?", 2)[0]))
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	header := make(http.Header)
+	header.Set("Content-Type", contentType)
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// notArchived builds the 404 response for URLs missing from the archive
+func notArchived(req *http.Request) *http.Response {
+	body := "not in replay archive\n"
+	header := make(http.Header)
+	header.Set("Content-Type", "text/plain; charset=utf-8")
+	return &http.Response{
+		Status:        "404 Not Found",
+		StatusCode:    http.StatusNotFound,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,151 @@
+package replay
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// get fetches rawURL through an HTTP client using archive as its transport
+func get(t *testing.T, archive *Archive, rawURL string) (*http.Response, string) {
+	t.Helper()
+	resp, err := (&http.Client{Transport: archive}).Get(rawURL)
+	if err != nil {
+		t.Fatalf("Get(%s) failed: %v", rawURL, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return resp, string(body)
+}
+
+func TestArchive_Directory(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"example.com/index.html":         "<html>home</html>",
+		"example.com/docs/index.html":    "<html>docs</html>",
+		"example.com/about.html":         "<html>about</html>",
+		"example.com/style.css":          "body {}",
+		"example.com/search?q=go":        "<html>results</html>",
+		"example.com/../outside.txt":     "", // Never served
+		"example.com/docs/guide/install": "<!DOCTYPE html><html>install</html>",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+
+	tests := []struct {
+		url         string
+		status      int
+		body        string
+		contentType string
+	}{
+		{"https://example.com", 200, "<html>home</html>", "text/html"},
+		{"https://example.com/docs/", 200, "<html>docs</html>", "text/html"},
+		{"https://example.com/docs", 200, "<html>docs</html>", "text/html"},
+		{"https://example.com/about", 200, "<html>about</html>", "text/html"},
+		{"https://example.com/style.css", 200, "body {}", "text/css"},
+		{"https://example.com/search?q=go", 200, "<html>results</html>", "text/html"},
+		{"https://example.com/docs/guide/install", 200, "<!DOCTYPE html><html>install</html>", "text/html"},
+		{"https://example.com/../outside.txt", 404, "", ""},
+		{"https://example.com/missing", 404, "", ""},
+	}
+	for _, tt := range tests {
+		resp, body := get(t, archive, tt.url)
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: status %d, want %d", tt.url, resp.StatusCode, tt.status)
+			continue
+		}
+		if tt.status != 200 {
+			continue
+		}
+		if body != tt.body {
+			t.Errorf("%s: body %q, want %q", tt.url, body, tt.body)
+		}
+		if !strings.HasPrefix(resp.Header.Get("Content-Type"), tt.contentType) {
+			t.Errorf("%s: Content-Type %q, want %s", tt.url, resp.Header.Get("Content-Type"), tt.contentType)
+		}
+	}
+}
+
+// warcRecord formats a WARC record with the given type, target and block
+func warcRecord(recordType, target, block string) string {
+	return fmt.Sprintf("WARC/1.0\r\nWARC-Type: %s\r\nWARC-Target-URI: %s\r\nContent-Length: %d\r\n\r\n%s\r\n\r\n",
+		recordType, target, len(block), block)
+}
+
+func TestArchive_WARC(t *testing.T) {
+	home := "HTTP/1.1 200 OK\r\nContent-Type: text/html\r\nContent-Length: 17\r\n\r\n<html>home</html>"
+	moved := "HTTP/1.1 301 Moved Permanently\r\nLocation: /\r\nContent-Length: 0\r\n\r\n"
+	warc := warcRecord("warcinfo", "", "software: test\r\n") +
+		warcRecord("request", "https://example.com/", "GET / HTTP/1.1\r\n\r\n") +
+		warcRecord("response", "<https://example.com/>", home) +
+		warcRecord("response", "https://example.com/old", moved)
+
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "site.warc")
+	if err := os.WriteFile(plain, []byte(warc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var gz bytes.Buffer
+	writer := gzip.NewWriter(&gz)
+	writer.Write([]byte(warc))
+	writer.Close()
+	compressed := filepath.Join(dir, "site.warc.gz")
+	if err := os.WriteFile(compressed, gz.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, source := range []string{plain, compressed} {
+		archive, err := Open(source)
+		if err != nil {
+			t.Fatalf("Open(%s) failed: %v", source, err)
+		}
+
+		resp, body := get(t, archive, "https://example.com")
+		if resp.StatusCode != 200 || body != "<html>home</html>" {
+			t.Errorf("%s: got %d %q for the home page", source, resp.StatusCode, body)
+		}
+
+		resp, body = get(t, archive, "https://example.com/old")
+		if resp.StatusCode != 200 || body != "<html>home</html>" || resp.Request.URL.Path != "/" {
+			t.Errorf("%s: redirect not replayed: %d %q", source, resp.StatusCode, body)
+		}
+
+		if resp, _ := get(t, archive, "https://example.com/missing"); resp.StatusCode != 404 {
+			t.Errorf("%s: expected 404 for a missing URL, got %d", source, resp.StatusCode)
+		}
+	}
+}
+
+func TestOpen_Errors(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "missing.warc")); err == nil {
+		t.Error("expected an error for a missing archive")
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.warc")
+	os.WriteFile(bad, []byte("not a warc file\r\n"), 0o644)
+	if _, err := Open(bad); err == nil {
+		t.Error("expected an error for an invalid WARC file")
+	}
+}
@@ -0,0 +1,85 @@
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// readWARC reads the response records of a WARC file, optionally gzipped,
+// keyed by target URI. The first record of a URI wins.
+func readWARC(r io.Reader) (map[string][]byte, error) {
+	buffered := bufio.NewReader(r)
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		buffered = bufio.NewReader(gz)
+	}
+
+	reader := textproto.NewReader(buffered)
+	responses := make(map[string][]byte)
+	for {
+		version, err := nextRecord(reader)
+		if err == io.EOF {
+			return responses, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(version, "WARC/") {
+			return nil, fmt.Errorf("invalid WARC record start %q", version)
+		}
+
+		header, err := reader.ReadMIMEHeader()
+		if err != nil {
+			return nil, fmt.Errorf("invalid WARC record header: %w", err)
+		}
+		length, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+		if err != nil || length < 0 {
+			return nil, fmt.Errorf("invalid WARC Content-Length %q", header.Get("Content-Length"))
+		}
+		block := make([]byte, length)
+		if _, err := io.ReadFull(buffered, block); err != nil {
+			return nil, fmt.Errorf("truncated WARC record: %w", err)
+		}
+
+		uri := archiveKey(header.Get("WARC-Target-URI"))
+		if header.Get("WARC-Type") != "response" || uri == "" || !bytes.HasPrefix(block, []byte("HTTP/")) {
+			continue
+		}
+		if _, ok := responses[uri]; !ok {
+			responses[uri] = block
+		}
+	}
+}
+
+// nextRecord skips the blank lines between records and returns the version line
+func nextRecord(reader *textproto.Reader) (string, error) {
+	for {
+		line, err := reader.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(line) != "" {
+			return line, nil
+		}
+	}
+}
+
+// archiveKey normalizes a URL for lookups: WARC 1.0 writers may wrap target
+// URIs in angle brackets, and fragments are never sent to servers
+func archiveKey(uri string) string {
+	uri = strings.Trim(strings.TrimSpace(uri), "<>")
+	if i := strings.Index(uri, "#"); i >= 0 {
+		uri = uri[:i]
+	}
+	return uri
+}
@@ -7,22 +7,48 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// RobotsChecker handles robots.txt parsing and URL validation
+// DefaultCacheTTL is how long a robots.txt is used before it is fetched
+// again, the longest Google caches it
+const DefaultCacheTTL = 24 * time.Hour
+
+// RobotsChecker handles robots.txt parsing and URL validation. It is safe for
+// concurrent use, so one checker can be shared by all workers and crawls;
+// each robots.txt is fetched once until its cache entry expires.
 type RobotsChecker struct {
 	userAgent string
 	logger    *slog.Logger
-	cache     map[string]*RobotsData
+	ttl       time.Duration // 0 = entries never expire
+
+	mu       sync.Mutex
+	cache    map[string]*RobotsData
+	fetching map[string]chan struct{} // Closed when the domain's fetch ends
+
+	hits        atomic.Int64
+	misses      atomic.Int64
+	revalidated atomic.Int64
+}
+
+// CacheStats describes the use of the robots.txt cache
+type CacheStats struct {
+	Hits        int64 // Lookups answered from the cache
+	Misses      int64 // Lookups that fetched robots.txt
+	Revalidated int64 // Expired entries the server confirmed unchanged (304)
+	Entries     int   // Domains currently cached
 }
 
 // RobotsData represents parsed robots.txt data for a domain
 type RobotsData struct {
-	rules      []Rule
-	crawlDelay time.Duration
-	sitemaps   []string
-	fetchTime  time.Time
+	rules        []Rule
+	crawlDelay   time.Duration
+	sitemaps     []string
+	fetchTime    time.Time
+	etag         string // Validators for conditional refetching
+	lastModified string
 }
 
 // Rule represents a robots.txt rule
@@ -41,10 +67,66 @@ func NewRobotsChecker(userAgent string, logger *slog.Logger) *RobotsChecker {
 	return &RobotsChecker{
 		userAgent: userAgent,
 		logger:    logger,
+		ttl:       DefaultCacheTTL,
 		cache:     make(map[string]*RobotsData),
+		fetching:  make(map[string]chan struct{}),
 	}
 }
 
+// SetCacheTTL sets how long a robots.txt is used before it is fetched again
+// (0 = for the life of the checker). Expired entries are revalidated with
+// If-None-Match and If-Modified-Since when the server sent validators.
+func (rc *RobotsChecker) SetCacheTTL(ttl time.Duration) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.ttl = ttl
+}
+
+// lookup returns the robots.txt data of domain from the cache, fetching it
+// when it is missing or expired. Concurrent lookups of a domain share one
+// fetch. When refetching an expired entry fails, the expired entry is used
+// for another TTL.
+func (rc *RobotsChecker) lookup(domain string) (*RobotsData, error) {
+	rc.mu.Lock()
+	for {
+		data, ok := rc.cache[domain]
+		if ok && (rc.ttl <= 0 || time.Since(data.fetchTime) < rc.ttl) {
+			rc.mu.Unlock()
+			rc.hits.Add(1)
+			return data, nil
+		}
+		done, busy := rc.fetching[domain]
+		if !busy {
+			break
+		}
+		rc.mu.Unlock()
+		<-done
+		rc.mu.Lock()
+	}
+	stale := rc.cache[domain]
+	done := make(chan struct{})
+	rc.fetching[domain] = done
+	rc.mu.Unlock()
+
+	rc.misses.Add(1)
+	data, err := rc.fetchRobots(domain, stale)
+	if err != nil && stale != nil {
+		rc.logger.Warn("Failed to refetch robots.txt, using the cached copy", "domain", domain, "error", err)
+		refreshed := *stale
+		refreshed.fetchTime = time.Now()
+		data, err = &refreshed, nil
+	}
+
+	rc.mu.Lock()
+	delete(rc.fetching, domain)
+	if err == nil {
+		rc.cache[domain] = data
+	}
+	close(done)
+	rc.mu.Unlock()
+	return data, err
+}
+
 // IsAllowed checks if a URL is allowed according to robots.txt
 func (rc *RobotsChecker) IsAllowed(targetURL string) (bool, error) {
 	parsedURL, err := url.Parse(targetURL)
@@ -60,17 +142,11 @@ func (rc *RobotsChecker) IsAllowed(targetURL string) (bool, error) {
 	// Get domain key for caching
 	domain := parsedURL.Scheme + "://" + parsedURL.Host
 
-	// Check cache first
-	robotsData, exists := rc.cache[domain]
-	if !exists {
-		// Fetch and parse robots.txt
-		robotsData, err = rc.fetchRobots(domain)
-		if err != nil {
-			rc.logger.Warn("Failed to fetch robots.txt, allowing by default",
-				"domain", domain, "error", err)
-			return true, nil // Allow by default if robots.txt is unavailable
-		}
-		rc.cache[domain] = robotsData
+	robotsData, err := rc.lookup(domain)
+	if err != nil {
+		rc.logger.Warn("Failed to fetch robots.txt, allowing by default",
+			"domain", domain, "error", err)
+		return true, nil // Allow by default if robots.txt is unavailable
 	}
 
 	// Check if URL is allowed based on rules
@@ -90,20 +166,17 @@ func (rc *RobotsChecker) GetCrawlDelay(targetURL string) (time.Duration, error)
 	}
 
 	domain := parsedURL.Scheme + "://" + parsedURL.Host
-	robotsData, exists := rc.cache[domain]
-	if !exists {
-		robotsData, err = rc.fetchRobots(domain)
-		if err != nil {
-			return 0, nil // No delay if robots.txt is unavailable
-		}
-		rc.cache[domain] = robotsData
+	robotsData, err := rc.lookup(domain)
+	if err != nil {
+		return 0, nil // No delay if robots.txt is unavailable
 	}
 
 	return robotsData.crawlDelay, nil
 }
 
-// fetchRobots fetches and parses robots.txt from a domain
-func (rc *RobotsChecker) fetchRobots(domain string) (*RobotsData, error) {
+// fetchRobots fetches and parses robots.txt from a domain. With the expired
+// entry stale, the request is conditional and a 304 answer renews stale.
+func (rc *RobotsChecker) fetchRobots(domain string, stale *RobotsData) (*RobotsData, error) {
 	robotsURL := domain + "/robots.txt"
 
 	rc.logger.Debug("Fetching robots.txt", "url", robotsURL)
@@ -118,6 +191,14 @@ func (rc *RobotsChecker) fetchRobots(domain string) (*RobotsData, error) {
 	}
 
 	req.Header.Set("User-Agent", rc.userAgent)
+	if stale != nil {
+		if stale.etag != "" {
+			req.Header.Set("If-None-Match", stale.etag)
+		}
+		if stale.lastModified != "" {
+			req.Header.Set("If-Modified-Since", stale.lastModified)
+		}
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -125,14 +206,25 @@ func (rc *RobotsChecker) fetchRobots(domain string) (*RobotsData, error) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode == http.StatusNotModified && stale != nil {
+		rc.revalidated.Add(1)
+		rc.logger.Debug("robots.txt not modified", "domain", domain)
+		refreshed := *stale
+		refreshed.fetchTime = time.Now()
+		return &refreshed, nil
+	}
+
+	// Some servers answer plain requests with 206 and the whole file
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
 		return nil, fmt.Errorf("robots.txt returned status %d", resp.StatusCode)
 	}
 
 	// Parse robots.txt content
 	robotsData := &RobotsData{
-		rules:     make([]Rule, 0),
-		fetchTime: time.Now(),
+		rules:        make([]Rule, 0),
+		fetchTime:    time.Now(),
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
 	}
 
 	scanner := bufio.NewScanner(resp.Body)
@@ -253,10 +345,24 @@ func (rc *RobotsChecker) pathMatches(pattern, urlPath string) bool {
 
 // ClearCache clears the robots.txt cache
 func (rc *RobotsChecker) ClearCache() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
 	rc.cache = make(map[string]*RobotsData)
 }
 
 // GetCacheSize returns the number of cached robots.txt entries
 func (rc *RobotsChecker) GetCacheSize() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
 	return len(rc.cache)
 }
+
+// Stats returns the cache statistics
+func (rc *RobotsChecker) Stats() CacheStats {
+	return CacheStats{
+		Hits:        rc.hits.Load(),
+		Misses:      rc.misses.Load(),
+		Revalidated: rc.revalidated.Load(),
+		Entries:     rc.GetCacheSize(),
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -133,7 +135,7 @@ Sitemap: https://example.com/sitemap.xml
 
 	checker := NewRobotsChecker("TestBot/1.0", slog.Default())
 
-	robotsData, err := checker.fetchRobots(server.URL)
+	robotsData, err := checker.fetchRobots(server.URL, nil)
 	if err != nil {
 		t.Fatalf("fetchRobots failed: %v", err)
 	}
@@ -155,6 +157,24 @@ Sitemap: https://example.com/sitemap.xml
 	}
 }
 
+func TestFetchRobotsPartialContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPartialContent)
+		fmt.Fprint(w, "User-agent: *\nDisallow: /admin/\n")
+	}))
+	defer server.Close()
+
+	checker := NewRobotsChecker("TestBot/1.0", slog.Default())
+
+	allowed, err := checker.IsAllowed(server.URL + "/admin/")
+	if err != nil {
+		t.Fatalf("IsAllowed failed: %v", err)
+	}
+	if allowed {
+		t.Error("Expected the rules of a 206 robots.txt to apply")
+	}
+}
+
 func TestIsAllowed(t *testing.T) {
 	robotsContent := `User-agent: TestBot
 Disallow: /admin/
@@ -306,7 +326,7 @@ Allow: /special
 
 	checker := NewRobotsChecker("TestBot/1.0", slog.Default())
 
-	robotsData, err := checker.fetchRobots(server.URL)
+	robotsData, err := checker.fetchRobots(server.URL, nil)
 	if err != nil {
 		t.Fatalf("fetchRobots failed: %v", err)
 	}
@@ -342,3 +362,92 @@ Disallow: /private/*
 		checker.IsAllowed(testURL)
 	}
 }
+
+func TestCacheSharedFetch(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, "User-agent: *\nDisallow: /admin/\n")
+	}))
+	defer server.Close()
+
+	checker := NewRobotsChecker("TestBot/1.0", slog.Default())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if allowed, _ := checker.IsAllowed(server.URL + "/admin/"); allowed {
+				t.Error("Expected /admin/ to be disallowed")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if requests.Load() != 1 {
+		t.Errorf("Expected concurrent lookups to share one fetch, got %d requests", requests.Load())
+	}
+	if stats := checker.Stats(); stats.Misses != 1 || stats.Hits != 9 || stats.Entries != 1 {
+		t.Errorf("Unexpected cache stats: %+v", stats)
+	}
+}
+
+func TestCacheExpiryRevalidation(t *testing.T) {
+	var requests, conditional atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "User-agent: *\nDisallow: /admin/\n")
+	}))
+	defer server.Close()
+
+	checker := NewRobotsChecker("TestBot/1.0", slog.Default())
+	checker.SetCacheTTL(time.Hour)
+
+	checker.IsAllowed(server.URL + "/")
+	checker.IsAllowed(server.URL + "/")
+	if requests.Load() != 1 {
+		t.Fatalf("Expected one fetch within the TTL, got %d", requests.Load())
+	}
+
+	// Expire the entry: the refetch is conditional and keeps the rules
+	checker.SetCacheTTL(time.Nanosecond)
+	allowed, err := checker.IsAllowed(server.URL + "/admin/")
+	if err != nil || allowed {
+		t.Errorf("Expected the revalidated rules to apply, got %v, %v", allowed, err)
+	}
+	if conditional.Load() != 1 {
+		t.Errorf("Expected a conditional refetch, got %d", conditional.Load())
+	}
+	if stats := checker.Stats(); stats.Revalidated != 1 || stats.Misses != 2 {
+		t.Errorf("Unexpected cache stats: %+v", stats)
+	}
+}
+
+func TestCacheStaleOnError(t *testing.T) {
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "User-agent: *\nDisallow: /admin/\n")
+	}))
+	defer server.Close()
+
+	checker := NewRobotsChecker("TestBot/1.0", slog.Default())
+	checker.IsAllowed(server.URL + "/")
+
+	failing.Store(true)
+	checker.SetCacheTTL(time.Nanosecond)
+	if allowed, _ := checker.IsAllowed(server.URL + "/admin/"); allowed {
+		t.Error("Expected the cached rules to apply when the refetch fails")
+	}
+}
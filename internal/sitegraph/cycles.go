@@ -0,0 +1,218 @@
+package sitegraph
+
+import (
+	neturl "net/url"
+	"sort"
+)
+
+// Cycles holds link graph structures that point to architecture problems
+type Cycles struct {
+	RedirectLoops    [][]string // Redirect chains that lead back to themselves
+	SelfLinkOnly     []string   // Pages whose only internal links point to themselves
+	IsolatedClusters [][]string // Groups of pages linking to each other that the homepage cannot reach
+}
+
+// AnalyzeCycles looks for redirect loops, pages linking only to themselves
+// and strongly connected clusters unreachable from the homepage.
+// The homepage is the crawled root path of a host; without one, the seeds
+// (depth 0) are used.
+func AnalyzeCycles(pages []Page) Cycles {
+	crawled := make(map[string]bool, len(pages))
+	for _, page := range pages {
+		crawled[page.URL] = true
+	}
+
+	var cycles Cycles
+	cycles.RedirectLoops = redirectLoops(pages)
+
+	edges := make(map[string][]string, len(pages))
+	for _, page := range pages {
+		if page.Failed {
+			continue
+		}
+		links := internalLinks(page, crawled)
+		if len(page.Links) > 0 && onlySelfLinks(page) {
+			cycles.SelfLinkOnly = append(cycles.SelfLinkOnly, page.URL)
+		}
+		if page.RedirectTo != "" && crawled[page.RedirectTo] && page.RedirectTo != page.URL {
+			links = append(links, page.RedirectTo)
+		}
+		edges[page.URL] = links
+	}
+	sort.Strings(cycles.SelfLinkOnly)
+
+	reachable := reachableFrom(homepages(pages), edges)
+	var unreachable []string
+	for _, page := range pages {
+		if !reachable[page.URL] {
+			unreachable = append(unreachable, page.URL)
+		}
+	}
+	for _, component := range stronglyConnected(unreachable, edges) {
+		if len(component) > 1 {
+			cycles.IsolatedClusters = append(cycles.IsolatedClusters, component)
+		}
+	}
+	sort.Slice(cycles.IsolatedClusters, func(i, j int) bool {
+		return cycles.IsolatedClusters[i][0] < cycles.IsolatedClusters[j][0]
+	})
+
+	return cycles
+}
+
+// onlySelfLinks reports whether every link of page points to the page itself
+func onlySelfLinks(page Page) bool {
+	for _, link := range page.Links {
+		if link != page.URL {
+			return false
+		}
+	}
+	return true
+}
+
+// redirectLoops returns pages whose redirects looped, plus cycles formed by
+// redirects between crawled pages, each starting at its smallest URL
+func redirectLoops(pages []Page) [][]string {
+	redirects := make(map[string]string)
+	var loops [][]string
+	for _, page := range pages {
+		if page.RedirectLoop {
+			loops = append(loops, []string{page.URL})
+		}
+		if page.RedirectTo != "" {
+			redirects[page.URL] = page.RedirectTo
+		}
+	}
+
+	done := make(map[string]bool)
+	for _, page := range pages {
+		// Follow the chain, remembering the position of each URL on it
+		position := make(map[string]int)
+		var chain []string
+		current := page.URL
+		for current != "" && !done[current] {
+			if start, ok := position[current]; ok {
+				loops = append(loops, rotateToSmallest(chain[start:]))
+				break
+			}
+			position[current] = len(chain)
+			chain = append(chain, current)
+			current = redirects[current]
+		}
+		for _, url := range chain {
+			done[url] = true
+		}
+	}
+
+	sort.Slice(loops, func(i, j int) bool {
+		return loops[i][0] < loops[j][0]
+	})
+	return loops
+}
+
+// rotateToSmallest rotates a cycle so that it starts at its smallest URL
+func rotateToSmallest(cycle []string) []string {
+	smallest := 0
+	for i, url := range cycle {
+		if url < cycle[smallest] {
+			smallest = i
+		}
+	}
+	return append(append([]string(nil), cycle[smallest:]...), cycle[:smallest]...)
+}
+
+// homepages returns the crawled root pages, or the seeds if no root was crawled
+func homepages(pages []Page) []string {
+	var roots, seeds []string
+	for _, page := range pages {
+		if parsed, err := neturl.Parse(page.URL); err == nil && (parsed.Path == "" || parsed.Path == "/") && parsed.RawQuery == "" {
+			roots = append(roots, page.URL)
+		}
+		if page.Depth == 0 {
+			seeds = append(seeds, page.URL)
+		}
+	}
+	if len(roots) > 0 {
+		return roots
+	}
+	return seeds
+}
+
+// reachableFrom returns the pages reachable from the start pages
+func reachableFrom(start []string, edges map[string][]string) map[string]bool {
+	reachable := make(map[string]bool, len(edges))
+	queue := append([]string(nil), start...)
+	for _, url := range start {
+		reachable[url] = true
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range edges[current] {
+			if !reachable[next] {
+				reachable[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return reachable
+}
+
+// stronglyConnected returns the strongly connected components among nodes
+// (Tarjan's algorithm), ignoring edges that leave nodes. Each component is sorted.
+func stronglyConnected(nodes []string, edges map[string][]string) [][]string {
+	inSet := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		inSet[node] = true
+	}
+
+	index := make(map[string]int, len(nodes))
+	lowlink := make(map[string]int, len(nodes))
+	onStack := make(map[string]bool, len(nodes))
+	var stack []string
+	var components [][]string
+	next := 0
+
+	var visit func(node string)
+	visit = func(node string) {
+		index[node] = next
+		lowlink[node] = next
+		next++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, target := range edges[node] {
+			if !inSet[target] {
+				continue
+			}
+			if _, visited := index[target]; !visited {
+				visit(target)
+				lowlink[node] = min(lowlink[node], lowlink[target])
+			} else if onStack[target] {
+				lowlink[node] = min(lowlink[node], index[target])
+			}
+		}
+
+		if lowlink[node] == index[node] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == node {
+					break
+				}
+			}
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, node := range nodes {
+		if _, visited := index[node]; !visited {
+			visit(node)
+		}
+	}
+	return components
+}
@@ -0,0 +1,68 @@
+package sitegraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeCycles(t *testing.T) {
+	pages := []Page{
+		{URL: "https://example.com/", Links: []string{"https://example.com/about", "https://example.com/old"}},
+		{URL: "https://example.com/about", Depth: 1, Links: []string{"https://example.com/about", "https://example.com/about"}},
+		{URL: "https://example.com/old", Depth: 1, RedirectTo: "https://example.com/older"},
+		{URL: "https://example.com/older", Depth: 2, RedirectTo: "https://example.com/old"},
+		{URL: "https://example.com/loop", Depth: 1, Failed: true, RedirectLoop: true},
+
+		// Reached from a second seed only
+		{URL: "https://example.com/archive/", Links: []string{"https://example.com/archive/2019"}},
+		{URL: "https://example.com/archive/2019", Depth: 1, Links: []string{"https://example.com/archive/2020"}},
+		{URL: "https://example.com/archive/2020", Depth: 1, Links: []string{"https://example.com/archive/2019", "https://example.com/"}},
+	}
+
+	cycles := AnalyzeCycles(pages)
+
+	wantLoops := [][]string{
+		{"https://example.com/loop"},
+		{"https://example.com/old", "https://example.com/older"},
+	}
+	if !reflect.DeepEqual(cycles.RedirectLoops, wantLoops) {
+		t.Errorf("RedirectLoops = %v, want %v", cycles.RedirectLoops, wantLoops)
+	}
+
+	if !reflect.DeepEqual(cycles.SelfLinkOnly, []string{"https://example.com/about"}) {
+		t.Errorf("SelfLinkOnly = %v", cycles.SelfLinkOnly)
+	}
+
+	wantClusters := [][]string{{"https://example.com/archive/2019", "https://example.com/archive/2020"}}
+	if !reflect.DeepEqual(cycles.IsolatedClusters, wantClusters) {
+		t.Errorf("IsolatedClusters = %v, want %v", cycles.IsolatedClusters, wantClusters)
+	}
+}
+
+func TestAnalyzeCyclesWithoutRootPage(t *testing.T) {
+	// Seeds stand in for the homepage when the root path was not crawled
+	pages := []Page{
+		{URL: "https://example.com/docs", Links: []string{"https://example.com/docs/a"}},
+		{URL: "https://example.com/docs/a", Depth: 1, Links: []string{"https://example.com/docs/b"}},
+		{URL: "https://example.com/docs/b", Depth: 2, Links: []string{"https://example.com/docs/a"}},
+	}
+
+	if cycles := AnalyzeCycles(pages); len(cycles.IsolatedClusters) != 0 {
+		t.Errorf("expected no isolated clusters, got %v", cycles.IsolatedClusters)
+	}
+}
+
+func TestStronglyConnected(t *testing.T) {
+	edges := map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a", "d"},
+		"d": {"e"},
+	}
+
+	components := stronglyConnected([]string{"a", "b", "c", "d"}, edges)
+	want := [][]string{{"d"}, {"a", "b", "c"}}
+	if !reflect.DeepEqual(components, want) {
+		t.Errorf("stronglyConnected() = %v, want %v", components, want)
+	}
+}
@@ -0,0 +1,56 @@
+package sitegraph
+
+import "sort"
+
+// SinglePath is a page that only one other page links to
+type SinglePath struct {
+	URL  string // Page with a single inbound link
+	From string // The only page linking to it
+}
+
+// DeadEnds holds pages that hinder navigation through a site
+type DeadEnds struct {
+	NoOutlinks  []string     // Crawled pages without links to other crawled pages
+	SinglePaths []SinglePath // Pages reachable through a single inbound link only
+}
+
+// AnalyzeDeadEnds finds pages without outgoing internal links and pages that
+// only one other page links to. Seeds (depth 0) are never single-path pages,
+// as they are entry points.
+func AnalyzeDeadEnds(pages []Page) DeadEnds {
+	crawled := make(map[string]bool, len(pages))
+	for _, page := range pages {
+		crawled[page.URL] = true
+	}
+
+	var deadEnds DeadEnds
+	inbound := make(map[string][]string, len(pages))
+	for _, page := range pages {
+		if page.Failed {
+			continue
+		}
+		links := internalLinks(page, crawled)
+		// A redirected page leads on to its target
+		if len(links) == 0 && page.RedirectTo == "" {
+			deadEnds.NoOutlinks = append(deadEnds.NoOutlinks, page.URL)
+		}
+		for _, link := range links {
+			inbound[link] = append(inbound[link], page.URL)
+		}
+	}
+
+	for _, page := range pages {
+		if page.Depth == 0 {
+			continue
+		}
+		if from := inbound[page.URL]; len(from) == 1 {
+			deadEnds.SinglePaths = append(deadEnds.SinglePaths, SinglePath{URL: page.URL, From: from[0]})
+		}
+	}
+
+	sort.Strings(deadEnds.NoOutlinks)
+	sort.Slice(deadEnds.SinglePaths, func(i, j int) bool {
+		return deadEnds.SinglePaths[i].URL < deadEnds.SinglePaths[j].URL
+	})
+	return deadEnds
+}
@@ -0,0 +1,31 @@
+package sitegraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeDeadEnds(t *testing.T) {
+	pages := []Page{
+		{URL: "https://example.com/", Links: []string{"https://example.com/a", "https://example.com/b", "https://other.example/"}},
+		{URL: "https://example.com/a", Depth: 1, Links: []string{"https://example.com/b", "https://example.com/a/deep"}},
+		{URL: "https://example.com/b", Depth: 1, Links: []string{"https://example.com/b", "https://other.example/"}},
+		{URL: "https://example.com/a/deep", Depth: 2, Links: []string{"https://example.com/"}},
+		{URL: "https://example.com/moved", Depth: 2, RedirectTo: "https://example.com/b"},
+		{URL: "https://example.com/broken", Depth: 2, Failed: true},
+	}
+
+	deadEnds := AnalyzeDeadEnds(pages)
+
+	if !reflect.DeepEqual(deadEnds.NoOutlinks, []string{"https://example.com/b"}) {
+		t.Errorf("NoOutlinks = %v, want [https://example.com/b]", deadEnds.NoOutlinks)
+	}
+
+	wantSingle := []SinglePath{
+		{URL: "https://example.com/a", From: "https://example.com/"},
+		{URL: "https://example.com/a/deep", From: "https://example.com/a"},
+	}
+	if !reflect.DeepEqual(deadEnds.SinglePaths, wantSingle) {
+		t.Errorf("SinglePaths = %+v, want %+v", deadEnds.SinglePaths, wantSingle)
+	}
+}
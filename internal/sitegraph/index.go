@@ -0,0 +1,41 @@
+package sitegraph
+
+import "sort"
+
+// IndexComparison compares the crawled site with the URLs a search engine
+// has indexed
+type IndexComparison struct {
+	NotLinked  []string // Indexed URLs the crawl did not reach (orphan pages)
+	NotIndexed []string // Crawled pages missing from the index
+}
+
+// CompareIndex compares the crawled pages with indexed URLs, which the
+// caller normalizes like crawled URLs. Failed and redirected pages are
+// neither expected in the index nor reported as missing from it.
+func CompareIndex(pages []Page, indexed []string) IndexComparison {
+	crawled := make(map[string]bool, len(pages))
+	for _, page := range pages {
+		crawled[page.URL] = true
+	}
+	inIndex := make(map[string]bool, len(indexed))
+	for _, u := range indexed {
+		inIndex[u] = true
+	}
+
+	var comparison IndexComparison
+	for u := range inIndex {
+		if !crawled[u] {
+			comparison.NotLinked = append(comparison.NotLinked, u)
+		}
+	}
+	for _, page := range pages {
+		if page.Failed || page.RedirectTo != "" || inIndex[page.URL] {
+			continue
+		}
+		comparison.NotIndexed = append(comparison.NotIndexed, page.URL)
+	}
+
+	sort.Strings(comparison.NotLinked)
+	sort.Strings(comparison.NotIndexed)
+	return comparison
+}
@@ -0,0 +1,32 @@
+package sitegraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareIndex(t *testing.T) {
+	pages := []Page{
+		{URL: "https://example.com/"},
+		{URL: "https://example.com/a", Depth: 1},
+		{URL: "https://example.com/new", Depth: 1},
+		{URL: "https://example.com/moved", Depth: 1, RedirectTo: "https://example.com/a"},
+		{URL: "https://example.com/broken", Depth: 1, Failed: true},
+	}
+	indexed := []string{
+		"https://example.com/",
+		"https://example.com/a",
+		"https://example.com/orphan",
+		"https://example.com/broken",
+		"https://example.com/orphan",
+	}
+
+	comparison := CompareIndex(pages, indexed)
+
+	if !reflect.DeepEqual(comparison.NotLinked, []string{"https://example.com/orphan"}) {
+		t.Errorf("NotLinked = %v, want [https://example.com/orphan]", comparison.NotLinked)
+	}
+	if !reflect.DeepEqual(comparison.NotIndexed, []string{"https://example.com/new"}) {
+		t.Errorf("NotIndexed = %v, want [https://example.com/new]", comparison.NotIndexed)
+	}
+}
@@ -0,0 +1,74 @@
+package sitegraph
+
+import "sort"
+
+// Degree is the number of internal links to or from a page
+type Degree struct {
+	URL   string
+	Count int
+}
+
+// Linking holds the most linked pages of a site
+type Linking struct {
+	InDegree  []Degree // Pages linked from the most other pages
+	OutDegree []Degree // Pages linking to the most other pages
+}
+
+// AnalyzeLinking returns the top pages by in-degree and out-degree.
+// Only links between crawled pages count; self-links and repeated links
+// from the same page are ignored. A top below 1 returns every page.
+func AnalyzeLinking(pages []Page, top int) Linking {
+	crawled := make(map[string]bool, len(pages))
+	for _, page := range pages {
+		crawled[page.URL] = true
+	}
+
+	in := make(map[string]int, len(pages))
+	out := make(map[string]int, len(pages))
+	for _, page := range pages {
+		if page.Failed {
+			continue
+		}
+		for _, target := range internalLinks(page, crawled) {
+			in[target]++
+			out[page.URL]++
+		}
+	}
+
+	return Linking{
+		InDegree:  topDegrees(in, top),
+		OutDegree: topDegrees(out, top),
+	}
+}
+
+// internalLinks returns the distinct links of page to other crawled pages
+func internalLinks(page Page, crawled map[string]bool) []string {
+	seen := make(map[string]bool, len(page.Links))
+	links := make([]string, 0, len(page.Links))
+	for _, link := range page.Links {
+		if link == page.URL || !crawled[link] || seen[link] {
+			continue
+		}
+		seen[link] = true
+		links = append(links, link)
+	}
+	return links
+}
+
+// topDegrees sorts the counts by count (highest first), then URL, and keeps the top entries
+func topDegrees(counts map[string]int, top int) []Degree {
+	degrees := make([]Degree, 0, len(counts))
+	for url, count := range counts {
+		degrees = append(degrees, Degree{URL: url, Count: count})
+	}
+	sort.Slice(degrees, func(i, j int) bool {
+		if degrees[i].Count != degrees[j].Count {
+			return degrees[i].Count > degrees[j].Count
+		}
+		return degrees[i].URL < degrees[j].URL
+	})
+	if top > 0 && len(degrees) > top {
+		degrees = degrees[:top]
+	}
+	return degrees
+}
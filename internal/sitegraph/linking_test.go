@@ -0,0 +1,40 @@
+package sitegraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeLinking(t *testing.T) {
+	pages := []Page{
+		{URL: "https://example.com/", Links: []string{
+			"https://example.com/a", "https://example.com/b", "https://example.com/c",
+			"https://example.com/", "https://other.example/",
+		}},
+		{URL: "https://example.com/a", Links: []string{"https://example.com/", "https://example.com/b", "https://example.com/b"}},
+		{URL: "https://example.com/b", Links: []string{"https://example.com/"}},
+		{URL: "https://example.com/c", Failed: true, Links: []string{"https://example.com/a"}},
+	}
+
+	linking := AnalyzeLinking(pages, 2)
+
+	wantIn := []Degree{
+		{URL: "https://example.com/", Count: 2},
+		{URL: "https://example.com/b", Count: 2},
+	}
+	if !reflect.DeepEqual(linking.InDegree, wantIn) {
+		t.Errorf("InDegree = %+v, want %+v", linking.InDegree, wantIn)
+	}
+
+	wantOut := []Degree{
+		{URL: "https://example.com/", Count: 3},
+		{URL: "https://example.com/a", Count: 2},
+	}
+	if !reflect.DeepEqual(linking.OutDegree, wantOut) {
+		t.Errorf("OutDegree = %+v, want %+v", linking.OutDegree, wantOut)
+	}
+
+	if all := AnalyzeLinking(pages, 0); len(all.InDegree) != 4 || len(all.OutDegree) != 3 {
+		t.Errorf("expected all pages without a limit, got %+v", all)
+	}
+}
@@ -0,0 +1,28 @@
+package sitegraph
+
+import "sort"
+
+// Referrers returns the pages linking to each URL that is linked from a
+// crawled page, whether or not the URL itself was crawled. The referring
+// pages are sorted; self-links and repeated links from the same page are
+// ignored.
+func Referrers(pages []Page) map[string][]string {
+	referrers := make(map[string][]string)
+	for _, page := range pages {
+		if page.Failed {
+			continue
+		}
+		seen := make(map[string]bool, len(page.Links))
+		for _, link := range page.Links {
+			if link == page.URL || seen[link] {
+				continue
+			}
+			seen[link] = true
+			referrers[link] = append(referrers[link], page.URL)
+		}
+	}
+	for _, from := range referrers {
+		sort.Strings(from)
+	}
+	return referrers
+}
@@ -0,0 +1,23 @@
+package sitegraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReferrers(t *testing.T) {
+	pages := []Page{
+		{URL: "https://example.com/b", Depth: 1, Links: []string{"https://example.com/b", "https://example.com/missing"}},
+		{URL: "https://example.com/", Links: []string{"https://example.com/b", "https://example.com/missing", "https://example.com/missing", "https://other.example/"}},
+		{URL: "https://example.com/missing", Depth: 1, Failed: true, Links: []string{"https://example.com/"}},
+	}
+
+	want := map[string][]string{
+		"https://example.com/b":       {"https://example.com/"},
+		"https://example.com/missing": {"https://example.com/", "https://example.com/b"},
+		"https://other.example/":      {"https://example.com/"},
+	}
+	if got := Referrers(pages); !reflect.DeepEqual(got, want) {
+		t.Errorf("Referrers() = %v, want %v", got, want)
+	}
+}
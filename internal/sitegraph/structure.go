@@ -0,0 +1,96 @@
+package sitegraph
+
+import "sort"
+
+// Page is a crawled URL and the internal links found on it
+type Page struct {
+	URL    string   // Absolute URL
+	Depth  int      // Crawl depth (0 = seed)
+	Parent string   // Page the URL was first found on (empty for the seed)
+	Links  []string // Links found on the page
+	Failed bool     // The page could not be crawled
+
+	RedirectTo   string // URL the page redirected to (empty if not redirected)
+	RedirectLoop bool   // Following the page's redirects led back to an earlier URL
+}
+
+// Structure summarizes the shape of a crawled site
+type Structure struct {
+	Pages        int      // Pages crawled, including failed ones
+	DepthCounts  []int    // Pages per depth level, indexed by depth
+	AvgLinks     float64  // Average links per successfully crawled page
+	MaxBreadth   int      // Most pages found on a single depth level
+	BreadthDepth int      // Depth level with MaxBreadth pages (the shallowest on ties)
+	LongestChain []string // Longest discovery path, from the seed to the deepest page
+}
+
+// AnalyzeStructure computes depth and breadth statistics for the crawled pages
+func AnalyzeStructure(pages []Page) Structure {
+	var structure Structure
+	structure.Pages = len(pages)
+
+	links, crawled := 0, 0
+	for _, page := range pages {
+		if page.Depth < 0 {
+			continue
+		}
+		for len(structure.DepthCounts) <= page.Depth {
+			structure.DepthCounts = append(structure.DepthCounts, 0)
+		}
+		structure.DepthCounts[page.Depth]++
+
+		if !page.Failed {
+			links += len(page.Links)
+			crawled++
+		}
+	}
+
+	if crawled > 0 {
+		structure.AvgLinks = float64(links) / float64(crawled)
+	}
+
+	for depth, count := range structure.DepthCounts {
+		if count > structure.MaxBreadth {
+			structure.MaxBreadth = count
+			structure.BreadthDepth = depth
+		}
+	}
+
+	structure.LongestChain = longestChain(pages)
+	return structure
+}
+
+// longestChain follows the parents of the deepest page back to its seed.
+// Ties between equally deep pages are broken by URL so the result is stable.
+func longestChain(pages []Page) []string {
+	if len(pages) == 0 {
+		return nil
+	}
+
+	parents := make(map[string]string, len(pages))
+	for _, page := range pages {
+		parents[page.URL] = page.Parent
+	}
+
+	deepest := make([]Page, len(pages))
+	copy(deepest, pages)
+	sort.Slice(deepest, func(i, j int) bool {
+		if deepest[i].Depth != deepest[j].Depth {
+			return deepest[i].Depth > deepest[j].Depth
+		}
+		return deepest[i].URL < deepest[j].URL
+	})
+
+	var chain []string
+	seen := make(map[string]bool)
+	for current := deepest[0].URL; current != "" && !seen[current]; current = parents[current] {
+		seen[current] = true
+		chain = append(chain, current)
+	}
+
+	// Reverse to read from the seed down
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
@@ -0,0 +1,54 @@
+package sitegraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeStructure(t *testing.T) {
+	pages := []Page{
+		{URL: "https://example.com/", Depth: 0, Links: []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"}},
+		{URL: "https://example.com/a", Depth: 1, Parent: "https://example.com/", Links: []string{"https://example.com/a/1"}},
+		{URL: "https://example.com/b", Depth: 1, Parent: "https://example.com/", Links: []string{"https://example.com/b/1", "https://example.com/"}},
+		{URL: "https://example.com/c", Depth: 1, Parent: "https://example.com/", Failed: true},
+		{URL: "https://example.com/b/1", Depth: 2, Parent: "https://example.com/b"},
+		{URL: "https://example.com/a/1", Depth: 2, Parent: "https://example.com/a"},
+	}
+
+	structure := AnalyzeStructure(pages)
+
+	if structure.Pages != 6 {
+		t.Errorf("Pages = %d, want 6", structure.Pages)
+	}
+	if !reflect.DeepEqual(structure.DepthCounts, []int{1, 3, 2}) {
+		t.Errorf("DepthCounts = %v, want [1 3 2]", structure.DepthCounts)
+	}
+	if structure.AvgLinks != 1.2 {
+		t.Errorf("AvgLinks = %v, want 1.2", structure.AvgLinks)
+	}
+	if structure.MaxBreadth != 3 || structure.BreadthDepth != 1 {
+		t.Errorf("MaxBreadth = %d at depth %d, want 3 at depth 1", structure.MaxBreadth, structure.BreadthDepth)
+	}
+
+	wantChain := []string{"https://example.com/", "https://example.com/a", "https://example.com/a/1"}
+	if !reflect.DeepEqual(structure.LongestChain, wantChain) {
+		t.Errorf("LongestChain = %v, want %v", structure.LongestChain, wantChain)
+	}
+}
+
+func TestAnalyzeStructureEmpty(t *testing.T) {
+	structure := AnalyzeStructure(nil)
+	if structure.Pages != 0 || structure.AvgLinks != 0 || structure.LongestChain != nil {
+		t.Errorf("unexpected structure for no pages: %+v", structure)
+	}
+}
+
+func TestLongestChainParentCycle(t *testing.T) {
+	pages := []Page{
+		{URL: "https://example.com/a", Depth: 1, Parent: "https://example.com/b"},
+		{URL: "https://example.com/b", Depth: 1, Parent: "https://example.com/a"},
+	}
+	if chain := longestChain(pages); len(chain) != 2 {
+		t.Errorf("longestChain() = %v, want 2 pages", chain)
+	}
+}
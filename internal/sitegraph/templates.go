@@ -0,0 +1,53 @@
+package sitegraph
+
+import (
+	"sort"
+
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// Template is a group of URLs that share a path template
+type Template struct {
+	Pattern string // Path template, e.g. "example.com/products/{id}"
+	Count   int    // Distinct URLs matching the template
+	Example string // First matching URL, in discovery order
+}
+
+// ClusterTemplates groups every discovered URL, crawled or only linked to,
+// by path template. Templates are ordered by count, largest first.
+func ClusterTemplates(pages []Page) []Template {
+	seen := make(map[string]bool)
+	index := make(map[string]int)
+	var templates []Template
+
+	add := func(rawURL string) {
+		if seen[rawURL] {
+			return
+		}
+		seen[rawURL] = true
+
+		pattern := url.PathTemplate(rawURL)
+		i, ok := index[pattern]
+		if !ok {
+			i = len(templates)
+			index[pattern] = i
+			templates = append(templates, Template{Pattern: pattern, Example: rawURL})
+		}
+		templates[i].Count++
+	}
+
+	for _, page := range pages {
+		add(page.URL)
+		for _, link := range page.Links {
+			add(link)
+		}
+	}
+
+	sort.SliceStable(templates, func(i, j int) bool {
+		if templates[i].Count != templates[j].Count {
+			return templates[i].Count > templates[j].Count
+		}
+		return templates[i].Pattern < templates[j].Pattern
+	})
+	return templates
+}
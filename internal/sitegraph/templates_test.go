@@ -0,0 +1,34 @@
+package sitegraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClusterTemplates(t *testing.T) {
+	pages := []Page{
+		{URL: "https://example.com/", Links: []string{
+			"https://example.com/products/1",
+			"https://example.com/products/2",
+			"https://example.com/about",
+		}},
+		{URL: "https://example.com/products/1", Depth: 1, Links: []string{
+			"https://example.com/products/2",
+			"https://example.com/products/3?ref=related",
+		}},
+		{URL: "https://example.com/about", Depth: 1},
+	}
+
+	want := []Template{
+		{Pattern: "example.com/products/{id}", Count: 3, Example: "https://example.com/products/1"},
+		{Pattern: "example.com/", Count: 1, Example: "https://example.com/"},
+		{Pattern: "example.com/about", Count: 1, Example: "https://example.com/about"},
+	}
+	if got := ClusterTemplates(pages); !reflect.DeepEqual(got, want) {
+		t.Errorf("ClusterTemplates() = %+v, want %+v", got, want)
+	}
+
+	if got := ClusterTemplates(nil); got != nil {
+		t.Errorf("ClusterTemplates(nil) = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,87 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+// Checkpoint is the saved progress of a crawl, from which a crawl that was
+// killed can continue where it left off
+type Checkpoint struct {
+	Seed    string                `json:"seed"`
+	SavedAt time.Time             `json:"saved_at"`
+	Visited []string              `json:"visited"` // URLs queued, crawled or skipped
+	Pending []PendingURL          `json:"pending"` // URLs queued or being fetched
+	Results []output.NDJSONResult `json:"results"` // Pages crawled
+	Stats   CheckpointStats       `json:"stats"`
+}
+
+// PendingURL is a URL that was queued but not crawled yet
+type PendingURL struct {
+	URL           string   `json:"url"`
+	Depth         int      `json:"depth"`
+	Parent        string   `json:"parent,omitempty"`
+	Attempt       int      `json:"attempt,omitempty"`        // Times the URL was rescheduled after throttling
+	LowConfidence bool     `json:"low_confidence,omitempty"` // Found only in an inline script
+	Guessed       bool     `json:"guessed,omitempty"`        // Guessed next page of its parent
+	Historical    bool     `json:"historical,omitempty"`     // From a web archive
+	Tags          []string `json:"tags,omitempty"`           // Classifier tags
+}
+
+// CheckpointStats holds the crawl statistics at the checkpoint
+type CheckpointStats struct {
+	TotalURLs       int           `json:"total_urls"`
+	CrawledURLs     int           `json:"crawled_urls"`
+	FailedURLs      int           `json:"failed_urls"`
+	SkippedURLs     int           `json:"skipped_urls"`
+	ThrottleEvents  int           `json:"throttle_events,omitempty"`
+	MaxDepthReached int           `json:"max_depth_reached"`
+	Elapsed         time.Duration `json:"elapsed_ns"`
+	Frontier        []string      `json:"frontier,omitempty"`
+}
+
+// LoadCheckpoint reads a checkpoint file
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	cp := &Checkpoint{}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	return cp, nil
+}
+
+// Save writes the checkpoint to path. The file is replaced at once, so a
+// crawl killed while saving leaves the previous checkpoint intact.
+func (c *Checkpoint) Save(path string) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
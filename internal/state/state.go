@@ -0,0 +1,140 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// PageState holds the validators recorded for a crawled page, and where it
+// was found and what it links to so an incremental crawl can skip it
+type PageState struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	ContentHash  string    `json:"content_hash,omitempty"`
+	FetchTime    time.Time `json:"fetch_time"`
+	StatusCode   int       `json:"status_code,omitempty"`
+	Depth        int       `json:"depth,omitempty"`
+	Parent       string    `json:"parent,omitempty"`
+	Title        string    `json:"title,omitempty"`
+	Links        []string  `json:"links,omitempty"`
+}
+
+// State is the stored result of a crawl, used to detect changes on re-crawl
+type State struct {
+	CrawledAt time.Time            `json:"crawled_at"`
+	Pages     map[string]PageState `json:"pages"`
+}
+
+// New creates an empty state
+func New() *State {
+	return &State{
+		CrawledAt: time.Now(),
+		Pages:     make(map[string]PageState),
+	}
+}
+
+// Load reads a state file. A missing file yields an empty state.
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	s := New()
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if s.Pages == nil {
+		s.Pages = make(map[string]PageState)
+	}
+	return s, nil
+}
+
+// Save writes the state to path
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// Add records a page in the state
+func (s *State) Add(page PageState) {
+	s.Pages[page.URL] = page
+}
+
+// ChangeKind describes how a page differs from the previous crawl
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is a page that differs between two crawls
+type Change struct {
+	URL    string     // Page URL
+	Kind   ChangeKind // Added, removed or changed
+	Reason string     // Validator that detected the change (etag, last-modified, content)
+}
+
+// Compare returns the pages that were added, removed or changed in current
+// relative to previous, sorted by URL.
+//
+// Pages are compared by ETag when both crawls recorded one, otherwise by
+// Last-Modified, otherwise by content hash.
+func Compare(previous, current *State) []Change {
+	var changes []Change
+
+	for url, page := range current.Pages {
+		old, ok := previous.Pages[url]
+		if !ok {
+			changes = append(changes, Change{URL: url, Kind: ChangeAdded})
+			continue
+		}
+		if reason := changeReason(old, page); reason != "" {
+			changes = append(changes, Change{URL: url, Kind: ChangeChanged, Reason: reason})
+		}
+	}
+
+	for url := range previous.Pages {
+		if _, ok := current.Pages[url]; !ok {
+			changes = append(changes, Change{URL: url, Kind: ChangeRemoved})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].URL < changes[j].URL
+	})
+	return changes
+}
+
+// changeReason returns the validator that differs, or "" if the page is unchanged
+func changeReason(old, current PageState) string {
+	switch {
+	case old.ETag != "" && current.ETag != "":
+		if old.ETag != current.ETag {
+			return "etag"
+		}
+	case old.LastModified != "" && current.LastModified != "":
+		if old.LastModified != current.LastModified {
+			return "last-modified"
+		}
+	case old.ContentHash != current.ContentHash:
+		return "content"
+	}
+	return ""
+}
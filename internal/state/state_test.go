@@ -0,0 +1,98 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/output"
+)
+
+func TestCompare(t *testing.T) {
+	previous := New()
+	previous.Add(PageState{URL: "https://example.com/etag", ETag: `"v1"`, ContentHash: "a"})
+	previous.Add(PageState{URL: "https://example.com/etag-same", ETag: `"v1"`, ContentHash: "a"})
+	previous.Add(PageState{URL: "https://example.com/modified", LastModified: "Mon, 01 Jan 2024 00:00:00 GMT"})
+	previous.Add(PageState{URL: "https://example.com/content", ContentHash: "a"})
+	previous.Add(PageState{URL: "https://example.com/removed", ContentHash: "a"})
+
+	current := New()
+	current.Add(PageState{URL: "https://example.com/etag", ETag: `"v2"`, ContentHash: "a"})
+	// Same ETag wins over a differing content hash (e.g. dynamic timestamps)
+	current.Add(PageState{URL: "https://example.com/etag-same", ETag: `"v1"`, ContentHash: "b"})
+	current.Add(PageState{URL: "https://example.com/modified", LastModified: "Tue, 02 Jan 2024 00:00:00 GMT"})
+	current.Add(PageState{URL: "https://example.com/content", ContentHash: "b"})
+	current.Add(PageState{URL: "https://example.com/added", ContentHash: "a"})
+
+	expected := []Change{
+		{URL: "https://example.com/added", Kind: ChangeAdded},
+		{URL: "https://example.com/content", Kind: ChangeChanged, Reason: "content"},
+		{URL: "https://example.com/etag", Kind: ChangeChanged, Reason: "etag"},
+		{URL: "https://example.com/modified", Kind: ChangeChanged, Reason: "last-modified"},
+		{URL: "https://example.com/removed", Kind: ChangeRemoved},
+	}
+
+	if got := Compare(previous, current); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Compare() = %+v\nwant %+v", got, expected)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	s := New()
+	s.Add(PageState{URL: "https://example.com/", ETag: `"v1"`, ContentHash: "abc"})
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.Pages["https://example.com/"].ETag != `"v1"` {
+		t.Errorf("unexpected loaded state: %+v", loaded.Pages)
+	}
+
+	missing, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || len(missing.Pages) != 0 {
+		t.Errorf("missing file should yield empty state: %+v, %v", missing, err)
+	}
+}
+
+func TestCheckpointSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "crawl.state")
+
+	cp := &Checkpoint{
+		Seed:    "https://example.com/",
+		Visited: []string{"https://example.com/", "https://example.com/a"},
+		Pending: []PendingURL{{URL: "https://example.com/a", Depth: 1, Parent: "https://example.com/"}},
+		Results: []output.NDJSONResult{{URL: "https://example.com/", StatusCode: 200, Links: []string{"https://example.com/a"}}},
+		Stats:   CheckpointStats{TotalURLs: 2, CrawledURLs: 1, Elapsed: time.Minute},
+	}
+	if err := cp.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	// Saving again replaces the file without leaving temporary files behind
+	if err := cp.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected only the checkpoint file, got %d entries", len(entries))
+	}
+
+	loaded, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint() error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, cp) {
+		t.Errorf("LoadCheckpoint() = %+v\nwant %+v", loaded, cp)
+	}
+
+	if _, err := LoadCheckpoint(filepath.Join(dir, "missing.state")); err == nil {
+		t.Error("expected error for missing checkpoint")
+	}
+}
@@ -0,0 +1,29 @@
+package terminal
+
+import (
+	"io"
+	"os"
+	"strconv"
+)
+
+// IsTerminal reports whether w is an interactive terminal (a console on
+// Windows). Files, pipes and other writers are not.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && isTerminal(f)
+}
+
+// Width returns the number of columns of the terminal w writes to. When the
+// size cannot be queried it falls back to the COLUMNS environment variable,
+// and returns 0 if that is not set either.
+func Width(w io.Writer) int {
+	if f, ok := w.(*os.File); ok {
+		if width := width(f); width > 0 {
+			return width
+		}
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return 0
+}
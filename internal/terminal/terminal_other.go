@@ -0,0 +1,17 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly || windows)
+
+package terminal
+
+import "os"
+
+// isTerminal reports whether f is a character device, the best guess
+// available without terminal ioctls
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// width returns 0; the size of the terminal cannot be queried here
+func width(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,49 @@
+package terminal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsTerminal(t *testing.T) {
+	if IsTerminal(&bytes.Buffer{}) {
+		t.Error("buffer reported as terminal")
+	}
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if IsTerminal(file) {
+		t.Error("regular file reported as terminal")
+	}
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	if IsTerminal(devNull) {
+		t.Error("null device reported as terminal")
+	}
+}
+
+func TestWidth_Columns(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	if got := Width(&bytes.Buffer{}); got != 0 {
+		t.Errorf("Width() = %d, want 0", got)
+	}
+
+	t.Setenv("COLUMNS", "120")
+	if got := Width(&bytes.Buffer{}); got != 120 {
+		t.Errorf("Width() with COLUMNS=120 = %d, want 120", got)
+	}
+
+	t.Setenv("COLUMNS", "wide")
+	if got := Width(&bytes.Buffer{}); got != 0 {
+		t.Errorf("Width() with invalid COLUMNS = %d, want 0", got)
+	}
+}
@@ -0,0 +1,37 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package terminal
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the terminal size returned by TIOCGWINSZ
+type winsize struct {
+	rows, cols, xpixel, ypixel uint16
+}
+
+// getWinsize queries the size of the terminal f refers to
+func getWinsize(f *os.File) (winsize, bool) {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	return ws, errno == 0
+}
+
+// isTerminal reports whether f is a terminal; /dev/null and other character
+// devices that are not terminals have no window size
+func isTerminal(f *os.File) bool {
+	_, ok := getWinsize(f)
+	return ok
+}
+
+// width returns the columns of the terminal f, or 0 if unknown
+func width(f *os.File) int {
+	ws, ok := getWinsize(f)
+	if !ok {
+		return 0
+	}
+	return int(ws.cols)
+}
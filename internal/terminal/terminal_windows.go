@@ -0,0 +1,37 @@
+//go:build windows
+
+package terminal
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var procGetConsoleScreenBufferInfo = syscall.NewLazyDLL("kernel32.dll").NewProc("GetConsoleScreenBufferInfo")
+
+// consoleScreenBufferInfo mirrors CONSOLE_SCREEN_BUFFER_INFO
+type consoleScreenBufferInfo struct {
+	sizeX, sizeY                   int16
+	cursorX, cursorY               int16
+	attributes                     uint16
+	left, top, right, bottom       int16
+	maximumWindowX, maximumWindowY int16
+}
+
+// isTerminal reports whether f is a console. NUL and pipes, including the
+// pipes of mintty and CI runners, are not.
+func isTerminal(f *os.File) bool {
+	var mode uint32
+	return syscall.GetConsoleMode(syscall.Handle(f.Fd()), &mode) == nil
+}
+
+// width returns the columns of the console window f, or 0 if unknown
+func width(f *os.File) int {
+	var info consoleScreenBufferInfo
+	ok, _, _ := procGetConsoleScreenBufferInfo.Call(f.Fd(), uintptr(unsafe.Pointer(&info)))
+	if ok == 0 {
+		return 0
+	}
+	return int(info.right-info.left) + 1
+}
@@ -0,0 +1,55 @@
+package url
+
+import "strings"
+
+// hashRoutePrefixes start the fragments hash routers keep their routes in,
+// e.g. https://example.com/#/about or the older hashbang #!/about
+var hashRoutePrefixes = []string{"/", "!/"}
+
+// IsHashRoute reports whether the fragment of rawURL is a client-side route
+// of a hash router rather than an anchor in the page. rawURL may be relative,
+// e.g. "#/about".
+func IsHashRoute(rawURL string) bool {
+	return hashRoute(rawURL) != ""
+}
+
+// NormalizeHashRouteURL normalizes rawURL like NormalizeURL but keeps a hash
+// route in its fragment, so every client-side route of a single page app is
+// a URL of its own. The route loses its trailing slash, and the root route
+// (#/) is dropped as it is the page itself.
+func NormalizeHashRouteURL(rawURL string) (string, error) {
+	normalized, err := NormalizeURL(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if route := hashRoute(rawURL); route != "" {
+		normalized += "#" + route
+	}
+	return normalized, nil
+}
+
+// hashRoute returns the normalized hash route of rawURL, or "" if its
+// fragment is not a route or is the root route
+func hashRoute(rawURL string) string {
+	_, fragment, found := strings.Cut(strings.TrimSpace(rawURL), "#")
+	if !found {
+		return ""
+	}
+
+	for _, prefix := range hashRoutePrefixes {
+		if !strings.HasPrefix(fragment, prefix) {
+			continue
+		}
+		path, query, hasQuery := strings.Cut(fragment[len(prefix):], "?")
+		path = strings.TrimRight(path, "/")
+		if path == "" && query == "" {
+			return ""
+		}
+		route := prefix + path
+		if hasQuery && query != "" {
+			route += "?" + query
+		}
+		return route
+	}
+	return ""
+}
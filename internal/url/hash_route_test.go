@@ -0,0 +1,52 @@
+package url
+
+import "testing"
+
+func TestNormalizeHashRouteURL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"no fragment", "https://example.com/app/", "https://example.com/app"},
+		{"anchor dropped", "https://example.com/docs#install", "https://example.com/docs"},
+		{"hash route kept", "https://example.com/#/about", "https://example.com/#/about"},
+		{"hashbang route kept", "https://example.com/#!/about", "https://example.com/#!/about"},
+		{"trailing slash", "https://example.com/#/about/", "https://example.com/#/about"},
+		{"root route", "https://example.com/#/", "https://example.com/"},
+		{"route query", "https://example.com/#/search?q=go", "https://example.com/#/search?q=go"},
+		{"root route query", "https://example.com/#/?tab=2", "https://example.com/#/?tab=2"},
+		{"page path normalized", "https://example.com/app/#/users/1", "https://example.com/app#/users/1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeHashRouteURL(tt.in)
+			if err != nil {
+				t.Fatalf("NormalizeHashRouteURL(%q) failed: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeHashRouteURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsHashRoute(t *testing.T) {
+	tests := map[string]bool{
+		"#/about":                      true,
+		"#!/about":                     true,
+		"https://example.com/#/about":  true,
+		"#/":                           false,
+		"#top":                         false,
+		"https://example.com/#section": false,
+		"https://example.com/about":    false,
+		"https://example.com/a#b/c":    false,
+	}
+
+	for in, want := range tests {
+		if got := IsHashRoute(in); got != want {
+			t.Errorf("IsHashRoute(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package url
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// NextPage returns the URL of the page after a paginated URL and its page
+// number. Pagination is recognized by a page query parameter (?page=2) or a
+// page path segment (/page/2/); when a URL has both, the query wins. ok is
+// false for URLs that are not paginated.
+func NextPage(rawURL string) (next string, page int, ok bool) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", 0, false
+	}
+
+	// ?page=N, keeping the order of the other parameters
+	params := strings.Split(parsed.RawQuery, "&")
+	for i, param := range params {
+		name, value, found := strings.Cut(param, "=")
+		if !found || !strings.EqualFold(name, "page") {
+			continue
+		}
+		if n, ok := pageNumber(value); ok {
+			params[i] = name + "=" + strconv.Itoa(n+1)
+			parsed.RawQuery = strings.Join(params, "&")
+			return parsed.String(), n + 1, true
+		}
+	}
+
+	// /page/N, the last occurrence in the path
+	segments := strings.Split(parsed.Path, "/")
+	for i := len(segments) - 1; i > 0; i-- {
+		if !strings.EqualFold(segments[i-1], "page") {
+			continue
+		}
+		if n, ok := pageNumber(segments[i]); ok {
+			segments[i] = strconv.Itoa(n + 1)
+			parsed.Path = strings.Join(segments, "/")
+			parsed.RawPath = ""
+			return parsed.String(), n + 1, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// pageNumber parses a page number, which must be a positive integer
+func pageNumber(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || strings.HasPrefix(s, "+") {
+		return 0, false
+	}
+	return n, true
+}
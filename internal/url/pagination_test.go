@@ -0,0 +1,35 @@
+package url
+
+import "testing"
+
+func TestNextPage(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantNext string
+		wantPage int
+		wantOK   bool
+	}{
+		{"https://example.com/blog?page=2", "https://example.com/blog?page=3", 3, true},
+		{"https://example.com/search?q=go&page=1&sort=new", "https://example.com/search?q=go&page=2&sort=new", 2, true},
+		{"https://example.com/blog?Page=9", "https://example.com/blog?Page=10", 10, true},
+		{"https://example.com/blog/page/2", "https://example.com/blog/page/3", 3, true},
+		{"https://example.com/blog/page/2/", "https://example.com/blog/page/3/", 3, true},
+		{"https://example.com/page/1/tag/page/4", "https://example.com/page/1/tag/page/5", 5, true},
+		{"https://example.com/blog", "", 0, false},
+		{"https://example.com/blog?page=", "", 0, false},
+		{"https://example.com/blog?page=0", "", 0, false},
+		{"https://example.com/blog?page=-1", "", 0, false},
+		{"https://example.com/blog?page=last", "", 0, false},
+		{"https://example.com/blog?pages=2", "", 0, false},
+		{"https://example.com/page/about", "", 0, false},
+		{"https://example.com/products/2", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		next, page, ok := NextPage(tt.input)
+		if next != tt.wantNext || page != tt.wantPage || ok != tt.wantOK {
+			t.Errorf("NextPage(%q) = %q, %d, %v; want %q, %d, %v",
+				tt.input, next, page, ok, tt.wantNext, tt.wantPage, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package url
+
+import (
+	"net/url"
+	"strings"
+)
+
+// sessionParams are the lower-cased names of query and path parameters that
+// carry session IDs
+var sessionParams = map[string]bool{
+	"jsessionid": true,
+	"phpsessid":  true,
+	"sid":        true,
+	"sessionid":  true,
+	"session_id": true,
+	"cfid":       true,
+	"cftoken":    true,
+	"zenid":      true,
+	"oscsid":     true,
+}
+
+// IsSessionParam reports whether a query or path parameter name carries a
+// session ID. ASP.NET's ASPSESSIONID<suffix> names are matched by prefix.
+func IsSessionParam(name string) bool {
+	name = strings.ToLower(name)
+	return sessionParams[name] || strings.HasPrefix(name, "aspsessionid")
+}
+
+// StripSessionIDs removes session-ID query parameters and ;jsessionid style
+// path parameters from rawURL, keeping the order of the other parameters.
+// URLs that cannot be parsed are returned unchanged.
+func StripSessionIDs(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	changed := false
+	if i := strings.Index(parsed.Path, ";"); i >= 0 {
+		name, _, _ := strings.Cut(parsed.Path[i+1:], "=")
+		if IsSessionParam(name) {
+			parsed.Path = parsed.Path[:i]
+			parsed.RawPath = ""
+			changed = true
+		}
+	}
+
+	if parsed.RawQuery != "" {
+		params := strings.Split(parsed.RawQuery, "&")
+		kept := params[:0]
+		for _, param := range params {
+			name, _, _ := strings.Cut(param, "=")
+			if unescaped, err := url.QueryUnescape(name); err == nil {
+				name = unescaped
+			}
+			if IsSessionParam(name) {
+				changed = true
+				continue
+			}
+			kept = append(kept, param)
+		}
+		parsed.RawQuery = strings.Join(kept, "&")
+	}
+
+	if !changed {
+		return rawURL
+	}
+	return parsed.String()
+}
@@ -0,0 +1,30 @@
+package url
+
+import "testing"
+
+func TestStripSessionIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"no parameters", "https://example.com/page", "https://example.com/page"},
+		{"other parameters kept", "https://example.com/?page=2&q=go", "https://example.com/?page=2&q=go"},
+		{"PHPSESSID", "https://example.com/list?PHPSESSID=abc&page=2", "https://example.com/list?page=2"},
+		{"only session parameter", "https://example.com/list?sid=abc", "https://example.com/list"},
+		{"order preserved", "https://example.com/?b=1&sid=x&a=2", "https://example.com/?b=1&a=2"},
+		{"ASP.NET", "https://example.com/?ASPSESSIONIDQQGGQGPG=abc", "https://example.com/"},
+		{"jsessionid path parameter", "https://example.com/cart;jsessionid=ABC123?item=1", "https://example.com/cart?item=1"},
+		{"other path parameter", "https://example.com/a;v=1", "https://example.com/a;v=1"},
+		{"similar name kept", "https://example.com/?side=left", "https://example.com/?side=left"},
+		{"fragment kept", "https://example.com/?sid=1#top", "https://example.com/#top"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripSessionIDs(tt.in); got != tt.want {
+				t.Errorf("StripSessionIDs(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package url
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Path segment patterns replaced by placeholders, checked in order
+var templateSegments = []struct {
+	pattern     *regexp.Regexp
+	placeholder string
+}{
+	{regexp.MustCompile(`^[0-9]+$`), "{id}"},
+	{regexp.MustCompile(`^(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`), "{uuid}"},
+	{regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`), "{date}"},
+	{regexp.MustCompile(`^(?i)[0-9a-f]{16,}$`), "{hash}"},
+	{regexp.MustCompile(`^[a-zA-Z0-9]+(-[a-zA-Z0-9]+)*-[0-9]+$`), "{slug-id}"},
+}
+
+// PathTemplate returns the host and path of a URL with variable segments
+// replaced by placeholders, e.g. "example.com/products/{id}" for
+// https://example.com/products/42?color=red. The query and fragment are dropped.
+func PathTemplate(rawURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return rawURL
+	}
+
+	segments := strings.Split(parsed.Path, "/")
+	for i, segment := range segments {
+		for _, s := range templateSegments {
+			if s.pattern.MatchString(segment) {
+				segments[i] = s.placeholder
+				break
+			}
+		}
+	}
+
+	path := strings.Join(segments, "/")
+	if path == "" {
+		path = "/"
+	}
+	return strings.ToLower(parsed.Host) + path
+}
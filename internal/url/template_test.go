@@ -0,0 +1,27 @@
+package url
+
+import "testing"
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"https://example.com/products/42", "example.com/products/{id}"},
+		{"https://example.com/products/42?color=red#top", "example.com/products/{id}"},
+		{"https://example.com/products/42/reviews/7", "example.com/products/{id}/reviews/{id}"},
+		{"https://example.com/orders/3f2504e0-4f89-11d3-9a0c-0305e82c3301", "example.com/orders/{uuid}"},
+		{"https://example.com/blog/2024-05-01/launch", "example.com/blog/{date}/launch"},
+		{"https://example.com/assets/d41d8cd98f00b204e9800998ecf8427e", "example.com/assets/{hash}"},
+		{"https://example.com/items/blue-shoe-123", "example.com/items/{slug-id}"},
+		{"https://example.com/products/", "example.com/products/"},
+		{"https://Example.com/about", "example.com/about"},
+		{"https://example.com", "example.com/"},
+	}
+
+	for _, tt := range tests {
+		if got := PathTemplate(tt.input); got != tt.expected {
+			t.Errorf("PathTemplate(%q) = %q; want %q", tt.input, got, tt.expected)
+		}
+	}
+}
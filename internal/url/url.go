@@ -1,8 +1,10 @@
 package url
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"strings"
 )
@@ -199,3 +201,20 @@ func ShouldSkipURL(rawURL string) bool {
 
 	return false
 }
+
+// ReadURLList reads one URL per line, skipping blank lines and '#' comments
+func ReadURLList(r io.Reader) ([]string, error) {
+	var urls []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read URL list: %w", err)
+	}
+	return urls, nil
+}
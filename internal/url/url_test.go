@@ -1,6 +1,7 @@
 package url
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -304,3 +305,19 @@ func TestShouldSkipURL(t *testing.T) {
 		})
 	}
 }
+
+func TestReadURLList(t *testing.T) {
+	input := `
+# comment
+https://example.com/a
+
+  https://example.com/b
+`
+	urls, err := ReadURLList(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadURLList() error: %v", err)
+	}
+	if len(urls) != 2 || urls[0] != "https://example.com/a" || urls[1] != "https://example.com/b" {
+		t.Errorf("unexpected URLs: %v", urls)
+	}
+}
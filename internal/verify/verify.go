@@ -0,0 +1,181 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/progress"
+	"github.com/aoshimash/urlmap/internal/url"
+)
+
+// Result holds the outcome of verifying a single URL
+type Result struct {
+	URL          string        // URL as provided
+	FinalURL     string        // URL after following redirects
+	StatusCode   int           // Final HTTP status code (0 if the request failed)
+	ResponseTime time.Duration // Time taken including redirects
+	Error        error         // Network error or non-success status
+	Server       string        // Server header of an HTTP error response
+	ErrorBody    string        // Start of the body of an HTTP error response (only with ErrorBodySize)
+	ErrorKind    string        // DNS, TCP or TLS failure (client.FailureDNS etc.), "" otherwise
+}
+
+// Config holds configuration for the verifier
+type Config struct {
+	Client   *client.Client             // HTTP client used for requests
+	Workers  int                        // Number of concurrent requests
+	Progress *progress.ProgressReporter // Progress reporter (optional)
+	Logger   *slog.Logger               // Logger instance
+
+	// ErrorBodySize is how many bytes of the body of HTTP error responses
+	// are kept in results (0 = none)
+	ErrorBodySize int
+}
+
+// Verifier fetches a fixed list of URLs without following links
+type Verifier struct {
+	client   *client.Client
+	workers  int
+	progress *progress.ProgressReporter
+	logger   *slog.Logger
+	bodySize int
+}
+
+// New creates a new verifier
+func New(config *Config) *Verifier {
+	if config == nil {
+		config = &Config{}
+	}
+
+	httpClient := config.Client
+	if httpClient == nil {
+		httpClient = client.NewDefaultClient()
+	}
+
+	workers := config.Workers
+	if workers <= 0 {
+		workers = 10
+	}
+
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Verifier{
+		client:   httpClient,
+		workers:  workers,
+		progress: config.Progress,
+		logger:   logger,
+		bodySize: config.ErrorBodySize,
+	}
+}
+
+// Verify fetches every URL concurrently and returns results in input order
+func (v *Verifier) Verify(ctx context.Context, urls []string) []Result {
+	results := make([]Result, len(urls))
+	indexes := make(chan int)
+
+	if v.progress != nil {
+		v.progress.UpdateStats(0, int64(len(urls)), 0, 0, v.workers, len(urls))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < v.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				results[index] = v.verifyOne(ctx, urls[index])
+			}
+		}()
+	}
+
+	for i := range urls {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			// Mark the remaining URLs as cancelled
+			for j := i; j < len(urls); j++ {
+				results[j] = Result{URL: urls[j], Error: ctx.Err()}
+			}
+			close(indexes)
+			wg.Wait()
+			return results
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// verifyOne fetches a single URL
+func (v *Verifier) verifyOne(ctx context.Context, targetURL string) Result {
+	result := Result{URL: targetURL}
+
+	if v.progress != nil {
+		v.progress.WaitForRateLimit()
+		defer v.progress.IncrementProcessed()
+	}
+
+	if !url.IsValidURL(targetURL) {
+		result.Error = fmt.Errorf("invalid URL: %s", targetURL)
+		v.markFailed()
+		return result
+	}
+
+	// Keep to the per-host rate limit
+	if v.progress != nil {
+		host, _ := url.ExtractDomain(targetURL)
+		if err := v.progress.WaitForHostRateLimit(ctx, host); err != nil {
+			result.Error = err
+			v.markFailed()
+			return result
+		}
+	}
+
+	startTime := time.Now()
+	resp, err := v.client.Get(ctx, targetURL)
+	result.ResponseTime = time.Since(startTime)
+
+	if err != nil {
+		result.Error = fmt.Errorf("failed to fetch URL: %w", err)
+		result.ErrorKind = client.FailureKind(err)
+		v.markFailed()
+		v.logger.Warn("Verification failed", "url", targetURL, "error", err, "kind", result.ErrorKind)
+		return result
+	}
+
+	result.StatusCode = resp.StatusCode()
+	result.FinalURL = targetURL
+	if resp.RawResponse != nil && resp.RawResponse.Request != nil {
+		result.FinalURL = resp.RawResponse.Request.URL.String()
+	}
+
+	if resp.StatusCode() < 200 || resp.StatusCode() >= 400 {
+		result.Error = fmt.Errorf("HTTP error: %d", resp.StatusCode())
+		result.Server = resp.Header().Get("Server")
+		result.ErrorBody = client.BodySnippet(resp.String(), v.bodySize)
+		v.markFailed()
+	}
+
+	v.logger.Info("Verified URL",
+		"url", targetURL,
+		"status_code", result.StatusCode,
+		"final_url", result.FinalURL,
+		"duration", result.ResponseTime)
+
+	return result
+}
+
+// markFailed records a failure in the progress reporter
+func (v *Verifier) markFailed() {
+	if v.progress != nil {
+		v.progress.IncrementFailed()
+	}
+}
@@ -0,0 +1,130 @@
+package verify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aoshimash/urlmap/internal/client"
+	"github.com/aoshimash/urlmap/internal/progress"
+)
+
+func newTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestClient() *client.Client {
+	config := client.DefaultConfig()
+	config.RetryCount = 0
+	config.Timeout = 5 * time.Second
+	return client.NewClient(config)
+}
+
+func TestVerify(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	urls := []string{
+		server.URL + "/ok",
+		server.URL + "/old",
+		server.URL + "/missing",
+		"not-a-url",
+	}
+
+	reporter := progress.NewProgressReporter(&progress.Config{ShowProgress: false})
+	verifier := New(&Config{Client: newTestClient(), Workers: 2, Progress: reporter})
+	results := verifier.Verify(context.Background(), urls)
+
+	if len(results) != len(urls) {
+		t.Fatalf("expected %d results, got %d", len(urls), len(results))
+	}
+
+	for i, result := range results {
+		if result.URL != urls[i] {
+			t.Errorf("result %d out of order: %s", i, result.URL)
+		}
+	}
+
+	if results[0].StatusCode != 200 || results[0].Error != nil {
+		t.Errorf("unexpected /ok result: %+v", results[0])
+	}
+	if results[1].FinalURL != server.URL+"/ok" {
+		t.Errorf("redirect target not recorded: %+v", results[1])
+	}
+	if results[2].StatusCode != 404 || results[2].Error == nil {
+		t.Errorf("404 should be reported as error: %+v", results[2])
+	}
+	if results[3].Error == nil {
+		t.Errorf("invalid URL should be reported as error: %+v", results[3])
+	}
+
+	stats := reporter.GetStats()
+	if stats.URLsProcessed != 4 || stats.URLsFailed != 2 {
+		t.Errorf("unexpected progress stats: processed=%d failed=%d", stats.URLsProcessed, stats.URLsFailed)
+	}
+}
+
+func TestVerifyErrorBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "AkamaiGHost")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("<H1>Access Denied</H1>\n\nReference #18.2f"))
+	}))
+	defer server.Close()
+
+	for _, size := range []int{0, 512} {
+		verifier := New(&Config{Client: newTestClient(), ErrorBodySize: size})
+		result := verifier.Verify(context.Background(), []string{server.URL})[0]
+
+		want := ""
+		if size > 0 {
+			want = "<H1>Access Denied</H1> Reference #18.2f"
+		}
+		if result.Server != "AkamaiGHost" || result.ErrorBody != want {
+			t.Errorf("ErrorBodySize %d: server %q, error body %q", size, result.Server, result.ErrorBody)
+		}
+	}
+}
+
+func TestVerifyErrorKind(t *testing.T) {
+	server := newTestServer()
+	server.Close() // Nothing listens on the port any more
+
+	result := New(&Config{Client: newTestClient()}).Verify(context.Background(), []string{server.URL + "/ok"})[0]
+	if result.Error == nil || result.ErrorKind != client.FailureTCP {
+		t.Errorf("expected a TCP failure, got %+v", result)
+	}
+}
+
+func TestVerifyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	verifier := New(&Config{Client: newTestClient(), Workers: 1})
+	results := verifier.Verify(ctx, []string{"http://127.0.0.1:1/a", "http://127.0.0.1:1/b"})
+
+	for _, result := range results {
+		if result.Error == nil {
+			t.Errorf("expected error for cancelled verification: %+v", result)
+		}
+	}
+}
+
+func TestNewDefaults(t *testing.T) {
+	v := New(nil)
+	if v.client == nil || v.logger == nil || v.workers != 10 {
+		t.Errorf("unexpected defaults: %+v", v)
+	}
+}
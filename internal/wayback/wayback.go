@@ -0,0 +1,127 @@
+// Package wayback looks up the historical URLs of a site in the Internet
+// Archive's CDX index, so pages the live site no longer links to can be
+// checked.
+package wayback
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultEndpoint is the Internet Archive's CDX API
+const DefaultEndpoint = "https://web.archive.org/cdx/search/cdx"
+
+// DefaultLimit is the number of distinct URLs requested from the index
+const DefaultLimit = 5000
+
+// Client queries a CDX index
+type Client struct {
+	Endpoint  string       // CDX API URL (DefaultEndpoint if empty)
+	Limit     int          // Maximum number of URLs (DefaultLimit if zero)
+	UserAgent string       // User-Agent header of index requests
+	HTTP      *http.Client // HTTP client (a client with a 60s timeout if nil)
+	Logger    *slog.Logger // Logger instance (slog.Default if nil)
+}
+
+// URLs returns the distinct URLs archived under seed's host and path that
+// were captured as HTML pages answering 200. They are rewritten to the
+// scheme and host of seed, since archives often hold the http:// or :80
+// form of pages now served over https.
+func (c *Client) URLs(ctx context.Context, seed string) ([]string, error) {
+	base, err := neturl.Parse(seed)
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid seed URL: %s", seed)
+	}
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	limit := c.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	logger := c.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	query := neturl.Values{}
+	query.Set("url", base.Host+strings.TrimSuffix(base.Path, "/")+"/")
+	query.Set("matchType", "prefix")
+	query.Set("output", "json")
+	query.Set("fl", "original")
+	query.Set("collapse", "urlkey")
+	query.Add("filter", "statuscode:200")
+	query.Add("filter", "mimetype:text/html")
+	query.Set("limit", strconv.Itoa(limit))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CDX request: %w", err)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	logger.Debug("Querying web archive", "url", req.URL.String())
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query web archive: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("web archive returned status %d", resp.StatusCode)
+	}
+
+	// Rows of the requested fields, after a header row; no rows at all
+	// for a site without captures
+	var rows [][]string
+	if err := json.NewDecoder(resp.Body).Decode(&rows); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse web archive response: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var urls []string
+	for i, row := range rows {
+		if i == 0 || len(row) == 0 {
+			continue
+		}
+		u, ok := onSeedHost(row[0], base)
+		if !ok || seen[u] {
+			continue
+		}
+		seen[u] = true
+		urls = append(urls, u)
+	}
+	return urls, nil
+}
+
+// onSeedHost moves an archived URL to the scheme and host of seed
+func onSeedHost(archived string, seed *neturl.URL) (string, bool) {
+	u, err := neturl.Parse(archived)
+	if err != nil || !strings.EqualFold(u.Hostname(), seed.Hostname()) {
+		return "", false
+	}
+	u.Scheme = seed.Scheme
+	u.Host = seed.Host
+	u.Fragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u.String(), true
+}
@@ -0,0 +1,68 @@
+package wayback
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientURLs(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		fmt.Fprint(w, `[["original"],
+			["http://example.com:80/docs/old-page"],
+			["http://example.com/docs/old-page"],
+			["http://EXAMPLE.com/docs/guide?lang=en"],
+			["http://other.example.com/docs/"],
+			["http://example.com/docs/#top"]]`)
+	}))
+	defer server.Close()
+
+	archive := &Client{Endpoint: server.URL, Limit: 10}
+	urls, err := archive.URLs(context.Background(), "https://example.com/docs/")
+	if err != nil {
+		t.Fatalf("URLs: %v", err)
+	}
+
+	expected := []string{
+		"https://example.com/docs/old-page",
+		"https://example.com/docs/guide?lang=en",
+		"https://example.com/docs/",
+	}
+	if strings.Join(urls, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected %v, got %v", expected, urls)
+	}
+
+	for _, param := range []string{"url=example.com%2Fdocs%2F", "matchType=prefix", "filter=statuscode%3A200", "limit=10"} {
+		if !strings.Contains(query, param) {
+			t.Errorf("query %q is missing %s", query, param)
+		}
+	}
+}
+
+func TestClientURLsEmpty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	archive := &Client{Endpoint: server.URL}
+	urls, err := archive.URLs(context.Background(), "https://example.com")
+	if err != nil || len(urls) != 0 {
+		t.Errorf("expected no URLs for a site without captures, got %v, %v", urls, err)
+	}
+}
+
+func TestClientURLsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	archive := &Client{Endpoint: server.URL}
+	if _, err := archive.URLs(context.Background(), "https://example.com"); err == nil {
+		t.Error("expected error for a failing archive")
+	}
+}
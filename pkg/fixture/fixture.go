@@ -0,0 +1,177 @@
+package fixture
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrNotRecorded is returned by a Replayer for requests without a fixture
+var ErrNotRecorded = errors.New("request not recorded")
+
+// Exchange is a recorded request/response pair, stored as one JSON file
+type Exchange struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Request    http.Header `json:"request_headers,omitempty"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"response_headers,omitempty"`
+	Body       string      `json:"body,omitempty"`        // Response body, if valid UTF-8
+	BodyBase64 string      `json:"body_base64,omitempty"` // Response body otherwise
+}
+
+// Recorder is an http.RoundTripper that forwards requests and saves every
+// exchange to a fixtures directory
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+}
+
+// Record returns a Recorder writing to dir. Requests are sent with next
+// (http.DefaultTransport if nil). Recording a request again overwrites its
+// fixture, so fixtures can be refreshed by re-running the test.
+func Record(dir string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, next: next}
+}
+
+// RoundTrip sends req and records the response
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response for recording: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	exchange := Exchange{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Request:    req.Header.Clone(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+	}
+	if utf8.Valid(body) {
+		exchange.Body = string(body)
+	} else {
+		exchange.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+	if err := r.save(exchange); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes the fixture file of an exchange
+func (r *Recorder) save(exchange Exchange) error {
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create fixtures directory: %w", err)
+	}
+	path := filepath.Join(r.dir, fixtureName(exchange.Method, exchange.URL))
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// Replayer is an http.RoundTripper that answers requests from recorded
+// fixtures without using the network
+type Replayer struct {
+	exchanges map[string]Exchange
+}
+
+// Replay loads the fixtures recorded in dir
+func Replay(dir string) (*Replayer, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	replayer := &Replayer{exchanges: make(map[string]Exchange, len(paths))}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		var exchange Exchange
+		if err := json.Unmarshal(data, &exchange); err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", filepath.Base(path), err)
+		}
+		replayer.exchanges[exchangeKey(exchange.Method, exchange.URL)] = exchange
+	}
+	return replayer, nil
+}
+
+// RoundTrip answers req from its fixture, failing with ErrNotRecorded if there is none
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	exchange, ok := r.exchanges[exchangeKey(req.Method, req.URL.String())]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, req.Method, req.URL)
+	}
+
+	body := []byte(exchange.Body)
+	if exchange.BodyBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(exchange.BodyBase64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixture body for %s: %w", req.URL, err)
+		}
+		body = decoded
+	}
+
+	header := exchange.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", exchange.StatusCode, http.StatusText(exchange.StatusCode)),
+		StatusCode:    exchange.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// Len returns the number of recorded exchanges
+func (r *Replayer) Len() int {
+	return len(r.exchanges)
+}
+
+// exchangeKey identifies the exchange of a request
+func exchangeKey(method, rawURL string) string {
+	return strings.ToUpper(method) + " " + rawURL
+}
+
+// fixtureName returns a stable file name for the exchange of a request
+func fixtureName(method, rawURL string) string {
+	sum := sha256.Sum256([]byte(exchangeKey(method, rawURL)))
+	return hex.EncodeToString(sum[:8]) + ".json"
+}
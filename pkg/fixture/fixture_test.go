@@ -0,0 +1,92 @@
+package fixture
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/", http.StatusMovedPermanently)
+		case "/logo.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte{0x89, 'P', 'N', 'G', 0xff, 0x00})
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html>home</html>"))
+		}
+	}))
+
+	dir := t.TempDir()
+	recording := &http.Client{Transport: Record(dir, nil)}
+	for _, path := range []string{"/old", "/logo.png"} {
+		resp, err := recording.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("recording %s failed: %v", path, err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	server.Close()
+
+	replayer, err := Replay(dir)
+	if err != nil {
+		t.Fatalf("Replay() failed: %v", err)
+	}
+	if replayer.Len() != 3 {
+		t.Errorf("expected 3 exchanges (redirect, page, image), got %d", replayer.Len())
+	}
+
+	replaying := &http.Client{Transport: replayer}
+	resp, err := replaying.Get(server.URL + "/old")
+	if err != nil {
+		t.Fatalf("replaying /old failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != 200 || string(body) != "<html>home</html>" || resp.Request.URL.Path != "/" {
+		t.Errorf("redirect not replayed: %d %q", resp.StatusCode, body)
+	}
+	if resp.Header.Get("Content-Type") != "text/html" {
+		t.Errorf("headers not replayed: %v", resp.Header)
+	}
+
+	resp, err = replaying.Get(server.URL + "/logo.png")
+	if err != nil {
+		t.Fatalf("replaying /logo.png failed: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != string([]byte{0x89, 'P', 'N', 'G', 0xff, 0x00}) {
+		t.Errorf("binary body not replayed: %v", body)
+	}
+
+	if _, err := replaying.Get(server.URL + "/missing"); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("expected ErrNotRecorded, got %v", err)
+	}
+}
+
+func TestReplay_InvalidFixture(t *testing.T) {
+	dir := t.TempDir()
+	recorder := Record(dir, nil)
+	if err := recorder.save(Exchange{Method: "GET", URL: "https://example.com/", StatusCode: 200}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Replay(dir); err != nil {
+		t.Fatalf("Replay() failed: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Replay(dir); err == nil {
+		t.Error("expected an error for an invalid fixture")
+	}
+}
@@ -0,0 +1,141 @@
+package testsite
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Site serves a synthetic site: Pages pages forming a tree in which every
+// page links to Fanout child pages and back to the home page. Page i links
+// to pages i*Fanout+1 through (i+1)*Fanout.
+type Site struct {
+	Pages   int           // Number of pages, including the home page
+	Fanout  int           // Child pages linked from each page
+	Latency time.Duration // Delay before every response (0 = none)
+
+	// ErrorEvery makes every page whose number is a multiple of ErrorEvery
+	// answer ErrorStatus instead; the home page never fails (0 = no errors).
+	// The children of a failing page are not linked from anywhere else.
+	ErrorEvery int
+	// ErrorStatus is the status of injected errors (0 = 500)
+	ErrorStatus int
+
+	// SPA serves every page as an empty shell whose links are added by
+	// JavaScript, so only a crawl rendering JavaScript finds them
+	SPA bool
+}
+
+// PagePath returns the path of page i ("/" for the home page)
+func PagePath(i int) string {
+	if i == 0 {
+		return "/"
+	}
+	return "/p/" + strconv.Itoa(i)
+}
+
+// Start serves the site on a new httptest.Server; the caller must Close it
+func (s Site) Start() *httptest.Server {
+	return httptest.NewServer(s)
+}
+
+// ServeHTTP serves the page at r's path, or 404 for unknown paths
+func (s Site) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.Latency > 0 {
+		select {
+		case <-time.After(s.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	page, ok := s.pageIndex(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if s.fails(page) {
+		status := s.ErrorStatus
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	links := []link{{PagePath(0), "Home"}}
+	for child := page*s.Fanout + 1; child <= (page+1)*s.Fanout && child < s.Pages; child++ {
+		links = append(links, link{PagePath(child), fmt.Sprintf("Page %d", child)})
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if s.SPA {
+		w.Write([]byte(spaPage(page, links)))
+		return
+	}
+	w.Write([]byte(staticPage(page, links)))
+}
+
+// fails reports whether page answers with an injected error
+func (s Site) fails(page int) bool {
+	return s.ErrorEvery > 0 && page > 0 && page%s.ErrorEvery == 0
+}
+
+// pageIndex maps a path to its page number
+func (s Site) pageIndex(path string) (int, bool) {
+	if path == "/" || path == "" {
+		return 0, s.Pages > 0
+	}
+	number, ok := strings.CutPrefix(path, "/p/")
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(number)
+	if err != nil || i < 1 || i >= s.Pages {
+		return 0, false
+	}
+	return i, true
+}
+
+// link is an anchor on a generated page
+type link struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// staticPage renders page with its links as HTML anchors
+func staticPage(page int, links []link) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!DOCTYPE html><html><head><title>Page %d</title></head><body>\n", page)
+	fmt.Fprintf(&b, "<h1>Page %d</h1><p>Synthetic test page with some text to parse.</p>\n<nav>", page)
+	for _, l := range links {
+		fmt.Fprintf(&b, `<a href="%s">%s</a>`, l.Href, l.Text)
+	}
+	b.WriteString("</nav></body></html>\n")
+	return b.String()
+}
+
+// spaPage renders page as a single-page-app shell that adds its links at runtime
+func spaPage(page int, links []link) string {
+	data, _ := json.Marshal(links)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!DOCTYPE html><html><head><title>Page %d</title></head><body>\n", page)
+	b.WriteString(`<div id="root"></div>` + "\n<script>\n")
+	fmt.Fprintf(&b, "const links = %s;\n", data)
+	b.WriteString(`const nav = document.createElement("nav");
+for (const l of links) {
+  const a = document.createElement("a");
+  a.href = l.href;
+  a.textContent = l.text;
+  nav.appendChild(a);
+}
+document.getElementById("root").appendChild(nav);
+</script></body></html>
+`)
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package testsite
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSite(t *testing.T) {
+	site := Site{Pages: 5, Fanout: 2}
+
+	tests := []struct {
+		path      string
+		status    int
+		links     []string
+		notLinked []string
+	}{
+		{path: "/", status: http.StatusOK, links: []string{`href="/p/1"`, `href="/p/2"`}, notLinked: []string{`href="/p/3"`}},
+		{path: "/p/1", status: http.StatusOK, links: []string{`href="/"`, `href="/p/3"`, `href="/p/4"`}},
+		{path: "/p/2", status: http.StatusOK, links: []string{`href="/"`}, notLinked: []string{`href="/p/5"`}},
+		{path: "/p/5", status: http.StatusNotFound},
+		{path: "/p/0", status: http.StatusNotFound},
+		{path: "/p/x", status: http.StatusNotFound},
+		{path: "/other", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			site.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+			body, _ := io.ReadAll(recorder.Body)
+			for _, link := range tt.links {
+				if !strings.Contains(string(body), link) {
+					t.Errorf("body does not contain %s", link)
+				}
+			}
+			for _, link := range tt.notLinked {
+				if strings.Contains(string(body), link) {
+					t.Errorf("body contains %s", link)
+				}
+			}
+		})
+	}
+}
+
+func TestSite_Errors(t *testing.T) {
+	site := Site{Pages: 10, Fanout: 3, ErrorEvery: 3}
+
+	for path, want := range map[string]int{"/": 200, "/p/1": 200, "/p/3": 500, "/p/6": 500, "/p/7": 200} {
+		recorder := httptest.NewRecorder()
+		site.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if recorder.Code != want {
+			t.Errorf("%s: status = %d, want %d", path, recorder.Code, want)
+		}
+	}
+
+	site.ErrorStatus = http.StatusServiceUnavailable
+	recorder := httptest.NewRecorder()
+	site.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/p/3", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestSite_SPA(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	Site{Pages: 3, Fanout: 2, SPA: true}.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	body := recorder.Body.String()
+	if strings.Contains(body, "<a ") {
+		t.Error("SPA page contains static anchors")
+	}
+	if !strings.Contains(body, `{"href":"/p/1","text":"Page 1"}`) || !strings.Contains(body, `id="root"`) {
+		t.Errorf("SPA page does not embed its links:\n%s", body)
+	}
+}
+
+func TestSite_Latency(t *testing.T) {
+	server := Site{Pages: 1, Latency: 50 * time.Millisecond}.Start()
+	defer server.Close()
+
+	start := time.Now()
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("response took %v, want at least 50ms", elapsed)
+	}
+}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/aoshimash/urlmap/pkg/testsite"
 )
 
 const (
@@ -129,6 +131,22 @@ func TestCrawlCommand_BasicFunctionality(t *testing.T) {
 	}
 }
 
+func TestCrawlCommand_SyntheticSite(t *testing.T) {
+	site := testsite.Site{Pages: 20, Fanout: 4}
+	server := site.Start()
+	defer server.Close()
+
+	binaryPath, cleanup := setupCLITest(t)
+	defer cleanup()
+
+	output, err := exec.Command(binaryPath, "--concurrent", "10", "--progress=false", server.URL).Output()
+	require.NoError(t, err)
+
+	for i := 0; i < site.Pages; i++ {
+		assert.Contains(t, string(output), server.URL+testsite.PagePath(i)+"\n")
+	}
+}
+
 func TestCrawlCommand_WithDepth(t *testing.T) {
 	// Setup test server
 	server := createTestServer()
@@ -2,7 +2,6 @@ package shared
 
 import (
 	"fmt"
-	"net/http"
 	"net/http/httptest"
 	"os"
 	"os/exec"
@@ -11,6 +10,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/aoshimash/urlmap/pkg/testsite"
 )
 
 // TestEnvironment represents a test environment setup
@@ -29,7 +30,7 @@ func SetupTestEnvironment(t *testing.T) *TestEnvironment {
 	}
 
 	// Create test server
-	server := CreateBasicTestServer()
+	server := testsite.StartBasic()
 
 	// Build binary
 	binaryPath := BuildTestBinary(t)
@@ -54,66 +55,6 @@ func (te *TestEnvironment) Cleanup() {
 	}
 }
 
-// CreateBasicTestServer creates a basic test server for testing
-func CreateBasicTestServer() *httptest.Server {
-	mux := http.NewServeMux()
-
-	// Basic HTML pages
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprint(w, `<!DOCTYPE html>
-<html>
-<head><title>Test Home</title></head>
-<body>
-    <h1>Test Home Page</h1>
-    <a href="/page1">Page 1</a>
-    <a href="/page2">Page 2</a>
-    <a href="/nested/deep">Deep Nested</a>
-</body>
-</html>`)
-	})
-
-	mux.HandleFunc("/page1", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprint(w, `<!DOCTYPE html>
-<html>
-<head><title>Page 1</title></head>
-<body>
-    <h1>Page 1</h1>
-    <a href="/">Home</a>
-    <a href="/page2">Page 2</a>
-</body>
-</html>`)
-	})
-
-	mux.HandleFunc("/page2", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprint(w, `<!DOCTYPE html>
-<html>
-<head><title>Page 2</title></head>
-<body>
-    <h1>Page 2</h1>
-    <a href="/">Home</a>
-    <a href="/page1">Page 1</a>
-</body>
-</html>`)
-	})
-
-	mux.HandleFunc("/nested/deep", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprint(w, `<!DOCTYPE html>
-<html>
-<head><title>Deep Page</title></head>
-<body>
-    <h1>Deep Nested Page</h1>
-    <a href="/">Home</a>
-</body>
-</html>`)
-	})
-
-	return httptest.NewServer(mux)
-}
-
 // findProjectRoot finds the project root directory
 func findProjectRoot() (string, error) {
 	// Get the current file's directory
@@ -221,175 +162,8 @@ func AssertStringNotContains(t *testing.T, str, substr, context string) {
 	}
 }
 
-// CreateServerWithError creates a test server that returns errors for certain paths
-func CreateServerWithError(errorPaths []string) *httptest.Server {
-	mux := http.NewServeMux()
-
-	// Default handler
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		// Check if this path should return an error
-		for _, errorPath := range errorPaths {
-			if r.URL.Path == errorPath {
-				w.WriteHeader(http.StatusInternalServerError)
-				fmt.Fprint(w, "Internal Server Error")
-				return
-			}
-		}
-
-		// Normal response
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<!DOCTYPE html>
-<html>
-<head><title>Test Page</title></head>
-<body>
-    <h1>Test Page: %s</h1>
-    <a href="/test1">Test 1</a>
-    <a href="/test2">Test 2</a>
-    <a href="/error">Error Page</a>
-</body>
-</html>`, r.URL.Path)
-	})
-
-	return httptest.NewServer(mux)
-}
-
-// CreateSlowServer creates a test server with slow responses
-func CreateSlowServer(delay time.Duration) *httptest.Server {
-	mux := http.NewServeMux()
-
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		time.Sleep(delay)
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprint(w, `<!DOCTYPE html>
-<html>
-<head><title>Slow Page</title></head>
-<body>
-    <h1>Slow Loading Page</h1>
-    <p>This page took a while to load.</p>
-</body>
-</html>`)
-	})
-
-	return httptest.NewServer(mux)
-}
-
 // GetProjectRoot returns the project root directory
 func GetProjectRoot() string {
 	_, filename, _, _ := runtime.Caller(0)
 	return filepath.Join(filepath.Dir(filename), "..", "..")
 }
-
-// CreateComplexSiteServer creates a test server with a complex site structure
-func CreateComplexSiteServer() *httptest.Server {
-	mux := http.NewServeMux()
-
-	// Home page with multiple navigation links
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprint(w, `<!DOCTYPE html>
-<html>
-<head><title>Complex Site Home</title></head>
-<body>
-    <nav>
-        <a href="/about">About</a>
-        <a href="/products">Products</a>
-        <a href="/blog">Blog</a>
-        <a href="/contact">Contact</a>
-    </nav>
-    <main>
-        <h1>Welcome to Complex Site</h1>
-        <p>This is a complex site for testing.</p>
-        <section>
-            <a href="/services">Services</a>
-            <a href="/support">Support</a>
-        </section>
-    </main>
-</body>
-</html>`)
-	})
-
-	// About page
-	mux.HandleFunc("/about", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprint(w, `<!DOCTYPE html>
-<html>
-<head><title>About Us</title></head>
-<body>
-    <h1>About Us</h1>
-    <p>Learn about our company.</p>
-    <a href="/">Home</a>
-    <a href="/team">Team</a>
-    <a href="/history">History</a>
-</body>
-</html>`)
-	})
-
-	// Products page with subcategories
-	mux.HandleFunc("/products", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprint(w, `<!DOCTYPE html>
-<html>
-<head><title>Products</title></head>
-<body>
-    <h1>Our Products</h1>
-    <div class="categories">
-        <a href="/products/software">Software</a>
-        <a href="/products/hardware">Hardware</a>
-        <a href="/products/services">Services</a>
-    </div>
-    <a href="/">Home</a>
-</body>
-</html>`)
-	})
-
-	// Blog page with posts
-	mux.HandleFunc("/blog", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprint(w, `<!DOCTYPE html>
-<html>
-<head><title>Blog</title></head>
-<body>
-    <h1>Blog</h1>
-    <div class="posts">
-        <a href="/blog/post1">How to Use Our Product</a>
-        <a href="/blog/post2">Latest Updates</a>
-        <a href="/blog/post3">Best Practices</a>
-    </div>
-    <a href="/">Home</a>
-</body>
-</html>`)
-	})
-
-	// Dynamic handlers for subcategories
-	mux.HandleFunc("/products/", func(w http.ResponseWriter, r *http.Request) {
-		category := strings.TrimPrefix(r.URL.Path, "/products/")
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<!DOCTYPE html>
-<html>
-<head><title>%s Products</title></head>
-<body>
-    <h1>%s Products</h1>
-    <p>Details about our %s products.</p>
-    <a href="/products">Back to Products</a>
-    <a href="/">Home</a>
-</body>
-</html>`, category, category, category)
-	})
-
-	mux.HandleFunc("/blog/", func(w http.ResponseWriter, r *http.Request) {
-		post := strings.TrimPrefix(r.URL.Path, "/blog/")
-		w.Header().Set("Content-Type", "text/html")
-		fmt.Fprintf(w, `<!DOCTYPE html>
-<html>
-<head><title>Blog Post: %s</title></head>
-<body>
-    <h1>Blog Post: %s</h1>
-    <p>Content of the blog post.</p>
-    <a href="/blog">Back to Blog</a>
-    <a href="/">Home</a>
-</body>
-</html>`, post, post)
-	})
-
-	return httptest.NewServer(mux)
-}